@@ -0,0 +1,325 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/go-playground/validator"
+	"github.com/ysfada/product-management-system/database"
+	"github.com/ysfada/product-management-system/database/generator"
+	"github.com/ysfada/product-management-system/database/migrations"
+	"github.com/ysfada/product-management-system/database/repositories"
+	"github.com/ysfada/product-management-system/domain/common"
+	"github.com/ysfada/product-management-system/domain/dtos"
+	"github.com/ysfada/product-management-system/services"
+	"github.com/ysfada/product-management-system/util/feed"
+	"github.com/ysfada/product-management-system/util/hasher"
+	"github.com/ysfada/product-management-system/util/storage"
+	"github.com/ysfada/product-management-system/util/synthetic"
+)
+
+const usage = `Usage: %s [command]
+
+Commands:
+  serve                                 start the server, the default
+  seed                                  apply the seeds of SEED_SOURCE_URL
+  unseed                                revert the seeds
+  generate [flags]                      add a synthetic catalog for load testing
+  migrate up [N] | down [N] | goto VERSION | status
+                                        change or show the schema version
+  createsuperuser -username NAME [-password PASSWORD]
+                                        create an active staff superuser, the
+                                        password is read from stdin if omitted
+  user activate | deactivate USERNAME   allow or deny a user to sign in
+  reindex                               rebuild the indexes of the searches
+  purge-images [-dry-run]               remove images which belong to no product
+  renditions [-all]                     render the renditions of the images
+                                        without any, or of every image
+  snapshot export FILE | import [-strategy skip|overwrite|rename] FILE
+                                        back up or restore the catalog with its
+                                        images
+  storage migrate -from BACKEND -to BACKEND [-overwrite]
+                                        copy the image files between the local
+                                        and s3 storage backends
+
+Flags:
+`
+
+// generate adds a synthetic catalog for load testing.
+func generate(args []string) {
+	flags := flag.NewFlagSet("generate", flag.ExitOnError)
+	var options synthetic.Options
+	flags.IntVar(&options.Categories, "categories", 20, "number of categories")
+	flags.IntVar(&options.Products, "products", 1000, "number of products")
+	flags.IntVar(&options.Variants, "variants", 3000, "number of variants of all products, at least one per product")
+	flags.IntVar(&options.Attributes, "attributes", 50, "number of attributes")
+	flags.Int64Var(&options.Seed, "seed", 1, "random seed, the same seed generates the same catalog")
+	flags.Parse(args)
+
+	catalog, err := synthetic.Generate(options)
+	if err != nil {
+		log.Fatalf("Unable to generate catalog: %v\n", err)
+	}
+	if err := generator.Insert(context.Background(), database.DbConn, catalog); err != nil {
+		log.Fatalf("Unable to insert catalog: %v\n", err)
+	}
+	log.Printf("Generated %d categories, %d attributes, %d products and %d variants\n",
+		options.Categories, options.Attributes, options.Products, options.Variants)
+}
+
+// migrate changes or shows the schema version.
+func migrate(sourceURL string, databaseURL string, args []string) {
+	if len(args) == 0 {
+		log.Fatal("Error missing migrate command, expected up, down, goto or status")
+	}
+
+	var err error
+	switch args[0] {
+	case "up":
+		err = migrations.Up(sourceURL, databaseURL, count(args, 0))
+	case "down":
+		err = migrations.Down(sourceURL, databaseURL, count(args, 1))
+	case "goto":
+		if len(args) < 2 {
+			log.Fatal("Error missing version to migrate to")
+		}
+		if version, parseErr := strconv.ParseUint(args[1], 10, 64); parseErr != nil {
+			log.Fatalf("Error invalid version %q\n", args[1])
+		} else {
+			err = migrations.Goto(sourceURL, databaseURL, uint(version))
+		}
+	case "status":
+		err = migrations.Status(sourceURL, databaseURL, os.Stdout)
+	default:
+		log.Fatalf("Error unknown migrate command %q, expected up, down, goto or status\n", args[0])
+	}
+	if err != nil {
+		log.Fatalf("Unable to migrate: %v\n", err)
+	}
+}
+
+// count returns the positive number of steps after the migrate command, or
+// fallback if there is none.
+func count(args []string, fallback int) int {
+	if len(args) < 2 {
+		return fallback
+	}
+	n, err := strconv.Atoi(args[1])
+	if err != nil || n <= 0 {
+		log.Fatalf("Error invalid number of migrations %q\n", args[1])
+	}
+	return n
+}
+
+// createSuperuser creates an active staff superuser, the api can not.
+func createSuperuser(args []string) {
+	flags := flag.NewFlagSet("createsuperuser", flag.ExitOnError)
+	var dto dtos.SignupDto
+	flags.StringVar(&dto.Username, "username", "", "username")
+	flags.StringVar(&dto.Password, "password", "", "password, read from stdin if omitted")
+	flags.Parse(args)
+
+	if len(dto.Password) == 0 {
+		fmt.Fprint(os.Stderr, "Password: ")
+		password, err := bufio.NewReader(os.Stdin).ReadString('\n')
+		if err != nil && len(password) == 0 {
+			log.Fatalf("Unable to read password: %v\n", err)
+		}
+		dto.Password = strings.TrimRight(password, "\r\n")
+	}
+
+	if err := userService().CreateSuperuser(context.Background(), &dto); err != nil {
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			log.Fatalf("Error invalid user: %v\n", validationErrors)
+		}
+		switch err {
+		case common.ErrConflict:
+			log.Fatalf("Error user %s already exists\n", dto.Username)
+		default:
+			log.Fatalf("Unable to create superuser: %v\n", err)
+		}
+	}
+	log.Printf("Created superuser %s\n", dto.Username)
+}
+
+// user activates or deactivates a user.
+func user(args []string) {
+	if len(args) < 2 || (args[0] != "activate" && args[0] != "deactivate") {
+		log.Fatal("Error expected user activate USERNAME or user deactivate USERNAME")
+	}
+
+	active := args[0] == "activate"
+	if err := userService().SetActive(context.Background(), args[1], active); err != nil {
+		switch err {
+		case common.ErrNotFound:
+			log.Fatalf("Error user %s not found\n", args[1])
+		default:
+			log.Fatalf("Unable to %s user: %v\n", args[0], err)
+		}
+	}
+	log.Printf("User %s %sd\n", args[1], args[0])
+}
+
+// reindex rebuilds the indexes of the product and variant searches.
+func reindex() {
+	productService := services.NewProductService(
+		repositories.NewProductRepository(database.DbConn),
+		services.NewImageService(repositories.NewImageRepository(database.DbConn), newStorage()),
+	)
+	if err := productService.Reindex(context.Background()); err != nil {
+		log.Fatalf("Unable to reindex: %v\n", err)
+	}
+	log.Println("Reindexed search")
+}
+
+// purgeImages removes the images which belong to no product.
+func purgeImages(args []string) {
+	flags := flag.NewFlagSet("purge-images", flag.ExitOnError)
+	dryRun := flags.Bool("dry-run", false, "only list the images")
+	flags.Parse(args)
+
+	imageService := services.NewImageService(repositories.NewImageRepository(database.DbConn), newStorage())
+	images, err := imageService.PurgeOrphans(context.Background(), *dryRun)
+	for _, image := range images {
+		fmt.Printf("%d %s %s\n", image.ID, image.Name, image.ImageUrl)
+	}
+	if err != nil {
+		log.Fatalf("Unable to purge images: %v\n", err)
+	}
+	if *dryRun {
+		log.Printf("Found %d orphaned images\n", len(images))
+	} else {
+		log.Printf("Purged %d orphaned images\n", len(images))
+	}
+}
+
+// snapshot exports the catalog to an archive or imports one.
+func snapshot(args []string) {
+	if len(args) == 0 || (args[0] != "export" && args[0] != "import") {
+		log.Fatal("Error expected snapshot export FILE or snapshot import FILE")
+	}
+
+	flags := flag.NewFlagSet("snapshot "+args[0], flag.ExitOnError)
+	strategy := flags.String("strategy", "skip", "how to resolve rows named like existing ones, skip, overwrite or rename")
+	flags.Parse(args[1:])
+	if flags.NArg() != 1 {
+		log.Fatalf("Error expected snapshot %s FILE\n", args[0])
+	}
+	name := flags.Arg(0)
+
+	imageStorage := newStorage()
+	imageService := services.NewImageService(repositories.NewImageRepository(database.DbConn), imageStorage)
+	snapshotService := services.NewSnapshotService(repositories.NewSnapshotRepository(database.DbConn), imageStorage, imageService)
+	if args[0] == "export" {
+		file, err := os.Create(name)
+		if err != nil {
+			log.Fatalf("Unable to create %s: %v\n", name, err)
+		}
+		if err := snapshotService.Export(context.Background(), file); err != nil {
+			file.Close()
+			os.Remove(name)
+			log.Fatalf("Unable to export snapshot: %v\n", err)
+		}
+		if err := file.Close(); err != nil {
+			log.Fatalf("Unable to write %s: %v\n", name, err)
+		}
+		log.Printf("Exported snapshot to %s\n", name)
+		return
+	}
+
+	file, err := os.Open(name)
+	if err != nil {
+		log.Fatalf("Unable to open %s: %v\n", name, err)
+	}
+	defer file.Close()
+
+	snapshotImport, err := snapshotService.Import(context.Background(), file, *strategy)
+	if err != nil {
+		log.Fatalf("Unable to import snapshot: %v\n", err)
+	}
+	for _, count := range []struct {
+		name  string
+		count *dtos.SnapshotImportCountDto
+	}{
+		{"categories", snapshotImport.Categories},
+		{"attributes", snapshotImport.Attributes},
+		{"images", snapshotImport.Images},
+		{"products", snapshotImport.Products},
+		{"variants", snapshotImport.Variants},
+	} {
+		log.Printf("Imported %s: %d created, %d updated, %d skipped, %d renamed\n",
+			count.name, count.count.Created, count.count.Updated, count.count.Skipped, count.count.Renamed)
+	}
+}
+
+// renditions renders the renditions of existing images.
+func renditions(args []string) {
+	flags := flag.NewFlagSet("renditions", flag.ExitOnError)
+	all := flags.Bool("all", false, "render every image again, e.g. after changing IMAGE_RENDITIONS")
+	flags.Parse(args)
+
+	imageService := services.NewImageService(repositories.NewImageRepository(database.DbConn), newStorage())
+	images, err := imageService.RenderAll(context.Background(), *all)
+	for _, image := range images {
+		fmt.Printf("%d %s %d renditions\n", image.ID, image.ImageUrl, len(image.Renditions))
+	}
+	if err != nil {
+		log.Fatalf("Unable to render images: %v\n", err)
+	}
+	log.Printf("Rendered %d images\n", len(images))
+}
+
+// storageCommand copies the image files between storage backends.
+func storageCommand(args []string) {
+	if len(args) == 0 || args[0] != "migrate" {
+		log.Fatal("Error expected storage migrate -from BACKEND -to BACKEND")
+	}
+
+	flags := flag.NewFlagSet("storage migrate", flag.ExitOnError)
+	from := flags.String("from", "local", "backend to copy the files from, local or s3")
+	to := flags.String("to", "s3", "backend to copy the files to, local or s3")
+	overwrite := flags.Bool("overwrite", false, "copy files the target backend already has")
+	flags.Parse(args[1:])
+
+	storageService := services.NewStorageService(newStorage())
+	migration, err := storageService.Migrate(context.Background(), *from, *to, *overwrite)
+	if err != nil {
+		if migration != nil {
+			log.Printf("Copied %d files before failing\n", migration.Copied)
+		}
+		log.Fatalf("Unable to migrate storage: %v\n", err)
+	}
+	log.Printf("Copied %d files from %s to %s, skipped %d it already had\n",
+		migration.Copied, migration.From, migration.To, migration.Skipped)
+}
+
+// newStorage returns the storage of STORAGE_BACKEND.
+func newStorage() storage.Storage {
+	imageStorage, err := services.NewStorage("")
+	if err != nil {
+		log.Fatalf("Unable to configure storage: %v\n", err)
+	}
+	return imageStorage
+}
+
+func newFeedConfig() feed.Config {
+	config, err := services.NewFeedConfig()
+	if err != nil {
+		log.Fatalf("Unable to configure feeds: %v\n", err)
+	}
+	return config
+}
+
+func userService() *services.UserService {
+	return services.NewUserService(
+		repositories.NewUserRepository(database.DbConn),
+		hasher.NewArgon2(),
+		services.NewCartService(repositories.NewCartRepository(database.DbConn)),
+	)
+}
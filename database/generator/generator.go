@@ -0,0 +1,233 @@
+package generator
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+	"github.com/ysfada/product-management-system/util/synthetic"
+)
+
+// batchSize is the number of rows inserted by a statement.
+const batchSize = 1000
+
+// Insert adds catalog to the database in one transaction. Categories and
+// attributes which already exist are reused, the opening stock of the
+// variants is recorded with the reason "generated".
+func Insert(ctx context.Context, dbConn *pgxpool.Pool, catalog *synthetic.Catalog) error {
+	return dbConn.BeginFunc(ctx, func(tx pgx.Tx) error {
+		if _, err := tx.Exec(ctx, `SELECT set_config('pms.movement_reason', 'generated', true)`); err != nil {
+			return err
+		}
+
+		categoryIDs, err := insertCategories(ctx, tx, catalog.Categories)
+		if err != nil {
+			return err
+		}
+		attributeIDs, err := insertAttributes(ctx, tx, catalog.Attributes)
+		if err != nil {
+			return err
+		}
+
+		variants := 0
+		for _, product := range catalog.Products {
+			variants += len(product.Variants)
+		}
+		productIDs, err := nextIDs(ctx, tx, "product", len(catalog.Products))
+		if err != nil {
+			return err
+		}
+		variantIDs, err := nextIDs(ctx, tx, "product_variant", variants)
+		if err != nil {
+			return err
+		}
+
+		var products, variantRows, links rows
+		v := 0
+		for i, product := range catalog.Products {
+			products.add(productIDs[i], product.Name, product.Description, categoryIDs[product.Category])
+			for _, variant := range product.Variants {
+				variantRows.add(variantIDs[v], productIDs[i], variant.Name, variant.Price, variant.Stock)
+				for _, attribute := range variant.Attributes {
+					links.add(variantIDs[v], attributeIDs[attribute])
+				}
+				v++
+			}
+		}
+
+		if err := products.insert(ctx, tx, `
+        INSERT INTO "public"."product" ("id", "name", "description", "category_id")
+        SELECT *
+        FROM UNNEST($1::int[], $2::text[], $3::text[], $4::int[])
+        `); err != nil {
+			return err
+		}
+		if err := variantRows.insert(ctx, tx, `
+        INSERT INTO "public"."product_variant" ("id", "product_id", "name", "price", "stock")
+        SELECT *
+        FROM UNNEST($1::int[], $2::int[], $3::text[], $4::float8[], $5::float8[])
+        `); err != nil {
+			return err
+		}
+		return links.insert(ctx, tx, `
+        INSERT INTO "public"."product_attributes" ("product_variant_id", "attribute_id")
+        SELECT *
+        FROM UNNEST($1::int[], $2::int[])
+        `)
+	})
+}
+
+// insertCategories returns the ids of the categories by index, a category
+// with the name of an existing one is that one.
+func insertCategories(ctx context.Context, tx pgx.Tx, categories []*synthetic.Category) ([]int, error) {
+	names := make([]string, len(categories))
+	descriptions := make([]string, len(categories))
+	for i, category := range categories {
+		names[i] = category.Name
+		descriptions[i] = category.Description
+	}
+
+	sql := `
+    INSERT INTO "public"."category" ("name", "description")
+    SELECT *
+    FROM UNNEST($1::text[], $2::text[])
+    ON CONFLICT ("name") DO UPDATE
+    SET "name" = EXCLUDED."name"
+    RETURNING "id", "name"::text
+    `
+	byName := make(map[string]int)
+	if err := scanIDs(ctx, tx, byName, sql, names, descriptions); err != nil {
+		return nil, err
+	}
+
+	ids := make([]int, len(categories))
+	for i, category := range categories {
+		ids[i] = byName[category.Name]
+	}
+	return ids, nil
+}
+
+// insertAttributes returns the ids of the attributes by index, an attribute
+// of the type and name of an existing one is that one.
+func insertAttributes(ctx context.Context, tx pgx.Tx, attributes []*synthetic.Attribute) ([]int, error) {
+	types := make([]string, len(attributes))
+	names := make([]string, len(attributes))
+	for i, attribute := range attributes {
+		types[i] = attribute.Type
+		names[i] = attribute.Name
+	}
+
+	sql := `
+    WITH "input" AS (
+        SELECT *
+        FROM UNNEST($1::text[], $2::text[]) AS "input"("type", "name")
+    ), "inserted" AS (
+        INSERT INTO "public"."attribute" ("type", "name")
+        SELECT "i"."type", "i"."name"
+        FROM "input" "i"
+        WHERE NOT EXISTS (
+            SELECT 1
+            FROM "public"."attribute" "a"
+            WHERE "a"."type" = "i"."type" AND "a"."name" = "i"."name"
+        )
+        RETURNING "id", "type", "name"
+    )
+    SELECT "id", "type"::text || ':' || "name"::text
+    FROM "inserted"
+    UNION ALL
+    SELECT DISTINCT ON ("a"."type", "a"."name") "a"."id", "a"."type"::text || ':' || "a"."name"::text
+    FROM "public"."attribute" "a"
+    JOIN "input" "i" ON "a"."type" = "i"."type" AND "a"."name" = "i"."name"
+    ORDER BY 1
+    `
+	byName := make(map[string]int)
+	if err := scanIDs(ctx, tx, byName, sql, types, names); err != nil {
+		return nil, err
+	}
+
+	ids := make([]int, len(attributes))
+	for i, attribute := range attributes {
+		ids[i] = byName[attribute.Type+":"+attribute.Name]
+	}
+	return ids, nil
+}
+
+// scanIDs reads rows of id and name into byName.
+func scanIDs(ctx context.Context, tx pgx.Tx, byName map[string]int, sql string, args ...interface{}) error {
+	rows, err := tx.Query(ctx, sql, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id int
+		var name string
+		if err := rows.Scan(&id, &name); err != nil {
+			return err
+		}
+		// the first, which is the inserted or oldest row
+		if _, ok := byName[name]; !ok {
+			byName[name] = id
+		}
+	}
+	return rows.Err()
+}
+
+// nextIDs reserves n ids of the identity column of table.
+func nextIDs(ctx context.Context, tx pgx.Tx, table string, n int) ([]int, error) {
+	sql := `
+    SELECT NEXTVAL(PG_GET_SERIAL_SEQUENCE('"public"."' || $1 || '"', 'id'))
+    FROM GENERATE_SERIES(1, $2)
+    `
+	rows, err := tx.Query(ctx, sql, table, n)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ids := make([]int, 0, n)
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// rows are the columns of rows to insert, column by column.
+type rows struct {
+	columns [][]interface{}
+}
+
+func (r *rows) add(values ...interface{}) {
+	if r.columns == nil {
+		r.columns = make([][]interface{}, len(values))
+	}
+	for i, value := range values {
+		r.columns[i] = append(r.columns[i], value)
+	}
+}
+
+// insert runs sql with the columns as arrays, batchSize rows at a time.
+func (r *rows) insert(ctx context.Context, tx pgx.Tx, sql string) error {
+	if len(r.columns) == 0 {
+		return nil
+	}
+	for start := 0; start < len(r.columns[0]); start += batchSize {
+		end := start + batchSize
+		if end > len(r.columns[0]) {
+			end = len(r.columns[0])
+		}
+		args := make([]interface{}, len(r.columns))
+		for i, column := range r.columns {
+			args[i] = column[start:end]
+		}
+		if _, err := tx.Exec(ctx, sql, args...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
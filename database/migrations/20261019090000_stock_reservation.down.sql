@@ -0,0 +1,5 @@
+drop table "public"."stock_reservation";
+
+alter table "public"."product_variant"
+    drop constraint "product_variant_reserved_check",
+    drop column "reserved";
@@ -0,0 +1,35 @@
+alter table "public"."product_variant"
+    add column if not exists "reserved" int not null default 0,
+    add constraint "product_variant_reserved_check" check(("reserved" >= 0) and ("reserved" <= "stock"));
+
+create table if not exists "public"."stock_reservation"(
+    -- "id"                 uuid        not null default gen_random_uuid(),
+    "id"                 int         not null generated by default as identity(start with 1 increment by 1),
+    "product_variant_id" int         not null,
+    "quantity"           int         not null,
+    "owner_ref"          citext      not null,
+    "status"             citext      not null default 'active',
+    "expires_at"         timestamptz not null,
+    "created_at"         timestamptz not null,
+    "updated_at"         timestamptz null,
+    "deleted_at"         timestamptz null,
+    foreign key("product_variant_id") references "product_variant"("id") on delete restrict deferrable initially deferred,
+    constraint "stock_reservation_id_pkey"        primary key("id"),
+    constraint "stock_reservation_quantity_check"  check("quantity" > 0),
+    constraint "stock_reservation_owner_ref_check" check((length(("owner_ref")::text) >= 1) and (length(("owner_ref")::text) <= 64)),
+    constraint "stock_reservation_status_check"    check("status" in ('active', 'confirmed', 'released', 'expired'))
+);
+
+create index if not exists "stock_reservation_product_variant_id"
+on "public"."stock_reservation"(
+	"product_variant_id"
+);
+
+create index if not exists "stock_reservation_active_expires_at"
+on "public"."stock_reservation"(
+	"expires_at"
+) where "status" = 'active';
+
+create trigger "_timestamps" before insert or update or delete
+on "public"."stock_reservation" for each row
+    execute procedure "public"."tg__timestamps"();
@@ -0,0 +1,15 @@
+drop trigger "_low_stock" on "public"."product_variant";
+
+drop function "public"."tg__product_variant_low_stock"();
+
+alter table "public"."product_variant"
+    drop constraint "product_variant_reorder_quantity_check",
+    drop constraint "product_variant_reorder_point_check",
+    drop column "reorder_quantity",
+    drop column "reorder_point";
+
+alter table "public"."category"
+    drop constraint "category_reorder_quantity_check",
+    drop constraint "category_reorder_point_check",
+    drop column "reorder_quantity",
+    drop column "reorder_point";
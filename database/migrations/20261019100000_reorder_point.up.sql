@@ -0,0 +1,56 @@
+alter table "public"."category"
+    add column if not exists "reorder_point"    int null,
+    add column if not exists "reorder_quantity" int null,
+    add constraint "category_reorder_point_check"    check("reorder_point" >= 0),
+    add constraint "category_reorder_quantity_check" check("reorder_quantity" > 0);
+
+alter table "public"."product_variant"
+    add column if not exists "reorder_point"    int null,
+    add column if not exists "reorder_quantity" int null,
+    add constraint "product_variant_reorder_point_check"    check("reorder_point" >= 0),
+    add constraint "product_variant_reorder_quantity_check" check("reorder_quantity" > 0);
+
+-- notifies 'low_stock' listeners when the available stock of a variant
+-- drops to or below its reorder point (or the default of its category)
+create function "public"."tg__product_variant_low_stock"() returns trigger as $$
+declare
+    "category_reorder_point" int;
+    "old_threshold"          int;
+    "new_threshold"          int;
+begin
+    select "c"."reorder_point"
+    into "category_reorder_point"
+    from "public"."product" "p"
+    join "public"."category" "c" on "c"."id" = "p"."category_id"
+    where "p"."id" = NEW."product_id";
+
+    "new_threshold" = coalesce(NEW."reorder_point", "category_reorder_point");
+    if "new_threshold" is null or NEW."stock" - NEW."reserved" > "new_threshold" then
+        return NEW;
+    end if;
+
+    if TG_OP = 'UPDATE' then
+        "old_threshold" = coalesce(OLD."reorder_point", "category_reorder_point");
+        if "old_threshold" is not null and OLD."stock" - OLD."reserved" <= "old_threshold" then
+            return NEW;
+        end if;
+    end if;
+
+    perform pg_notify('low_stock', json_build_object(
+        'product_variant_id', NEW."id",
+        'product_id', NEW."product_id",
+        'name', NEW."name",
+        'stock', NEW."stock",
+        'reserved', NEW."reserved",
+        'available', NEW."stock" - NEW."reserved",
+        'reorder_point', "new_threshold",
+        'reorder_quantity', NEW."reorder_quantity"
+    )::text);
+
+    return NEW;
+end;
+$$ language plpgsql volatile set search_path to pg_catalog, public, pg_temp;
+
+create trigger "_low_stock" after insert or update of "stock", "reserved", "reorder_point"
+on "public"."product_variant" for each row
+    execute procedure "public"."tg__product_variant_low_stock"();
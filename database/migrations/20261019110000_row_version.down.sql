@@ -0,0 +1,11 @@
+drop trigger "_version" on "public"."attribute";
+drop trigger "_version" on "public"."product_variant";
+drop trigger "_version" on "public"."product";
+drop trigger "_version" on "public"."category";
+
+alter table "public"."attribute"       drop column "version";
+alter table "public"."product_variant" drop column "version";
+alter table "public"."product"         drop column "version";
+alter table "public"."category"        drop column "version";
+
+drop function "public"."tg__version"();
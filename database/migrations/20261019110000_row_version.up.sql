@@ -0,0 +1,27 @@
+create function "public"."tg__version"() returns trigger as $$
+begin
+    NEW."version" = OLD."version" + 1;
+    return NEW;
+end;
+$$ language plpgsql volatile set search_path to pg_catalog, public, pg_temp;
+
+alter table "public"."category"        add column if not exists "version" int not null default 1;
+alter table "public"."product"         add column if not exists "version" int not null default 1;
+alter table "public"."product_variant" add column if not exists "version" int not null default 1;
+alter table "public"."attribute"       add column if not exists "version" int not null default 1;
+
+create trigger "_version" before update
+on "public"."category" for each row
+    execute procedure "public"."tg__version"();
+
+create trigger "_version" before update
+on "public"."product" for each row
+    execute procedure "public"."tg__version"();
+
+create trigger "_version" before update
+on "public"."product_variant" for each row
+    execute procedure "public"."tg__version"();
+
+create trigger "_version" before update
+on "public"."attribute" for each row
+    execute procedure "public"."tg__version"();
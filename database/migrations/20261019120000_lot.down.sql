@@ -0,0 +1,7 @@
+drop trigger "_consume_lots" on "public"."product_variant";
+
+drop function "public"."tg__product_variant_consume_lots"();
+
+drop table "public"."lot";
+
+drop function "public"."tg__lot_stock"();
@@ -0,0 +1,114 @@
+create table if not exists "public"."lot"(
+    -- "id"                 uuid        not null default gen_random_uuid(),
+    "id"                 int         not null generated by default as identity(start with 1 increment by 1),
+    "product_variant_id" int         not null,
+    "lot_number"         citext      not null,
+    "expires_at"         date        null,
+    "manufactured_at"    date        null,
+    "quantity"           int         not null default 0,
+    "created_at"         timestamptz not null,
+    "updated_at"         timestamptz null,
+    "deleted_at"         timestamptz null,
+    foreign key("product_variant_id") references "product_variant"("id") on delete restrict deferrable initially deferred,
+    constraint "lot_id_pkey"                         primary key("id"),
+    constraint "lot_product_variant_id_lot_number_key" unique("product_variant_id", "lot_number"),
+    constraint "lot_quantity_check"                  check("quantity" >= 0),
+    constraint "lot_lot_number_check"                check((length(("lot_number")::text) >= 1) and (length(("lot_number")::text) <= 64)),
+    constraint "lot_dates_check"                     check("manufactured_at" <= "expires_at")
+);
+
+create index if not exists "lot_product_variant_id_expires_at"
+on "public"."lot"(
+	"product_variant_id",
+	"expires_at"
+);
+
+create index if not exists "lot_expires_at"
+on "public"."lot"(
+	"expires_at"
+) where "quantity" > 0;
+
+create trigger "_timestamps" before insert or update or delete
+on "public"."lot" for each row
+    execute procedure "public"."tg__timestamps"();
+
+-- keeps the stock of a lot tracked variant equal to the sum of its lots
+create function "public"."tg__lot_stock"() returns trigger as $$
+declare
+    "variant_id" int;
+begin
+    -- lots consumed by "tg__product_variant_consume_lots" are already counted
+    if pg_trigger_depth() > 1 then
+        return null;
+    end if;
+
+    "variant_id" = (
+        case
+            when TG_OP = 'DELETE'
+                then OLD."product_variant_id"
+                else NEW."product_variant_id"
+        end
+    );
+
+    update "public"."product_variant"
+    set "stock" = (
+        select coalesce(sum("l"."quantity"), 0)
+        from "public"."lot" "l"
+        where "l"."product_variant_id" = "variant_id"
+    )
+    where "id" = "variant_id";
+
+    return null;
+end;
+$$ language plpgsql volatile set search_path to pg_catalog, public, pg_temp;
+
+create trigger "_stock" after insert or update of "quantity" or delete
+on "public"."lot" for each row
+    execute procedure "public"."tg__lot_stock"();
+
+-- takes a stock decrement of a lot tracked variant, e.g. a confirmed
+-- reservation, out of its lots first-expired-first-out
+create function "public"."tg__product_variant_consume_lots"() returns trigger as $$
+declare
+    "remaining" int;
+    "picked"    record;
+begin
+    -- stock synced from the lots by "tg__lot_stock"
+    if pg_trigger_depth() > 1 or NEW."stock" = OLD."stock" then
+        return NEW;
+    end if;
+
+    if not exists (select 1 from "public"."lot" "l" where "l"."product_variant_id" = NEW."id") then
+        return NEW;
+    end if;
+
+    if NEW."stock" > OLD."stock" then
+        raise exception 'stock of lot tracked variant % can only be increased through its lots', NEW."id"
+            using errcode = 'check_violation';
+    end if;
+
+    "remaining" = OLD."stock" - NEW."stock";
+    for "picked" in
+        select "l"."id", "l"."quantity"
+        from "public"."lot" "l"
+        where "l"."product_variant_id" = NEW."id"
+            and "l"."quantity" > 0
+        order by "l"."expires_at" asc nulls last, "l"."id" asc
+        for update
+    loop
+        exit when "remaining" = 0;
+
+        update "public"."lot"
+        set "quantity" = "quantity" - least("picked"."quantity", "remaining")
+        where "id" = "picked"."id";
+
+        "remaining" = "remaining" - least("picked"."quantity", "remaining");
+    end loop;
+
+    return NEW;
+end;
+$$ language plpgsql volatile set search_path to pg_catalog, public, pg_temp;
+
+create trigger "_consume_lots" before update of "stock"
+on "public"."product_variant" for each row
+    execute procedure "public"."tg__product_variant_consume_lots"();
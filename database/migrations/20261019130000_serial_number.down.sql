@@ -0,0 +1,12 @@
+drop trigger "_serials" on "public"."product_variant";
+
+drop function "public"."tg__product_variant_serials"();
+
+drop table "public"."serial_number_history";
+
+drop table "public"."serial_number";
+
+drop function "public"."tg__serial_number_status"();
+
+alter table "public"."product_variant"
+    drop column "serialized";
@@ -0,0 +1,117 @@
+alter table "public"."product_variant"
+    add column if not exists "serialized" boolean not null default false;
+
+create table if not exists "public"."serial_number"(
+    -- "id"                 uuid        not null default gen_random_uuid(),
+    "id"                 int         not null generated by default as identity(start with 1 increment by 1),
+    "product_variant_id" int         not null,
+    "serial"             citext      not null,
+    "status"             citext      not null default 'in_stock',
+    "note"               citext      null,
+    "created_at"         timestamptz not null,
+    "updated_at"         timestamptz null,
+    "deleted_at"         timestamptz null,
+    foreign key("product_variant_id") references "product_variant"("id") on delete restrict deferrable initially deferred,
+    constraint "serial_number_id_pkey"     primary key("id"),
+    constraint "serial_number_serial_key"  unique("serial"),
+    constraint "serial_number_serial_check" check((length(("serial")::text) >= 1) and (length(("serial")::text) <= 128)),
+    constraint "serial_number_status_check" check("status" in ('in_stock', 'reserved', 'sold', 'returned', 'scrapped')),
+    constraint "serial_number_note_check"   check(length(("note")::text) <= 256)
+);
+
+create index if not exists "serial_number_product_variant_id_status"
+on "public"."serial_number"(
+	"product_variant_id",
+	"status"
+);
+
+create trigger "_timestamps" before insert or update or delete
+on "public"."serial_number" for each row
+    execute procedure "public"."tg__timestamps"();
+
+create table if not exists "public"."serial_number_history"(
+    -- "id"               uuid        not null default gen_random_uuid(),
+    "id"               int         not null generated by default as identity(start with 1 increment by 1),
+    "serial_number_id" int         not null,
+    "from_status"      citext      null,
+    "to_status"        citext      not null,
+    "note"             citext      null,
+    "created_at"       timestamptz not null,
+    "updated_at"       timestamptz null,
+    "deleted_at"       timestamptz null,
+    foreign key("serial_number_id") references "serial_number"("id") on delete restrict deferrable initially deferred,
+    constraint "serial_number_history_id_pkey" primary key("id")
+);
+
+create index if not exists "serial_number_history_serial_number_id"
+on "public"."serial_number_history"(
+	"serial_number_id"
+);
+
+create trigger "_timestamps" before insert or update or delete
+on "public"."serial_number_history" for each row
+    execute procedure "public"."tg__timestamps"();
+
+-- records every status change of a serial and keeps the stock of its variant
+-- equal to the count of its serials in stock
+create function "public"."tg__serial_number_status"() returns trigger as $$
+begin
+    if TG_OP = 'UPDATE' and NEW."status" = OLD."status" then
+        return null;
+    end if;
+
+    insert into "public"."serial_number_history" ("serial_number_id", "from_status", "to_status", "note")
+    values (
+        NEW."id",
+        case when TG_OP = 'UPDATE' then OLD."status" end,
+        NEW."status",
+        NEW."note"
+    );
+
+    update "public"."product_variant"
+    set "stock" = (
+        select count(*)
+        from "public"."serial_number" "s"
+        where "s"."product_variant_id" = NEW."product_variant_id"
+            and "s"."status" = 'in_stock'
+    )
+    where "id" = NEW."product_variant_id";
+
+    return null;
+end;
+$$ language plpgsql volatile set search_path to pg_catalog, public, pg_temp;
+
+create trigger "_status" after insert or update of "status"
+on "public"."serial_number" for each row
+    execute procedure "public"."tg__serial_number_status"();
+
+-- the stock of a serialized variant only follows its serials
+create function "public"."tg__product_variant_serials"() returns trigger as $$
+begin
+    if not NEW."serialized" then
+        return NEW;
+    end if;
+
+    if not OLD."serialized" then
+        NEW."stock" = (
+            select count(*)
+            from "public"."serial_number" "s"
+            where "s"."product_variant_id" = NEW."id"
+                and "s"."status" = 'in_stock'
+        );
+        return NEW;
+    end if;
+
+    -- stock synced from the serials by "tg__serial_number_status"
+    if pg_trigger_depth() > 1 or NEW."stock" = OLD."stock" then
+        return NEW;
+    end if;
+
+    raise exception 'stock of serialized variant % can only be changed through its serials', NEW."id"
+        using errcode = 'check_violation';
+end;
+$$ language plpgsql volatile set search_path to pg_catalog, public, pg_temp;
+
+create trigger "_serials" before update of "stock", "serialized"
+on "public"."product_variant" for each row
+    execute procedure "public"."tg__product_variant_serials"();
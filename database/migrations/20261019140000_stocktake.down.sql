@@ -0,0 +1,5 @@
+drop table "public"."stocktake_count";
+
+drop table "public"."stocktake_line";
+
+drop table "public"."stocktake";
@@ -0,0 +1,66 @@
+create table if not exists "public"."stocktake"(
+    -- "id"          uuid        not null default gen_random_uuid(),
+    "id"          int         not null generated by default as identity(start with 1 increment by 1),
+    "category_id" int         null,
+    "status"      citext      not null default 'open',
+    "created_by"  citext      not null,
+    "approved_by" citext      null,
+    "approved_at" timestamptz null,
+    "created_at"  timestamptz not null,
+    "updated_at"  timestamptz null,
+    "deleted_at"  timestamptz null,
+    foreign key("category_id") references "category"("id") on delete restrict deferrable initially deferred,
+    constraint "stocktake_id_pkey"      primary key("id"),
+    constraint "stocktake_status_check" check("status" in ('open', 'approved', 'cancelled'))
+);
+
+create trigger "_timestamps" before insert or update or delete
+on "public"."stocktake" for each row
+    execute procedure "public"."tg__timestamps"();
+
+-- "expected" is the stock of the variant when the session was opened,
+-- "counted" and "adjustment" are recorded when the session is approved
+create table if not exists "public"."stocktake_line"(
+    -- "id"                 uuid        not null default gen_random_uuid(),
+    "id"                 int         not null generated by default as identity(start with 1 increment by 1),
+    "stocktake_id"       int         not null,
+    "product_variant_id" int         not null,
+    "expected"           int         not null,
+    "counted"            int         null,
+    "adjustment"         int         null,
+    "created_at"         timestamptz not null,
+    "updated_at"         timestamptz null,
+    "deleted_at"         timestamptz null,
+    foreign key("stocktake_id")       references "stocktake"("id")       on delete restrict deferrable initially deferred,
+    foreign key("product_variant_id") references "product_variant"("id") on delete restrict deferrable initially deferred,
+    constraint "stocktake_line_id_pkey"                         primary key("id"),
+    constraint "stocktake_line_stocktake_id_product_variant_id_key" unique("stocktake_id", "product_variant_id")
+);
+
+create trigger "_timestamps" before insert or update or delete
+on "public"."stocktake_line" for each row
+    execute procedure "public"."tg__timestamps"();
+
+-- every submission of a counter, the counted quantity of a line is the sum
+-- of its counts
+create table if not exists "public"."stocktake_count"(
+    -- "id"                uuid        not null default gen_random_uuid(),
+    "id"                int         not null generated by default as identity(start with 1 increment by 1),
+    "stocktake_line_id" int         not null,
+    "quantity"          int         not null,
+    "counted_by"        citext      not null,
+    "created_at"        timestamptz not null,
+    "updated_at"        timestamptz null,
+    "deleted_at"        timestamptz null,
+    foreign key("stocktake_line_id") references "stocktake_line"("id") on delete restrict deferrable initially deferred,
+    constraint "stocktake_count_id_pkey" primary key("id")
+);
+
+create index if not exists "stocktake_count_stocktake_line_id"
+on "public"."stocktake_count"(
+	"stocktake_line_id"
+);
+
+create trigger "_timestamps" before insert or update or delete
+on "public"."stocktake_count" for each row
+    execute procedure "public"."tg__timestamps"();
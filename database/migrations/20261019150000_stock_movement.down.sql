@@ -0,0 +1,13 @@
+drop trigger "_stock_movement" on "public"."product_variant";
+
+drop function "public"."tg__product_variant_stock_movement"();
+
+drop trigger "_unit_cost" on "public"."product_variant";
+
+drop function "public"."tg__product_variant_unit_cost"();
+
+drop table "public"."stock_movement";
+
+alter table "public"."product_variant"
+    drop constraint "product_variant_unit_cost_check",
+    drop column "unit_cost";
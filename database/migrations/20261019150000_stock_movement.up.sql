@@ -0,0 +1,91 @@
+alter table "public"."product_variant"
+    add column if not exists "unit_cost" decimal(19,4) null,
+    add constraint "product_variant_unit_cost_check" check("unit_cost" >= 0);
+
+-- every change of the stock of a variant, received quantities carry their
+-- purchase cost
+create table if not exists "public"."stock_movement"(
+    -- "id"                 uuid          not null default gen_random_uuid(),
+    "id"                 int           not null generated by default as identity(start with 1 increment by 1),
+    "product_variant_id" int           not null,
+    "quantity"           int           not null,
+    "unit_cost"          decimal(19,4) null,
+    "reason"             citext        not null,
+    "created_at"         timestamptz   not null,
+    "updated_at"         timestamptz   null,
+    "deleted_at"         timestamptz   null,
+    foreign key("product_variant_id") references "product_variant"("id") on delete restrict deferrable initially deferred,
+    constraint "stock_movement_id_pkey"          primary key("id"),
+    constraint "stock_movement_unit_cost_check"  check("unit_cost" >= 0),
+    constraint "stock_movement_reason_check"     check((length(("reason")::text) >= 1) and (length(("reason")::text) <= 32))
+);
+
+create index if not exists "stock_movement_product_variant_id_created_at"
+on "public"."stock_movement"(
+	"product_variant_id",
+	"created_at"
+);
+
+create trigger "_timestamps" before insert or update or delete
+on "public"."stock_movement" for each row
+    execute procedure "public"."tg__timestamps"();
+
+-- the opening stock has no known cost
+insert into "public"."stock_movement" ("product_variant_id", "quantity", "reason")
+select "id", "stock", 'opening'
+from "public"."product_variant"
+where "stock" <> 0;
+
+-- keeps the weighted average cost of a variant up to date, the cost of a
+-- receipt is passed through the transaction local "pms.movement_unit_cost"
+create function "public"."tg__product_variant_unit_cost"() returns trigger as $$
+declare
+    "received_cost" decimal(19,4);
+    "received"      int;
+    "on_hand"       int;
+begin
+    "received_cost" = nullif(current_setting('pms.movement_unit_cost', true), '')::decimal(19,4);
+    "on_hand" = case when TG_OP = 'UPDATE' then greatest(OLD."stock", 0) else 0 end;
+    "received" = NEW."stock" - case when TG_OP = 'UPDATE' then OLD."stock" else 0 end;
+
+    if "received_cost" is null or "received" <= 0 then
+        return NEW;
+    end if;
+
+    NEW."unit_cost" = (
+        coalesce(NEW."unit_cost", "received_cost") * "on_hand" + "received_cost" * "received"
+    ) / ("on_hand" + "received");
+    return NEW;
+end;
+$$ language plpgsql volatile set search_path to pg_catalog, public, pg_temp;
+
+create trigger "_unit_cost" before insert or update of "stock"
+on "public"."product_variant" for each row
+    execute procedure "public"."tg__product_variant_unit_cost"();
+
+-- records every stock change as a movement, the reason is passed through the
+-- transaction local "pms.movement_reason"
+create function "public"."tg__product_variant_stock_movement"() returns trigger as $$
+declare
+    "delta" int;
+begin
+    "delta" = NEW."stock" - case when TG_OP = 'UPDATE' then OLD."stock" else 0 end;
+    if "delta" = 0 then
+        return null;
+    end if;
+
+    insert into "public"."stock_movement" ("product_variant_id", "quantity", "unit_cost", "reason")
+    values (
+        NEW."id",
+        "delta",
+        case when "delta" > 0 then nullif(current_setting('pms.movement_unit_cost', true), '')::decimal(19,4) end,
+        coalesce(nullif(current_setting('pms.movement_reason', true), ''), 'adjustment')
+    );
+
+    return null;
+end;
+$$ language plpgsql volatile set search_path to pg_catalog, public, pg_temp;
+
+create trigger "_stock_movement" after insert or update of "stock"
+on "public"."product_variant" for each row
+    execute procedure "public"."tg__product_variant_stock_movement"();
@@ -0,0 +1,9 @@
+drop table "public"."purchase_order_receipt";
+
+drop table "public"."purchase_order_line";
+
+drop table "public"."purchase_order";
+
+drop table "public"."supplier_variant";
+
+drop table "public"."supplier";
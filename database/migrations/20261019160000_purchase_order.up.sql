@@ -0,0 +1,127 @@
+create table if not exists "public"."supplier"(
+    -- "id"             uuid        not null default gen_random_uuid(),
+    "id"             int         not null generated by default as identity(start with 1 increment by 1),
+    "name"           citext      not null,
+    "contact_name"   citext      null,
+    "email"          citext      null,
+    "phone"          citext      null,
+    "lead_time_days" int         not null default 0,
+    "currency"       citext      not null,
+    "created_at"     timestamptz not null,
+    "updated_at"     timestamptz null,
+    "deleted_at"     timestamptz null,
+    constraint "supplier_id_pkey"              primary key("id"),
+    constraint "supplier_name_unique"          unique("name"),
+    constraint "supplier_name_check"           check((length(("name")::text) >= 2) and (length(("name")::text) <= 64)),
+    constraint "supplier_lead_time_days_check" check("lead_time_days" >= 0),
+    constraint "supplier_currency_check"       check(length(("currency")::text) = 3)
+);
+
+create trigger "_timestamps" before insert or update or delete
+on "public"."supplier" for each row
+    execute procedure "public"."tg__timestamps"();
+
+-- the variants a supplier sells, at most one supplier is preferred per
+-- variant
+create table if not exists "public"."supplier_variant"(
+    -- "id"                 uuid          not null default gen_random_uuid(),
+    "id"                 int           not null generated by default as identity(start with 1 increment by 1),
+    "supplier_id"        int           not null,
+    "product_variant_id" int           not null,
+    "supplier_sku"       citext        null,
+    "unit_cost"          decimal(19,4) not null,
+    "preferred"          bool          not null default false,
+    "created_at"         timestamptz   not null,
+    "updated_at"         timestamptz   null,
+    "deleted_at"         timestamptz   null,
+    foreign key("supplier_id")        references "supplier"("id")        on delete restrict deferrable initially deferred,
+    foreign key("product_variant_id") references "product_variant"("id") on delete restrict deferrable initially deferred,
+    constraint "supplier_variant_id_pkey"                         primary key("id"),
+    constraint "supplier_variant_supplier_id_product_variant_id_key" unique("supplier_id", "product_variant_id"),
+    constraint "supplier_variant_unit_cost_check"                 check("unit_cost" >= 0)
+);
+
+create unique index if not exists "supplier_variant_product_variant_id_preferred"
+on "public"."supplier_variant"(
+	"product_variant_id"
+) where "preferred";
+
+create trigger "_timestamps" before insert or update or delete
+on "public"."supplier_variant" for each row
+    execute procedure "public"."tg__timestamps"();
+
+create table if not exists "public"."purchase_order"(
+    -- "id"          uuid        not null default gen_random_uuid(),
+    "id"          int         not null generated by default as identity(start with 1 increment by 1),
+    "supplier_id" int         not null,
+    "status"      citext      not null default 'draft',
+    "currency"    citext      not null,
+    "expected_at" date        null,
+    "note"        citext      null,
+    "created_by"  citext      not null,
+    "sent_at"     timestamptz null,
+    "closed_at"   timestamptz null,
+    "created_at"  timestamptz not null,
+    "updated_at"  timestamptz null,
+    "deleted_at"  timestamptz null,
+    foreign key("supplier_id") references "supplier"("id") on delete restrict deferrable initially deferred,
+    constraint "purchase_order_id_pkey"      primary key("id"),
+    constraint "purchase_order_status_check" check("status" in ('draft', 'sent', 'partially_received', 'received', 'closed'))
+);
+
+create index if not exists "purchase_order_supplier_id"
+on "public"."purchase_order"(
+	"supplier_id"
+);
+
+create trigger "_timestamps" before insert or update or delete
+on "public"."purchase_order" for each row
+    execute procedure "public"."tg__timestamps"();
+
+create table if not exists "public"."purchase_order_line"(
+    -- "id"                 uuid          not null default gen_random_uuid(),
+    "id"                 int           not null generated by default as identity(start with 1 increment by 1),
+    "purchase_order_id"  int           not null,
+    "product_variant_id" int           not null,
+    "quantity"           int           not null,
+    "unit_cost"          decimal(19,4) not null,
+    "received"           int           not null default 0,
+    "created_at"         timestamptz   not null,
+    "updated_at"         timestamptz   null,
+    "deleted_at"         timestamptz   null,
+    foreign key("purchase_order_id")  references "purchase_order"("id")  on delete restrict deferrable initially deferred,
+    foreign key("product_variant_id") references "product_variant"("id") on delete restrict deferrable initially deferred,
+    constraint "purchase_order_line_id_pkey"                              primary key("id"),
+    constraint "purchase_order_line_purchase_order_id_product_variant_id_key" unique("purchase_order_id", "product_variant_id"),
+    constraint "purchase_order_line_quantity_check"                       check("quantity" > 0),
+    constraint "purchase_order_line_unit_cost_check"                      check("unit_cost" >= 0),
+    constraint "purchase_order_line_received_check"                       check("received" >= 0 and "received" <= "quantity")
+);
+
+create trigger "_timestamps" before insert or update or delete
+on "public"."purchase_order_line" for each row
+    execute procedure "public"."tg__timestamps"();
+
+-- every delivery received against a line
+create table if not exists "public"."purchase_order_receipt"(
+    -- "id"                     uuid        not null default gen_random_uuid(),
+    "id"                     int         not null generated by default as identity(start with 1 increment by 1),
+    "purchase_order_line_id" int         not null,
+    "quantity"               int         not null,
+    "received_by"            citext      not null,
+    "created_at"             timestamptz not null,
+    "updated_at"             timestamptz null,
+    "deleted_at"             timestamptz null,
+    foreign key("purchase_order_line_id") references "purchase_order_line"("id") on delete restrict deferrable initially deferred,
+    constraint "purchase_order_receipt_id_pkey"        primary key("id"),
+    constraint "purchase_order_receipt_quantity_check" check("quantity" > 0)
+);
+
+create index if not exists "purchase_order_receipt_purchase_order_line_id"
+on "public"."purchase_order_receipt"(
+	"purchase_order_line_id"
+);
+
+create trigger "_timestamps" before insert or update or delete
+on "public"."purchase_order_receipt" for each row
+    execute procedure "public"."tg__timestamps"();
@@ -0,0 +1,196 @@
+drop trigger "_low_stock" on "public"."product_variant";
+drop trigger "_consume_lots" on "public"."product_variant";
+drop trigger "_serials" on "public"."product_variant";
+drop trigger "_unit_cost" on "public"."product_variant";
+drop trigger "_stock_movement" on "public"."product_variant";
+drop trigger "_stock" on "public"."lot";
+
+drop function "public"."unit_factor"(int, citext);
+
+drop table "public"."unit_of_measure";
+
+alter table "public"."purchase_order_receipt"
+    alter column "quantity" type int using round("quantity");
+
+alter table "public"."purchase_order_line"
+    alter column "quantity" type int using round("quantity"),
+    alter column "received" type int using round("received");
+
+alter table "public"."stocktake_count"
+    alter column "quantity" type int using round("quantity");
+
+alter table "public"."stocktake_line"
+    alter column "expected"   type int using round("expected"),
+    alter column "counted"    type int using round("counted"),
+    alter column "adjustment" type int using round("adjustment");
+
+alter table "public"."stock_movement"
+    alter column "quantity" type int using round("quantity");
+
+alter table "public"."lot"
+    alter column "quantity" type int using round("quantity");
+
+alter table "public"."stock_reservation"
+    alter column "quantity" type int using round("quantity");
+
+alter table "public"."category"
+    alter column "reorder_point"    type int using round("reorder_point"),
+    alter column "reorder_quantity" type int using round("reorder_quantity");
+
+alter table "public"."product_variant"
+    drop constraint "product_variant_fractional_check",
+    drop constraint "product_variant_base_unit_check",
+    drop column "fractional",
+    drop column "base_unit",
+    alter column "stock"            type int using round("stock"),
+    alter column "reserved"         type int using round("reserved"),
+    alter column "reorder_point"    type int using round("reorder_point"),
+    alter column "reorder_quantity" type int using round("reorder_quantity"),
+    drop constraint "product_stock_check",
+    add constraint "product_stock_check" check("stock"::int >= 0);
+
+create or replace function "public"."tg__product_variant_low_stock"() returns trigger as $$
+declare
+    "category_reorder_point" int;
+    "old_threshold"          int;
+    "new_threshold"          int;
+begin
+    select "c"."reorder_point"
+    into "category_reorder_point"
+    from "public"."product" "p"
+    join "public"."category" "c" on "c"."id" = "p"."category_id"
+    where "p"."id" = NEW."product_id";
+
+    "new_threshold" = coalesce(NEW."reorder_point", "category_reorder_point");
+    if "new_threshold" is null or NEW."stock" - NEW."reserved" > "new_threshold" then
+        return NEW;
+    end if;
+
+    if TG_OP = 'UPDATE' then
+        "old_threshold" = coalesce(OLD."reorder_point", "category_reorder_point");
+        if "old_threshold" is not null and OLD."stock" - OLD."reserved" <= "old_threshold" then
+            return NEW;
+        end if;
+    end if;
+
+    perform pg_notify('low_stock', json_build_object(
+        'product_variant_id', NEW."id",
+        'product_id', NEW."product_id",
+        'name', NEW."name",
+        'stock', NEW."stock",
+        'reserved', NEW."reserved",
+        'available', NEW."stock" - NEW."reserved",
+        'reorder_point', "new_threshold",
+        'reorder_quantity', NEW."reorder_quantity"
+    )::text);
+
+    return NEW;
+end;
+$$ language plpgsql volatile set search_path to pg_catalog, public, pg_temp;
+
+create or replace function "public"."tg__product_variant_consume_lots"() returns trigger as $$
+declare
+    "remaining" int;
+    "picked"    record;
+begin
+    -- stock synced from the lots by "tg__lot_stock"
+    if pg_trigger_depth() > 1 or NEW."stock" = OLD."stock" then
+        return NEW;
+    end if;
+
+    if not exists (select 1 from "public"."lot" "l" where "l"."product_variant_id" = NEW."id") then
+        return NEW;
+    end if;
+
+    if NEW."stock" > OLD."stock" then
+        raise exception 'stock of lot tracked variant % can only be increased through its lots', NEW."id"
+            using errcode = 'check_violation';
+    end if;
+
+    "remaining" = OLD."stock" - NEW."stock";
+    for "picked" in
+        select "l"."id", "l"."quantity"
+        from "public"."lot" "l"
+        where "l"."product_variant_id" = NEW."id"
+            and "l"."quantity" > 0
+        order by "l"."expires_at" asc nulls last, "l"."id" asc
+        for update
+    loop
+        exit when "remaining" = 0;
+
+        update "public"."lot"
+        set "quantity" = "quantity" - least("picked"."quantity", "remaining")
+        where "id" = "picked"."id";
+
+        "remaining" = "remaining" - least("picked"."quantity", "remaining");
+    end loop;
+
+    return NEW;
+end;
+$$ language plpgsql volatile set search_path to pg_catalog, public, pg_temp;
+
+create or replace function "public"."tg__product_variant_unit_cost"() returns trigger as $$
+declare
+    "received_cost" decimal(19,4);
+    "received"      int;
+    "on_hand"       int;
+begin
+    "received_cost" = nullif(current_setting('pms.movement_unit_cost', true), '')::decimal(19,4);
+    "on_hand" = case when TG_OP = 'UPDATE' then greatest(OLD."stock", 0) else 0 end;
+    "received" = NEW."stock" - case when TG_OP = 'UPDATE' then OLD."stock" else 0 end;
+
+    if "received_cost" is null or "received" <= 0 then
+        return NEW;
+    end if;
+
+    NEW."unit_cost" = (
+        coalesce(NEW."unit_cost", "received_cost") * "on_hand" + "received_cost" * "received"
+    ) / ("on_hand" + "received");
+    return NEW;
+end;
+$$ language plpgsql volatile set search_path to pg_catalog, public, pg_temp;
+
+create or replace function "public"."tg__product_variant_stock_movement"() returns trigger as $$
+declare
+    "delta" int;
+begin
+    "delta" = NEW."stock" - case when TG_OP = 'UPDATE' then OLD."stock" else 0 end;
+    if "delta" = 0 then
+        return null;
+    end if;
+
+    insert into "public"."stock_movement" ("product_variant_id", "quantity", "unit_cost", "reason")
+    values (
+        NEW."id",
+        "delta",
+        case when "delta" > 0 then nullif(current_setting('pms.movement_unit_cost', true), '')::decimal(19,4) end,
+        coalesce(nullif(current_setting('pms.movement_reason', true), ''), 'adjustment')
+    );
+
+    return null;
+end;
+$$ language plpgsql volatile set search_path to pg_catalog, public, pg_temp;
+
+create trigger "_low_stock" after insert or update of "stock", "reserved", "reorder_point"
+on "public"."product_variant" for each row
+    execute procedure "public"."tg__product_variant_low_stock"();
+
+create trigger "_consume_lots" before update of "stock"
+on "public"."product_variant" for each row
+    execute procedure "public"."tg__product_variant_consume_lots"();
+
+create trigger "_serials" before update of "stock", "serialized"
+on "public"."product_variant" for each row
+    execute procedure "public"."tg__product_variant_serials"();
+
+create trigger "_unit_cost" before insert or update of "stock"
+on "public"."product_variant" for each row
+    execute procedure "public"."tg__product_variant_unit_cost"();
+
+create trigger "_stock_movement" after insert or update of "stock"
+on "public"."product_variant" for each row
+    execute procedure "public"."tg__product_variant_stock_movement"();
+
+create trigger "_stock" after insert or update of "quantity" or delete
+on "public"."lot" for each row
+    execute procedure "public"."tg__lot_stock"();
@@ -0,0 +1,251 @@
+-- column types used in a trigger definition can not be altered
+drop trigger "_low_stock" on "public"."product_variant";
+drop trigger "_consume_lots" on "public"."product_variant";
+drop trigger "_serials" on "public"."product_variant";
+drop trigger "_unit_cost" on "public"."product_variant";
+drop trigger "_stock_movement" on "public"."product_variant";
+drop trigger "_stock" on "public"."lot";
+
+-- quantities are kept in the base unit of their variant, which may be
+-- fractional (kg, m)
+alter table "public"."product_variant"
+    alter column "stock"            type decimal(19,4),
+    alter column "reserved"         type decimal(19,4),
+    alter column "reorder_point"    type decimal(19,4),
+    alter column "reorder_quantity" type decimal(19,4),
+    add column if not exists "base_unit"  citext not null default 'pcs',
+    add column if not exists "fractional" bool   not null default false,
+    drop constraint "product_stock_check",
+    add constraint "product_stock_check" check("stock" >= 0),
+    add constraint "product_variant_base_unit_check"  check((length(("base_unit")::text) >= 1) and (length(("base_unit")::text) <= 16)),
+    add constraint "product_variant_fractional_check" check("fractional" or ("stock" = trunc("stock") and "reserved" = trunc("reserved")));
+
+alter table "public"."category"
+    alter column "reorder_point"    type decimal(19,4),
+    alter column "reorder_quantity" type decimal(19,4);
+
+alter table "public"."stock_reservation"
+    alter column "quantity" type decimal(19,4);
+
+alter table "public"."lot"
+    alter column "quantity" type decimal(19,4);
+
+alter table "public"."stock_movement"
+    alter column "quantity" type decimal(19,4);
+
+alter table "public"."stocktake_line"
+    alter column "expected"   type decimal(19,4),
+    alter column "counted"    type decimal(19,4),
+    alter column "adjustment" type decimal(19,4);
+
+alter table "public"."stocktake_count"
+    alter column "quantity" type decimal(19,4);
+
+alter table "public"."purchase_order_line"
+    alter column "quantity" type decimal(19,4),
+    alter column "received" type decimal(19,4);
+
+alter table "public"."purchase_order_receipt"
+    alter column "quantity" type decimal(19,4);
+
+-- alternative units of a variant, one "name" is "factor" base units
+create table if not exists "public"."unit_of_measure"(
+    -- "id"                 uuid          not null default gen_random_uuid(),
+    "id"                 int           not null generated by default as identity(start with 1 increment by 1),
+    "product_variant_id" int           not null,
+    "name"               citext        not null,
+    "factor"             decimal(19,6) not null,
+    "created_at"         timestamptz   not null,
+    "updated_at"         timestamptz   null,
+    "deleted_at"         timestamptz   null,
+    foreign key("product_variant_id") references "product_variant"("id") on delete cascade deferrable initially deferred,
+    constraint "unit_of_measure_id_pkey"                      primary key("id"),
+    constraint "unit_of_measure_product_variant_id_name_key" unique("product_variant_id", "name"),
+    constraint "unit_of_measure_name_check"                   check((length(("name")::text) >= 1) and (length(("name")::text) <= 16)),
+    constraint "unit_of_measure_factor_check"                 check("factor" > 0)
+);
+
+create trigger "_timestamps" before insert or update or delete
+on "public"."unit_of_measure" for each row
+    execute procedure "public"."tg__timestamps"();
+
+-- returns how many base units one "unit" of a variant is, an empty unit is
+-- the base unit
+create function "public"."unit_factor"("variant_id" int, "unit" citext) returns decimal as $$
+declare
+    "base_unit" citext;
+    "factor"    decimal;
+begin
+    if "unit" is null or "unit" = '' then
+        return 1;
+    end if;
+
+    select "pv"."base_unit"
+    into "base_unit"
+    from "public"."product_variant" "pv"
+    where "pv"."id" = "variant_id";
+
+    if "base_unit" = "unit" then
+        return 1;
+    end if;
+
+    select "u"."factor"
+    into "factor"
+    from "public"."unit_of_measure" "u"
+    where "u"."product_variant_id" = "variant_id"
+        and "u"."name" = "unit";
+
+    if "factor" is null then
+        raise exception 'unknown unit % of variant %', "unit", "variant_id"
+            using errcode = 'check_violation';
+    end if;
+
+    return "factor";
+end;
+$$ language plpgsql stable set search_path to pg_catalog, public, pg_temp;
+
+create or replace function "public"."tg__product_variant_low_stock"() returns trigger as $$
+declare
+    "category_reorder_point" decimal(19,4);
+    "old_threshold"          decimal(19,4);
+    "new_threshold"          decimal(19,4);
+begin
+    select "c"."reorder_point"
+    into "category_reorder_point"
+    from "public"."product" "p"
+    join "public"."category" "c" on "c"."id" = "p"."category_id"
+    where "p"."id" = NEW."product_id";
+
+    "new_threshold" = coalesce(NEW."reorder_point", "category_reorder_point");
+    if "new_threshold" is null or NEW."stock" - NEW."reserved" > "new_threshold" then
+        return NEW;
+    end if;
+
+    if TG_OP = 'UPDATE' then
+        "old_threshold" = coalesce(OLD."reorder_point", "category_reorder_point");
+        if "old_threshold" is not null and OLD."stock" - OLD."reserved" <= "old_threshold" then
+            return NEW;
+        end if;
+    end if;
+
+    perform pg_notify('low_stock', json_build_object(
+        'product_variant_id', NEW."id",
+        'product_id', NEW."product_id",
+        'name', NEW."name",
+        'stock', NEW."stock",
+        'reserved', NEW."reserved",
+        'available', NEW."stock" - NEW."reserved",
+        'reorder_point', "new_threshold",
+        'reorder_quantity', NEW."reorder_quantity"
+    )::text);
+
+    return NEW;
+end;
+$$ language plpgsql volatile set search_path to pg_catalog, public, pg_temp;
+
+create or replace function "public"."tg__product_variant_consume_lots"() returns trigger as $$
+declare
+    "remaining" decimal(19,4);
+    "picked"    record;
+begin
+    -- stock synced from the lots by "tg__lot_stock"
+    if pg_trigger_depth() > 1 or NEW."stock" = OLD."stock" then
+        return NEW;
+    end if;
+
+    if not exists (select 1 from "public"."lot" "l" where "l"."product_variant_id" = NEW."id") then
+        return NEW;
+    end if;
+
+    if NEW."stock" > OLD."stock" then
+        raise exception 'stock of lot tracked variant % can only be increased through its lots', NEW."id"
+            using errcode = 'check_violation';
+    end if;
+
+    "remaining" = OLD."stock" - NEW."stock";
+    for "picked" in
+        select "l"."id", "l"."quantity"
+        from "public"."lot" "l"
+        where "l"."product_variant_id" = NEW."id"
+            and "l"."quantity" > 0
+        order by "l"."expires_at" asc nulls last, "l"."id" asc
+        for update
+    loop
+        exit when "remaining" = 0;
+
+        update "public"."lot"
+        set "quantity" = "quantity" - least("picked"."quantity", "remaining")
+        where "id" = "picked"."id";
+
+        "remaining" = "remaining" - least("picked"."quantity", "remaining");
+    end loop;
+
+    return NEW;
+end;
+$$ language plpgsql volatile set search_path to pg_catalog, public, pg_temp;
+
+create or replace function "public"."tg__product_variant_unit_cost"() returns trigger as $$
+declare
+    "received_cost" decimal(19,4);
+    "received"      decimal(19,4);
+    "on_hand"       decimal(19,4);
+begin
+    "received_cost" = nullif(current_setting('pms.movement_unit_cost', true), '')::decimal(19,4);
+    "on_hand" = case when TG_OP = 'UPDATE' then greatest(OLD."stock", 0) else 0 end;
+    "received" = NEW."stock" - case when TG_OP = 'UPDATE' then OLD."stock" else 0 end;
+
+    if "received_cost" is null or "received" <= 0 then
+        return NEW;
+    end if;
+
+    NEW."unit_cost" = (
+        coalesce(NEW."unit_cost", "received_cost") * "on_hand" + "received_cost" * "received"
+    ) / ("on_hand" + "received");
+    return NEW;
+end;
+$$ language plpgsql volatile set search_path to pg_catalog, public, pg_temp;
+
+create or replace function "public"."tg__product_variant_stock_movement"() returns trigger as $$
+declare
+    "delta" decimal(19,4);
+begin
+    "delta" = NEW."stock" - case when TG_OP = 'UPDATE' then OLD."stock" else 0 end;
+    if "delta" = 0 then
+        return null;
+    end if;
+
+    insert into "public"."stock_movement" ("product_variant_id", "quantity", "unit_cost", "reason")
+    values (
+        NEW."id",
+        "delta",
+        case when "delta" > 0 then nullif(current_setting('pms.movement_unit_cost', true), '')::decimal(19,4) end,
+        coalesce(nullif(current_setting('pms.movement_reason', true), ''), 'adjustment')
+    );
+
+    return null;
+end;
+$$ language plpgsql volatile set search_path to pg_catalog, public, pg_temp;
+
+create trigger "_low_stock" after insert or update of "stock", "reserved", "reorder_point"
+on "public"."product_variant" for each row
+    execute procedure "public"."tg__product_variant_low_stock"();
+
+create trigger "_consume_lots" before update of "stock"
+on "public"."product_variant" for each row
+    execute procedure "public"."tg__product_variant_consume_lots"();
+
+create trigger "_serials" before update of "stock", "serialized"
+on "public"."product_variant" for each row
+    execute procedure "public"."tg__product_variant_serials"();
+
+create trigger "_unit_cost" before insert or update of "stock"
+on "public"."product_variant" for each row
+    execute procedure "public"."tg__product_variant_unit_cost"();
+
+create trigger "_stock_movement" after insert or update of "stock"
+on "public"."product_variant" for each row
+    execute procedure "public"."tg__product_variant_stock_movement"();
+
+create trigger "_stock" after insert or update of "quantity" or delete
+on "public"."lot" for each row
+    execute procedure "public"."tg__lot_stock"();
@@ -0,0 +1,13 @@
+drop table "public"."transfer_order_receipt";
+
+drop table "public"."transfer_order_line";
+
+drop table "public"."transfer_order";
+
+drop trigger "_warehouse_stock" on "public"."product_variant";
+
+drop function "public"."tg__product_variant_warehouse_stock"();
+
+drop table "public"."warehouse_stock";
+
+drop table "public"."warehouse";
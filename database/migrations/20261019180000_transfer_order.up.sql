@@ -0,0 +1,168 @@
+create table if not exists "public"."warehouse"(
+    -- "id"         uuid        not null default gen_random_uuid(),
+    "id"         int         not null generated by default as identity(start with 1 increment by 1),
+    "code"       citext      not null,
+    "name"       citext      not null,
+    "is_default" bool        not null default false,
+    "created_at" timestamptz not null,
+    "updated_at" timestamptz null,
+    "deleted_at" timestamptz null,
+    constraint "warehouse_id_pkey"     primary key("id"),
+    constraint "warehouse_code_unique" unique("code"),
+    constraint "warehouse_code_check"  check((length(("code")::text) >= 1) and (length(("code")::text) <= 16)),
+    constraint "warehouse_name_check"  check((length(("name")::text) >= 2) and (length(("name")::text) <= 64))
+);
+
+-- stock that is not moved anywhere else lives at the default warehouse
+create unique index if not exists "warehouse_is_default"
+on "public"."warehouse"(
+	"is_default"
+) where "is_default";
+
+create trigger "_timestamps" before insert or update or delete
+on "public"."warehouse" for each row
+    execute procedure "public"."tg__timestamps"();
+
+insert into "public"."warehouse" ("code", "name", "is_default")
+values ('MAIN', 'Main warehouse', true);
+
+-- the stock of a variant per warehouse, the stock of the variant is the sum
+-- of its warehouses plus what is in transit between them
+create table if not exists "public"."warehouse_stock"(
+    -- "id"                 uuid          not null default gen_random_uuid(),
+    "id"                 int           not null generated by default as identity(start with 1 increment by 1),
+    "warehouse_id"       int           not null,
+    "product_variant_id" int           not null,
+    "quantity"           decimal(19,4) not null default 0,
+    "created_at"         timestamptz   not null,
+    "updated_at"         timestamptz   null,
+    "deleted_at"         timestamptz   null,
+    foreign key("warehouse_id")       references "warehouse"("id")       on delete restrict deferrable initially deferred,
+    foreign key("product_variant_id") references "product_variant"("id") on delete cascade deferrable initially deferred,
+    constraint "warehouse_stock_id_pkey"                              primary key("id"),
+    constraint "warehouse_stock_warehouse_id_product_variant_id_key" unique("warehouse_id", "product_variant_id"),
+    constraint "warehouse_stock_quantity_check"                      check("quantity" >= 0)
+);
+
+create index if not exists "warehouse_stock_product_variant_id"
+on "public"."warehouse_stock"(
+	"product_variant_id"
+);
+
+create trigger "_timestamps" before insert or update or delete
+on "public"."warehouse_stock" for each row
+    execute procedure "public"."tg__timestamps"();
+
+insert into "public"."warehouse_stock" ("warehouse_id", "product_variant_id", "quantity")
+select "w"."id", "pv"."id", "pv"."stock"
+from "public"."product_variant" "pv"
+cross join "public"."warehouse" "w"
+where "w"."is_default"
+    and "pv"."stock" > 0;
+
+-- books a stock change of a variant at the warehouse in the transaction-local
+-- "pms.warehouse_id" setting, or at the default warehouse if it is unset.
+-- 'none' books it nowhere, for units that leave while in transit.
+create function "public"."tg__product_variant_warehouse_stock"() returns trigger as $$
+declare
+    "delta"     decimal(19,4);
+    "warehouse" text;
+begin
+    "delta" = NEW."stock" - case when TG_OP = 'UPDATE' then OLD."stock" else 0 end;
+    "warehouse" = nullif(current_setting('pms.warehouse_id', true), '');
+    if "delta" = 0 or "warehouse" = 'none' then
+        return null;
+    end if;
+
+    insert into "public"."warehouse_stock" ("warehouse_id", "product_variant_id", "quantity")
+    select "w"."id", NEW."id", "delta"
+    from "public"."warehouse" "w"
+    where case when "warehouse" is null then "w"."is_default" else "w"."id" = "warehouse"::int end
+    on conflict ("warehouse_id", "product_variant_id") do update
+    set "quantity" = "warehouse_stock"."quantity" + excluded."quantity";
+
+    return null;
+end;
+$$ language plpgsql volatile set search_path to pg_catalog, public, pg_temp;
+
+create trigger "_warehouse_stock" after insert or update of "stock"
+on "public"."product_variant" for each row
+    execute procedure "public"."tg__product_variant_warehouse_stock"();
+
+create table if not exists "public"."transfer_order"(
+    -- "id"                       uuid        not null default gen_random_uuid(),
+    "id"                       int         not null generated by default as identity(start with 1 increment by 1),
+    "source_warehouse_id"      int         not null,
+    "destination_warehouse_id" int         not null,
+    "status"                   citext      not null default 'draft',
+    "note"                     citext      null,
+    "created_by"               citext      not null,
+    "dispatched_by"            citext      null,
+    "dispatched_at"            timestamptz null,
+    "closed_at"                timestamptz null,
+    "created_at"               timestamptz not null,
+    "updated_at"               timestamptz null,
+    "deleted_at"               timestamptz null,
+    foreign key("source_warehouse_id")      references "warehouse"("id") on delete restrict deferrable initially deferred,
+    foreign key("destination_warehouse_id") references "warehouse"("id") on delete restrict deferrable initially deferred,
+    constraint "transfer_order_id_pkey"           primary key("id"),
+    constraint "transfer_order_status_check"      check("status" in ('draft', 'in_transit', 'partially_received', 'received', 'closed', 'cancelled')),
+    constraint "transfer_order_warehouses_check"  check("source_warehouse_id" <> "destination_warehouse_id")
+);
+
+create trigger "_timestamps" before insert or update or delete
+on "public"."transfer_order" for each row
+    execute procedure "public"."tg__timestamps"();
+
+-- "lost" is what never arrived when the transfer was closed
+create table if not exists "public"."transfer_order_line"(
+    -- "id"                 uuid          not null default gen_random_uuid(),
+    "id"                 int           not null generated by default as identity(start with 1 increment by 1),
+    "transfer_order_id"  int           not null,
+    "product_variant_id" int           not null,
+    "quantity"           decimal(19,4) not null,
+    "received"           decimal(19,4) not null default 0,
+    "lost"               decimal(19,4) not null default 0,
+    "created_at"         timestamptz   not null,
+    "updated_at"         timestamptz   null,
+    "deleted_at"         timestamptz   null,
+    foreign key("transfer_order_id")  references "transfer_order"("id")  on delete restrict deferrable initially deferred,
+    foreign key("product_variant_id") references "product_variant"("id") on delete restrict deferrable initially deferred,
+    constraint "transfer_order_line_id_pkey"                              primary key("id"),
+    constraint "transfer_order_line_transfer_order_id_product_variant_id_key" unique("transfer_order_id", "product_variant_id"),
+    constraint "transfer_order_line_quantity_check"                       check("quantity" > 0),
+    constraint "transfer_order_line_received_check"                       check("received" >= 0 and "lost" >= 0 and "received" + "lost" <= "quantity")
+);
+
+create index if not exists "transfer_order_line_product_variant_id"
+on "public"."transfer_order_line"(
+	"product_variant_id"
+);
+
+create trigger "_timestamps" before insert or update or delete
+on "public"."transfer_order_line" for each row
+    execute procedure "public"."tg__timestamps"();
+
+-- every arrival received against a line
+create table if not exists "public"."transfer_order_receipt"(
+    -- "id"                     uuid          not null default gen_random_uuid(),
+    "id"                     int           not null generated by default as identity(start with 1 increment by 1),
+    "transfer_order_line_id" int           not null,
+    "quantity"               decimal(19,4) not null,
+    "received_by"            citext        not null,
+    "created_at"             timestamptz   not null,
+    "updated_at"             timestamptz   null,
+    "deleted_at"             timestamptz   null,
+    foreign key("transfer_order_line_id") references "transfer_order_line"("id") on delete restrict deferrable initially deferred,
+    constraint "transfer_order_receipt_id_pkey"        primary key("id"),
+    constraint "transfer_order_receipt_quantity_check" check("quantity" > 0)
+);
+
+create index if not exists "transfer_order_receipt_transfer_order_line_id"
+on "public"."transfer_order_receipt"(
+	"transfer_order_line_id"
+);
+
+create trigger "_timestamps" before insert or update or delete
+on "public"."transfer_order_receipt" for each row
+    execute procedure "public"."tg__timestamps"();
@@ -0,0 +1,3 @@
+drop table "public"."sales_order_line";
+
+drop table "public"."sales_order";
@@ -0,0 +1,54 @@
+create table if not exists "public"."sales_order"(
+    -- "id"           uuid        not null default gen_random_uuid(),
+    "id"           int         not null generated by default as identity(start with 1 increment by 1),
+    "customer"     citext      not null,
+    "status"       citext      not null default 'pending',
+    "note"         citext      null,
+    "paid_at"      timestamptz null,
+    "fulfilled_at" timestamptz null,
+    "cancelled_at" timestamptz null,
+    "created_at"   timestamptz not null,
+    "updated_at"   timestamptz null,
+    "deleted_at"   timestamptz null,
+    constraint "sales_order_id_pkey"      primary key("id"),
+    constraint "sales_order_status_check" check("status" in ('pending', 'paid', 'fulfilled', 'cancelled'))
+);
+
+create index if not exists "sales_order_customer"
+on "public"."sales_order"(
+	"customer"
+);
+
+create trigger "_timestamps" before insert or update or delete
+on "public"."sales_order" for each row
+    execute procedure "public"."tg__timestamps"();
+
+-- the name and price of the variant are captured when the order is placed,
+-- "quantity" is in the base unit and "unit_price" per base unit
+create table if not exists "public"."sales_order_line"(
+    -- "id"                 uuid          not null default gen_random_uuid(),
+    "id"                 int           not null generated by default as identity(start with 1 increment by 1),
+    "sales_order_id"     int           not null,
+    "product_variant_id" int           not null,
+    "name"               citext        not null,
+    "quantity"           decimal(19,4) not null,
+    "unit_price"         decimal(19,4) not null,
+    "created_at"         timestamptz   not null,
+    "updated_at"         timestamptz   null,
+    "deleted_at"         timestamptz   null,
+    foreign key("sales_order_id")     references "sales_order"("id")     on delete restrict deferrable initially deferred,
+    foreign key("product_variant_id") references "product_variant"("id") on delete restrict deferrable initially deferred,
+    constraint "sales_order_line_id_pkey"                           primary key("id"),
+    constraint "sales_order_line_sales_order_id_product_variant_id_key" unique("sales_order_id", "product_variant_id"),
+    constraint "sales_order_line_quantity_check"                    check("quantity" > 0),
+    constraint "sales_order_line_unit_price_check"                  check("unit_price" >= 0)
+);
+
+create index if not exists "sales_order_line_product_variant_id"
+on "public"."sales_order_line"(
+	"product_variant_id"
+);
+
+create trigger "_timestamps" before insert or update or delete
+on "public"."sales_order_line" for each row
+    execute procedure "public"."tg__timestamps"();
@@ -0,0 +1,3 @@
+drop table "public"."cart_line";
+
+drop table "public"."cart";
@@ -0,0 +1,50 @@
+-- anonymous carts are found by their token, user carts by their owner. A cart
+-- expires when it was not touched for the idle timeout
+create table if not exists "public"."cart"(
+    -- "id"         uuid        not null default gen_random_uuid(),
+    "id"         int         not null generated by default as identity(start with 1 increment by 1),
+    "token"      citext      not null,
+    "owner"      citext      null,
+    "expires_at" timestamptz not null,
+    "created_at" timestamptz not null,
+    "updated_at" timestamptz null,
+    "deleted_at" timestamptz null,
+    constraint "cart_id_pkey"      primary key("id"),
+    constraint "cart_token_unique" unique("token")
+);
+
+create unique index if not exists "cart_owner"
+on "public"."cart"(
+	"owner"
+) where "owner" is not null;
+
+create index if not exists "cart_expires_at"
+on "public"."cart"(
+	"expires_at"
+);
+
+create trigger "_timestamps" before insert or update or delete
+on "public"."cart" for each row
+    execute procedure "public"."tg__timestamps"();
+
+-- "quantity" is in the base unit, prices and stock are read live from
+-- product_variant
+create table if not exists "public"."cart_line"(
+    -- "id"                 uuid          not null default gen_random_uuid(),
+    "id"                 int           not null generated by default as identity(start with 1 increment by 1),
+    "cart_id"            int           not null,
+    "product_variant_id" int           not null,
+    "quantity"           decimal(19,4) not null,
+    "created_at"         timestamptz   not null,
+    "updated_at"         timestamptz   null,
+    "deleted_at"         timestamptz   null,
+    foreign key("cart_id")            references "cart"("id")            on delete cascade deferrable initially deferred,
+    foreign key("product_variant_id") references "product_variant"("id") on delete cascade deferrable initially deferred,
+    constraint "cart_line_id_pkey"                        primary key("id"),
+    constraint "cart_line_cart_id_product_variant_id_key" unique("cart_id", "product_variant_id"),
+    constraint "cart_line_quantity_check"                 check("quantity" > 0)
+);
+
+create trigger "_timestamps" before insert or update or delete
+on "public"."cart_line" for each row
+    execute procedure "public"."tg__timestamps"();
@@ -0,0 +1,5 @@
+drop table "public"."return_authorization_event";
+
+drop table "public"."return_authorization_line";
+
+drop table "public"."return_authorization";
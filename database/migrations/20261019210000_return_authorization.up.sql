@@ -0,0 +1,95 @@
+-- a return is requested by a customer, either against a fulfilled sales
+-- order or for loose variants, and moves from requested over approved and
+-- received to inspected, or is rejected
+create table if not exists "public"."return_authorization"(
+    -- "id"             uuid        not null default gen_random_uuid(),
+    "id"             int         not null generated by default as identity(start with 1 increment by 1),
+    "customer"       citext      not null,
+    "sales_order_id" int         null,
+    "status"         citext      not null default 'requested',
+    "note"           citext      null,
+    "approved_at"    timestamptz null,
+    "rejected_at"    timestamptz null,
+    "received_at"    timestamptz null,
+    "inspected_at"   timestamptz null,
+    "created_at"     timestamptz not null,
+    "updated_at"     timestamptz null,
+    "deleted_at"     timestamptz null,
+    foreign key("sales_order_id") references "sales_order"("id") on delete restrict deferrable initially deferred,
+    constraint "return_authorization_id_pkey"      primary key("id"),
+    constraint "return_authorization_status_check" check("status" in ('requested', 'approved', 'rejected', 'received', 'inspected'))
+);
+
+create index if not exists "return_authorization_customer"
+on "public"."return_authorization"(
+	"customer"
+);
+
+create index if not exists "return_authorization_sales_order_id"
+on "public"."return_authorization"(
+	"sales_order_id"
+);
+
+create trigger "_timestamps" before insert or update or delete
+on "public"."return_authorization" for each row
+    execute procedure "public"."tg__timestamps"();
+
+-- quantities are in the base unit. Every received unit gets exactly one
+-- disposition when the return is inspected, only "restocked" goes back into
+-- the stock of the variant
+create table if not exists "public"."return_authorization_line"(
+    -- "id"                      uuid          not null default gen_random_uuid(),
+    "id"                      int           not null generated by default as identity(start with 1 increment by 1),
+    "return_authorization_id" int           not null,
+    "product_variant_id"      int           not null,
+    "quantity"                decimal(19,4) not null,
+    "reason"                  citext        not null,
+    "received"                decimal(19,4) not null default 0,
+    "restocked"               decimal(19,4) not null default 0,
+    "refurbished"             decimal(19,4) not null default 0,
+    "scrapped"                decimal(19,4) not null default 0,
+    "created_at"              timestamptz   not null,
+    "updated_at"              timestamptz   null,
+    "deleted_at"              timestamptz   null,
+    foreign key("return_authorization_id") references "return_authorization"("id") on delete restrict deferrable initially deferred,
+    foreign key("product_variant_id")      references "product_variant"("id")      on delete restrict deferrable initially deferred,
+    constraint "return_authorization_line_id_pkey"                         primary key("id"),
+    constraint "return_authorization_line_return_id_product_variant_id_key" unique("return_authorization_id", "product_variant_id"),
+    constraint "return_authorization_line_quantity_check"                  check("quantity" > 0),
+    constraint "return_authorization_line_reason_check"                    check((length(("reason")::text) >= 1) and (length(("reason")::text) <= 64)),
+    constraint "return_authorization_line_received_check"                  check("received" >= 0 and "received" <= "quantity"),
+    constraint "return_authorization_line_disposition_check"               check("restocked" >= 0 and "refurbished" >= 0 and "scrapped" >= 0 and "restocked" + "refurbished" + "scrapped" <= "received")
+);
+
+create index if not exists "return_authorization_line_product_variant_id"
+on "public"."return_authorization_line"(
+	"product_variant_id"
+);
+
+create trigger "_timestamps" before insert or update or delete
+on "public"."return_authorization_line" for each row
+    execute procedure "public"."tg__timestamps"();
+
+-- the audit trail of a return, one event per status it went through
+create table if not exists "public"."return_authorization_event"(
+    -- "id"                      uuid        not null default gen_random_uuid(),
+    "id"                      int         not null generated by default as identity(start with 1 increment by 1),
+    "return_authorization_id" int         not null,
+    "status"                  citext      not null,
+    "actor"                   citext      not null,
+    "note"                    citext      null,
+    "created_at"              timestamptz not null,
+    "updated_at"              timestamptz null,
+    "deleted_at"              timestamptz null,
+    foreign key("return_authorization_id") references "return_authorization"("id") on delete restrict deferrable initially deferred,
+    constraint "return_authorization_event_id_pkey" primary key("id")
+);
+
+create index if not exists "return_authorization_event_return_authorization_id"
+on "public"."return_authorization_event"(
+	"return_authorization_id"
+);
+
+create trigger "_timestamps" before insert or update or delete
+on "public"."return_authorization_event" for each row
+    execute procedure "public"."tg__timestamps"();
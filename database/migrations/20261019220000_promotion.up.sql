@@ -0,0 +1,50 @@
+-- a promotion without a code applies automatically. Line promotions target
+-- the variants matching all of their category, product, variant and
+-- attribute, every variant if none is given. "used" counts the redemptions
+-- against "usage_limit"
+create table if not exists "public"."promotion"(
+    -- "id"                 uuid          not null default gen_random_uuid(),
+    "id"                 int           not null generated by default as identity(start with 1 increment by 1),
+    "name"               citext        not null,
+    "code"               citext        null,
+    "kind"               citext        not null,
+    "percent"            decimal(5,2)  null,
+    "buy_quantity"       int           null,
+    "get_quantity"       int           null,
+    "amount"             decimal(19,4) null,
+    "min_subtotal"       decimal(19,4) not null default 0,
+    "category_id"        int           null,
+    "product_id"         int           null,
+    "product_variant_id" int           null,
+    "attribute_id"       int           null,
+    "starts_at"          timestamptz   null,
+    "ends_at"            timestamptz   null,
+    "usage_limit"        int           null,
+    "used"               int           not null default 0,
+    "exclusive"          bool          not null default false,
+    "priority"           int           not null default 0,
+    "created_at"         timestamptz   not null,
+    "updated_at"         timestamptz   null,
+    "deleted_at"         timestamptz   null,
+    foreign key("category_id")        references "category"("id")        on delete restrict deferrable initially deferred,
+    foreign key("product_id")         references "product"("id")         on delete restrict deferrable initially deferred,
+    foreign key("product_variant_id") references "product_variant"("id") on delete restrict deferrable initially deferred,
+    foreign key("attribute_id")       references "attribute"("id")       on delete restrict deferrable initially deferred,
+    constraint "promotion_id_pkey"           primary key("id"),
+    constraint "promotion_code_unique"       unique("code"),
+    constraint "promotion_name_check"        check((length(("name")::text) >= 2) and (length(("name")::text) <= 64)),
+    constraint "promotion_code_check"        check((length(("code")::text) >= 1) and (length(("code")::text) <= 32)),
+    constraint "promotion_kind_check"        check(
+        ("kind" = 'percent_off' and "percent" > 0 and "percent" <= 100)
+        or ("kind" = 'buy_x_get_y' and "buy_quantity" > 0 and "get_quantity" > 0)
+        or ("kind" = 'amount_off_order' and "amount" > 0)
+    ),
+    constraint "promotion_min_subtotal_check" check("min_subtotal" >= 0),
+    constraint "promotion_window_check"       check("ends_at" > "starts_at"),
+    constraint "promotion_usage_limit_check"  check("usage_limit" > 0),
+    constraint "promotion_used_check"         check("used" >= 0 and ("usage_limit" is null or "used" <= "usage_limit"))
+);
+
+create trigger "_timestamps" before insert or update or delete
+on "public"."promotion" for each row
+    execute procedure "public"."tg__timestamps"();
@@ -0,0 +1 @@
+drop table "public"."catalog_import";
@@ -0,0 +1,23 @@
+-- every catalog import, dry runs included, with the errors of its rows so
+-- they can be downloaded later
+create table if not exists "public"."catalog_import"(
+    -- "id"         uuid        not null default gen_random_uuid(),
+    "id"         int         not null generated by default as identity(start with 1 increment by 1),
+    "file_name"  citext      not null,
+    "dry_run"    bool        not null,
+    "rows"       int         not null,
+    "created"    int         not null,
+    "updated"    int         not null,
+    "failed"     int         not null,
+    "errors"     jsonb       not null default '[]',
+    "created_by" citext      not null,
+    "created_at" timestamptz not null,
+    "updated_at" timestamptz null,
+    "deleted_at" timestamptz null,
+    constraint "catalog_import_id_pkey"      primary key("id"),
+    constraint "catalog_import_counts_check" check("rows" >= 0 and "created" >= 0 and "updated" >= 0 and "failed" >= 0)
+);
+
+create trigger "_timestamps" before insert or update or delete
+on "public"."catalog_import" for each row
+    execute procedure "public"."tg__timestamps"();
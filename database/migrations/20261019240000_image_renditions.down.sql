@@ -0,0 +1,2 @@
+alter table "public"."image"
+    drop column "renditions";
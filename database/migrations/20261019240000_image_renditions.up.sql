@@ -0,0 +1,4 @@
+-- the resized copies of an image, objects with the name, format, url, width
+-- and height of a rendition
+alter table "public"."image"
+    add column if not exists "renditions" jsonb not null default '[]';
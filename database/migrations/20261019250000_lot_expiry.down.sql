@@ -0,0 +1,40 @@
+create or replace function "public"."tg__product_variant_consume_lots"() returns trigger as $$
+declare
+    "remaining" decimal(19,4);
+    "picked"    record;
+begin
+    -- stock synced from the lots by "tg__lot_stock"
+    if pg_trigger_depth() > 1 or NEW."stock" = OLD."stock" then
+        return NEW;
+    end if;
+
+    if not exists (select 1 from "public"."lot" "l" where "l"."product_variant_id" = NEW."id") then
+        return NEW;
+    end if;
+
+    if NEW."stock" > OLD."stock" then
+        raise exception 'stock of lot tracked variant % can only be increased through its lots', NEW."id"
+            using errcode = 'check_violation';
+    end if;
+
+    "remaining" = OLD."stock" - NEW."stock";
+    for "picked" in
+        select "l"."id", "l"."quantity"
+        from "public"."lot" "l"
+        where "l"."product_variant_id" = NEW."id"
+            and "l"."quantity" > 0
+        order by "l"."expires_at" asc nulls last, "l"."id" asc
+        for update
+    loop
+        exit when "remaining" = 0;
+
+        update "public"."lot"
+        set "quantity" = "quantity" - least("picked"."quantity", "remaining")
+        where "id" = "picked"."id";
+
+        "remaining" = "remaining" - least("picked"."quantity", "remaining");
+    end loop;
+
+    return NEW;
+end;
+$$ language plpgsql volatile set search_path to pg_catalog, public, pg_temp;
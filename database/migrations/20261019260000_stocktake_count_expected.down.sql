@@ -0,0 +1,2 @@
+alter table "public"."stocktake_count"
+    drop column if exists "expected";
@@ -0,0 +1,5 @@
+-- the stock of the variant when a count was submitted, a session posts the
+-- counted quantity against the stock at its last count so that stock which
+-- moved while the session was open is kept
+alter table "public"."stocktake_count"
+    add column if not exists "expected" decimal(19,4) null;
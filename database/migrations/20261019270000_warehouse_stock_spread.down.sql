@@ -0,0 +1,21 @@
+create or replace function "public"."tg__product_variant_warehouse_stock"() returns trigger as $$
+declare
+    "delta"     decimal(19,4);
+    "warehouse" text;
+begin
+    "delta" = NEW."stock" - case when TG_OP = 'UPDATE' then OLD."stock" else 0 end;
+    "warehouse" = nullif(current_setting('pms.warehouse_id', true), '');
+    if "delta" = 0 or "warehouse" = 'none' then
+        return null;
+    end if;
+
+    insert into "public"."warehouse_stock" ("warehouse_id", "product_variant_id", "quantity")
+    select "w"."id", NEW."id", "delta"
+    from "public"."warehouse" "w"
+    where case when "warehouse" is null then "w"."is_default" else "w"."id" = "warehouse"::int end
+    on conflict ("warehouse_id", "product_variant_id") do update
+    set "quantity" = "warehouse_stock"."quantity" + excluded."quantity";
+
+    return null;
+end;
+$$ language plpgsql volatile set search_path to pg_catalog, public, pg_temp;
@@ -0,0 +1,54 @@
+-- a decrement that is not booked at a warehouse is taken from the default
+-- warehouse first and then from the other warehouses that hold the variant,
+-- stock in transit can not be taken
+create or replace function "public"."tg__product_variant_warehouse_stock"() returns trigger as $$
+declare
+    "delta"     decimal(19,4);
+    "warehouse" text;
+    "remaining" decimal(19,4);
+    "picked"    record;
+begin
+    "delta" = NEW."stock" - case when TG_OP = 'UPDATE' then OLD."stock" else 0 end;
+    "warehouse" = nullif(current_setting('pms.warehouse_id', true), '');
+    if "delta" = 0 or "warehouse" = 'none' then
+        return null;
+    end if;
+
+    if "delta" > 0 or "warehouse" is not null then
+        insert into "public"."warehouse_stock" ("warehouse_id", "product_variant_id", "quantity")
+        select "w"."id", NEW."id", "delta"
+        from "public"."warehouse" "w"
+        where case when "warehouse" is null then "w"."is_default" else "w"."id" = "warehouse"::int end
+        on conflict ("warehouse_id", "product_variant_id") do update
+        set "quantity" = "warehouse_stock"."quantity" + excluded."quantity";
+
+        return null;
+    end if;
+
+    "remaining" = -"delta";
+    for "picked" in
+        select "ws"."id", "ws"."quantity"
+        from "public"."warehouse_stock" "ws"
+        join "public"."warehouse" "w" on "w"."id" = "ws"."warehouse_id"
+        where "ws"."product_variant_id" = NEW."id"
+            and "ws"."quantity" > 0
+        order by "w"."is_default" desc, "ws"."quantity" desc, "ws"."id" asc
+        for update of "ws"
+    loop
+        exit when "remaining" = 0;
+
+        update "public"."warehouse_stock"
+        set "quantity" = "quantity" - least("picked"."quantity", "remaining")
+        where "id" = "picked"."id";
+
+        "remaining" = "remaining" - least("picked"."quantity", "remaining");
+    end loop;
+
+    if "remaining" > 0 then
+        raise exception 'warehouses of variant % are % short', NEW."id", "remaining"
+            using errcode = 'check_violation';
+    end if;
+
+    return null;
+end;
+$$ language plpgsql volatile set search_path to pg_catalog, public, pg_temp;
@@ -0,0 +1,3 @@
+drop table "public"."sales_order_line_serial";
+
+drop table "public"."sales_order_line_lot";
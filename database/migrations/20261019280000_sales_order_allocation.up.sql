@@ -0,0 +1,50 @@
+-- the lots a line of a lot tracked variant was taken from, so a cancelled
+-- order goes back into the same lots
+create table if not exists "public"."sales_order_line_lot"(
+    -- "id"                  uuid          not null default gen_random_uuid(),
+    "id"                  int           not null generated by default as identity(start with 1 increment by 1),
+    "sales_order_line_id" int           not null,
+    "lot_id"              int           not null,
+    "quantity"            decimal(19,4) not null,
+    "created_at"          timestamptz   not null,
+    "updated_at"          timestamptz   null,
+    "deleted_at"          timestamptz   null,
+    foreign key("sales_order_line_id") references "sales_order_line"("id") on delete restrict deferrable initially deferred,
+    foreign key("lot_id")              references "lot"("id")              on delete restrict deferrable initially deferred,
+    constraint "sales_order_line_lot_id_pkey"                        primary key("id"),
+    constraint "sales_order_line_lot_sales_order_line_id_lot_id_key" unique("sales_order_line_id", "lot_id"),
+    constraint "sales_order_line_lot_quantity_check"                 check("quantity" > 0)
+);
+
+create index if not exists "sales_order_line_lot_lot_id"
+on "public"."sales_order_line_lot"(
+	"lot_id"
+);
+
+create trigger "_timestamps" before insert or update or delete
+on "public"."sales_order_line_lot" for each row
+    execute procedure "public"."tg__timestamps"();
+
+-- the serials sold on a line of a serialized variant
+create table if not exists "public"."sales_order_line_serial"(
+    -- "id"                  uuid        not null default gen_random_uuid(),
+    "id"                  int         not null generated by default as identity(start with 1 increment by 1),
+    "sales_order_line_id" int         not null,
+    "serial_number_id"    int         not null,
+    "created_at"          timestamptz not null,
+    "updated_at"          timestamptz null,
+    "deleted_at"          timestamptz null,
+    foreign key("sales_order_line_id") references "sales_order_line"("id") on delete restrict deferrable initially deferred,
+    foreign key("serial_number_id")    references "serial_number"("id")    on delete restrict deferrable initially deferred,
+    constraint "sales_order_line_serial_id_pkey"                                  primary key("id"),
+    constraint "sales_order_line_serial_sales_order_line_id_serial_number_id_key" unique("sales_order_line_id", "serial_number_id")
+);
+
+create index if not exists "sales_order_line_serial_serial_number_id"
+on "public"."sales_order_line_serial"(
+	"serial_number_id"
+);
+
+create trigger "_timestamps" before insert or update or delete
+on "public"."sales_order_line_serial" for each row
+    execute procedure "public"."tg__timestamps"();
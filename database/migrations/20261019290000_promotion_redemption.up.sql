@@ -0,0 +1,24 @@
+-- a promotion is redeemed at most once per sales order, "used" of the
+-- promotion counts its redemptions
+create table if not exists "public"."promotion_redemption"(
+    -- "id"             uuid        not null default gen_random_uuid(),
+    "id"             int         not null generated by default as identity(start with 1 increment by 1),
+    "promotion_id"   int         not null,
+    "sales_order_id" int         not null,
+    "created_at"     timestamptz not null,
+    "updated_at"     timestamptz null,
+    "deleted_at"     timestamptz null,
+    foreign key("promotion_id")   references "promotion"("id")   on delete restrict deferrable initially deferred,
+    foreign key("sales_order_id") references "sales_order"("id") on delete restrict deferrable initially deferred,
+    constraint "promotion_redemption_id_pkey"                         primary key("id"),
+    constraint "promotion_redemption_promotion_id_sales_order_id_key" unique("promotion_id", "sales_order_id")
+);
+
+create index if not exists "promotion_redemption_sales_order_id"
+on "public"."promotion_redemption"(
+	"sales_order_id"
+);
+
+create trigger "_timestamps" before insert or update or delete
+on "public"."promotion_redemption" for each row
+    execute procedure "public"."tg__timestamps"();
@@ -0,0 +1,2 @@
+alter table "public"."user"
+    alter column "is_active" set default false;
@@ -0,0 +1,10 @@
+-- users are active once they sign up, only deactivated ones are locked out
+alter table "public"."user"
+    alter column "is_active" set default true;
+
+-- users who signed up inactive and were never changed since, a deactivated
+-- user has been updated
+update "public"."user"
+set "is_active" = true
+where not "is_active"
+    and "updated_at" is null;
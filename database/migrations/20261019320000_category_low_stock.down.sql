@@ -0,0 +1,3 @@
+drop trigger "_low_stock" on "public"."category";
+
+drop function "public"."tg__category_low_stock"();
@@ -0,0 +1,36 @@
+-- notifies "low_stock" for the variants of a category that fall to or below
+-- a raised category reorder point, those with a reorder point of their own
+-- are notified by "tg__product_variant_low_stock"
+create function "public"."tg__category_low_stock"() returns trigger as $$
+declare
+    "variant" record;
+begin
+    for "variant" in
+        select "pv".*
+        from "public"."product_variant" "pv"
+        join "public"."product" "p" on "p"."id" = "pv"."product_id"
+        where "p"."category_id" = NEW."id"
+            and "pv"."reorder_point" is null
+            and "pv"."stock" - "pv"."reserved" <= NEW."reorder_point"
+            and (OLD."reorder_point" is null or "pv"."stock" - "pv"."reserved" > OLD."reorder_point")
+        order by "pv"."id"
+    loop
+        perform pg_notify('low_stock', json_build_object(
+            'product_variant_id', "variant"."id",
+            'product_id', "variant"."product_id",
+            'name', "variant"."name",
+            'stock', "variant"."stock",
+            'reserved', "variant"."reserved",
+            'available', "variant"."stock" - "variant"."reserved",
+            'reorder_point', NEW."reorder_point",
+            'reorder_quantity', coalesce("variant"."reorder_quantity", NEW."reorder_quantity")
+        )::text);
+    end loop;
+
+    return NEW;
+end;
+$$ language plpgsql volatile set search_path to pg_catalog, public, pg_temp;
+
+create trigger "_low_stock" after update of "reorder_point"
+on "public"."category" for each row
+    execute procedure "public"."tg__category_low_stock"();
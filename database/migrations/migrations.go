@@ -1,10 +1,15 @@
 package migrations
 
 import (
+	"errors"
+	"fmt"
+	"io"
 	"log"
+	"os"
 
 	"github.com/golang-migrate/migrate/v4"
 	_ "github.com/golang-migrate/migrate/v4/database/postgres"
+	"github.com/golang-migrate/migrate/v4/source"
 	_ "github.com/golang-migrate/migrate/v4/source/file"
 )
 
@@ -22,3 +27,88 @@ func Run(sourceURL string, databaseURL string) {
 		}
 	}
 }
+
+// Up applies the next n migrations, all pending ones if n is 0.
+func Up(sourceURL string, databaseURL string, n int) error {
+	return change(sourceURL, databaseURL, func(m *migrate.Migrate) error {
+		if n == 0 {
+			return m.Up()
+		}
+		return m.Steps(n)
+	})
+}
+
+// Down reverts the last n migrations.
+func Down(sourceURL string, databaseURL string, n int) error {
+	return change(sourceURL, databaseURL, func(m *migrate.Migrate) error {
+		return m.Steps(-n)
+	})
+}
+
+// Goto migrates up or down to version.
+func Goto(sourceURL string, databaseURL string, version uint) error {
+	return change(sourceURL, databaseURL, func(m *migrate.Migrate) error {
+		return m.Migrate(version)
+	})
+}
+
+// Status writes the version of the database and every migration with
+// whether it is applied.
+func Status(sourceURL string, databaseURL string, w io.Writer) error {
+	m, err := migrate.New(sourceURL, databaseURL)
+	if err != nil {
+		return err
+	}
+	defer m.Close()
+
+	current, dirty, err := m.Version()
+	if err != nil && err != migrate.ErrNilVersion {
+		return err
+	}
+	if err == migrate.ErrNilVersion {
+		fmt.Fprintln(w, "version: none")
+	} else if dirty {
+		fmt.Fprintf(w, "version: %d (dirty)\n", current)
+	} else {
+		fmt.Fprintf(w, "version: %d\n", current)
+	}
+
+	migrations, err := source.Open(sourceURL)
+	if err != nil {
+		return err
+	}
+	defer migrations.Close()
+
+	version, err := migrations.First()
+	for err == nil {
+		state := "pending"
+		if current > 0 && version <= current {
+			state = "applied"
+		}
+		identifier := ""
+		if r, id, err := migrations.ReadUp(version); err == nil {
+			r.Close()
+			identifier = id
+		}
+		fmt.Fprintf(w, "%s %d %s\n", state, version, identifier)
+		version, err = migrations.Next(version)
+	}
+	if !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// change runs fn on the migrations, a change to nothing is no error.
+func change(sourceURL string, databaseURL string, fn func(m *migrate.Migrate) error) error {
+	m, err := migrate.New(sourceURL, databaseURL)
+	if err != nil {
+		return err
+	}
+	defer m.Close()
+
+	if err := fn(m); err != nil && err != migrate.ErrNoChange {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+package migrations
+
+import (
+	"log"
+	"net/url"
+
+	"github.com/golang-migrate/migrate/v4"
+)
+
+// seedsTable keeps the version of the seeds apart from the schema version.
+const seedsTable = "schema_seeds"
+
+// Seed applies the seeds which are not applied yet.
+func Seed(sourceURL string, databaseURL string) {
+	m := newSeeds(sourceURL, databaseURL)
+	if err := m.Up(); err != nil {
+		if err != migrate.ErrNoChange {
+			log.Fatalf("Unable to apply seeds: %v\n", err)
+		}
+		log.Printf("Unable to apply seeds: %v\n", err)
+	}
+}
+
+// Unseed reverts every applied seed.
+func Unseed(sourceURL string, databaseURL string) {
+	m := newSeeds(sourceURL, databaseURL)
+	if err := m.Down(); err != nil {
+		if err != migrate.ErrNoChange {
+			log.Fatalf("Unable to revert seeds: %v\n", err)
+		}
+		log.Printf("Unable to revert seeds: %v\n", err)
+	}
+}
+
+func newSeeds(sourceURL string, databaseURL string) *migrate.Migrate {
+	u, err := url.Parse(databaseURL)
+	if err != nil {
+		log.Fatalf("Unable to parse database url: %v\n", err)
+	}
+	query := u.Query()
+	query.Set("x-migrations-table", seedsTable)
+	u.RawQuery = query.Encode()
+
+	m, err := migrate.New(sourceURL, u.String())
+	if err != nil {
+		log.Fatalf("Unable to load seeds: %v\n", err)
+	}
+	return m
+}
@@ -10,7 +10,6 @@ import (
 	"github.com/jackc/pgconn"
 	"github.com/jackc/pgerrcode"
 	"github.com/jackc/pgx/v4"
-	"github.com/jackc/pgx/v4/pgxpool"
 	"github.com/ysfada/product-management-system/domain/common"
 	"github.com/ysfada/product-management-system/domain/dtos"
 	"github.com/ysfada/product-management-system/domain/entities"
@@ -18,12 +17,12 @@ import (
 )
 
 type AttributeRepository struct {
-	dbConn *pgxpool.Pool
+	dbConn DBTX
 }
 
 var _ interfaces.IAttributeRepository = (*AttributeRepository)(nil)
 
-func NewAttributeRepository(dbConn *pgxpool.Pool) *AttributeRepository {
+func NewAttributeRepository(dbConn DBTX) *AttributeRepository {
 	return &AttributeRepository{
 		dbConn: dbConn,
 	}
@@ -40,6 +39,7 @@ func (r *AttributeRepository) Fetch(ctx context.Context, page int, size int, sor
 			(SELECT "a"."id",
 					"a"."name",
 					"a"."type",
+					"a"."version",
 					"a"."created_at",
 					"a"."updated_at",
 					"a"."deleted_at"
@@ -88,6 +88,7 @@ func (r *AttributeRepository) GetByID(ctx context.Context, id int) (res *entitie
     SELECT "a"."id",
         "a"."name",
         "a"."type",
+        "a"."version",
         "a"."created_at",
         "a"."updated_at",
         "a"."deleted_at"
@@ -100,6 +101,7 @@ func (r *AttributeRepository) GetByID(ctx context.Context, id int) (res *entitie
 		&attribute.ID,
 		&attribute.Name,
 		&attribute.Type,
+		&attribute.Version,
 		&attribute.CreatedAt,
 		&attribute.UpdatedAt,
 		&attribute.DeletedAt,
@@ -127,6 +129,7 @@ func (r *AttributeRepository) Search(ctx context.Context, q string, page int, si
 			(SELECT "a"."id",
 					"a"."name",
 					"a"."type",
+					"a"."version",
 					"a"."created_at",
 					"a"."updated_at",
 					"a"."deleted_at"
@@ -177,9 +180,10 @@ func (r *AttributeRepository) Update(ctx context.Context, dto *dtos.UpdateAttrib
     SET "name" = $1,
         "type" = $2
     WHERE "id" = $3
+        AND ($4::int[] IS NULL OR "version" = ANY($4))
     `
 
-	_, err := r.dbConn.Exec(ctx, sql, dto.Name, dto.Type, dto.ID)
+	cmd, err := r.dbConn.Exec(ctx, sql, dto.Name, dto.Type, dto.ID, dto.Versions)
 
 	var pgErr *pgconn.PgError
 	if errors.As(err, &pgErr) {
@@ -187,38 +191,47 @@ func (r *AttributeRepository) Update(ctx context.Context, dto *dtos.UpdateAttrib
 			return common.ErrBadParamInput
 		}
 	}
-	return err
+	if err != nil {
+		return err
+	}
+	if cmd.RowsAffected() == 0 {
+		return staleOrNotFound(ctx, r.dbConn, "attribute", dto.ID, dto.Versions)
+	}
+	return nil
 }
 
-func (r *AttributeRepository) Create(ctx context.Context, dto *dtos.CreateAttributeDto) error {
+func (r *AttributeRepository) Create(ctx context.Context, dto *dtos.CreateAttributeDto) (int, error) {
 	sql := `
     INSERT INTO "public"."attribute"("name","type")
     VALUES ($1, $2)
+    RETURNING "id"
     `
-	_, err := r.dbConn.Exec(ctx, sql, dto.Name, dto.Type)
+	var id int
+	err := r.dbConn.QueryRow(ctx, sql, dto.Name, dto.Type).Scan(&id)
 
 	var pgErr *pgconn.PgError
 	if errors.As(err, &pgErr) {
 		if pgErr.Code == pgerrcode.CheckViolation {
-			return common.ErrBadParamInput
+			return 0, common.ErrBadParamInput
 		}
 	}
-	return err
+	return id, err
 }
 
-func (r *AttributeRepository) Delete(ctx context.Context, id int) error {
+func (r *AttributeRepository) Delete(ctx context.Context, id int, versions []int) error {
 	sql := `
     DELETE
     FROM "public"."attribute"
     WHERE "id" = $1
+        AND ($2::int[] IS NULL OR "version" = ANY($2))
     `
-	if cmd, err := r.dbConn.Exec(ctx, sql, id); err != nil {
-		return err
+	if cmd, err := r.dbConn.Exec(ctx, sql, id, versions); err != nil {
+		return stillReferenced(err)
 	} else {
 		if cmd.RowsAffected() > 0 {
 			return nil
 		} else {
-			return common.ErrNotFound
+			return staleOrNotFound(ctx, r.dbConn, "attribute", id, versions)
 		}
 	}
 }
@@ -0,0 +1,32 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+	"github.com/ysfada/product-management-system/domain/interfaces"
+)
+
+type BatchRepository struct {
+	dbConn *pgxpool.Pool
+}
+
+var _ interfaces.IBatchRepository = (*BatchRepository)(nil)
+
+func NewBatchRepository(dbConn *pgxpool.Pool) *BatchRepository {
+	return &BatchRepository{
+		dbConn: dbConn,
+	}
+}
+
+func (r *BatchRepository) Transaction(ctx context.Context, fn func(categories interfaces.ICategoryRepository, products interfaces.IProductRepository, attributes interfaces.IAttributeRepository) error) error {
+	return r.dbConn.BeginFunc(ctx, func(tx pgx.Tx) error {
+		// deferred constraints are checked by each operation, so its error
+		// is reported for it and not at the commit
+		if _, err := tx.Exec(ctx, `SET CONSTRAINTS ALL IMMEDIATE`); err != nil {
+			return err
+		}
+		return fn(NewCategoryRepository(tx), NewProductRepository(tx), NewAttributeRepository(tx))
+	})
+}
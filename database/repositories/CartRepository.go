@@ -0,0 +1,333 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v4"
+	"github.com/ysfada/product-management-system/domain/common"
+	"github.com/ysfada/product-management-system/domain/entities"
+	"github.com/ysfada/product-management-system/domain/interfaces"
+)
+
+type CartRepository struct {
+	dbConn DBTX
+}
+
+var _ interfaces.ICartRepository = (*CartRepository)(nil)
+
+func NewCartRepository(dbConn DBTX) *CartRepository {
+	return &CartRepository{
+		dbConn: dbConn,
+	}
+}
+
+// Get returns the cart of owner, or the anonymous cart of token if owner is
+// empty, with the current price and available stock of its lines. Expired
+// carts are not found.
+func (r *CartRepository) Get(ctx context.Context, owner string, token string) (*entities.Cart, error) {
+	sql := `
+    SELECT  "c"."id",
+            "c"."token",
+            "c"."owner",
+            "c"."expires_at",
+            "c"."created_at",
+            "c"."updated_at",
+            "c"."deleted_at"
+    FROM "public"."cart" "c"
+    WHERE CASE WHEN $1 <> '' THEN "c"."owner" = $1 ELSE "c"."owner" IS NULL AND "c"."token" = $2 END
+        AND "c"."expires_at" > NOW()
+    LIMIT 1
+    `
+	var cart entities.Cart
+	if err := r.dbConn.QueryRow(ctx, sql, owner, token).Scan(
+		&cart.ID,
+		&cart.Token,
+		&cart.Owner,
+		&cart.ExpiresAt,
+		&cart.CreatedAt,
+		&cart.UpdatedAt,
+		&cart.DeletedAt,
+	); err != nil {
+		switch err {
+		case pgx.ErrNoRows:
+			return nil, common.ErrNotFound
+		default:
+			return nil, err
+		}
+	}
+
+	sql = `
+    SELECT  "pv"."id",
+            "pv"."product_id",
+            "pv"."name",
+            "pv"."base_unit",
+            "l"."quantity",
+            "pv"."price",
+            "pv"."stock" - "pv"."reserved"
+    FROM "public"."cart_line" "l"
+    INNER JOIN "public"."product_variant" "pv"
+        ON "pv"."id" = "l"."product_variant_id"
+    WHERE "l"."cart_id" = $1
+    ORDER BY "l"."id" ASC
+    `
+	rows, err := r.dbConn.Query(ctx, sql, cart.ID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var line entities.CartLine
+		if err := rows.Scan(
+			&line.ProductVariantID,
+			&line.ProductID,
+			&line.Name,
+			&line.BaseUnit,
+			&line.Quantity,
+			&line.Price,
+			&line.Available,
+		); err != nil {
+			return nil, err
+		}
+		cart.Lines = append(cart.Lines, &line)
+	}
+
+	return &cart, rows.Err()
+}
+
+// Create creates an empty cart and returns its id. An empty owner creates an
+// anonymous cart. If owner already has a cart that one is kept and its id is
+// returned, an expired one is replaced.
+func (r *CartRepository) Create(ctx context.Context, owner string, token string, expiresAt time.Time) (int, error) {
+	var id int
+	err := r.dbConn.BeginFunc(ctx, func(tx pgx.Tx) error {
+		sql := `
+        DELETE FROM "public"."cart"
+        WHERE "owner" = $1
+            AND "expires_at" <= NOW()
+        `
+		if _, err := tx.Exec(ctx, sql, owner); err != nil {
+			return err
+		}
+
+		sql = `
+        INSERT INTO "public"."cart" ("token", "owner", "expires_at")
+        VALUES ($1, NULLIF($2, ''), $3)
+        ON CONFLICT ("owner") WHERE "owner" IS NOT NULL DO UPDATE
+        SET "expires_at" = EXCLUDED."expires_at"
+        RETURNING "id"
+        `
+		return tx.QueryRow(ctx, sql, token, owner, expiresAt).Scan(&id)
+	})
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.UniqueViolation {
+		return 0, common.ErrConflict
+	}
+	if err != nil {
+		return 0, err
+	}
+	return id, nil
+}
+
+// SetLine sets the quantity of a variant in the cart, or adds to it if add is
+// true, and extends the cart to expiresAt. The quantity is given in unit and
+// stored in the base unit of the variant. If the resulting quantity is more
+// than the available stock nothing is changed and
+// common.ErrInsufficientStock is returned.
+func (r *CartRepository) SetLine(ctx context.Context, id int, variantID int, quantity float64, unit string, add bool, expiresAt time.Time) error {
+	err := r.dbConn.BeginFunc(ctx, func(tx pgx.Tx) error {
+		sql := `
+        WITH "q" AS (
+            SELECT $3::decimal * "public"."unit_factor"($2, $4) "quantity"
+        ), "l" AS (
+            INSERT INTO "public"."cart_line" ("cart_id", "product_variant_id", "quantity")
+            SELECT $1, "pv"."id", "q"."quantity"
+            FROM "public"."product_variant" "pv", "q"
+            WHERE "pv"."id" = $2
+            ON CONFLICT ("cart_id", "product_variant_id") DO UPDATE
+            SET "quantity" = CASE WHEN $5 THEN "cart_line"."quantity" + EXCLUDED."quantity" ELSE EXCLUDED."quantity" END
+            RETURNING "product_variant_id", "quantity"
+        )
+        SELECT "pv"."fractional" OR "l"."quantity" = TRUNC("l"."quantity"),
+                "l"."quantity" <= "pv"."stock" - "pv"."reserved"
+        FROM "l"
+        INNER JOIN "public"."product_variant" "pv"
+            ON "pv"."id" = "l"."product_variant_id"
+        `
+		var whole, available bool
+		if err := tx.QueryRow(ctx, sql, id, variantID, quantity, unit, add).Scan(&whole, &available); err != nil {
+			if err == pgx.ErrNoRows {
+				return common.ErrNotFound
+			}
+			return err
+		}
+		if !whole {
+			return common.ErrBadParamInput
+		}
+		if !available {
+			return common.ErrInsufficientStock
+		}
+
+		return r.touch(ctx, tx, id, expiresAt)
+	})
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		switch pgErr.Code {
+		case pgerrcode.CheckViolation:
+			// an unknown unit
+			return common.ErrBadParamInput
+		case pgerrcode.ForeignKeyViolation:
+			// the cart was deleted meanwhile
+			return common.ErrNotFound
+		}
+	}
+	return err
+}
+
+// RemoveLine removes a variant from the cart and extends the cart to
+// expiresAt.
+func (r *CartRepository) RemoveLine(ctx context.Context, id int, variantID int, expiresAt time.Time) error {
+	return r.dbConn.BeginFunc(ctx, func(tx pgx.Tx) error {
+		sql := `
+        DELETE FROM "public"."cart_line"
+        WHERE "cart_id" = $1
+            AND "product_variant_id" = $2
+        `
+		if cmd, err := tx.Exec(ctx, sql, id, variantID); err != nil {
+			return err
+		} else if cmd.RowsAffected() == 0 {
+			return common.ErrNotFound
+		}
+
+		return r.touch(ctx, tx, id, expiresAt)
+	})
+}
+
+func (r *CartRepository) Delete(ctx context.Context, id int) error {
+	sql := `
+    DELETE FROM "public"."cart"
+    WHERE "id" = $1
+    `
+	if cmd, err := r.dbConn.Exec(ctx, sql, id); err != nil {
+		return err
+	} else if cmd.RowsAffected() == 0 {
+		return common.ErrNotFound
+	}
+
+	return nil
+}
+
+// Merge moves the lines of the anonymous cart of token into the cart of
+// owner, adding up the quantities of variants in both, and deletes the
+// anonymous cart. If owner has no cart the anonymous cart becomes its cart.
+// Merged quantities are not checked against the available stock.
+func (r *CartRepository) Merge(ctx context.Context, token string, owner string, expiresAt time.Time) error {
+	err := r.dbConn.BeginFunc(ctx, func(tx pgx.Tx) error {
+		sql := `
+        SELECT "id"
+        FROM "public"."cart"
+        WHERE "token" = $1
+            AND "owner" IS NULL
+            AND "expires_at" > NOW()
+        FOR UPDATE
+        `
+		var anonymousID int
+		if err := tx.QueryRow(ctx, sql, token).Scan(&anonymousID); err != nil {
+			if err == pgx.ErrNoRows {
+				return common.ErrNotFound
+			}
+			return err
+		}
+
+		sql = `
+        DELETE FROM "public"."cart"
+        WHERE "owner" = $1
+            AND "expires_at" <= NOW()
+        `
+		if _, err := tx.Exec(ctx, sql, owner); err != nil {
+			return err
+		}
+
+		sql = `
+        SELECT "id"
+        FROM "public"."cart"
+        WHERE "owner" = $1
+        FOR UPDATE
+        `
+		var ownerID int
+		if err := tx.QueryRow(ctx, sql, owner).Scan(&ownerID); err != nil {
+			if err != pgx.ErrNoRows {
+				return err
+			}
+
+			sql = `
+            UPDATE "public"."cart"
+            SET "owner" = $2
+            WHERE "id" = $1
+            `
+			if _, err := tx.Exec(ctx, sql, anonymousID, owner); err != nil {
+				return err
+			}
+			return r.touch(ctx, tx, anonymousID, expiresAt)
+		}
+
+		sql = `
+        INSERT INTO "public"."cart_line" ("cart_id", "product_variant_id", "quantity")
+        SELECT $2, "product_variant_id", "quantity"
+        FROM "public"."cart_line"
+        WHERE "cart_id" = $1
+        ON CONFLICT ("cart_id", "product_variant_id") DO UPDATE
+        SET "quantity" = "cart_line"."quantity" + EXCLUDED."quantity"
+        `
+		if _, err := tx.Exec(ctx, sql, anonymousID, ownerID); err != nil {
+			return err
+		}
+
+		sql = `
+        DELETE FROM "public"."cart"
+        WHERE "id" = $1
+        `
+		if _, err := tx.Exec(ctx, sql, anonymousID); err != nil {
+			return err
+		}
+		return r.touch(ctx, tx, ownerID, expiresAt)
+	})
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.UniqueViolation {
+		// owner got a cart meanwhile
+		return common.ErrConflict
+	}
+	return err
+}
+
+// DeleteExpired deletes every expired cart and returns the number of deleted
+// carts.
+func (r *CartRepository) DeleteExpired(ctx context.Context) (int, error) {
+	sql := `
+    DELETE FROM "public"."cart"
+    WHERE "expires_at" <= NOW()
+    `
+	if cmd, err := r.dbConn.Exec(ctx, sql); err != nil {
+		return 0, err
+	} else {
+		return int(cmd.RowsAffected()), nil
+	}
+}
+
+// touch extends the cart to expiresAt.
+func (r *CartRepository) touch(ctx context.Context, tx pgx.Tx, id int, expiresAt time.Time) error {
+	sql := `
+    UPDATE "public"."cart"
+    SET "expires_at" = $2
+    WHERE "id" = $1
+    `
+	_, err := tx.Exec(ctx, sql, id, expiresAt)
+	return err
+}
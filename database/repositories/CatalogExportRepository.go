@@ -0,0 +1,140 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/ysfada/product-management-system/domain/dtos"
+	"github.com/ysfada/product-management-system/domain/entities"
+	"github.com/ysfada/product-management-system/domain/interfaces"
+)
+
+// catalogExportFetchSize is the number of rows fetched from the cursor at a
+// time.
+const catalogExportFetchSize = 500
+
+type CatalogExportRepository struct {
+	dbConn DBTX
+}
+
+var _ interfaces.ICatalogExportRepository = (*CatalogExportRepository)(nil)
+
+func NewCatalogExportRepository(dbConn DBTX) *CatalogExportRepository {
+	return &CatalogExportRepository{
+		dbConn: dbConn,
+	}
+}
+
+// Export calls fn with every variant matching the query, ordered by product
+// and variant, and with every product without variants as a row of
+// VariantID 0. The rows are read from a cursor, catalogExportFetchSize at a
+// time, an error of fn stops the export and is returned.
+func (r *CatalogExportRepository) Export(ctx context.Context, query *dtos.CatalogExportQueryDto, fn func(row *entities.CatalogExportRow) error) error {
+	args := []interface{}{query.Q, query.CategoryID}
+	attributeFilter := ""
+	for _, attr := range query.Attrs {
+		args = append(args, attr.Type, attr.Names)
+		attributeFilter += fmt.Sprintf(`
+        AND EXISTS (
+            SELECT 1
+            FROM "public"."product_attributes" "pa"
+            JOIN "public"."attribute" "a" ON "a"."id" = "pa"."attribute_id"
+            WHERE "pa"."product_variant_id" = "pv"."id"
+                AND "a"."type" = $%d
+                AND "a"."name" = ANY($%d::text[]::citext[])
+        )`, len(args)-1, len(args))
+	}
+
+	return r.dbConn.BeginFunc(ctx, func(tx pgx.Tx) error {
+		sql := fmt.Sprintf(`
+        DECLARE "catalog_export" NO SCROLL CURSOR FOR
+        SELECT  "p"."id",
+                "p"."name",
+                COALESCE("p"."description", ''),
+                "c"."name",
+                COALESCE("pv"."id", 0),
+                COALESCE("pv"."name", ''),
+                COALESCE("pv"."price", 0),
+                COALESCE("pv"."stock", 0),
+                ARRAY(
+                    SELECT "a"."type"::text
+                    FROM "public"."product_attributes" "pa"
+                    JOIN "public"."attribute" "a" ON "a"."id" = "pa"."attribute_id"
+                    WHERE "pa"."product_variant_id" = "pv"."id"
+                    ORDER BY "a"."type", "a"."name"
+                ) "attribute_types",
+                ARRAY(
+                    SELECT "a"."name"::text
+                    FROM "public"."product_attributes" "pa"
+                    JOIN "public"."attribute" "a" ON "a"."id" = "pa"."attribute_id"
+                    WHERE "pa"."product_variant_id" = "pv"."id"
+                    ORDER BY "a"."type", "a"."name"
+                ) "attribute_names",
+                ARRAY(
+                    SELECT "i"."name"::text
+                    FROM "public"."product_images" "pi"
+                    JOIN "public"."image" "i" ON "i"."id" = "pi"."image_id"
+                    WHERE "pi"."product_id" = "p"."id"
+                        AND COALESCE("i"."name", '') <> ''
+                    ORDER BY "i"."id"
+                ) "images"
+        FROM "public"."product" "p"
+        JOIN "public"."category" "c" ON "c"."id" = "p"."category_id"
+        LEFT JOIN "public"."product_variant" "pv" ON "pv"."product_id" = "p"."id"
+        WHERE "p"."name" LIKE '%%' || $1 || '%%'
+            AND ($2 = 0 OR "p"."category_id" = $2)
+            %s
+        ORDER BY "p"."%s" %s, "p"."id", "pv"."id"
+        `, attributeFilter, query.SortBy, query.OrderBy)
+		if _, err := tx.Exec(ctx, sql, args...); err != nil {
+			return err
+		}
+
+		for {
+			rows, err := tx.Query(ctx, fmt.Sprintf(`FETCH FORWARD %d FROM "catalog_export"`, catalogExportFetchSize))
+			if err != nil {
+				return err
+			}
+
+			var batch []*entities.CatalogExportRow
+			for rows.Next() {
+				var row entities.CatalogExportRow
+				var attributeTypes, attributeNames []string
+				if err := rows.Scan(
+					&row.ProductID,
+					&row.Product,
+					&row.Description,
+					&row.Category,
+					&row.VariantID,
+					&row.Variant,
+					&row.Price,
+					&row.Stock,
+					&attributeTypes,
+					&attributeNames,
+					&row.Images,
+				); err != nil {
+					rows.Close()
+					return err
+				}
+				for i := range attributeTypes {
+					row.Attributes = append(row.Attributes, &entities.Attribute{Type: attributeTypes[i], Name: attributeNames[i]})
+				}
+				batch = append(batch, &row)
+			}
+			if err := rows.Err(); err != nil {
+				return err
+			}
+
+			// fn runs after the fetch is read, the connection is free again
+			for _, row := range batch {
+				if err := fn(row); err != nil {
+					return err
+				}
+			}
+			if len(batch) < catalogExportFetchSize {
+				return nil
+			}
+		}
+	})
+}
@@ -0,0 +1,400 @@
+package repositories
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"github.com/ysfada/product-management-system/domain/common"
+	"github.com/ysfada/product-management-system/domain/entities"
+	"github.com/ysfada/product-management-system/domain/interfaces"
+)
+
+type CatalogImportRepository struct {
+	dbConn DBTX
+}
+
+var _ interfaces.ICatalogImportRepository = (*CatalogImportRepository)(nil)
+
+func NewCatalogImportRepository(dbConn DBTX) *CatalogImportRepository {
+	return &CatalogImportRepository{
+		dbConn: dbConn,
+	}
+}
+
+// errDryRun rolls back the transaction of a dry run.
+var errDryRun = errors.New("dry run")
+
+// rowError fails a row of an import with a message for the user.
+type rowError struct {
+	column  string
+	message string
+}
+
+func (e *rowError) Error() string {
+	return e.message
+}
+
+// Apply imports the batches of rows, every batch in a transaction of its
+// own and every row in a savepoint so a failing row is left out without
+// failing the others. A dry run applies the batches in savepoints of one
+// transaction which is rolled back at the end, so a batch sees what the ones
+// before it would have done and its plans and errors are exactly what the
+// import would do.
+func (r *CatalogImportRepository) Apply(ctx context.Context, batches [][]*entities.CatalogImportRow, dryRun bool) ([]*entities.CatalogImportPlan, []*entities.CatalogImportError, error) {
+	var plans []*entities.CatalogImportPlan
+	var rowErrors []*entities.CatalogImportError
+	apply := func(tx pgx.Tx, rows []*entities.CatalogImportRow) error {
+		batchPlans, batchErrors, err := r.applyBatch(ctx, tx, rows)
+		plans = append(plans, batchPlans...)
+		rowErrors = append(rowErrors, batchErrors...)
+		return err
+	}
+
+	if !dryRun {
+		for _, rows := range batches {
+			if err := r.dbConn.BeginFunc(ctx, func(tx pgx.Tx) error {
+				return apply(tx, rows)
+			}); err != nil {
+				return nil, nil, err
+			}
+		}
+		return plans, rowErrors, nil
+	}
+
+	err := r.dbConn.BeginFunc(ctx, func(tx pgx.Tx) error {
+		for _, rows := range batches {
+			if err := tx.BeginFunc(ctx, func(sp pgx.Tx) error {
+				return apply(sp, rows)
+			}); err != nil {
+				return err
+			}
+		}
+		return errDryRun
+	})
+	if err != nil && err != errDryRun {
+		return nil, nil, err
+	}
+
+	return plans, rowErrors, nil
+}
+
+// applyBatch applies the rows of a batch, every row in a savepoint of its
+// own.
+func (r *CatalogImportRepository) applyBatch(ctx context.Context, tx pgx.Tx, rows []*entities.CatalogImportRow) ([]*entities.CatalogImportPlan, []*entities.CatalogImportError, error) {
+	// the reason of the stock movements recorded by product_variant
+	if _, err := tx.Exec(ctx, `SELECT set_config('pms.movement_reason', 'import', true)`); err != nil {
+		return nil, nil, err
+	}
+
+	var plans []*entities.CatalogImportPlan
+	var rowErrors []*entities.CatalogImportError
+	for _, row := range rows {
+		var plan *entities.CatalogImportPlan
+		err := tx.BeginFunc(ctx, func(sp pgx.Tx) error {
+			var err error
+			plan, err = r.applyRow(ctx, sp, row)
+			return err
+		})
+
+		var rowErr *rowError
+		var pgErr *pgconn.PgError
+		switch {
+		case err == nil:
+			plans = append(plans, plan)
+		case errors.As(err, &rowErr):
+			rowErrors = append(rowErrors, &entities.CatalogImportError{Line: row.Line, Column: rowErr.column, Message: rowErr.message})
+		case errors.As(err, &pgErr):
+			// a constraint of the catalog, e.g. a stock below the reserved
+			// quantity or a fractional stock of a variant counted in whole
+			// units
+			rowErrors = append(rowErrors, &entities.CatalogImportError{Line: row.Line, Message: pgErr.Message})
+		default:
+			return nil, nil, err
+		}
+	}
+	return plans, rowErrors, nil
+}
+
+func (r *CatalogImportRepository) applyRow(ctx context.Context, tx pgx.Tx, row *entities.CatalogImportRow) (*entities.CatalogImportPlan, error) {
+	plan := entities.CatalogImportPlan{
+		Line:    row.Line,
+		Product: row.Product,
+		Variant: row.Variant,
+	}
+
+	sql := `
+    WITH "c" AS (
+        INSERT INTO "public"."category" ("name")
+        VALUES ($1)
+        ON CONFLICT ("name") DO NOTHING
+        RETURNING "id"
+    )
+    SELECT "id" FROM "c"
+    UNION ALL
+    SELECT "id" FROM "public"."category" WHERE "name" = $1
+    LIMIT 1
+    `
+	var categoryID int
+	if err := tx.QueryRow(ctx, sql, row.Category).Scan(&categoryID); err != nil {
+		return nil, err
+	}
+
+	// product names are not unique, a name used twice can not be imported
+	sql = `
+    SELECT "id"
+    FROM "public"."product"
+    WHERE "name" = $1
+    LIMIT 2
+    `
+	var productIDs []int
+	if dbRows, err := tx.Query(ctx, sql, row.Product); err != nil {
+		return nil, err
+	} else {
+		defer dbRows.Close()
+		for dbRows.Next() {
+			var id int
+			if err := dbRows.Scan(&id); err != nil {
+				return nil, err
+			}
+			productIDs = append(productIDs, id)
+		}
+		if err := dbRows.Err(); err != nil {
+			return nil, err
+		}
+	}
+
+	var productID int
+	switch len(productIDs) {
+	case 0:
+		sql = `
+        INSERT INTO "public"."product" ("name", "description", "category_id")
+        VALUES ($1, NULLIF($2, ''), $3)
+        RETURNING "id"
+        `
+		if err := tx.QueryRow(ctx, sql, row.Product, row.Description, categoryID).Scan(&productID); err != nil {
+			return nil, err
+		}
+		plan.ProductAction = "create"
+	case 1:
+		productID = productIDs[0]
+		sql = `
+        UPDATE "public"."product"
+        SET "description" = COALESCE(NULLIF($2, ''), "description"),
+            "category_id" = $3
+        WHERE "id" = $1
+        `
+		if _, err := tx.Exec(ctx, sql, productID, row.Description, categoryID); err != nil {
+			return nil, err
+		}
+		plan.ProductAction = "update"
+	default:
+		return nil, &rowError{column: "product", message: fmt.Sprintf("more than one product is named %q", row.Product)}
+	}
+
+	// a row without a variant is a product without variants
+	if len(row.Variant) == 0 {
+		if err := r.addImages(ctx, tx, productID, row.Images); err != nil {
+			return nil, err
+		}
+		return &plan, nil
+	}
+
+	sql = `
+    SELECT "id"
+    FROM "public"."product_variant"
+    WHERE "product_id" = $1
+        AND "name" = $2
+    ORDER BY "id"
+    LIMIT 1
+    `
+	var variantID int
+	switch err := tx.QueryRow(ctx, sql, productID, row.Variant).Scan(&variantID); err {
+	case pgx.ErrNoRows:
+		sql = `
+        INSERT INTO "public"."product_variant" ("product_id", "name", "price", "stock")
+        VALUES ($1, $2, $3, COALESCE($4::decimal, 0))
+        RETURNING "id"
+        `
+		if err := tx.QueryRow(ctx, sql, productID, row.Variant, row.Price, row.Stock).Scan(&variantID); err != nil {
+			return nil, err
+		}
+		plan.VariantAction = "create"
+	case nil:
+		// the stock of a serialized or lot tracked variant follows its
+		// serials or lots and is kept as is
+		sql = `
+        UPDATE "public"."product_variant" "pv"
+        SET "price" = $2,
+            "stock" = CASE
+                WHEN $3::decimal IS NULL
+                    OR "pv"."serialized"
+                    OR EXISTS (SELECT 1 FROM "public"."lot" "l" WHERE "l"."product_variant_id" = "pv"."id")
+                THEN "pv"."stock"
+                ELSE $3::decimal
+            END
+        WHERE "pv"."id" = $1
+        `
+		if _, err := tx.Exec(ctx, sql, variantID, row.Price, row.Stock); err != nil {
+			return nil, err
+		}
+		plan.VariantAction = "update"
+	default:
+		return nil, err
+	}
+
+	if len(row.Attributes) > 0 {
+		var attributeIDs []int
+		for _, attribute := range row.Attributes {
+			sql = `
+            WITH "a" AS (
+                SELECT "id"
+                FROM "public"."attribute"
+                WHERE "type" = $1
+                    AND "name" = $2
+                ORDER BY "id"
+                LIMIT 1
+            ), "i" AS (
+                INSERT INTO "public"."attribute" ("type", "name")
+                SELECT $1, $2
+                WHERE NOT EXISTS (SELECT 1 FROM "a")
+                RETURNING "id"
+            )
+            SELECT "id" FROM "a"
+            UNION ALL
+            SELECT "id" FROM "i"
+            `
+			var attributeID int
+			if err := tx.QueryRow(ctx, sql, attribute.Type, attribute.Name).Scan(&attributeID); err != nil {
+				return nil, err
+			}
+			attributeIDs = append(attributeIDs, attributeID)
+		}
+
+		// the attributes of the row replace those of the variant
+		sql = `
+        DELETE FROM "public"."product_attributes"
+        WHERE "product_variant_id" = $1
+            AND NOT ("attribute_id" = ANY($2::int[]))
+        `
+		if _, err := tx.Exec(ctx, sql, variantID, attributeIDs); err != nil {
+			return nil, err
+		}
+		sql = `
+        INSERT INTO "public"."product_attributes" ("product_variant_id", "attribute_id")
+        SELECT $1, UNNEST($2::int[])
+        ON CONFLICT DO NOTHING
+        `
+		if _, err := tx.Exec(ctx, sql, variantID, attributeIDs); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := r.addImages(ctx, tx, productID, row.Images); err != nil {
+		return nil, err
+	}
+
+	return &plan, nil
+}
+
+// addImages adds the images of a row, by their names, to its product.
+func (r *CatalogImportRepository) addImages(ctx context.Context, tx pgx.Tx, productID int, images []string) error {
+	for _, image := range images {
+		sql := `
+        SELECT "id"
+        FROM "public"."image"
+        WHERE "name" = $1
+        ORDER BY "id"
+        LIMIT 1
+        `
+		var imageID int
+		switch err := tx.QueryRow(ctx, sql, image).Scan(&imageID); err {
+		case pgx.ErrNoRows:
+			return &rowError{column: "images", message: fmt.Sprintf("no image is named %q, upload it first", image)}
+		case nil:
+		default:
+			return err
+		}
+
+		sql = `
+        INSERT INTO "public"."product_images" ("product_id", "image_id")
+        VALUES ($1, $2)
+        ON CONFLICT DO NOTHING
+        `
+		if _, err := tx.Exec(ctx, sql, productID, imageID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *CatalogImportRepository) GetByID(ctx context.Context, id int) (*entities.CatalogImport, error) {
+	sql := `
+    SELECT  "ci"."id",
+            "ci"."file_name",
+            "ci"."dry_run",
+            "ci"."rows",
+            "ci"."created",
+            "ci"."updated",
+            "ci"."failed",
+            "ci"."errors",
+            "ci"."created_by",
+            "ci"."created_at",
+            "ci"."updated_at",
+            "ci"."deleted_at"
+    FROM "public"."catalog_import" "ci"
+    WHERE "ci"."id" = $1
+    LIMIT 1
+    `
+	var catalogImport entities.CatalogImport
+	var rowErrors json.RawMessage
+	if err := r.dbConn.QueryRow(ctx, sql, id).Scan(
+		&catalogImport.ID,
+		&catalogImport.FileName,
+		&catalogImport.DryRun,
+		&catalogImport.Rows,
+		&catalogImport.Created,
+		&catalogImport.Updated,
+		&catalogImport.Failed,
+		&rowErrors,
+		&catalogImport.CreatedBy,
+		&catalogImport.CreatedAt,
+		&catalogImport.UpdatedAt,
+		&catalogImport.DeletedAt,
+	); err != nil {
+		switch err {
+		case pgx.ErrNoRows:
+			return nil, common.ErrNotFound
+		default:
+			return nil, err
+		}
+	}
+
+	if err := json.Unmarshal([]byte(rowErrors), &catalogImport.Errors); err != nil {
+		return nil, err
+	}
+
+	return &catalogImport, nil
+}
+
+// Create stores the report of an import and sets its id and creation time.
+func (r *CatalogImportRepository) Create(ctx context.Context, catalogImport *entities.CatalogImport) error {
+	rowErrors, err := json.Marshal(catalogImport.Errors)
+	if err != nil {
+		return err
+	}
+	if catalogImport.Errors == nil {
+		rowErrors = []byte("[]")
+	}
+
+	sql := `
+    INSERT INTO "public"."catalog_import" ("file_name", "dry_run", "rows", "created", "updated", "failed", "errors", "created_by")
+    VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+    RETURNING "id", "created_at"
+    `
+	return r.dbConn.QueryRow(ctx, sql, catalogImport.FileName, catalogImport.DryRun, catalogImport.Rows, catalogImport.Created,
+		catalogImport.Updated, catalogImport.Failed, string(rowErrors), catalogImport.CreatedBy).Scan(&catalogImport.ID, &catalogImport.CreatedAt)
+}
@@ -0,0 +1,67 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/ysfada/product-management-system/domain/entities"
+)
+
+func TestDryRunPlansAcrossBatches(t *testing.T) {
+	tx := testTx(t)
+	repository := NewCatalogImportRepository(tx)
+	name := fmt.Sprintf("test-%d", time.Now().UnixNano())
+
+	row := func(line int) *entities.CatalogImportRow {
+		return &entities.CatalogImportRow{Line: line, Product: name, Category: name, Variant: name, Price: 1}
+	}
+	plans, rowErrors, err := repository.Apply(context.Background(), [][]*entities.CatalogImportRow{{row(2)}, {row(3)}}, true)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Empty(t, rowErrors)
+	if assert.Len(t, plans, 2) {
+		assert.Equal(t, "create", plans[0].VariantAction)
+		assert.Equal(t, "update", plans[1].VariantAction)
+	}
+
+	var exists bool
+	err = tx.QueryRow(context.Background(), `
+    SELECT EXISTS (SELECT 1 FROM "public"."product" WHERE "name" = $1)
+    `, name).Scan(&exists)
+	if assert.NoError(t, err) {
+		assert.False(t, exists)
+	}
+}
+
+func TestApplyProductWithoutVariants(t *testing.T) {
+	tx := testTx(t)
+	repository := NewCatalogImportRepository(tx)
+	name := fmt.Sprintf("test-%d", time.Now().UnixNano())
+
+	plans, rowErrors, err := repository.Apply(context.Background(), [][]*entities.CatalogImportRow{
+		{{Line: 2, Product: name, Category: name}},
+	}, false)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Empty(t, rowErrors)
+	if assert.Len(t, plans, 1) {
+		assert.Equal(t, "create", plans[0].ProductAction)
+		assert.Empty(t, plans[0].VariantAction)
+	}
+
+	var variants int
+	err = tx.QueryRow(context.Background(), `
+    SELECT COUNT("pv"."id")
+    FROM "public"."product" "p"
+    LEFT JOIN "public"."product_variant" "pv" ON "pv"."product_id" = "p"."id"
+    WHERE "p"."name" = $1
+    `, name).Scan(&variants)
+	if assert.NoError(t, err) {
+		assert.Equal(t, 0, variants)
+	}
+}
@@ -10,7 +10,6 @@ import (
 	"github.com/jackc/pgconn"
 	"github.com/jackc/pgerrcode"
 	"github.com/jackc/pgx/v4"
-	"github.com/jackc/pgx/v4/pgxpool"
 	"github.com/ysfada/product-management-system/domain/common"
 	"github.com/ysfada/product-management-system/domain/dtos"
 	"github.com/ysfada/product-management-system/domain/entities"
@@ -18,12 +17,12 @@ import (
 )
 
 type CategoryRepository struct {
-	dbConn *pgxpool.Pool
+	dbConn DBTX
 }
 
 var _ interfaces.ICategoryRepository = (*CategoryRepository)(nil)
 
-func NewCategoryRepository(dbConn *pgxpool.Pool) *CategoryRepository {
+func NewCategoryRepository(dbConn DBTX) *CategoryRepository {
 	return &CategoryRepository{
 		dbConn: dbConn,
 	}
@@ -40,6 +39,9 @@ func (r *CategoryRepository) Fetch(ctx context.Context, page int, size int, sort
 			(SELECT "c"."id",
 					"c"."name",
 					COALESCE("c"."description", '') "description",
+					"c"."reorder_point",
+					"c"."reorder_quantity",
+					"c"."version",
 					"c"."created_at",
 					"c"."updated_at",
 					"c"."deleted_at"
@@ -88,6 +90,9 @@ func (r *CategoryRepository) GetByID(ctx context.Context, id int) (res *entities
     SELECT  "c"."id",
 	        "c"."name",
 	        COALESCE("c"."description", '') AS DESCRIPTION,
+	        "c"."reorder_point",
+	        "c"."reorder_quantity",
+	        "c"."version",
 	        "c"."created_at",
 	        "c"."updated_at",
 	        "c"."deleted_at"
@@ -100,6 +105,9 @@ func (r *CategoryRepository) GetByID(ctx context.Context, id int) (res *entities
 		&category.ID,
 		&category.Name,
 		&category.Description,
+		&category.ReorderPoint,
+		&category.ReorderQuantity,
+		&category.Version,
 		&category.CreatedAt,
 		&category.UpdatedAt,
 		&category.DeletedAt,
@@ -127,6 +135,9 @@ func (r *CategoryRepository) Search(ctx context.Context, q string, page int, siz
             SELECT "c"."id",
                 "c"."name",
                 COALESCE("c"."description", '') AS DESCRIPTION,
+                "c"."reorder_point",
+                "c"."reorder_quantity",
+                "c"."version",
                 "c"."created_at",
                 "c"."updated_at",
                 "c"."deleted_at"
@@ -175,11 +186,14 @@ func (r *CategoryRepository) Update(ctx context.Context, dto *dtos.UpdateCategor
 	sql := `
     UPDATE "public"."category"
     SET "name" = $1,
-        "description" = $2
-    WHERE "id" = $3
+        "description" = $2,
+        "reorder_point" = $3,
+        "reorder_quantity" = $4
+    WHERE "id" = $5
+        AND ($6::int[] IS NULL OR "version" = ANY($6))
     `
 
-	_, err := r.dbConn.Exec(ctx, sql, dto.Name, dto.Description, dto.ID)
+	cmd, err := r.dbConn.Exec(ctx, sql, dto.Name, dto.Description, dto.ReorderPoint, dto.ReorderQuantity, dto.ID, dto.Versions)
 
 	var pgErr *pgconn.PgError
 	if errors.As(err, &pgErr) {
@@ -187,38 +201,50 @@ func (r *CategoryRepository) Update(ctx context.Context, dto *dtos.UpdateCategor
 			return common.ErrBadParamInput
 		}
 	}
-	return err
+	if err != nil {
+		return err
+	}
+	if cmd.RowsAffected() == 0 {
+		return staleOrNotFound(ctx, r.dbConn, "category", dto.ID, dto.Versions)
+	}
+	return nil
 }
 
-func (r *CategoryRepository) Create(ctx context.Context, dto *dtos.CreateCategoryDto) error {
+func (r *CategoryRepository) Create(ctx context.Context, dto *dtos.CreateCategoryDto) (int, error) {
 	sql := `
-    INSERT INTO "public"."category"("name", "description")
-    VALUES ($1, $2)
+    INSERT INTO "public"."category"("name", "description", "reorder_point", "reorder_quantity")
+    VALUES ($1, $2, $3, $4)
+    RETURNING "id"
     `
-	_, err := r.dbConn.Exec(ctx, sql, dto.Name, dto.Description)
+	var id int
+	err := r.dbConn.QueryRow(ctx, sql, dto.Name, dto.Description, dto.ReorderPoint, dto.ReorderQuantity).Scan(&id)
 
 	var pgErr *pgconn.PgError
 	if errors.As(err, &pgErr) {
-		if pgErr.Code == pgerrcode.CheckViolation {
-			return common.ErrBadParamInput
+		switch pgErr.Code {
+		case pgerrcode.CheckViolation:
+			return 0, common.ErrBadParamInput
+		case pgerrcode.UniqueViolation:
+			return 0, common.ErrConflict
 		}
 	}
-	return err
+	return id, err
 }
 
-func (r *CategoryRepository) Delete(ctx context.Context, id int) error {
+func (r *CategoryRepository) Delete(ctx context.Context, id int, versions []int) error {
 	sql := `
     DELETE
     FROM "public"."category"
     WHERE "id" = $1
+        AND ($2::int[] IS NULL OR "version" = ANY($2))
     `
-	if cmd, err := r.dbConn.Exec(ctx, sql, id); err != nil {
-		return err
+	if cmd, err := r.dbConn.Exec(ctx, sql, id, versions); err != nil {
+		return stillReferenced(err)
 	} else {
 		if cmd.RowsAffected() > 0 {
 			return nil
 		} else {
-			return common.ErrNotFound
+			return staleOrNotFound(ctx, r.dbConn, "category", id, versions)
 		}
 	}
 }
@@ -257,6 +283,7 @@ func (r *CategoryRepository) GetProducts(ctx context.Context, id int, page int,
                             "i"."name",
                             "i"."image_url",
                             "i"."thumbnail_url",
+                            "i"."renditions",
                             "i"."created_at",
                             "i"."updated_at",
                             "i"."deleted_at"
@@ -0,0 +1,116 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/ysfada/product-management-system/domain/entities"
+	"github.com/ysfada/product-management-system/domain/interfaces"
+)
+
+type FeedRepository struct {
+	dbConn DBTX
+}
+
+var _ interfaces.IFeedRepository = (*FeedRepository)(nil)
+
+func NewFeedRepository(dbConn DBTX) *FeedRepository {
+	return &FeedRepository{
+		dbConn: dbConn,
+	}
+}
+
+// Items calls fn with every variant, ordered by product and variant, and
+// with every product without variants as an item of VariantID 0. The rows
+// are read from a cursor, catalogExportFetchSize at a time, an error of
+// fn stops the feed and is returned.
+func (r *FeedRepository) Items(ctx context.Context, fn func(item *entities.FeedItem) error) error {
+	return r.dbConn.BeginFunc(ctx, func(tx pgx.Tx) error {
+		sql := `
+        DECLARE "feed" NO SCROLL CURSOR FOR
+        SELECT  "p"."id",
+                "p"."name",
+                COALESCE("p"."description", ''),
+                "c"."name",
+                COALESCE("pv"."id", 0),
+                COALESCE("pv"."name", ''),
+                COALESCE("pv"."price", 0),
+                COALESCE("pv"."stock" - "pv"."reserved", 0),
+                ARRAY(
+                    SELECT "a"."type"::text
+                    FROM "public"."product_attributes" "pa"
+                    JOIN "public"."attribute" "a" ON "a"."id" = "pa"."attribute_id"
+                    WHERE "pa"."product_variant_id" = "pv"."id"
+                    ORDER BY "a"."type", "a"."name"
+                ) "attribute_types",
+                ARRAY(
+                    SELECT "a"."name"::text
+                    FROM "public"."product_attributes" "pa"
+                    JOIN "public"."attribute" "a" ON "a"."id" = "pa"."attribute_id"
+                    WHERE "pa"."product_variant_id" = "pv"."id"
+                    ORDER BY "a"."type", "a"."name"
+                ) "attribute_names",
+                ARRAY(
+                    SELECT "i"."image_url"
+                    FROM "public"."product_images" "pi"
+                    JOIN "public"."image" "i" ON "i"."id" = "pi"."image_id"
+                    WHERE "pi"."product_id" = "p"."id"
+                    ORDER BY "i"."id"
+                ) "image_urls"
+        FROM "public"."product" "p"
+        JOIN "public"."category" "c" ON "c"."id" = "p"."category_id"
+        LEFT JOIN "public"."product_variant" "pv" ON "pv"."product_id" = "p"."id"
+        ORDER BY "p"."id", "pv"."id"
+        `
+		if _, err := tx.Exec(ctx, sql); err != nil {
+			return err
+		}
+
+		for {
+			rows, err := tx.Query(ctx, fmt.Sprintf(`FETCH FORWARD %d FROM "feed"`, catalogExportFetchSize))
+			if err != nil {
+				return err
+			}
+
+			var batch []*entities.FeedItem
+			for rows.Next() {
+				var item entities.FeedItem
+				var attributeTypes, attributeNames []string
+				if err := rows.Scan(
+					&item.ProductID,
+					&item.Product,
+					&item.Description,
+					&item.Category,
+					&item.VariantID,
+					&item.Variant,
+					&item.Price,
+					&item.Available,
+					&attributeTypes,
+					&attributeNames,
+					&item.ImageURLs,
+				); err != nil {
+					rows.Close()
+					return err
+				}
+				for i := range attributeTypes {
+					item.Attributes = append(item.Attributes, &entities.Attribute{Type: attributeTypes[i], Name: attributeNames[i]})
+				}
+				batch = append(batch, &item)
+			}
+			if err := rows.Err(); err != nil {
+				return err
+			}
+
+			// fn runs after the fetch is read, the connection is free again
+			for _, item := range batch {
+				if err := fn(item); err != nil {
+					return err
+				}
+			}
+			if len(batch) < catalogExportFetchSize {
+				return nil
+			}
+		}
+	})
+}
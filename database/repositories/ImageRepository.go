@@ -2,21 +2,22 @@ package repositories
 
 import (
 	"context"
+	"encoding/json"
 
 	"github.com/jackc/pgx/v4"
-	"github.com/jackc/pgx/v4/pgxpool"
 	"github.com/ysfada/product-management-system/domain/common"
 	"github.com/ysfada/product-management-system/domain/dtos"
+	"github.com/ysfada/product-management-system/domain/entities"
 	"github.com/ysfada/product-management-system/domain/interfaces"
 )
 
 type ImageRepository struct {
-	dbConn *pgxpool.Pool
+	dbConn DBTX
 }
 
 var _ interfaces.IImageRepository = (*ImageRepository)(nil)
 
-func NewImageRepository(dbConn *pgxpool.Pool) *ImageRepository {
+func NewImageRepository(dbConn DBTX) *ImageRepository {
 	return &ImageRepository{
 		dbConn: dbConn,
 	}
@@ -27,12 +28,17 @@ func (r *ImageRepository) Update(ctx context.Context, dto *dtos.UpdateImageDto)
 }
 
 func (r *ImageRepository) Create(ctx context.Context, dto *dtos.CreateImageDto) (int, error) {
+	renditions, err := renditionsJSON(dto.Renditions)
+	if err != nil {
+		return -1, err
+	}
+
 	sql := `
-    INSERT INTO "public"."image" ("name", "image_url", "thumbnail_url")
-    VALUES ($1, $2, $3) RETURNING "id"
+    INSERT INTO "public"."image" ("name", "image_url", "thumbnail_url", "renditions")
+    VALUES ($1, $2, $3, $4) RETURNING "id"
     `
 	var id int
-	if err := r.dbConn.QueryRow(ctx, sql, dto.Name, dto.ImageUrl, dto.ThumbnailUrl).Scan(&id); err != nil {
+	if err := r.dbConn.QueryRow(ctx, sql, dto.Name, dto.ImageUrl, dto.ThumbnailUrl, renditions).Scan(&id); err != nil {
 		switch err {
 		case pgx.ErrNoRows:
 			return -1, common.ErrNotFound
@@ -44,21 +50,141 @@ func (r *ImageRepository) Create(ctx context.Context, dto *dtos.CreateImageDto)
 	return id, nil
 }
 
-func (r *ImageRepository) Delete(ctx context.Context, id int) (string, error) {
+// Delete returns the deleted image, its files are left to the caller.
+func (r *ImageRepository) Delete(ctx context.Context, id int) (*entities.Image, error) {
 	sql := `
     DELETE
     FROM "public"."image"
-    WHERE "id" = $1 RETURNING "image_url"
+    WHERE "id" = $1 RETURNING "image_url", "thumbnail_url", "renditions"
     `
-	var imageURL string
-	if err := r.dbConn.QueryRow(ctx, sql, id).Scan(&imageURL); err != nil {
+	image := entities.Image{
+		ID: id,
+	}
+	if err := r.dbConn.QueryRow(ctx, sql, id).Scan(&image.ImageUrl, &image.ThumbnailUrl, &image.Renditions); err != nil {
 		switch err {
 		case pgx.ErrNoRows:
-			return "", common.ErrNotFound
+			return nil, common.ErrNotFound
 		default:
-			return "", err
+			return nil, err
 		}
 	}
 
-	return imageURL, nil
+	return &image, nil
+}
+
+// Fetch returns every image, or only those without renditions.
+func (r *ImageRepository) Fetch(ctx context.Context, withoutRenditions bool) ([]*entities.Image, error) {
+	sql := `
+    SELECT  "i"."id",
+            COALESCE("i"."name", '') "name",
+            "i"."image_url",
+            "i"."thumbnail_url",
+            "i"."renditions",
+            "i"."created_at",
+            "i"."updated_at",
+            "i"."deleted_at"
+    FROM "public"."image" "i"
+    WHERE NOT $1 OR "i"."renditions" = '[]'
+    ORDER BY "i"."id"
+    `
+	return r.query(ctx, sql, withoutRenditions)
+}
+
+// SetRenditions replaces the renditions of an image and its thumbnail url.
+func (r *ImageRepository) SetRenditions(ctx context.Context, id int, thumbnailUrl string, renditions []*dtos.ImageRenditionDto) error {
+	data, err := renditionsJSON(renditions)
+	if err != nil {
+		return err
+	}
+
+	sql := `
+    UPDATE "public"."image"
+    SET "thumbnail_url" = $2,
+        "renditions" = $3
+    WHERE "id" = $1
+    `
+	if cmd, err := r.dbConn.Exec(ctx, sql, id, thumbnailUrl, data); err != nil {
+		return err
+	} else if cmd.RowsAffected() == 0 {
+		return common.ErrNotFound
+	}
+	return nil
+}
+
+// FetchOrphans returns the images which belong to no product.
+func (r *ImageRepository) FetchOrphans(ctx context.Context) ([]*entities.Image, error) {
+	sql := `
+    SELECT  "i"."id",
+            COALESCE("i"."name", '') "name",
+            "i"."image_url",
+            "i"."thumbnail_url",
+            "i"."renditions",
+            "i"."created_at",
+            "i"."updated_at",
+            "i"."deleted_at"
+    FROM "public"."image" "i"
+    WHERE NOT EXISTS (
+        SELECT 1
+        FROM "public"."product_images" "pi"
+        WHERE "pi"."image_id" = "i"."id"
+    )
+    ORDER BY "i"."id"
+    `
+	return r.query(ctx, sql)
+}
+
+func (r *ImageRepository) query(ctx context.Context, sql string, args ...interface{}) ([]*entities.Image, error) {
+	rows, err := r.dbConn.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var images []*entities.Image
+	for rows.Next() {
+		var image entities.Image
+		if err := rows.Scan(
+			&image.ID,
+			&image.Name,
+			&image.ImageUrl,
+			&image.ThumbnailUrl,
+			&image.Renditions,
+			&image.CreatedAt,
+			&image.UpdatedAt,
+			&image.DeletedAt,
+		); err != nil {
+			return nil, err
+		}
+		images = append(images, &image)
+	}
+	return images, rows.Err()
+}
+
+// IsURLInUse reports whether an image has the url as its image, thumbnail or
+// rendition url, files may be shared by images.
+func (r *ImageRepository) IsURLInUse(ctx context.Context, url string) (bool, error) {
+	sql := `
+    SELECT EXISTS (
+        SELECT 1
+        FROM "public"."image"
+        WHERE "image_url" = $1
+            OR "thumbnail_url" = $1
+            OR "renditions" @> JSONB_BUILD_ARRAY(JSONB_BUILD_OBJECT('url', $1::text))
+    )
+    `
+	var inUse bool
+	if err := r.dbConn.QueryRow(ctx, sql, url).Scan(&inUse); err != nil {
+		return false, err
+	}
+	return inUse, nil
+}
+
+// renditionsJSON encodes renditions for a jsonb parameter, no renditions as
+// an empty array.
+func renditionsJSON(renditions []*dtos.ImageRenditionDto) (string, error) {
+	if renditions == nil {
+		renditions = []*dtos.ImageRenditionDto{}
+	}
+	data, err := json.Marshal(renditions)
+	return string(data), err
 }
@@ -0,0 +1,243 @@
+package repositories
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+	"github.com/ysfada/product-management-system/domain/common"
+	"github.com/ysfada/product-management-system/domain/dtos"
+	"github.com/ysfada/product-management-system/domain/entities"
+	"github.com/ysfada/product-management-system/domain/interfaces"
+)
+
+const lowStockChannel = "low_stock"
+
+type InventoryRepository struct {
+	dbConn *pgxpool.Pool
+}
+
+var _ interfaces.IInventoryRepository = (*InventoryRepository)(nil)
+
+func NewInventoryRepository(dbConn *pgxpool.Pool) *InventoryRepository {
+	return &InventoryRepository{
+		dbConn: dbConn,
+	}
+}
+
+// LowStock lists the variants whose available stock is at or below their
+// reorder point, falling back to the reorder point of their category.
+// A categoryID of 0 lists the variants of every category.
+func (r *InventoryRepository) LowStock(ctx context.Context, categoryID int, page int, size int, sortBy string, orderBy string) (*entities.LowStockPaginated, error) {
+	sql := fmt.Sprintf(`
+    WITH "low" AS (
+        SELECT "pv"."id",
+                "pv"."name",
+                "pv"."stock",
+                "pv"."reserved",
+                "pv"."stock" - "pv"."reserved" "available",
+                COALESCE("pv"."reorder_point", "c"."reorder_point") "reorder_point",
+                COALESCE("pv"."reorder_quantity", "c"."reorder_quantity") "reorder_quantity",
+                JSONB_BUILD_OBJECT(
+                    'id', "p"."id",
+                    'name', "p"."name",
+                    'description', COALESCE("p"."description", ''),
+                    'category_id', "p"."category_id"
+                ) "product",
+                JSONB_BUILD_OBJECT(
+                    'id', "c"."id",
+                    'name', "c"."name",
+                    'description', COALESCE("c"."description", ''),
+                    'reorder_point', "c"."reorder_point",
+                    'reorder_quantity', "c"."reorder_quantity"
+                ) "category"
+        FROM "public"."product_variant" "pv"
+        JOIN "public"."product" "p" ON "p"."id" = "pv"."product_id"
+        JOIN "public"."category" "c" ON "c"."id" = "p"."category_id"
+        WHERE COALESCE("pv"."reorder_point", "c"."reorder_point") IS NOT NULL
+            AND "pv"."stock" - "pv"."reserved" <= COALESCE("pv"."reorder_point", "c"."reorder_point")
+            AND ($1 = 0 OR "p"."category_id" = $1)
+    )
+    SELECT
+        (SELECT COUNT(*)
+            FROM "low") "count",
+
+        (SELECT COALESCE(JSONB_AGG("result".*), '[]')
+            FROM
+                (SELECT *
+                    FROM "low"
+                    ORDER BY "low"."%s" %s, "low"."id" ASC
+                    OFFSET $2 ROWS FETCH NEXT $3 ROWS ONLY) "result") "variants"
+    `, sortBy, orderBy)
+
+	var variants entities.LowStockPaginated
+	var rows json.RawMessage
+	if err := r.dbConn.QueryRow(ctx, sql, categoryID, (page-1)*size, size).Scan(
+		&variants.Count,
+		&rows,
+	); err != nil {
+		switch err {
+		case pgx.ErrNoRows:
+			return nil, common.ErrNotFound
+		default:
+			return nil, err
+		}
+	}
+
+	if err := json.Unmarshal([]byte(rows), &variants.Variants); err != nil {
+		return nil, err
+	}
+
+	variants.Size = size
+	variants.TotalPage = int(math.Ceil(float64(variants.Count) / float64(size)))
+	variants.CurrentPage = page
+	if variants.CurrentPage <= variants.TotalPage && variants.CurrentPage > 1 {
+		variants.PreviousPage = variants.CurrentPage - 1
+	} else {
+		variants.PreviousPage = -1
+	}
+	if variants.CurrentPage < variants.TotalPage {
+		variants.NextPage = variants.CurrentPage + 1
+	} else {
+		variants.NextPage = -1
+	}
+
+	return &variants, nil
+}
+
+// ListenLowStock blocks on the notifications sent by the "_low_stock" trigger
+// of product_variant and passes every event to handler until ctx is done or
+// the connection fails.
+func (r *InventoryRepository) ListenLowStock(ctx context.Context, handler func(event *entities.LowStockEvent)) error {
+	conn, err := r.dbConn.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Release()
+
+	if _, err := conn.Exec(ctx, fmt.Sprintf(`LISTEN "%s"`, lowStockChannel)); err != nil {
+		return err
+	}
+	defer conn.Exec(context.Background(), fmt.Sprintf(`UNLISTEN "%s"`, lowStockChannel))
+
+	for {
+		notification, err := conn.Conn().WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+
+		var event entities.LowStockEvent
+		if err := json.Unmarshal([]byte(notification.Payload), &event); err != nil {
+			log.Printf("Unable to parse low stock notification: %v\n", err)
+			continue
+		}
+		handler(&event)
+	}
+}
+
+// Receive adds received stock to a variant. The purchase cost and the reason
+// are passed to the "_unit_cost" and "_stock_movement" triggers of
+// product_variant through transaction local settings.
+func (r *InventoryRepository) Receive(ctx context.Context, dto *dtos.CreateStockReceiptDto) error {
+	err := r.dbConn.BeginFunc(ctx, func(tx pgx.Tx) error {
+		// the cost is kept per base unit
+		sql := `
+        SELECT set_config('pms.movement_reason', 'receipt', true),
+                set_config('pms.movement_unit_cost', ($1::decimal / "public"."unit_factor"($2, $3))::text, true)
+        `
+		if _, err := tx.Exec(ctx, sql, strconv.FormatFloat(dto.UnitCost, 'f', -1, 64), dto.ProductVariantID, dto.Unit); err != nil {
+			return err
+		}
+
+		sql = `
+        UPDATE "public"."product_variant"
+        SET "stock" = "stock" + $2 * "public"."unit_factor"($1, $3)
+        WHERE "id" = $1
+        `
+		if cmd, err := tx.Exec(ctx, sql, dto.ProductVariantID, dto.Quantity, dto.Unit); err != nil {
+			return err
+		} else if cmd.RowsAffected() == 0 {
+			return common.ErrNotFound
+		}
+		return nil
+	})
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.CheckViolation {
+		// lot tracked and serialized variants receive stock through their
+		// lots and serials
+		return common.ErrBadParamInput
+	}
+	return err
+}
+
+// Movements returns the stock movements up to asOf of every variant, or of
+// the variants of a category if categoryID is not 0.
+func (r *InventoryRepository) Movements(ctx context.Context, categoryID int, asOf time.Time) ([]*entities.VariantMovements, error) {
+	sql := `
+    SELECT  "pv"."id",
+            "pv"."name",
+            "p"."id",
+            "c"."id",
+            "c"."name",
+            "m"."id",
+            "m"."quantity",
+            "m"."unit_cost",
+            "m"."reason",
+            "m"."created_at",
+            "m"."updated_at",
+            "m"."deleted_at"
+    FROM "public"."stock_movement" "m"
+    JOIN "public"."product_variant" "pv" ON "pv"."id" = "m"."product_variant_id"
+    JOIN "public"."product" "p" ON "p"."id" = "pv"."product_id"
+    JOIN "public"."category" "c" ON "c"."id" = "p"."category_id"
+    WHERE "m"."created_at" <= $2
+        AND ($1 = 0 OR "p"."category_id" = $1)
+    ORDER BY "pv"."id" ASC, "m"."created_at" ASC, "m"."id" ASC
+    `
+	rows, err := r.dbConn.Query(ctx, sql, categoryID, asOf)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var variants []*entities.VariantMovements
+	for rows.Next() {
+		var variant entities.VariantMovements
+		var movement entities.StockMovement
+		if err := rows.Scan(
+			&variant.ProductVariantID,
+			&variant.Name,
+			&variant.ProductID,
+			&variant.CategoryID,
+			&variant.CategoryName,
+			&movement.ID,
+			&movement.Quantity,
+			&movement.UnitCost,
+			&movement.Reason,
+			&movement.CreatedAt,
+			&movement.UpdatedAt,
+			&movement.DeletedAt,
+		); err != nil {
+			return nil, err
+		}
+		movement.ProductVariantID = variant.ProductVariantID
+
+		if len(variants) == 0 || variants[len(variants)-1].ProductVariantID != variant.ProductVariantID {
+			variants = append(variants, &variant)
+		}
+		last := variants[len(variants)-1]
+		last.Movements = append(last.Movements, &movement)
+	}
+
+	return variants, rows.Err()
+}
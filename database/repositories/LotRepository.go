@@ -0,0 +1,271 @@
+package repositories
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v4"
+	"github.com/ysfada/product-management-system/domain/common"
+	"github.com/ysfada/product-management-system/domain/dtos"
+	"github.com/ysfada/product-management-system/domain/entities"
+	"github.com/ysfada/product-management-system/domain/interfaces"
+)
+
+type LotRepository struct {
+	dbConn DBTX
+}
+
+var _ interfaces.ILotRepository = (*LotRepository)(nil)
+
+func NewLotRepository(dbConn DBTX) *LotRepository {
+	return &LotRepository{
+		dbConn: dbConn,
+	}
+}
+
+// Fetch lists the lots of a variant in first-expired-first-out order.
+func (r *LotRepository) Fetch(ctx context.Context, id int, variantID int) ([]*entities.Lot, error) {
+	sql := `
+    SELECT  "l"."id",
+            "l"."product_variant_id",
+            "l"."lot_number",
+            "l"."expires_at",
+            "l"."manufactured_at",
+            "l"."quantity",
+            "l"."created_at",
+            "l"."updated_at",
+            "l"."deleted_at"
+    FROM "public"."lot" "l"
+    JOIN "public"."product_variant" "pv" ON "pv"."id" = "l"."product_variant_id"
+    WHERE "l"."product_variant_id" = $2
+        AND "pv"."product_id" = $1
+    ORDER BY "l"."expires_at" ASC NULLS LAST, "l"."id" ASC
+    `
+	rows, err := r.dbConn.Query(ctx, sql, id, variantID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var lots []*entities.Lot
+	for rows.Next() {
+		var lot entities.Lot
+		if err := rows.Scan(
+			&lot.ID,
+			&lot.ProductVariantID,
+			&lot.LotNumber,
+			&lot.ExpiresAt,
+			&lot.ManufacturedAt,
+			&lot.Quantity,
+			&lot.CreatedAt,
+			&lot.UpdatedAt,
+			&lot.DeletedAt,
+		); err != nil {
+			return nil, err
+		}
+		lots = append(lots, &lot)
+	}
+
+	return lots, rows.Err()
+}
+
+func (r *LotRepository) GetByID(ctx context.Context, id int, variantID int, lotID int) (*entities.Lot, error) {
+	sql := `
+    SELECT  "l"."id",
+            "l"."product_variant_id",
+            "l"."lot_number",
+            "l"."expires_at",
+            "l"."manufactured_at",
+            "l"."quantity",
+            "l"."created_at",
+            "l"."updated_at",
+            "l"."deleted_at"
+    FROM "public"."lot" "l"
+    JOIN "public"."product_variant" "pv" ON "pv"."id" = "l"."product_variant_id"
+    WHERE "l"."id" = $3
+        AND "l"."product_variant_id" = $2
+        AND "pv"."product_id" = $1
+    LIMIT 1
+    `
+	var lot entities.Lot
+	if err := r.dbConn.QueryRow(ctx, sql, id, variantID, lotID).Scan(
+		&lot.ID,
+		&lot.ProductVariantID,
+		&lot.LotNumber,
+		&lot.ExpiresAt,
+		&lot.ManufacturedAt,
+		&lot.Quantity,
+		&lot.CreatedAt,
+		&lot.UpdatedAt,
+		&lot.DeletedAt,
+	); err != nil {
+		switch err {
+		case pgx.ErrNoRows:
+			return nil, common.ErrNotFound
+		default:
+			return nil, err
+		}
+	}
+
+	return &lot, nil
+}
+
+// Create adds a lot to a variant. The "_stock" trigger of lot adds its
+// quantity to the stock of the variant.
+func (r *LotRepository) Create(ctx context.Context, dto *dtos.CreateLotDto) error {
+	sql := `
+    INSERT INTO "public"."lot" ("product_variant_id", "lot_number", "expires_at", "manufactured_at", "quantity")
+    SELECT "pv"."id", $3, $4, $5, $6 * "public"."unit_factor"("pv"."id", $7)
+    FROM "public"."product_variant" "pv"
+    WHERE "pv"."id" = $2
+        AND "pv"."product_id" = $1
+    `
+	cmd, err := r.dbConn.Exec(ctx, sql, dto.ProductID, dto.ProductVariantID, dto.LotNumber, dto.ExpiresAt, dto.ManufacturedAt, dto.Quantity, dto.Unit)
+	if err := lotError(err); err != nil {
+		return err
+	}
+	if cmd.RowsAffected() == 0 {
+		return common.ErrNotFound
+	}
+	return nil
+}
+
+func (r *LotRepository) Update(ctx context.Context, dto *dtos.UpdateLotDto) error {
+	sql := `
+    UPDATE "public"."lot" "l"
+    SET "lot_number" = $4,
+        "expires_at" = $5,
+        "manufactured_at" = $6,
+        "quantity" = $7 * "public"."unit_factor"("pv"."id", $8)
+    FROM "public"."product_variant" "pv"
+    WHERE "l"."id" = $3
+        AND "l"."product_variant_id" = $2
+        AND "pv"."id" = "l"."product_variant_id"
+        AND "pv"."product_id" = $1
+    `
+	cmd, err := r.dbConn.Exec(ctx, sql, dto.ProductID, dto.ProductVariantID, dto.ID, dto.LotNumber, dto.ExpiresAt, dto.ManufacturedAt, dto.Quantity, dto.Unit)
+	if err := lotError(err); err != nil {
+		return err
+	}
+	if cmd.RowsAffected() == 0 {
+		return common.ErrNotFound
+	}
+	return nil
+}
+
+func (r *LotRepository) Delete(ctx context.Context, id int, variantID int, lotID int) error {
+	sql := `
+    DELETE
+    FROM "public"."lot" "l"
+    USING "public"."product_variant" "pv"
+    WHERE "l"."id" = $3
+        AND "l"."product_variant_id" = $2
+        AND "pv"."id" = "l"."product_variant_id"
+        AND "pv"."product_id" = $1
+    `
+	cmd, err := r.dbConn.Exec(ctx, sql, id, variantID, lotID)
+	if err := lotError(err); err != nil {
+		return err
+	}
+	if cmd.RowsAffected() == 0 {
+		return common.ErrNotFound
+	}
+	return nil
+}
+
+// Expiring lists the lots in stock that expire within the given number of
+// days, including the ones that already expired.
+func (r *LotRepository) Expiring(ctx context.Context, days int, page int, size int) (*entities.ExpiringLotPaginated, error) {
+	sql := `
+    WITH "expiring" AS (
+        SELECT "l"."id",
+                "l"."lot_number",
+                "l"."expires_at"::timestamptz "expires_at",
+                "l"."expires_at" - CURRENT_DATE "days_left",
+                "l"."quantity",
+                JSONB_BUILD_OBJECT(
+                    'id', "pv"."id",
+                    'name', "pv"."name",
+                    'product_id', "pv"."product_id",
+                    'price', "pv"."price",
+                    'stock', "pv"."stock",
+                    'reserved', "pv"."reserved"
+                ) "product_variant",
+                JSONB_BUILD_OBJECT(
+                    'id', "p"."id",
+                    'name', "p"."name",
+                    'description', COALESCE("p"."description", ''),
+                    'category_id', "p"."category_id"
+                ) "product"
+        FROM "public"."lot" "l"
+        JOIN "public"."product_variant" "pv" ON "pv"."id" = "l"."product_variant_id"
+        JOIN "public"."product" "p" ON "p"."id" = "pv"."product_id"
+        WHERE "l"."quantity" > 0
+            AND "l"."expires_at" <= CURRENT_DATE + $1::int
+    )
+    SELECT
+        (SELECT COUNT(*)
+            FROM "expiring") "count",
+
+        (SELECT COALESCE(JSONB_AGG("result".*), '[]')
+            FROM
+                (SELECT *
+                    FROM "expiring"
+                    ORDER BY "expiring"."expires_at" ASC, "expiring"."id" ASC
+                    OFFSET $2 ROWS FETCH NEXT $3 ROWS ONLY) "result") "lots"
+    `
+
+	var lots entities.ExpiringLotPaginated
+	var rows json.RawMessage
+	if err := r.dbConn.QueryRow(ctx, sql, days, (page-1)*size, size).Scan(
+		&lots.Count,
+		&rows,
+	); err != nil {
+		switch err {
+		case pgx.ErrNoRows:
+			return nil, common.ErrNotFound
+		default:
+			return nil, err
+		}
+	}
+
+	if err := json.Unmarshal([]byte(rows), &lots.Lots); err != nil {
+		return nil, err
+	}
+
+	lots.Size = size
+	lots.TotalPage = int(math.Ceil(float64(lots.Count) / float64(size)))
+	lots.CurrentPage = page
+	if lots.CurrentPage <= lots.TotalPage && lots.CurrentPage > 1 {
+		lots.PreviousPage = lots.CurrentPage - 1
+	} else {
+		lots.PreviousPage = -1
+	}
+	if lots.CurrentPage < lots.TotalPage {
+		lots.NextPage = lots.CurrentPage + 1
+	} else {
+		lots.NextPage = -1
+	}
+
+	return &lots, nil
+}
+
+// lotError maps the constraint violations of lot. A check violation is also
+// raised when a lot change would leave the variant with less stock than it
+// has reserved.
+func lotError(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		switch pgErr.Code {
+		case pgerrcode.UniqueViolation:
+			return common.ErrConflict
+		case pgerrcode.CheckViolation:
+			return common.ErrBadParamInput
+		}
+	}
+	return err
+}
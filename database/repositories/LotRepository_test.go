@@ -0,0 +1,41 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLotIsConsumedOnItsExpiryDay(t *testing.T) {
+	ctx := context.Background()
+	tx := testTx(t)
+	variantID := testVariant(t, tx, 0)
+
+	_, err := tx.Exec(ctx, `
+    INSERT INTO "public"."lot" ("product_variant_id", "lot_number", "expires_at", "quantity")
+    VALUES ($1, 'yesterday', CURRENT_DATE - 1, 2),
+        ($1, 'today', CURRENT_DATE, 3)
+    `, variantID)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, 5.0, stockOf(t, tx, variantID))
+
+	_, err = tx.Exec(ctx, `
+    UPDATE "public"."product_variant" SET "stock" = "stock" - 3 WHERE "id" = $1
+    `, variantID)
+	assert.NoError(t, err)
+
+	var today, yesterday float64
+	err = tx.QueryRow(ctx, `
+    SELECT SUM("quantity") FILTER (WHERE "lot_number" = 'today')::float8,
+            SUM("quantity") FILTER (WHERE "lot_number" = 'yesterday')::float8
+    FROM "public"."lot"
+    WHERE "product_variant_id" = $1
+    `, variantID).Scan(&today, &yesterday)
+	if assert.NoError(t, err) {
+		assert.Equal(t, 0.0, today)
+		assert.Equal(t, 2.0, yesterday)
+	}
+}
@@ -10,7 +10,6 @@ import (
 	"github.com/jackc/pgconn"
 	"github.com/jackc/pgerrcode"
 	"github.com/jackc/pgx/v4"
-	"github.com/jackc/pgx/v4/pgxpool"
 	"github.com/ysfada/product-management-system/domain/common"
 	"github.com/ysfada/product-management-system/domain/dtos"
 	"github.com/ysfada/product-management-system/domain/entities"
@@ -18,12 +17,12 @@ import (
 )
 
 type ProductRepository struct {
-	dbConn *pgxpool.Pool
+	dbConn DBTX
 }
 
 var _ interfaces.IProductRepository = (*ProductRepository)(nil)
 
-func NewProductRepository(dbConn *pgxpool.Pool) *ProductRepository {
+func NewProductRepository(dbConn DBTX) *ProductRepository {
 	return &ProductRepository{
 		dbConn: dbConn,
 	}
@@ -41,6 +40,7 @@ func (r *ProductRepository) Fetch(ctx context.Context, page int, size int, sortB
 					"p"."name",
 					COALESCE("p"."description", '') "description",
 					"p"."category_id",
+					"p"."version",
 					JSONB_BUILD_OBJECT(
                         'id', "c"."id",
                         'name', "c"."name",
@@ -62,6 +62,7 @@ func (r *ProductRepository) Fetch(ctx context.Context, page int, size int, sortB
 									"i"."name",
 									"i"."image_url",
 									"i"."thumbnail_url",
+									"i"."renditions",
 									"i"."created_at",
 									"i"."updated_at",
 									"i"."deleted_at"
@@ -117,6 +118,7 @@ func (r *ProductRepository) GetByID(ctx context.Context, id int) (*entities.Prod
             "p"."name",
             COALESCE("p"."description", '') "description",
             "p"."category_id",
+            "p"."version",
             "c"."id",
             "c"."name",
             COALESCE("c"."description", '') "description",
@@ -136,6 +138,7 @@ func (r *ProductRepository) GetByID(ctx context.Context, id int) (*entities.Prod
                         "i"."name",
                         "i"."image_url",
                         "i"."thumbnail_url",
+                        "i"."renditions",
                         "i"."created_at",
                         "i"."updated_at",
                         "i"."deleted_at"
@@ -155,6 +158,7 @@ func (r *ProductRepository) GetByID(ctx context.Context, id int) (*entities.Prod
 		&product.Name,
 		&product.Description,
 		&product.CategoryID,
+		&product.Version,
 		&category.ID,
 		&category.Name,
 		&category.Description,
@@ -196,6 +200,7 @@ func (r *ProductRepository) Search(ctx context.Context, q string, page int, size
 					"p"."name",
 					COALESCE("p"."description", '') "description",
 					"p"."category_id",
+					"p"."version",
 					JSONB_BUILD_OBJECT(
                         'id', "c"."id",
 						'name', "c"."name",
@@ -217,6 +222,7 @@ func (r *ProductRepository) Search(ctx context.Context, q string, page int, size
 									"i"."name",
 									"i"."image_url",
 									"i"."thumbnail_url",
+									"i"."renditions",
 									"i"."created_at",
 									"i"."updated_at",
 									"i"."deleted_at"
@@ -272,48 +278,66 @@ func (r *ProductRepository) Update(ctx context.Context, dto *dtos.UpdateProductD
         "description" = $2,
         "category_id" = $3
     WHERE "id" = $4
+        AND ($5::int[] IS NULL OR "version" = ANY($5))
     `
 
-	_, err := r.dbConn.Exec(ctx, sql, dto.Name, dto.Description, dto.CategoryID, dto.ID)
+	cmd, err := r.dbConn.Exec(ctx, sql, dto.Name, dto.Description, dto.CategoryID, dto.ID, dto.Versions)
 
 	var pgErr *pgconn.PgError
 	if errors.As(err, &pgErr) {
-		if pgErr.Code == pgerrcode.CheckViolation {
+		switch pgErr.Code {
+		case pgerrcode.CheckViolation:
 			return common.ErrBadParamInput
+		case pgerrcode.ForeignKeyViolation:
+			// no such category
+			return common.ErrNotFound
 		}
 	}
-	return err
+	if err != nil {
+		return err
+	}
+	if cmd.RowsAffected() == 0 {
+		return staleOrNotFound(ctx, r.dbConn, "product", dto.ID, dto.Versions)
+	}
+	return nil
 }
 
-func (r *ProductRepository) Create(ctx context.Context, dto *dtos.CreateProductDto) error {
+func (r *ProductRepository) Create(ctx context.Context, dto *dtos.CreateProductDto) (int, error) {
 	sql := `
-    INSERT INTO "public"."product" ("name", description", "category_id")
+    INSERT INTO "public"."product" ("name", "description", "category_id")
     VALUES ($1, $2, $3)
+    RETURNING "id"
     `
-	_, err := r.dbConn.Exec(ctx, sql, dto.Name, dto.Description, dto.CategoryID)
+	var id int
+	err := r.dbConn.QueryRow(ctx, sql, dto.Name, dto.Description, dto.CategoryID).Scan(&id)
 
 	var pgErr *pgconn.PgError
 	if errors.As(err, &pgErr) {
-		if pgErr.Code == pgerrcode.CheckViolation {
-			return common.ErrBadParamInput
+		switch pgErr.Code {
+		case pgerrcode.CheckViolation:
+			return 0, common.ErrBadParamInput
+		case pgerrcode.ForeignKeyViolation:
+			// no such category
+			return 0, common.ErrNotFound
 		}
 	}
-	return err
+	return id, err
 }
 
-func (r *ProductRepository) Delete(ctx context.Context, id int) error {
+func (r *ProductRepository) Delete(ctx context.Context, id int, versions []int) error {
 	sql := `
     DELETE
     FROM "public"."product"
     WHERE "id" = $1
+        AND ($2::int[] IS NULL OR "version" = ANY($2))
     `
-	if cmd, err := r.dbConn.Exec(ctx, sql, id); err != nil {
-		return err
+	if cmd, err := r.dbConn.Exec(ctx, sql, id, versions); err != nil {
+		return stillReferenced(err)
 	} else {
 		if cmd.RowsAffected() > 0 {
 			return nil
 		} else {
-			return common.ErrNotFound
+			return staleOrNotFound(ctx, r.dbConn, "product", id, versions)
 		}
 	}
 }
@@ -324,6 +348,7 @@ func (r *ProductRepository) GetImages(ctx context.Context, id int) ([]*entities.
             COALESCE("i"."name", '') "name",
             "i"."image_url",
             "i"."thumbnail_url",
+            "i"."renditions",
             "i"."created_at",
             "i"."updated_at",
             "i"."deleted_at"
@@ -343,6 +368,7 @@ func (r *ProductRepository) GetImages(ctx context.Context, id int) ([]*entities.
 				&image.Name,
 				&image.ImageUrl,
 				&image.ThumbnailUrl,
+				&image.Renditions,
 				&image.CreatedAt,
 				&image.UpdatedAt,
 				&image.DeletedAt,
@@ -401,6 +427,7 @@ func (r *ProductRepository) FetchVariants(ctx context.Context, id int, page int,
             'name', "p"."name",
             'description', COALESCE("p"."description", ''),
             'category_id', "p"."category_id",
+            'version', "p"."version",
             'category', JSONB_BUILD_OBJECT(
                 'id', "c"."id",
                 'name', "c"."name",
@@ -424,6 +451,20 @@ func (r *ProductRepository) FetchVariants(ctx context.Context, id int, page int,
                         "pv"."product_id",
                         "pv"."price",
                         "pv"."stock",
+                        "pv"."reserved",
+                        "pv"."reorder_point",
+                        "pv"."reorder_quantity",
+                        "pv"."serialized",
+                        "pv"."base_unit",
+                        "pv"."fractional",
+                        (SELECT COALESCE(JSONB_AGG(JSONB_BUILD_OBJECT(
+                            'name', "u"."name",
+                            'factor', "u"."factor"
+                        ) ORDER BY "u"."factor"), '[]')
+                            FROM "public"."unit_of_measure" "u"
+                            WHERE "u"."product_variant_id" = "pv"."id") "units",
+                        "pv"."unit_cost",
+                        "pv"."version",
                         "pv"."created_at",
                         "pv"."updated_at",
                         "pv"."deleted_at",
@@ -479,6 +520,42 @@ func (r *ProductRepository) FetchVariants(ctx context.Context, id int, page int,
 	return &product_variants, nil
 }
 
+// GetAllVariants returns every variant of the product with its attributes,
+// without pagination, units or costs.
+func (r *ProductRepository) GetAllVariants(ctx context.Context, id int) ([]*entities.ProductVariant, error) {
+	sql := `
+    SELECT COALESCE(JSONB_AGG("variants" ORDER BY "variants"."id"), '[]')
+    FROM
+        (SELECT "pv"."id",
+                "pv"."name",
+                "pv"."product_id",
+                "pv"."price",
+                "pv"."stock",
+                "pv"."reserved",
+                "pv"."version",
+                (SELECT COALESCE(JSONB_AGG(JSONB_BUILD_OBJECT(
+                    'id', "a"."id",
+                    'name', "a"."name",
+                    'type', "a"."type"
+                ) ORDER BY "a"."type", "a"."id"), '[]')
+                    FROM "public"."product_attributes" "pa"
+                    JOIN "public"."attribute" "a" ON "a"."id" = "pa"."attribute_id"
+                    WHERE "pa"."product_variant_id" = "pv"."id") "attributes"
+            FROM "public"."product_variant" "pv"
+            WHERE "pv"."product_id" = $1) "variants"
+    `
+	var rows json.RawMessage
+	if err := r.dbConn.QueryRow(ctx, sql, id).Scan(&rows); err != nil {
+		return nil, err
+	}
+
+	var variants []*entities.ProductVariant
+	if err := json.Unmarshal([]byte(rows), &variants); err != nil {
+		return nil, err
+	}
+	return variants, nil
+}
+
 func (r *ProductRepository) GetVariantByID(ctx context.Context, id int, variantID int) (*entities.ProductVariant, error) {
 	sql := `
     SELECT
@@ -492,6 +569,7 @@ func (r *ProductRepository) GetVariantByID(ctx context.Context, id int, variantI
                 'name', "p"."name",
                 'description', COALESCE("p"."description", ''),
                 'category_id', "p"."category_id",
+                'version', "p"."version",
                 'category', JSONB_BUILD_OBJECT(
                     'id', "c"."id",
                     'name', "c"."name",
@@ -506,6 +584,22 @@ func (r *ProductRepository) GetVariantByID(ctx context.Context, id int, variantI
             ),
             'price', "pv"."price",
             'stock', "pv"."stock",
+            'reserved', "pv"."reserved",
+            'reorder_point', "pv"."reorder_point",
+            'reorder_quantity', "pv"."reorder_quantity",
+            'serialized', "pv"."serialized",
+            'base_unit', "pv"."base_unit",
+            'fractional', "pv"."fractional",
+            'units', (
+                SELECT COALESCE(JSONB_AGG(JSONB_BUILD_OBJECT(
+                    'name', "u"."name",
+                    'factor', "u"."factor"
+                ) ORDER BY "u"."factor"), '[]')
+                FROM "public"."unit_of_measure" "u"
+                WHERE "u"."product_variant_id" = "pv"."id"
+            ),
+            'unit_cost', "pv"."unit_cost",
+            'version', "pv"."version",
             'created_at', "pv"."created_at",
             'updated_at', "pv"."updated_at",
             'deleted_at', "pv"."deleted_at",
@@ -515,6 +609,7 @@ func (r *ProductRepository) GetVariantByID(ctx context.Context, id int, variantI
                     'name', "i"."name",
                     'image_url', "i"."image_url",
                     'thumbnail_url', "i"."thumbnail_url",
+                    'renditions', "i"."renditions",
                     'created_at', "i"."created_at",
                     'updated_at', "i"."updated_at",
                     'deleted_at', "i"."deleted_at"
@@ -559,39 +654,51 @@ func (r *ProductRepository) GetVariantByID(ctx context.Context, id int, variantI
 	return &productVariant, nil
 }
 
-func (r *ProductRepository) CreateVariant(ctx context.Context, dto *dtos.CreateProductVariantDto) error {
+func (r *ProductRepository) CreateVariant(ctx context.Context, dto *dtos.CreateProductVariantDto) (int, error) {
 	sql := `
-    INSERT INTO "public"."product_variant" ("product_id", "name", "price", "stock")
-    VALUES ($1, $2, $3, $4)
+    INSERT INTO "public"."product_variant" ("product_id", "name", "price", "stock", "reorder_point", "reorder_quantity", "serialized", "base_unit", "fractional")
+    VALUES ($1, $2, $3, CASE WHEN $7::bool THEN 0 ELSE $4 END, $5, $6, $7, COALESCE(NULLIF($8, ''), 'pcs'), $9)
+    RETURNING "id"
     `
-	_, err := r.dbConn.Exec(ctx, sql, dto.ProductId, dto.Name, dto.Price, dto.Stock)
+	var id int
+	err := r.dbConn.QueryRow(ctx, sql, dto.ProductId, dto.Name, dto.Price, dto.Stock, dto.ReorderPoint, dto.ReorderQuantity, dto.Serialized, dto.BaseUnit, dto.Fractional).Scan(&id)
 
 	var pgErr *pgconn.PgError
 	if errors.As(err, &pgErr) {
-		if pgErr.Code == pgerrcode.CheckViolation {
-			return common.ErrBadParamInput
-		}
 		switch pgErr.Code {
 		case pgerrcode.CheckViolation:
-			return common.ErrBadParamInput
+			return 0, common.ErrBadParamInput
+		case pgerrcode.ForeignKeyViolation:
+			// no such product
+			return 0, common.ErrNotFound
 		default:
-			return err
+			return 0, err
 		}
 	}
-	return err
+	return id, err
 }
 
 func (r *ProductRepository) UpdateVariant(ctx context.Context, dto *dtos.UpdateProductVariantDto) error {
 	sql := `
     UPDATE "public"."product_variant"
-    SET "product_id" = $1,
-        "name" = $2,
+    SET "name" = $2,
         "price" = $3,
-        "stock" = $4
-    WHERE "id" = $5
+        "stock" = CASE
+            -- the stock of lot tracked variants is the sum of their lots and
+            -- the stock of serialized variants the count of their serials
+            WHEN COALESCE($9, "serialized") OR EXISTS (SELECT 1 FROM "public"."lot" "l" WHERE "l"."product_variant_id" = $7) THEN "stock"
+            ELSE $4 * "public"."unit_factor"($7, $10)
+        END,
+        "reorder_point" = $5,
+        "reorder_quantity" = $6,
+        "serialized" = COALESCE($9, "serialized"),
+        "base_unit" = COALESCE(NULLIF($11, ''), "base_unit"),
+        "fractional" = COALESCE($12, "fractional")
+    WHERE "id" = $7 AND "product_id" = $1
+        AND ($8::int[] IS NULL OR "version" = ANY($8))
     `
 
-	_, err := r.dbConn.Exec(ctx, sql, dto.ProductId, dto.Name, dto.Price, dto.Stock, dto.ID)
+	cmd, err := r.dbConn.Exec(ctx, sql, dto.ProductId, dto.Name, dto.Price, dto.Stock, dto.ReorderPoint, dto.ReorderQuantity, dto.ID, dto.Versions, dto.Serialized, dto.StockUnit, dto.BaseUnit, dto.Fractional)
 
 	var pgErr *pgconn.PgError
 	if errors.As(err, &pgErr) {
@@ -602,22 +709,29 @@ func (r *ProductRepository) UpdateVariant(ctx context.Context, dto *dtos.UpdateP
 			return err
 		}
 	}
-	return err
+	if err != nil {
+		return err
+	}
+	if cmd.RowsAffected() == 0 {
+		return staleOrNotFoundOf(ctx, r.dbConn, "product_variant", "product_id", dto.ProductId, dto.ID, dto.Versions)
+	}
+	return nil
 }
 
-func (r *ProductRepository) DeleteVariant(ctx context.Context, id int, variantID int) error {
+func (r *ProductRepository) DeleteVariant(ctx context.Context, id int, variantID int, versions []int) error {
 	sql := `
     DELETE
     FROM "public"."product_variant"
     WHERE "id" = $2 AND "product_id" = $1
+        AND ($3::int[] IS NULL OR "version" = ANY($3))
     `
-	if cmd, err := r.dbConn.Exec(ctx, sql, id, variantID); err != nil {
-		return err
+	if cmd, err := r.dbConn.Exec(ctx, sql, id, variantID, versions); err != nil {
+		return stillReferenced(err)
 	} else {
 		if cmd.RowsAffected() > 0 {
 			return nil
 		} else {
-			return common.ErrNotFound
+			return staleOrNotFoundOf(ctx, r.dbConn, "product_variant", "product_id", id, variantID, versions)
 		}
 	}
 }
@@ -667,12 +781,14 @@ func (r *ProductRepository) AddAttribute(ctx context.Context, dto *dtos.CreatePr
 
 	var pgErr *pgconn.PgError
 	if errors.As(err, &pgErr) {
-		if pgErr.Code == pgerrcode.CheckViolation {
-			return common.ErrBadParamInput
-		}
 		switch pgErr.Code {
 		case pgerrcode.CheckViolation:
 			return common.ErrBadParamInput
+		case pgerrcode.ForeignKeyViolation:
+			// no such variant or attribute
+			return common.ErrNotFound
+		case pgerrcode.UniqueViolation:
+			return common.ErrConflict
 		default:
 			return err
 		}
@@ -736,6 +852,7 @@ func (r *ProductRepository) SearchVariants(ctx context.Context, q string, id int
             'name', "p"."name",
             'description', COALESCE("p"."description", ''),
             'category_id', "p"."category_id",
+            'version', "p"."version",
             'category', JSONB_BUILD_OBJECT(
                 'id', "c"."id",
                 'name', "c"."name",
@@ -759,6 +876,20 @@ func (r *ProductRepository) SearchVariants(ctx context.Context, q string, id int
                         "pv"."product_id",
                         "pv"."price",
                         "pv"."stock",
+                        "pv"."reserved",
+                        "pv"."reorder_point",
+                        "pv"."reorder_quantity",
+                        "pv"."serialized",
+                        "pv"."base_unit",
+                        "pv"."fractional",
+                        (SELECT COALESCE(JSONB_AGG(JSONB_BUILD_OBJECT(
+                            'name', "u"."name",
+                            'factor', "u"."factor"
+                        ) ORDER BY "u"."factor"), '[]')
+                            FROM "public"."unit_of_measure" "u"
+                            WHERE "u"."product_variant_id" = "pv"."id") "units",
+                        "pv"."unit_cost",
+                        "pv"."version",
                         "pv"."created_at",
                         "pv"."updated_at",
                         "pv"."deleted_at",
@@ -833,3 +964,20 @@ func (r *ProductRepository) SearchVariants(ctx context.Context, q string, id int
 
 	return &product_variants, nil
 }
+
+// searchTables are the tables the product and variant searches read.
+var searchTables = []string{"category", "product", "product_variant", "attribute", "product_attributes"}
+
+// Reindex rebuilds the indexes of the search tables and refreshes their
+// statistics for the planner.
+func (r *ProductRepository) Reindex(ctx context.Context) error {
+	for _, table := range searchTables {
+		if _, err := r.dbConn.Exec(ctx, fmt.Sprintf(`REINDEX TABLE "public"."%s"`, table)); err != nil {
+			return err
+		}
+		if _, err := r.dbConn.Exec(ctx, fmt.Sprintf(`ANALYZE "public"."%s"`, table)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
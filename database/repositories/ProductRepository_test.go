@@ -0,0 +1,76 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/ysfada/product-management-system/domain/common"
+	"github.com/ysfada/product-management-system/domain/dtos"
+)
+
+// variantProduct returns the product of a variant.
+func variantProduct(t *testing.T, tx pgx.Tx, variantID int) int {
+	var id int
+	err := tx.QueryRow(context.Background(), `
+    SELECT "product_id" FROM "public"."product_variant" WHERE "id" = $1
+    `, variantID).Scan(&id)
+	if err != nil {
+		t.Fatalf("Unable to get product: %v", err)
+	}
+	return id
+}
+
+func TestUpdateVariantKeepsSerialized(t *testing.T) {
+	ctx := context.Background()
+	tx := testTx(t)
+	repository := NewProductRepository(tx)
+	variantID := testVariant(t, tx, 0)
+
+	_, err := tx.Exec(ctx, `
+    UPDATE "public"."product_variant" SET "serialized" = true WHERE "id" = $1
+    `, variantID)
+	if !assert.NoError(t, err) {
+		return
+	}
+	_, err = tx.Exec(ctx, `
+    INSERT INTO "public"."serial_number" ("product_variant_id", "serial", "status")
+    SELECT $1, 'test-' || $1::text || '-' || "n"::text, 'in_stock'
+    FROM GENERATE_SERIES(1, 2) "n"
+    `, variantID)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.NoError(t, repository.UpdateVariant(ctx, &dtos.UpdateProductVariantDto{
+		ID:        variantID,
+		Name:      "test",
+		ProductId: variantProduct(t, tx, variantID),
+		Price:     1,
+		Stock:     10,
+	}))
+	assert.Equal(t, 2.0, stockOf(t, tx, variantID))
+
+	var serialized bool
+	err = tx.QueryRow(ctx, `
+    SELECT "serialized" FROM "public"."product_variant" WHERE "id" = $1
+    `, variantID).Scan(&serialized)
+	if assert.NoError(t, err) {
+		assert.True(t, serialized)
+	}
+}
+
+func TestDeleteVariantOfAnotherProduct(t *testing.T) {
+	ctx := context.Background()
+	tx := testTx(t)
+	repository := NewProductRepository(tx)
+	variantID := testVariant(t, tx, 0)
+	productID := variantProduct(t, tx, variantID)
+	otherID := variantProduct(t, tx, testVariant(t, tx, 0))
+
+	assert.ErrorIs(t, repository.DeleteVariant(ctx, otherID, variantID, nil), common.ErrNotFound)
+	assert.ErrorIs(t, repository.DeleteVariant(ctx, otherID, variantID, []int{1}), common.ErrNotFound)
+	assert.ErrorIs(t, repository.DeleteVariant(ctx, productID, variantID, []int{}), common.ErrPreconditionFailed)
+	assert.NoError(t, repository.DeleteVariant(ctx, productID, variantID, nil))
+}
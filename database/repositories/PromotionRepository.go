@@ -0,0 +1,457 @@
+package repositories
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v4"
+	"github.com/ysfada/product-management-system/domain/common"
+	"github.com/ysfada/product-management-system/domain/dtos"
+	"github.com/ysfada/product-management-system/domain/entities"
+	"github.com/ysfada/product-management-system/domain/interfaces"
+)
+
+type PromotionRepository struct {
+	dbConn DBTX
+}
+
+var _ interfaces.IPromotionRepository = (*PromotionRepository)(nil)
+
+func NewPromotionRepository(dbConn DBTX) *PromotionRepository {
+	return &PromotionRepository{
+		dbConn: dbConn,
+	}
+}
+
+func (r *PromotionRepository) Fetch(ctx context.Context, page int, size int) (*entities.PromotionPaginated, error) {
+	sql := `
+    SELECT
+        (SELECT COUNT(*)
+            FROM "public"."promotion" "p") "count",
+
+        (SELECT COALESCE(JSONB_AGG("result".*), '[]')
+            FROM
+                (SELECT "p"."id",
+                        "p"."name",
+                        "p"."code",
+                        "p"."kind",
+                        "p"."percent",
+                        "p"."buy_quantity",
+                        "p"."get_quantity",
+                        "p"."amount",
+                        "p"."min_subtotal",
+                        "p"."category_id",
+                        "p"."product_id",
+                        "p"."product_variant_id",
+                        "p"."attribute_id",
+                        "p"."starts_at",
+                        "p"."ends_at",
+                        "p"."usage_limit",
+                        "p"."used",
+                        "p"."exclusive",
+                        "p"."priority",
+                        "p"."created_at",
+                        "p"."updated_at",
+                        "p"."deleted_at"
+                    FROM "public"."promotion" "p"
+                    ORDER BY "p"."id" DESC
+                    OFFSET $1 ROWS FETCH NEXT $2 ROWS ONLY) "result") "promotions"
+    `
+
+	var promotions entities.PromotionPaginated
+	var rows json.RawMessage
+	if err := r.dbConn.QueryRow(ctx, sql, (page-1)*size, size).Scan(
+		&promotions.Count,
+		&rows,
+	); err != nil {
+		switch err {
+		case pgx.ErrNoRows:
+			return nil, common.ErrNotFound
+		default:
+			return nil, err
+		}
+	}
+
+	if err := json.Unmarshal([]byte(rows), &promotions.Promotions); err != nil {
+		return nil, err
+	}
+
+	promotions.Size = size
+	promotions.TotalPage = int(math.Ceil(float64(promotions.Count) / float64(size)))
+	promotions.CurrentPage = page
+	if promotions.CurrentPage <= promotions.TotalPage && promotions.CurrentPage > 1 {
+		promotions.PreviousPage = promotions.CurrentPage - 1
+	} else {
+		promotions.PreviousPage = -1
+	}
+	if promotions.CurrentPage < promotions.TotalPage {
+		promotions.NextPage = promotions.CurrentPage + 1
+	} else {
+		promotions.NextPage = -1
+	}
+
+	return &promotions, nil
+}
+
+func (r *PromotionRepository) GetByID(ctx context.Context, id int) (*entities.Promotion, error) {
+	sql := `
+    SELECT  "p"."id",
+            "p"."name",
+            "p"."code",
+            "p"."kind",
+            "p"."percent",
+            "p"."buy_quantity",
+            "p"."get_quantity",
+            "p"."amount",
+            "p"."min_subtotal",
+            "p"."category_id",
+            "p"."product_id",
+            "p"."product_variant_id",
+            "p"."attribute_id",
+            "p"."starts_at",
+            "p"."ends_at",
+            "p"."usage_limit",
+            "p"."used",
+            "p"."exclusive",
+            "p"."priority",
+            "p"."created_at",
+            "p"."updated_at",
+            "p"."deleted_at"
+    FROM "public"."promotion" "p"
+    WHERE "p"."id" = $1
+    LIMIT 1
+    `
+	if promotion, err := scanPromotion(r.dbConn.QueryRow(ctx, sql, id)); err != nil {
+		switch err {
+		case pgx.ErrNoRows:
+			return nil, common.ErrNotFound
+		default:
+			return nil, err
+		}
+	} else {
+		return promotion, nil
+	}
+}
+
+func (r *PromotionRepository) Create(ctx context.Context, dto *dtos.CreatePromotionDto) error {
+	sql := `
+    INSERT INTO "public"."promotion" ("name", "code", "kind", "percent", "buy_quantity", "get_quantity", "amount", "min_subtotal",
+        "category_id", "product_id", "product_variant_id", "attribute_id", "starts_at", "ends_at", "usage_limit", "exclusive", "priority")
+    VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17)
+    `
+	_, err := r.dbConn.Exec(ctx, sql, dto.Name, dto.Code, dto.Kind, dto.Percent, dto.BuyQuantity, dto.GetQuantity, dto.Amount, dto.MinSubtotal,
+		dto.CategoryID, dto.ProductID, dto.ProductVariantID, dto.AttributeID, dto.StartsAt, dto.EndsAt, dto.UsageLimit, dto.Exclusive, dto.Priority)
+	return promotionError(err)
+}
+
+// Update replaces the promotion, what it was used so far is kept.
+func (r *PromotionRepository) Update(ctx context.Context, dto *dtos.UpdatePromotionDto) error {
+	sql := `
+    UPDATE "public"."promotion"
+    SET "name" = $2,
+        "code" = $3,
+        "kind" = $4,
+        "percent" = $5,
+        "buy_quantity" = $6,
+        "get_quantity" = $7,
+        "amount" = $8,
+        "min_subtotal" = $9,
+        "category_id" = $10,
+        "product_id" = $11,
+        "product_variant_id" = $12,
+        "attribute_id" = $13,
+        "starts_at" = $14,
+        "ends_at" = $15,
+        "usage_limit" = $16,
+        "exclusive" = $17,
+        "priority" = $18
+    WHERE "id" = $1
+    `
+	cmd, err := r.dbConn.Exec(ctx, sql, dto.ID, dto.Name, dto.Code, dto.Kind, dto.Percent, dto.BuyQuantity, dto.GetQuantity, dto.Amount, dto.MinSubtotal,
+		dto.CategoryID, dto.ProductID, dto.ProductVariantID, dto.AttributeID, dto.StartsAt, dto.EndsAt, dto.UsageLimit, dto.Exclusive, dto.Priority)
+	if err := promotionError(err); err != nil {
+		return err
+	}
+	if cmd.RowsAffected() == 0 {
+		return common.ErrNotFound
+	}
+	return nil
+}
+
+func (r *PromotionRepository) Delete(ctx context.Context, id int) error {
+	sql := `
+    DELETE
+    FROM "public"."promotion"
+    WHERE "id" = $1
+    `
+	if cmd, err := r.dbConn.Exec(ctx, sql, id); err != nil {
+		return err
+	} else if cmd.RowsAffected() == 0 {
+		return common.ErrNotFound
+	}
+	return nil
+}
+
+// FetchByCodes returns the automatic promotions and the promotions of the
+// codes, whether they are running or not.
+func (r *PromotionRepository) FetchByCodes(ctx context.Context, codes []string) ([]*entities.Promotion, error) {
+	sql := `
+    SELECT  "p"."id",
+            "p"."name",
+            "p"."code",
+            "p"."kind",
+            "p"."percent",
+            "p"."buy_quantity",
+            "p"."get_quantity",
+            "p"."amount",
+            "p"."min_subtotal",
+            "p"."category_id",
+            "p"."product_id",
+            "p"."product_variant_id",
+            "p"."attribute_id",
+            "p"."starts_at",
+            "p"."ends_at",
+            "p"."usage_limit",
+            "p"."used",
+            "p"."exclusive",
+            "p"."priority",
+            "p"."created_at",
+            "p"."updated_at",
+            "p"."deleted_at"
+    FROM "public"."promotion" "p"
+    WHERE "p"."code" IS NULL
+        OR "p"."code" = ANY($1::text[]::citext[])
+    ORDER BY "p"."id" ASC
+    `
+	rows, err := r.dbConn.Query(ctx, sql, codes)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var promotions []*entities.Promotion
+	for rows.Next() {
+		if promotion, err := scanPromotion(rows); err != nil {
+			return nil, err
+		} else {
+			promotions = append(promotions, promotion)
+		}
+	}
+
+	return promotions, rows.Err()
+}
+
+// Lines looks up the variants of the lines with the current price and what
+// promotions can target. The quantities are converted to the base unit.
+func (r *PromotionRepository) Lines(ctx context.Context, lines []*dtos.EvaluatePromotionsLineDto) ([]*entities.PromotionLine, error) {
+	variantIDs := make([]int, 0, len(lines))
+	quantities := make([]float64, 0, len(lines))
+	units := make([]string, 0, len(lines))
+	for _, line := range lines {
+		variantIDs = append(variantIDs, line.ProductVariantID)
+		quantities = append(quantities, line.Quantity)
+		units = append(units, line.Unit)
+	}
+
+	sql := `
+    SELECT  "pv"."id",
+            "pv"."product_id",
+            "p"."category_id",
+            ARRAY(
+                SELECT "pa"."attribute_id"
+                FROM "public"."product_attributes" "pa"
+                WHERE "pa"."product_variant_id" = "pv"."id"
+                ORDER BY "pa"."attribute_id"
+            ),
+            "pv"."name",
+            "l"."quantity" * "public"."unit_factor"("pv"."id", "l"."unit"),
+            "pv"."price"
+    FROM UNNEST($1::int[], $2::float8[], $3::text[]) WITH ORDINALITY "l"("product_variant_id", "quantity", "unit", "position")
+    INNER JOIN "public"."product_variant" "pv"
+        ON "pv"."id" = "l"."product_variant_id"
+    INNER JOIN "public"."product" "p"
+        ON "p"."id" = "pv"."product_id"
+    ORDER BY "l"."position" ASC
+    `
+	rows, err := r.dbConn.Query(ctx, sql, variantIDs, quantities, units)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var promotionLines []*entities.PromotionLine
+	for rows.Next() {
+		var line entities.PromotionLine
+		if err := rows.Scan(
+			&line.ProductVariantID,
+			&line.ProductID,
+			&line.CategoryID,
+			&line.AttributeIDs,
+			&line.Name,
+			&line.Quantity,
+			&line.UnitPrice,
+		); err != nil {
+			return nil, err
+		}
+		promotionLines = append(promotionLines, &line)
+	}
+	if err := rows.Err(); err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.CheckViolation {
+			// an unknown unit
+			return nil, common.ErrBadParamInput
+		}
+		return nil, err
+	}
+
+	if len(promotionLines) < len(lines) {
+		return nil, common.ErrNotFound
+	}
+	return promotionLines, nil
+}
+
+// OrderLines returns the lines of a sales order in the base unit of their
+// variants. A cancelled order is reported as common.ErrConflict.
+func (r *PromotionRepository) OrderLines(ctx context.Context, salesOrderID int) ([]*dtos.EvaluatePromotionsLineDto, error) {
+	sql := `
+    SELECT "status"
+    FROM "public"."sales_order"
+    WHERE "id" = $1
+    `
+	var status string
+	if err := r.dbConn.QueryRow(ctx, sql, salesOrderID).Scan(&status); err != nil {
+		switch err {
+		case pgx.ErrNoRows:
+			return nil, common.ErrNotFound
+		default:
+			return nil, err
+		}
+	}
+	if status == "cancelled" {
+		return nil, common.ErrConflict
+	}
+
+	sql = `
+    SELECT "l"."product_variant_id", "l"."quantity"
+    FROM "public"."sales_order_line" "l"
+    WHERE "l"."sales_order_id" = $1
+    ORDER BY "l"."id" ASC
+    `
+	rows, err := r.dbConn.Query(ctx, sql, salesOrderID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var lines []*dtos.EvaluatePromotionsLineDto
+	for rows.Next() {
+		var line dtos.EvaluatePromotionsLineDto
+		if err := rows.Scan(&line.ProductVariantID, &line.Quantity); err != nil {
+			return nil, err
+		}
+		lines = append(lines, &line)
+	}
+	return lines, rows.Err()
+}
+
+// Redeem counts a use of each of the promotions for a sales order. A
+// promotion already redeemed for the order is not counted again. If any of
+// the others is not running or reached its usage limit meanwhile none is
+// counted and common.ErrConflict is returned.
+func (r *PromotionRepository) Redeem(ctx context.Context, salesOrderID int, ids []int) error {
+	return r.dbConn.BeginFunc(ctx, func(tx pgx.Tx) error {
+		sql := `
+        INSERT INTO "public"."promotion_redemption" ("promotion_id", "sales_order_id")
+        SELECT UNNEST($1::int[]), $2
+        ON CONFLICT ("promotion_id", "sales_order_id") DO NOTHING
+        RETURNING "promotion_id"
+        `
+		rows, err := tx.Query(ctx, sql, ids, salesOrderID)
+		if err != nil {
+			return err
+		}
+		var redeemed []int
+		for rows.Next() {
+			var id int
+			if err := rows.Scan(&id); err != nil {
+				rows.Close()
+				return err
+			}
+			redeemed = append(redeemed, id)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return err
+		}
+		if len(redeemed) == 0 {
+			return nil
+		}
+
+		sql = `
+        UPDATE "public"."promotion"
+        SET "used" = "used" + 1
+        WHERE "id" = ANY($1)
+            AND ("usage_limit" IS NULL OR "used" < "usage_limit")
+            AND ("starts_at" IS NULL OR "starts_at" <= NOW())
+            AND ("ends_at" IS NULL OR "ends_at" > NOW())
+        `
+		if cmd, err := tx.Exec(ctx, sql, redeemed); err != nil {
+			return err
+		} else if int(cmd.RowsAffected()) < len(redeemed) {
+			return common.ErrConflict
+		}
+		return nil
+	})
+}
+
+func scanPromotion(row pgx.Row) (*entities.Promotion, error) {
+	var promotion entities.Promotion
+	if err := row.Scan(
+		&promotion.ID,
+		&promotion.Name,
+		&promotion.Code,
+		&promotion.Kind,
+		&promotion.Percent,
+		&promotion.BuyQuantity,
+		&promotion.GetQuantity,
+		&promotion.Amount,
+		&promotion.MinSubtotal,
+		&promotion.CategoryID,
+		&promotion.ProductID,
+		&promotion.ProductVariantID,
+		&promotion.AttributeID,
+		&promotion.StartsAt,
+		&promotion.EndsAt,
+		&promotion.UsageLimit,
+		&promotion.Used,
+		&promotion.Exclusive,
+		&promotion.Priority,
+		&promotion.CreatedAt,
+		&promotion.UpdatedAt,
+		&promotion.DeletedAt,
+	); err != nil {
+		return nil, err
+	}
+	return &promotion, nil
+}
+
+// promotionError maps the constraint violations of promotion.
+func promotionError(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		switch pgErr.Code {
+		case pgerrcode.UniqueViolation:
+			return common.ErrConflict
+		case pgerrcode.CheckViolation:
+			return common.ErrBadParamInput
+		case pgerrcode.ForeignKeyViolation:
+			// an unknown target
+			return common.ErrNotFound
+		}
+	}
+	return err
+}
@@ -0,0 +1,40 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedeemCountsOncePerOrder(t *testing.T) {
+	ctx := context.Background()
+	tx := testTx(t)
+	repository := NewPromotionRepository(tx)
+	variantID := testVariant(t, tx, 10)
+	first := order(t, NewSalesOrderRepository(tx), variantID, 1)
+	second := order(t, NewSalesOrderRepository(tx), variantID, 1)
+
+	var promotionID int
+	err := tx.QueryRow(ctx, `
+    INSERT INTO "public"."promotion" ("name", "kind", "percent", "usage_limit")
+    VALUES ('test', 'percent_off', 10, 2)
+    RETURNING "id"
+    `).Scan(&promotionID)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.NoError(t, repository.Redeem(ctx, first, []int{promotionID}))
+	assert.NoError(t, repository.Redeem(ctx, first, []int{promotionID}))
+	promotion, err := repository.GetByID(ctx, promotionID)
+	if assert.NoError(t, err) {
+		assert.Equal(t, 1, promotion.Used)
+	}
+
+	assert.NoError(t, repository.Redeem(ctx, second, []int{promotionID}))
+	promotion, err = repository.GetByID(ctx, promotionID)
+	if assert.NoError(t, err) {
+		assert.Equal(t, 2, promotion.Used)
+	}
+}
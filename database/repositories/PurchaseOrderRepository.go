@@ -0,0 +1,517 @@
+package repositories
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v4"
+	"github.com/ysfada/product-management-system/domain/common"
+	"github.com/ysfada/product-management-system/domain/dtos"
+	"github.com/ysfada/product-management-system/domain/entities"
+	"github.com/ysfada/product-management-system/domain/interfaces"
+)
+
+type PurchaseOrderRepository struct {
+	dbConn DBTX
+}
+
+var _ interfaces.IPurchaseOrderRepository = (*PurchaseOrderRepository)(nil)
+
+func NewPurchaseOrderRepository(dbConn DBTX) *PurchaseOrderRepository {
+	return &PurchaseOrderRepository{
+		dbConn: dbConn,
+	}
+}
+
+// Fetch lists the purchase orders without their lines, newest first. A
+// supplierID of 0 or an empty status does not filter.
+func (r *PurchaseOrderRepository) Fetch(ctx context.Context, supplierID int, status string, page int, size int) (*entities.PurchaseOrderPaginated, error) {
+	sql := `
+    WITH "po" AS (
+        SELECT "po"."id",
+                "po"."supplier_id",
+                "s"."name" "supplier_name",
+                "po"."status",
+                "po"."currency",
+                "po"."expected_at"::timestamptz "expected_at",
+                "po"."note",
+                "po"."created_by",
+                "po"."sent_at",
+                "po"."closed_at",
+                (SELECT COALESCE(SUM("l"."quantity" * "l"."unit_cost"), 0)
+                    FROM "public"."purchase_order_line" "l"
+                    WHERE "l"."purchase_order_id" = "po"."id") "total",
+                "po"."created_at",
+                "po"."updated_at",
+                "po"."deleted_at"
+        FROM "public"."purchase_order" "po"
+        JOIN "public"."supplier" "s" ON "s"."id" = "po"."supplier_id"
+        WHERE ($1 = 0 OR "po"."supplier_id" = $1)
+            AND ($2 = '' OR "po"."status" = $2)
+    )
+    SELECT
+        (SELECT COUNT(*)
+            FROM "po") "count",
+
+        (SELECT COALESCE(JSONB_AGG("result".*), '[]')
+            FROM
+                (SELECT *
+                    FROM "po"
+                    ORDER BY "po"."id" DESC
+                    OFFSET $3 ROWS FETCH NEXT $4 ROWS ONLY) "result") "purchase_orders"
+    `
+
+	var purchaseOrders entities.PurchaseOrderPaginated
+	var rows json.RawMessage
+	if err := r.dbConn.QueryRow(ctx, sql, supplierID, status, (page-1)*size, size).Scan(
+		&purchaseOrders.Count,
+		&rows,
+	); err != nil {
+		switch err {
+		case pgx.ErrNoRows:
+			return nil, common.ErrNotFound
+		default:
+			return nil, err
+		}
+	}
+
+	if err := json.Unmarshal([]byte(rows), &purchaseOrders.PurchaseOrders); err != nil {
+		return nil, err
+	}
+
+	purchaseOrders.Size = size
+	purchaseOrders.TotalPage = int(math.Ceil(float64(purchaseOrders.Count) / float64(size)))
+	purchaseOrders.CurrentPage = page
+	if purchaseOrders.CurrentPage <= purchaseOrders.TotalPage && purchaseOrders.CurrentPage > 1 {
+		purchaseOrders.PreviousPage = purchaseOrders.CurrentPage - 1
+	} else {
+		purchaseOrders.PreviousPage = -1
+	}
+	if purchaseOrders.CurrentPage < purchaseOrders.TotalPage {
+		purchaseOrders.NextPage = purchaseOrders.CurrentPage + 1
+	} else {
+		purchaseOrders.NextPage = -1
+	}
+
+	return &purchaseOrders, nil
+}
+
+func (r *PurchaseOrderRepository) GetByID(ctx context.Context, id int) (*entities.PurchaseOrder, error) {
+	sql := `
+    SELECT  "po"."id",
+            "po"."supplier_id",
+            "s"."name",
+            "po"."status",
+            "po"."currency",
+            "po"."expected_at",
+            "po"."note",
+            "po"."created_by",
+            "po"."sent_at",
+            "po"."closed_at",
+            (SELECT COALESCE(SUM("l"."quantity" * "l"."unit_cost"), 0)
+                FROM "public"."purchase_order_line" "l"
+                WHERE "l"."purchase_order_id" = "po"."id") "total",
+            "po"."created_at",
+            "po"."updated_at",
+            "po"."deleted_at"
+    FROM "public"."purchase_order" "po"
+    JOIN "public"."supplier" "s" ON "s"."id" = "po"."supplier_id"
+    WHERE "po"."id" = $1
+    LIMIT 1
+    `
+	var purchaseOrder entities.PurchaseOrder
+	if err := r.dbConn.QueryRow(ctx, sql, id).Scan(
+		&purchaseOrder.ID,
+		&purchaseOrder.SupplierID,
+		&purchaseOrder.SupplierName,
+		&purchaseOrder.Status,
+		&purchaseOrder.Currency,
+		&purchaseOrder.ExpectedAt,
+		&purchaseOrder.Note,
+		&purchaseOrder.CreatedBy,
+		&purchaseOrder.SentAt,
+		&purchaseOrder.ClosedAt,
+		&purchaseOrder.Total,
+		&purchaseOrder.CreatedAt,
+		&purchaseOrder.UpdatedAt,
+		&purchaseOrder.DeletedAt,
+	); err != nil {
+		switch err {
+		case pgx.ErrNoRows:
+			return nil, common.ErrNotFound
+		default:
+			return nil, err
+		}
+	}
+
+	sql = `
+    SELECT  "l"."id",
+            "l"."product_variant_id",
+            "pv"."name",
+            "sv"."supplier_sku",
+            "l"."quantity",
+            "l"."unit_cost",
+            "l"."received"
+    FROM "public"."purchase_order_line" "l"
+    JOIN "public"."purchase_order" "po" ON "po"."id" = "l"."purchase_order_id"
+    JOIN "public"."product_variant" "pv" ON "pv"."id" = "l"."product_variant_id"
+    LEFT JOIN "public"."supplier_variant" "sv" ON "sv"."supplier_id" = "po"."supplier_id"
+        AND "sv"."product_variant_id" = "l"."product_variant_id"
+    WHERE "l"."purchase_order_id" = $1
+    ORDER BY "l"."id" ASC
+    `
+	rows, err := r.dbConn.Query(ctx, sql, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var line entities.PurchaseOrderLine
+		if err := rows.Scan(
+			&line.ID,
+			&line.ProductVariantID,
+			&line.Name,
+			&line.SupplierSKU,
+			&line.Quantity,
+			&line.UnitCost,
+			&line.Received,
+		); err != nil {
+			return nil, err
+		}
+		purchaseOrder.Lines = append(purchaseOrder.Lines, &line)
+	}
+
+	return &purchaseOrder, rows.Err()
+}
+
+// Create drafts a purchase order in the currency of its supplier. Lines
+// without a unit cost take the cost of the variant at the supplier, a line
+// of a variant the supplier has no cost for is rejected with
+// common.ErrBadParamInput.
+func (r *PurchaseOrderRepository) Create(ctx context.Context, dto *dtos.CreatePurchaseOrderDto) (int, error) {
+	variantIDs := make([]int, 0, len(dto.Lines))
+	quantities := make([]float64, 0, len(dto.Lines))
+	units := make([]string, 0, len(dto.Lines))
+	unitCosts := make([]*float64, 0, len(dto.Lines))
+	for _, line := range dto.Lines {
+		variantIDs = append(variantIDs, line.ProductVariantID)
+		quantities = append(quantities, line.Quantity)
+		units = append(units, line.Unit)
+		unitCosts = append(unitCosts, line.UnitCost)
+	}
+
+	var id int
+	err := r.dbConn.BeginFunc(ctx, func(tx pgx.Tx) error {
+		sql := `
+        INSERT INTO "public"."purchase_order" ("supplier_id", "currency", "expected_at", "note", "created_by")
+        SELECT "s"."id", "s"."currency", $2, $3, $4
+        FROM "public"."supplier" "s"
+        WHERE "s"."id" = $1
+        RETURNING "id"
+        `
+		if err := tx.QueryRow(ctx, sql, dto.SupplierID, dto.ExpectedAt, dto.Note, dto.CreatedBy).Scan(&id); err != nil {
+			if err == pgx.ErrNoRows {
+				return common.ErrNotFound
+			}
+			return err
+		}
+
+		// lines are kept in the base unit of their variant
+		sql = `
+        INSERT INTO "public"."purchase_order_line" ("purchase_order_id", "product_variant_id", "quantity", "unit_cost")
+        SELECT $1, "l"."product_variant_id", "l"."quantity" * "f"."factor", COALESCE("l"."unit_cost" / "f"."factor", "sv"."unit_cost")
+        FROM UNNEST($3::int[], $4::float8[], $5::float8[], $6::text[]) "l"("product_variant_id", "quantity", "unit_cost", "unit")
+        CROSS JOIN LATERAL (SELECT "public"."unit_factor"("l"."product_variant_id", "l"."unit") "factor") "f"
+        LEFT JOIN "public"."supplier_variant" "sv" ON "sv"."supplier_id" = $2
+            AND "sv"."product_variant_id" = "l"."product_variant_id"
+        `
+		_, err := tx.Exec(ctx, sql, id, dto.SupplierID, variantIDs, quantities, unitCosts, units)
+		return err
+	})
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		switch pgErr.Code {
+		case pgerrcode.ForeignKeyViolation:
+			return 0, common.ErrNotFound
+		case pgerrcode.NotNullViolation, pgerrcode.UniqueViolation, pgerrcode.CheckViolation:
+			return 0, common.ErrBadParamInput
+		}
+	}
+	if err != nil {
+		return 0, err
+	}
+	return id, nil
+}
+
+// Send marks a draft as sent to the supplier, its lines can be received from
+// then on.
+func (r *PurchaseOrderRepository) Send(ctx context.Context, id int) error {
+	sql := `
+    UPDATE "public"."purchase_order"
+    SET "status" = 'sent',
+        "sent_at" = NOW()
+    WHERE "id" = $1
+        AND "status" = 'draft'
+    `
+	return r.transition(ctx, sql, id)
+}
+
+// Receive books a delivery against the lines of a sent purchase order. The
+// received quantities are added to the stock of the variants at the unit cost
+// of their lines, and the order becomes partially received or received, all
+// in one transaction. Lot tracked variants, or lines with a lot number, are
+// received into the lot of the line and serialized variants as the serials
+// of the line. Receiving more than is outstanding on a line, a variant that
+// is not on the order, a lot tracked variant without a lot number or a
+// serialized variant without a serial per unit is rejected with
+// common.ErrBadParamInput, serials that already exist with
+// common.ErrConflict.
+func (r *PurchaseOrderRepository) Receive(ctx context.Context, dto *dtos.CreatePurchaseOrderReceiptDto) error {
+	err := r.dbConn.BeginFunc(ctx, func(tx pgx.Tx) error {
+		// serializes the deliveries of the order
+		sql := `
+        SELECT "status"
+        FROM "public"."purchase_order"
+        WHERE "id" = $1
+        FOR UPDATE
+        `
+		var status string
+		if err := tx.QueryRow(ctx, sql, dto.PurchaseOrderID).Scan(&status); err != nil {
+			if err == pgx.ErrNoRows {
+				return common.ErrNotFound
+			}
+			return err
+		}
+		if status != "sent" && status != "partially_received" {
+			return common.ErrConflict
+		}
+
+		// the reason of the stock movements recorded by product_variant
+		if _, err := tx.Exec(ctx, `SELECT set_config('pms.movement_reason', 'purchase_order', true)`); err != nil {
+			return err
+		}
+
+		for _, line := range dto.Lines {
+			// the cost of the received units for the "_unit_cost" and
+			// "_stock_movement" triggers of product_variant
+			sql = `
+            SELECT set_config('pms.movement_unit_cost', "unit_cost"::text, true)
+            FROM "public"."purchase_order_line"
+            WHERE "purchase_order_id" = $1
+                AND "product_variant_id" = $2
+            `
+			if cmd, err := tx.Exec(ctx, sql, dto.PurchaseOrderID, line.ProductVariantID); err != nil {
+				return err
+			} else if cmd.RowsAffected() == 0 {
+				return common.ErrBadParamInput
+			}
+
+			sql = `
+            WITH "q" AS (
+                SELECT $3::decimal * "public"."unit_factor"($2, $5) "quantity"
+            ), "l" AS (
+                UPDATE "public"."purchase_order_line"
+                SET "received" = "received" + "q"."quantity"
+                FROM "q"
+                WHERE "purchase_order_id" = $1
+                    AND "product_variant_id" = $2
+                RETURNING "id", "product_variant_id", "q"."quantity"
+            ), "receipt" AS (
+                INSERT INTO "public"."purchase_order_receipt" ("purchase_order_line_id", "quantity", "received_by")
+                SELECT "l"."id", "l"."quantity", $4
+                FROM "l"
+            )
+            SELECT "pv"."serialized",
+                    EXISTS (SELECT 1 FROM "public"."lot" "lot" WHERE "lot"."product_variant_id" = "pv"."id"),
+                    "l"."quantity"::float8
+            FROM "l"
+            JOIN "public"."product_variant" "pv" ON "pv"."id" = "l"."product_variant_id"
+            `
+			var serialized, lotTracked bool
+			var quantity float64
+			if err := tx.QueryRow(ctx, sql, dto.PurchaseOrderID, line.ProductVariantID, line.Quantity, dto.ReceivedBy, line.Unit).Scan(&serialized, &lotTracked, &quantity); err != nil {
+				return err
+			}
+
+			switch {
+			case serialized:
+				// the stock of serialized variants follows their serials
+				if float64(len(line.Serials)) != quantity {
+					return common.ErrBadParamInput
+				}
+				sql = `
+                INSERT INTO "public"."serial_number" ("product_variant_id", "serial", "status")
+                SELECT $1, "s"."serial", 'in_stock'
+                FROM UNNEST($2::text[]) "s"("serial")
+                `
+				if _, err := tx.Exec(ctx, sql, line.ProductVariantID, line.Serials); err != nil {
+					return err
+				}
+			case lotTracked || line.LotNumber != "":
+				// the stock of lot tracked variants is the sum of their lots
+				if line.LotNumber == "" {
+					return common.ErrBadParamInput
+				}
+				sql = `
+                INSERT INTO "public"."lot" ("product_variant_id", "lot_number", "expires_at", "quantity")
+                VALUES ($1, $2, $3, $4)
+                ON CONFLICT ("product_variant_id", "lot_number") DO UPDATE
+                SET "quantity" = "lot"."quantity" + EXCLUDED."quantity",
+                    "expires_at" = COALESCE("lot"."expires_at", EXCLUDED."expires_at")
+                `
+				if _, err := tx.Exec(ctx, sql, line.ProductVariantID, line.LotNumber, line.ExpiresAt, quantity); err != nil {
+					return err
+				}
+			default:
+				sql = `
+                UPDATE "public"."product_variant"
+                SET "stock" = "stock" + $2
+                WHERE "id" = $1
+                `
+				if _, err := tx.Exec(ctx, sql, line.ProductVariantID, quantity); err != nil {
+					return err
+				}
+			}
+		}
+
+		sql = `
+        UPDATE "public"."purchase_order" "po"
+        SET "status" = CASE
+                WHEN EXISTS (
+                    SELECT 1
+                    FROM "public"."purchase_order_line" "l"
+                    WHERE "l"."purchase_order_id" = "po"."id"
+                        AND "l"."received" < "l"."quantity"
+                ) THEN 'partially_received'
+                ELSE 'received'
+            END
+        WHERE "po"."id" = $1
+        `
+		_, err := tx.Exec(ctx, sql, dto.PurchaseOrderID)
+		return err
+	})
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		switch pgErr.Code {
+		case pgerrcode.CheckViolation:
+			// over-receipt, or a fraction of a variant that is not fractional
+			return common.ErrBadParamInput
+		case pgerrcode.UniqueViolation:
+			// a serial that already exists
+			return common.ErrConflict
+		}
+	}
+	return err
+}
+
+// Close finishes a sent order, whatever is still outstanding will not be
+// delivered.
+func (r *PurchaseOrderRepository) Close(ctx context.Context, id int) error {
+	sql := `
+    UPDATE "public"."purchase_order"
+    SET "status" = 'closed',
+        "closed_at" = NOW()
+    WHERE "id" = $1
+        AND "status" IN ('sent', 'partially_received', 'received')
+    `
+	return r.transition(ctx, sql, id)
+}
+
+// Suggestions lists the variants whose available stock plus the quantity on
+// open purchase orders is at or below their reorder point, with their
+// preferred supplier.
+func (r *PurchaseOrderRepository) Suggestions(ctx context.Context) ([]*entities.ReorderSuggestion, error) {
+	sql := `
+    WITH "on_order" AS (
+        SELECT "l"."product_variant_id", SUM("l"."quantity" - "l"."received") "quantity"
+        FROM "public"."purchase_order_line" "l"
+        JOIN "public"."purchase_order" "po" ON "po"."id" = "l"."purchase_order_id"
+        WHERE "po"."status" IN ('draft', 'sent', 'partially_received')
+        GROUP BY "l"."product_variant_id"
+    )
+    SELECT  "pv"."id",
+            "pv"."name",
+            "pv"."stock" - "pv"."reserved",
+            COALESCE("oo"."quantity", 0),
+            COALESCE("pv"."reorder_point", "c"."reorder_point"),
+            COALESCE("pv"."reorder_quantity", "c"."reorder_quantity"),
+            "s"."id",
+            "s"."name",
+            "s"."currency",
+            "s"."lead_time_days",
+            "sv"."supplier_sku",
+            "sv"."unit_cost"
+    FROM "public"."product_variant" "pv"
+    JOIN "public"."product" "p" ON "p"."id" = "pv"."product_id"
+    JOIN "public"."category" "c" ON "c"."id" = "p"."category_id"
+    LEFT JOIN "on_order" "oo" ON "oo"."product_variant_id" = "pv"."id"
+    LEFT JOIN "public"."supplier_variant" "sv" ON "sv"."product_variant_id" = "pv"."id"
+        AND "sv"."preferred"
+    LEFT JOIN "public"."supplier" "s" ON "s"."id" = "sv"."supplier_id"
+    WHERE COALESCE("pv"."reorder_point", "c"."reorder_point") IS NOT NULL
+        AND "pv"."stock" - "pv"."reserved" + COALESCE("oo"."quantity", 0) <= COALESCE("pv"."reorder_point", "c"."reorder_point")
+    ORDER BY "s"."id" ASC NULLS LAST, "pv"."id" ASC
+    `
+	rows, err := r.dbConn.Query(ctx, sql)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var suggestions []*entities.ReorderSuggestion
+	for rows.Next() {
+		var suggestion entities.ReorderSuggestion
+		if err := rows.Scan(
+			&suggestion.ProductVariantID,
+			&suggestion.Name,
+			&suggestion.Available,
+			&suggestion.OnOrder,
+			&suggestion.ReorderPoint,
+			&suggestion.ReorderQuantity,
+			&suggestion.SupplierID,
+			&suggestion.SupplierName,
+			&suggestion.Currency,
+			&suggestion.LeadTimeDays,
+			&suggestion.SupplierSKU,
+			&suggestion.UnitCost,
+		); err != nil {
+			return nil, err
+		}
+		suggestions = append(suggestions, &suggestion)
+	}
+
+	return suggestions, rows.Err()
+}
+
+// transition runs a status change of a purchase order and reports
+// common.ErrNotFound or common.ErrConflict when nothing was changed.
+func (r *PurchaseOrderRepository) transition(ctx context.Context, sql string, id int) error {
+	if cmd, err := r.dbConn.Exec(ctx, sql, id); err != nil {
+		return err
+	} else if cmd.RowsAffected() > 0 {
+		return nil
+	}
+
+	sql = `
+    SELECT EXISTS (
+        SELECT 1
+        FROM "public"."purchase_order"
+        WHERE "id" = $1
+    )
+    `
+	var exists bool
+	if err := r.dbConn.QueryRow(ctx, sql, id).Scan(&exists); err != nil {
+		return err
+	}
+	if !exists {
+		return common.ErrNotFound
+	}
+	return common.ErrConflict
+}
@@ -0,0 +1,135 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/ysfada/product-management-system/domain/common"
+	"github.com/ysfada/product-management-system/domain/dtos"
+)
+
+// sentOrder places and sends a purchase order of a variant.
+func sentOrder(t *testing.T, tx pgx.Tx, repository *PurchaseOrderRepository, variantID int, quantity float64) int {
+	ctx := context.Background()
+
+	var supplierID int
+	err := tx.QueryRow(ctx, `
+    INSERT INTO "public"."supplier" ("name", "currency") VALUES ($1, 'USD') RETURNING "id"
+    `, fmt.Sprintf("test-%d", time.Now().UnixNano())).Scan(&supplierID)
+	if err != nil {
+		t.Fatalf("Unable to add supplier: %v", err)
+	}
+
+	unitCost := 1.0
+	id, err := repository.Create(ctx, &dtos.CreatePurchaseOrderDto{
+		SupplierID: supplierID,
+		CreatedBy:  "test",
+		Lines: []*dtos.CreatePurchaseOrderLineDto{
+			{ProductVariantID: variantID, Quantity: quantity, UnitCost: &unitCost},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Unable to place purchase order: %v", err)
+	}
+	if err := repository.Send(ctx, id); err != nil {
+		t.Fatalf("Unable to send purchase order: %v", err)
+	}
+	return id
+}
+
+func TestReceiveAddsStock(t *testing.T) {
+	tx := testTx(t)
+	repository := NewPurchaseOrderRepository(tx)
+	variantID := testVariant(t, tx, 1)
+	id := sentOrder(t, tx, repository, variantID, 5)
+
+	assert.NoError(t, repository.Receive(context.Background(), &dtos.CreatePurchaseOrderReceiptDto{
+		PurchaseOrderID: id,
+		ReceivedBy:      "test",
+		Lines: []*dtos.CreatePurchaseOrderReceiptLineDto{
+			{ProductVariantID: variantID, Quantity: 3},
+		},
+	}))
+	assert.Equal(t, 4.0, stockOf(t, tx, variantID))
+}
+
+func TestReceiveRejectsOverReceipt(t *testing.T) {
+	tx := testTx(t)
+	repository := NewPurchaseOrderRepository(tx)
+	variantID := testVariant(t, tx, 1)
+	id := sentOrder(t, tx, repository, variantID, 5)
+
+	err := repository.Receive(context.Background(), &dtos.CreatePurchaseOrderReceiptDto{
+		PurchaseOrderID: id,
+		ReceivedBy:      "test",
+		Lines: []*dtos.CreatePurchaseOrderReceiptLineDto{
+			{ProductVariantID: variantID, Quantity: 6},
+		},
+	})
+	assert.ErrorIs(t, err, common.ErrBadParamInput)
+	assert.Equal(t, 1.0, stockOf(t, tx, variantID))
+}
+
+func TestReceiveIntoLot(t *testing.T) {
+	tx := testTx(t)
+	repository := NewPurchaseOrderRepository(tx)
+	variantID := testVariant(t, tx, 0)
+	lotID := testLot(t, tx, variantID, "A", 2)
+	id := sentOrder(t, tx, repository, variantID, 5)
+
+	err := repository.Receive(context.Background(), &dtos.CreatePurchaseOrderReceiptDto{
+		PurchaseOrderID: id,
+		ReceivedBy:      "test",
+		Lines: []*dtos.CreatePurchaseOrderReceiptLineDto{
+			{ProductVariantID: variantID, Quantity: 3},
+		},
+	})
+	assert.ErrorIs(t, err, common.ErrBadParamInput)
+
+	assert.NoError(t, repository.Receive(context.Background(), &dtos.CreatePurchaseOrderReceiptDto{
+		PurchaseOrderID: id,
+		ReceivedBy:      "test",
+		Lines: []*dtos.CreatePurchaseOrderReceiptLineDto{
+			{ProductVariantID: variantID, Quantity: 3, LotNumber: "B"},
+		},
+	}))
+	assert.Equal(t, 5.0, stockOf(t, tx, variantID))
+	assert.Equal(t, 2.0, lotQuantityOf(t, tx, lotID))
+}
+
+func TestReceiveSerials(t *testing.T) {
+	ctx := context.Background()
+	tx := testTx(t)
+	repository := NewPurchaseOrderRepository(tx)
+	variantID := testVariant(t, tx, 0)
+	id := sentOrder(t, tx, repository, variantID, 2)
+
+	_, err := tx.Exec(ctx, `
+    UPDATE "public"."product_variant" SET "serialized" = true WHERE "id" = $1
+    `, variantID)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	err = repository.Receive(ctx, &dtos.CreatePurchaseOrderReceiptDto{
+		PurchaseOrderID: id,
+		ReceivedBy:      "test",
+		Lines: []*dtos.CreatePurchaseOrderReceiptLineDto{
+			{ProductVariantID: variantID, Quantity: 2, Serials: []string{fmt.Sprintf("test-%d-1", variantID)}},
+		},
+	})
+	assert.ErrorIs(t, err, common.ErrBadParamInput)
+
+	assert.NoError(t, repository.Receive(ctx, &dtos.CreatePurchaseOrderReceiptDto{
+		PurchaseOrderID: id,
+		ReceivedBy:      "test",
+		Lines: []*dtos.CreatePurchaseOrderReceiptLineDto{
+			{ProductVariantID: variantID, Quantity: 2, Serials: []string{fmt.Sprintf("test-%d-1", variantID), fmt.Sprintf("test-%d-2", variantID)}},
+		},
+	}))
+	assert.Equal(t, 2.0, stockOf(t, tx, variantID))
+}
@@ -0,0 +1,284 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v4"
+	"github.com/ysfada/product-management-system/domain/common"
+	"github.com/ysfada/product-management-system/domain/dtos"
+	"github.com/ysfada/product-management-system/domain/entities"
+	"github.com/ysfada/product-management-system/domain/interfaces"
+)
+
+type ReservationRepository struct {
+	dbConn DBTX
+}
+
+var _ interfaces.IReservationRepository = (*ReservationRepository)(nil)
+
+func NewReservationRepository(dbConn DBTX) *ReservationRepository {
+	return &ReservationRepository{
+		dbConn: dbConn,
+	}
+}
+
+func (r *ReservationRepository) GetByID(ctx context.Context, id int) (*entities.Reservation, error) {
+	sql := `
+    SELECT  "r"."id",
+            "r"."product_variant_id",
+            "r"."quantity",
+            "r"."owner_ref",
+            "r"."status",
+            "r"."expires_at",
+            "r"."created_at",
+            "r"."updated_at",
+            "r"."deleted_at"
+    FROM "public"."stock_reservation" "r"
+    WHERE "r"."id" = $1
+    LIMIT 1
+    `
+	var reservation entities.Reservation
+	if err := r.dbConn.QueryRow(ctx, sql, id).Scan(
+		&reservation.ID,
+		&reservation.ProductVariantID,
+		&reservation.Quantity,
+		&reservation.OwnerRef,
+		&reservation.Status,
+		&reservation.ExpiresAt,
+		&reservation.CreatedAt,
+		&reservation.UpdatedAt,
+		&reservation.DeletedAt,
+	); err != nil {
+		switch err {
+		case pgx.ErrNoRows:
+			return nil, common.ErrNotFound
+		default:
+			return nil, err
+		}
+	}
+
+	return &reservation, nil
+}
+
+// Create holds the quantity on the variant and records the reservation in a
+// single statement. The conditional update locks the variant row, so
+// concurrent holds are serialized and can never exceed the stock.
+func (r *ReservationRepository) Create(ctx context.Context, dto *dtos.CreateReservationDto, ttl time.Duration) (*entities.Reservation, error) {
+	sql := `
+    WITH "q" AS (
+        SELECT $2::decimal * "public"."unit_factor"($1, $5) "quantity"
+    ), "pv" AS (
+        UPDATE "public"."product_variant"
+        SET "reserved" = "reserved" + "q"."quantity"
+        FROM "q"
+        WHERE "id" = $1
+            AND "stock" - "reserved" >= "q"."quantity"
+        RETURNING "id", "q"."quantity"
+    )
+    INSERT INTO "public"."stock_reservation" ("product_variant_id", "quantity", "owner_ref", "expires_at")
+    SELECT "pv"."id", "pv"."quantity", $3, NOW() + MAKE_INTERVAL(secs => $4)
+    FROM "pv"
+    RETURNING "id",
+            "product_variant_id",
+            "quantity",
+            "owner_ref",
+            "status",
+            "expires_at",
+            "created_at",
+            "updated_at",
+            "deleted_at"
+    `
+	var reservation entities.Reservation
+	if err := r.dbConn.QueryRow(ctx, sql, dto.ProductVariantID, dto.Quantity, dto.OwnerRef, ttl.Seconds(), dto.Unit).Scan(
+		&reservation.ID,
+		&reservation.ProductVariantID,
+		&reservation.Quantity,
+		&reservation.OwnerRef,
+		&reservation.Status,
+		&reservation.ExpiresAt,
+		&reservation.CreatedAt,
+		&reservation.UpdatedAt,
+		&reservation.DeletedAt,
+	); err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.CheckViolation {
+			return nil, common.ErrBadParamInput
+		}
+		if err != pgx.ErrNoRows {
+			return nil, err
+		}
+		if exists, err := r.variantExists(ctx, dto.ProductVariantID); err != nil {
+			return nil, err
+		} else if !exists {
+			return nil, common.ErrNotFound
+		}
+		return nil, common.ErrInsufficientStock
+	}
+
+	return &reservation, nil
+}
+
+// Confirm turns an active hold into a real stock decrement. A hold on a
+// serialized variant sells its oldest serials in stock, which the stock of
+// the variant follows.
+func (r *ReservationRepository) Confirm(ctx context.Context, id int) error {
+	err := r.dbConn.BeginFunc(ctx, func(tx pgx.Tx) error {
+		sql := `
+        WITH "r" AS (
+            UPDATE "public"."stock_reservation"
+            SET "status" = 'confirmed'
+            WHERE "id" = $1
+                AND "status" = 'active'
+                AND "expires_at" > NOW()
+            RETURNING "product_variant_id", "quantity"
+        )
+        UPDATE "public"."product_variant" "pv"
+        SET "stock" = CASE WHEN "pv"."serialized" THEN "pv"."stock" ELSE "pv"."stock" - "r"."quantity" END,
+            "reserved" = "pv"."reserved" - "r"."quantity"
+        FROM "r"
+        WHERE "pv"."id" = "r"."product_variant_id"
+        RETURNING "pv"."id", "pv"."serialized", "r"."quantity"::float8
+        `
+		var variantID int
+		var serialized bool
+		var quantity float64
+		if err := tx.QueryRow(ctx, sql, id).Scan(&variantID, &serialized, &quantity); err != nil {
+			return err
+		}
+		if !serialized {
+			return nil
+		}
+
+		sql = `
+        UPDATE "public"."serial_number"
+        SET "status" = 'sold'
+        WHERE "id" IN (
+            SELECT "id"
+            FROM "public"."serial_number"
+            WHERE "product_variant_id" = $1
+                AND "status" = 'in_stock'
+            ORDER BY "id" ASC
+            LIMIT CEIL($2::decimal)::int
+            FOR UPDATE
+        )
+        `
+		if cmd, err := tx.Exec(ctx, sql, variantID, quantity); err != nil {
+			return err
+		} else if float64(cmd.RowsAffected()) != quantity {
+			return common.ErrInsufficientStock
+		}
+		return nil
+	})
+	if err == pgx.ErrNoRows {
+		return r.unsettled(ctx, id)
+	}
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.CheckViolation {
+		return common.ErrInsufficientStock
+	}
+	return err
+}
+
+// Release gives the held quantity back to the available stock.
+func (r *ReservationRepository) Release(ctx context.Context, id int) error {
+	sql := `
+    WITH "r" AS (
+        UPDATE "public"."stock_reservation"
+        SET "status" = 'released'
+        WHERE "id" = $1
+            AND "status" = 'active'
+        RETURNING "product_variant_id", "quantity"
+    )
+    UPDATE "public"."product_variant" "pv"
+    SET "reserved" = "pv"."reserved" - "r"."quantity"
+    FROM "r"
+    WHERE "pv"."id" = "r"."product_variant_id"
+    `
+	return r.settle(ctx, sql, id)
+}
+
+// ExpireStale expires every active hold past its expiry time and returns the
+// number of expired reservations.
+func (r *ReservationRepository) ExpireStale(ctx context.Context) (int, error) {
+	sql := `
+    WITH "r" AS (
+        UPDATE "public"."stock_reservation"
+        SET "status" = 'expired'
+        WHERE "status" = 'active'
+            AND "expires_at" <= NOW()
+        RETURNING "product_variant_id", "quantity"
+    ), "totals" AS (
+        SELECT "product_variant_id", SUM("quantity") "quantity", COUNT(*) "count"
+        FROM "r"
+        GROUP BY "product_variant_id"
+    ), "pv" AS (
+        UPDATE "public"."product_variant" "pv"
+        SET "reserved" = "pv"."reserved" - "totals"."quantity"
+        FROM "totals"
+        WHERE "pv"."id" = "totals"."product_variant_id"
+        RETURNING "totals"."count"
+    )
+    SELECT COALESCE(SUM("count"), 0)::int
+    FROM "pv"
+    `
+	var count int
+	if err := r.dbConn.QueryRow(ctx, sql).Scan(&count); err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// settle runs a status transition of an active reservation and reports
+// common.ErrNotFound or common.ErrConflict when nothing was transitioned.
+func (r *ReservationRepository) settle(ctx context.Context, sql string, id int) error {
+	cmd, err := r.dbConn.Exec(ctx, sql, id)
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		switch pgErr.Code {
+		case pgerrcode.CheckViolation:
+			return common.ErrInsufficientStock
+		default:
+			return err
+		}
+	}
+	if err != nil {
+		return err
+	}
+	if cmd.RowsAffected() > 0 {
+		return nil
+	}
+
+	return r.unsettled(ctx, id)
+}
+
+// unsettled reports why a reservation was not transitioned,
+// common.ErrNotFound or common.ErrConflict.
+func (r *ReservationRepository) unsettled(ctx context.Context, id int) error {
+	if _, err := r.GetByID(ctx, id); err != nil {
+		return err
+	}
+	return common.ErrConflict
+}
+
+func (r *ReservationRepository) variantExists(ctx context.Context, id int) (bool, error) {
+	sql := `
+    SELECT EXISTS (
+        SELECT 1
+        FROM "public"."product_variant"
+        WHERE "id" = $1
+    )
+    `
+	var exists bool
+	if err := r.dbConn.QueryRow(ctx, sql, id).Scan(&exists); err != nil {
+		return false, err
+	}
+
+	return exists, nil
+}
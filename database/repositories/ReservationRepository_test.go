@@ -0,0 +1,145 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/ysfada/product-management-system/domain/common"
+	"github.com/ysfada/product-management-system/domain/dtos"
+)
+
+func reservedOf(t *testing.T, tx pgx.Tx, id int) float64 {
+	var reserved float64
+	err := tx.QueryRow(context.Background(), `
+    SELECT "reserved"::float8 FROM "public"."product_variant" WHERE "id" = $1
+    `, id).Scan(&reserved)
+	if err != nil {
+		t.Fatalf("Unable to get reserved: %v", err)
+	}
+	return reserved
+}
+
+// hold reserves quantity of a variant for ttl.
+func hold(t *testing.T, repository *ReservationRepository, variantID int, quantity float64, ttl time.Duration) int {
+	reservation, err := repository.Create(context.Background(), &dtos.CreateReservationDto{
+		ProductVariantID: variantID,
+		Quantity:         quantity,
+		OwnerRef:         "test",
+	}, ttl)
+	if err != nil {
+		t.Fatalf("Unable to reserve: %v", err)
+	}
+	return reservation.ID
+}
+
+func TestCreateHoldsStock(t *testing.T) {
+	tx := testTx(t)
+	repository := NewReservationRepository(tx)
+	variantID := testVariant(t, tx, 5)
+
+	hold(t, repository, variantID, 3, time.Minute)
+	assert.Equal(t, 3.0, reservedOf(t, tx, variantID))
+	assert.Equal(t, 5.0, stockOf(t, tx, variantID))
+
+	_, err := repository.Create(context.Background(), &dtos.CreateReservationDto{
+		ProductVariantID: variantID,
+		Quantity:         3,
+		OwnerRef:         "test",
+	}, time.Minute)
+	assert.ErrorIs(t, err, common.ErrInsufficientStock)
+}
+
+func TestConfirmDecrementsStock(t *testing.T) {
+	ctx := context.Background()
+	tx := testTx(t)
+	repository := NewReservationRepository(tx)
+	variantID := testVariant(t, tx, 5)
+	id := hold(t, repository, variantID, 3, time.Minute)
+
+	assert.NoError(t, repository.Confirm(ctx, id))
+	assert.Equal(t, 2.0, stockOf(t, tx, variantID))
+	assert.Equal(t, 0.0, reservedOf(t, tx, variantID))
+	assert.ErrorIs(t, repository.Confirm(ctx, id), common.ErrConflict)
+	assert.ErrorIs(t, repository.Confirm(ctx, 0), common.ErrNotFound)
+}
+
+func TestReleaseFreesStock(t *testing.T) {
+	ctx := context.Background()
+	tx := testTx(t)
+	repository := NewReservationRepository(tx)
+	variantID := testVariant(t, tx, 5)
+	id := hold(t, repository, variantID, 3, time.Minute)
+
+	assert.NoError(t, repository.Release(ctx, id))
+	assert.Equal(t, 5.0, stockOf(t, tx, variantID))
+	assert.Equal(t, 0.0, reservedOf(t, tx, variantID))
+	assert.ErrorIs(t, repository.Release(ctx, id), common.ErrConflict)
+}
+
+func TestExpireStaleFreesStock(t *testing.T) {
+	ctx := context.Background()
+	tx := testTx(t)
+	repository := NewReservationRepository(tx)
+	variantID := testVariant(t, tx, 5)
+	// NOW() is the start of the transaction, a hold without ttl is stale
+	stale := hold(t, repository, variantID, 3, 0)
+	active := hold(t, repository, variantID, 1, time.Minute)
+
+	count, err := repository.ExpireStale(ctx)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.GreaterOrEqual(t, count, 1)
+	assert.Equal(t, 1.0, reservedOf(t, tx, variantID))
+
+	if reservation, err := repository.GetByID(ctx, stale); assert.NoError(t, err) {
+		assert.Equal(t, "expired", reservation.Status)
+	}
+	if reservation, err := repository.GetByID(ctx, active); assert.NoError(t, err) {
+		assert.Equal(t, "active", reservation.Status)
+	}
+}
+
+func TestConfirmSellsSerials(t *testing.T) {
+	ctx := context.Background()
+	tx := testTx(t)
+	repository := NewReservationRepository(tx)
+	variantID := testVariant(t, tx, 0)
+
+	_, err := tx.Exec(ctx, `
+    UPDATE "public"."product_variant" SET "serialized" = true WHERE "id" = $1
+    `, variantID)
+	if !assert.NoError(t, err) {
+		return
+	}
+	_, err = tx.Exec(ctx, `
+    INSERT INTO "public"."serial_number" ("product_variant_id", "serial", "status")
+    SELECT $1, 'test-' || $1::text || '-' || "n"::text, 'in_stock'
+    FROM GENERATE_SERIES(1, 3) "n"
+    `, variantID)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	reservation, err := repository.Create(ctx, &dtos.CreateReservationDto{
+		ProductVariantID: variantID,
+		Quantity:         2,
+		OwnerRef:         "test",
+	}, time.Minute)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.NoError(t, repository.Confirm(ctx, reservation.ID))
+	assert.Equal(t, 1.0, stockOf(t, tx, variantID))
+
+	var sold int
+	err = tx.QueryRow(ctx, `
+    SELECT COUNT(*) FROM "public"."serial_number" WHERE "product_variant_id" = $1 AND "status" = 'sold'
+    `, variantID).Scan(&sold)
+	if assert.NoError(t, err) {
+		assert.Equal(t, 2, sold)
+	}
+}
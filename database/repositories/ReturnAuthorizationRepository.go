@@ -0,0 +1,665 @@
+package repositories
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math"
+	"time"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v4"
+	"github.com/ysfada/product-management-system/domain/common"
+	"github.com/ysfada/product-management-system/domain/dtos"
+	"github.com/ysfada/product-management-system/domain/entities"
+	"github.com/ysfada/product-management-system/domain/interfaces"
+)
+
+type ReturnAuthorizationRepository struct {
+	dbConn DBTX
+}
+
+var _ interfaces.IReturnAuthorizationRepository = (*ReturnAuthorizationRepository)(nil)
+
+func NewReturnAuthorizationRepository(dbConn DBTX) *ReturnAuthorizationRepository {
+	return &ReturnAuthorizationRepository{
+		dbConn: dbConn,
+	}
+}
+
+// Fetch lists the returns without their lines and events, newest first. An
+// empty customer or status does not filter.
+func (r *ReturnAuthorizationRepository) Fetch(ctx context.Context, customer string, status string, page int, size int) (*entities.ReturnAuthorizationPaginated, error) {
+	sql := `
+    WITH "ra" AS (
+        SELECT "ra"."id",
+                "ra"."customer",
+                "ra"."sales_order_id",
+                "ra"."status",
+                "ra"."note",
+                "ra"."approved_at",
+                "ra"."rejected_at",
+                "ra"."received_at",
+                "ra"."inspected_at",
+                "ra"."created_at",
+                "ra"."updated_at",
+                "ra"."deleted_at"
+        FROM "public"."return_authorization" "ra"
+        WHERE ($1 = '' OR "ra"."customer" = $1)
+            AND ($2 = '' OR "ra"."status" = $2)
+    )
+    SELECT
+        (SELECT COUNT(*)
+            FROM "ra") "count",
+
+        (SELECT COALESCE(JSONB_AGG("result".*), '[]')
+            FROM
+                (SELECT *
+                    FROM "ra"
+                    ORDER BY "ra"."id" DESC
+                    OFFSET $3 ROWS FETCH NEXT $4 ROWS ONLY) "result") "return_authorizations"
+    `
+
+	var returnAuthorizations entities.ReturnAuthorizationPaginated
+	var rows json.RawMessage
+	if err := r.dbConn.QueryRow(ctx, sql, customer, status, (page-1)*size, size).Scan(
+		&returnAuthorizations.Count,
+		&rows,
+	); err != nil {
+		switch err {
+		case pgx.ErrNoRows:
+			return nil, common.ErrNotFound
+		default:
+			return nil, err
+		}
+	}
+
+	if err := json.Unmarshal([]byte(rows), &returnAuthorizations.ReturnAuthorizations); err != nil {
+		return nil, err
+	}
+
+	returnAuthorizations.Size = size
+	returnAuthorizations.TotalPage = int(math.Ceil(float64(returnAuthorizations.Count) / float64(size)))
+	returnAuthorizations.CurrentPage = page
+	if returnAuthorizations.CurrentPage <= returnAuthorizations.TotalPage && returnAuthorizations.CurrentPage > 1 {
+		returnAuthorizations.PreviousPage = returnAuthorizations.CurrentPage - 1
+	} else {
+		returnAuthorizations.PreviousPage = -1
+	}
+	if returnAuthorizations.CurrentPage < returnAuthorizations.TotalPage {
+		returnAuthorizations.NextPage = returnAuthorizations.CurrentPage + 1
+	} else {
+		returnAuthorizations.NextPage = -1
+	}
+
+	return &returnAuthorizations, nil
+}
+
+func (r *ReturnAuthorizationRepository) GetByID(ctx context.Context, id int) (*entities.ReturnAuthorization, error) {
+	sql := `
+    SELECT  "ra"."id",
+            "ra"."customer",
+            "ra"."sales_order_id",
+            "ra"."status",
+            "ra"."note",
+            "ra"."approved_at",
+            "ra"."rejected_at",
+            "ra"."received_at",
+            "ra"."inspected_at",
+            "ra"."created_at",
+            "ra"."updated_at",
+            "ra"."deleted_at"
+    FROM "public"."return_authorization" "ra"
+    WHERE "ra"."id" = $1
+    LIMIT 1
+    `
+	var returnAuthorization entities.ReturnAuthorization
+	if err := r.dbConn.QueryRow(ctx, sql, id).Scan(
+		&returnAuthorization.ID,
+		&returnAuthorization.Customer,
+		&returnAuthorization.SalesOrderID,
+		&returnAuthorization.Status,
+		&returnAuthorization.Note,
+		&returnAuthorization.ApprovedAt,
+		&returnAuthorization.RejectedAt,
+		&returnAuthorization.ReceivedAt,
+		&returnAuthorization.InspectedAt,
+		&returnAuthorization.CreatedAt,
+		&returnAuthorization.UpdatedAt,
+		&returnAuthorization.DeletedAt,
+	); err != nil {
+		switch err {
+		case pgx.ErrNoRows:
+			return nil, common.ErrNotFound
+		default:
+			return nil, err
+		}
+	}
+
+	sql = `
+    SELECT  "l"."id",
+            "l"."product_variant_id",
+            "l"."quantity",
+            "l"."reason",
+            "l"."received",
+            "l"."restocked",
+            "l"."refurbished",
+            "l"."scrapped"
+    FROM "public"."return_authorization_line" "l"
+    WHERE "l"."return_authorization_id" = $1
+    ORDER BY "l"."id" ASC
+    `
+	lines, err := r.dbConn.Query(ctx, sql, id)
+	if err != nil {
+		return nil, err
+	}
+	defer lines.Close()
+
+	for lines.Next() {
+		var line entities.ReturnAuthorizationLine
+		if err := lines.Scan(
+			&line.ID,
+			&line.ProductVariantID,
+			&line.Quantity,
+			&line.Reason,
+			&line.Received,
+			&line.Restocked,
+			&line.Refurbished,
+			&line.Scrapped,
+		); err != nil {
+			return nil, err
+		}
+		returnAuthorization.Lines = append(returnAuthorization.Lines, &line)
+	}
+	if err := lines.Err(); err != nil {
+		return nil, err
+	}
+
+	sql = `
+    SELECT  "e"."status",
+            "e"."actor",
+            "e"."note",
+            "e"."created_at"
+    FROM "public"."return_authorization_event" "e"
+    WHERE "e"."return_authorization_id" = $1
+    ORDER BY "e"."id" ASC
+    `
+	events, err := r.dbConn.Query(ctx, sql, id)
+	if err != nil {
+		return nil, err
+	}
+	defer events.Close()
+
+	for events.Next() {
+		var event entities.ReturnAuthorizationEvent
+		if err := events.Scan(
+			&event.Status,
+			&event.Actor,
+			&event.Note,
+			&event.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		returnAuthorization.Events = append(returnAuthorization.Events, &event)
+	}
+
+	return &returnAuthorization, events.Err()
+}
+
+// Create requests a return for dto.Customer. A return against a sales order
+// is for the customer of the order, a non-empty customer only returns its
+// own orders. The order must be fulfilled, and the variants returned must be
+// on it at no more than the quantity ordered minus what other returns of the
+// order, unless rejected, already ask for.
+func (r *ReturnAuthorizationRepository) Create(ctx context.Context, dto *dtos.CreateReturnAuthorizationDto, customer string) (int, error) {
+	variantIDs := make([]int, 0, len(dto.Lines))
+	quantities := make([]float64, 0, len(dto.Lines))
+	units := make([]string, 0, len(dto.Lines))
+	reasons := make([]string, 0, len(dto.Lines))
+	for _, line := range dto.Lines {
+		variantIDs = append(variantIDs, line.ProductVariantID)
+		quantities = append(quantities, line.Quantity)
+		units = append(units, line.Unit)
+		reasons = append(reasons, line.Reason)
+	}
+
+	var id int
+	err := r.dbConn.BeginFunc(ctx, func(tx pgx.Tx) error {
+		returnCustomer := dto.Customer
+		if dto.SalesOrderID != nil {
+			// serializes the returns of the order
+			sql := `
+            SELECT "customer", "status"
+            FROM "public"."sales_order"
+            WHERE "id" = $1
+                AND ($2 = '' OR "customer" = $2)
+            FOR UPDATE
+            `
+			var status string
+			if err := tx.QueryRow(ctx, sql, *dto.SalesOrderID, customer).Scan(&returnCustomer, &status); err != nil {
+				if err == pgx.ErrNoRows {
+					return common.ErrNotFound
+				}
+				return err
+			}
+			if status != "fulfilled" {
+				return common.ErrConflict
+			}
+		}
+
+		sql := `
+        INSERT INTO "public"."return_authorization" ("customer", "sales_order_id", "note")
+        VALUES ($1, $2, $3)
+        RETURNING "id"
+        `
+		if err := tx.QueryRow(ctx, sql, returnCustomer, dto.SalesOrderID, dto.Note).Scan(&id); err != nil {
+			return err
+		}
+
+		sql = `
+        INSERT INTO "public"."return_authorization_line" ("return_authorization_id", "product_variant_id", "quantity", "reason")
+        SELECT $1, "l"."product_variant_id",
+                "l"."quantity" * "public"."unit_factor"("l"."product_variant_id", "l"."unit"),
+                "l"."reason"
+        FROM UNNEST($2::int[], $3::float8[], $4::text[], $5::text[]) "l"("product_variant_id", "quantity", "unit", "reason")
+        `
+		if _, err := tx.Exec(ctx, sql, id, variantIDs, quantities, units, reasons); err != nil {
+			return err
+		}
+
+		if dto.SalesOrderID != nil {
+			sql = `
+            SELECT EXISTS (
+                SELECT 1
+                FROM "public"."return_authorization_line" "l"
+                LEFT JOIN "public"."sales_order_line" "sol"
+                    ON "sol"."sales_order_id" = $2
+                        AND "sol"."product_variant_id" = "l"."product_variant_id"
+                WHERE "l"."return_authorization_id" = $1
+                    AND ("sol"."id" IS NULL
+                        OR "sol"."quantity" < (
+                            SELECT SUM("other"."quantity")
+                            FROM "public"."return_authorization_line" "other"
+                            INNER JOIN "public"."return_authorization" "ra"
+                                ON "ra"."id" = "other"."return_authorization_id"
+                            WHERE "ra"."sales_order_id" = $2
+                                AND "ra"."status" <> 'rejected'
+                                AND "other"."product_variant_id" = "l"."product_variant_id"))
+            )
+            `
+			var exceeds bool
+			if err := tx.QueryRow(ctx, sql, id, *dto.SalesOrderID).Scan(&exceeds); err != nil {
+				return err
+			}
+			if exceeds {
+				return common.ErrBadParamInput
+			}
+		}
+
+		return r.event(ctx, tx, id, "requested", dto.RequestedBy, nil)
+	})
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		switch pgErr.Code {
+		case pgerrcode.CheckViolation:
+			// an unknown unit
+			return 0, common.ErrBadParamInput
+		case pgerrcode.ForeignKeyViolation:
+			return 0, common.ErrNotFound
+		}
+	}
+	if err != nil {
+		return 0, err
+	}
+	return id, nil
+}
+
+func (r *ReturnAuthorizationRepository) Approve(ctx context.Context, dto *dtos.ReviewReturnAuthorizationDto) error {
+	sql := `
+    UPDATE "public"."return_authorization"
+    SET "status" = 'approved',
+        "approved_at" = NOW()
+    WHERE "id" = $1
+        AND "status" = 'requested'
+    `
+	return r.transition(ctx, sql, dto.ReturnAuthorizationID, "approved", dto.Actor, dto.Note)
+}
+
+// Reject rejects a requested return, or an approved one which never
+// arrived.
+func (r *ReturnAuthorizationRepository) Reject(ctx context.Context, dto *dtos.ReviewReturnAuthorizationDto) error {
+	sql := `
+    UPDATE "public"."return_authorization"
+    SET "status" = 'rejected',
+        "rejected_at" = NOW()
+    WHERE "id" = $1
+        AND "status" IN ('requested', 'approved')
+    `
+	return r.transition(ctx, sql, dto.ReturnAuthorizationID, "rejected", dto.Actor, dto.Note)
+}
+
+// Receive records what arrived of an approved return. Nothing goes into
+// stock before the return is inspected.
+func (r *ReturnAuthorizationRepository) Receive(ctx context.Context, dto *dtos.ReceiveReturnAuthorizationDto) error {
+	err := r.dbConn.BeginFunc(ctx, func(tx pgx.Tx) error {
+		if err := r.lock(ctx, tx, dto.ReturnAuthorizationID, "approved"); err != nil {
+			return err
+		}
+
+		for _, line := range dto.Lines {
+			sql := `
+            UPDATE "public"."return_authorization_line"
+            SET "received" = $3::decimal * "public"."unit_factor"($2, $4)
+            WHERE "return_authorization_id" = $1
+                AND "product_variant_id" = $2
+            `
+			if cmd, err := tx.Exec(ctx, sql, dto.ReturnAuthorizationID, line.ProductVariantID, line.Quantity, line.Unit); err != nil {
+				return err
+			} else if cmd.RowsAffected() == 0 {
+				return common.ErrBadParamInput
+			}
+		}
+
+		sql := `
+        UPDATE "public"."return_authorization"
+        SET "status" = 'received',
+            "received_at" = NOW()
+        WHERE "id" = $1
+        `
+		if _, err := tx.Exec(ctx, sql, dto.ReturnAuthorizationID); err != nil {
+			return err
+		}
+
+		return r.event(ctx, tx, dto.ReturnAuthorizationID, "received", dto.Actor, dto.Note)
+	})
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.CheckViolation {
+		// more received than returned, or an unknown unit
+		return common.ErrBadParamInput
+	}
+	return err
+}
+
+// Inspect records the disposition of every received unit of a received
+// return and puts the restocked quantities back into the stock of their
+// variants, recorded as "return_restock" movements. Lot tracked variants are
+// restocked into a lot of the return, which expires at the expiry of the
+// line, or with the earliest lot the order took of them. A loose return of
+// a lot tracked variant without an expiry is rejected with
+// common.ErrBadParamInput. The serials of a serialized variant must be given
+// for every disposition, they must have been sold, on the order if the return
+// is against one, and are back in stock, returned for refurbishment or
+// scrapped.
+func (r *ReturnAuthorizationRepository) Inspect(ctx context.Context, dto *dtos.InspectReturnAuthorizationDto) error {
+	serialVariantIDs := []int{}
+	serials := []string{}
+	statuses := []string{}
+	expiryVariantIDs := []int{}
+	expiries := []time.Time{}
+	for _, line := range dto.Lines {
+		if line.ExpiresAt != nil {
+			expiryVariantIDs = append(expiryVariantIDs, line.ProductVariantID)
+			expiries = append(expiries, *line.ExpiresAt)
+		}
+		for status, lineSerials := range map[string][]string{
+			"in_stock": line.RestockSerials,
+			"returned": line.RefurbishSerials,
+			"scrapped": line.ScrapSerials,
+		} {
+			for _, serial := range lineSerials {
+				serialVariantIDs = append(serialVariantIDs, line.ProductVariantID)
+				serials = append(serials, serial)
+				statuses = append(statuses, status)
+			}
+		}
+	}
+
+	err := r.dbConn.BeginFunc(ctx, func(tx pgx.Tx) error {
+		if err := r.lock(ctx, tx, dto.ReturnAuthorizationID, "received"); err != nil {
+			return err
+		}
+
+		for _, line := range dto.Lines {
+			sql := `
+            WITH "f" AS (
+                SELECT "public"."unit_factor"($2, $6) "factor"
+            )
+            UPDATE "public"."return_authorization_line"
+            SET "restocked" = $3::decimal * "f"."factor",
+                "refurbished" = $4::decimal * "f"."factor",
+                "scrapped" = $5::decimal * "f"."factor"
+            FROM "f"
+            WHERE "return_authorization_id" = $1
+                AND "product_variant_id" = $2
+            `
+			if cmd, err := tx.Exec(ctx, sql, dto.ReturnAuthorizationID, line.ProductVariantID, line.Restock, line.Refurbish, line.Scrap, line.Unit); err != nil {
+				return err
+			} else if cmd.RowsAffected() == 0 {
+				return common.ErrBadParamInput
+			}
+		}
+
+		// every received unit needs a disposition
+		sql := `
+        SELECT EXISTS (
+            SELECT 1
+            FROM "public"."return_authorization_line"
+            WHERE "return_authorization_id" = $1
+                AND "restocked" + "refurbished" + "scrapped" <> "received"
+        )
+        `
+		var incomplete bool
+		if err := tx.QueryRow(ctx, sql, dto.ReturnAuthorizationID).Scan(&incomplete); err != nil {
+			return err
+		}
+		if incomplete {
+			return common.ErrBadParamInput
+		}
+
+		// the serials of a serialized variant match its dispositions
+		sql = `
+        SELECT EXISTS (
+            SELECT 1
+            FROM "public"."return_authorization_line" "l"
+            JOIN "public"."product_variant" "pv" ON "pv"."id" = "l"."product_variant_id"
+            CROSS JOIN LATERAL (
+                SELECT COUNT(*) FILTER (WHERE "s"."status" = 'in_stock') "restocked",
+                        COUNT(*) FILTER (WHERE "s"."status" = 'returned') "refurbished",
+                        COUNT(*) FILTER (WHERE "s"."status" = 'scrapped') "scrapped"
+                FROM UNNEST($2::int[], $3::text[]) "s"("product_variant_id", "status")
+                WHERE "s"."product_variant_id" = "l"."product_variant_id"
+            ) "s"
+            WHERE "l"."return_authorization_id" = $1
+                AND "pv"."serialized"
+                AND ("l"."restocked" <> "s"."restocked"
+                    OR "l"."refurbished" <> "s"."refurbished"
+                    OR "l"."scrapped" <> "s"."scrapped")
+        )
+        `
+		var mismatch bool
+		if err := tx.QueryRow(ctx, sql, dto.ReturnAuthorizationID, serialVariantIDs, statuses).Scan(&mismatch); err != nil {
+			return err
+		}
+		if mismatch {
+			return common.ErrBadParamInput
+		}
+
+		// the reason of the stock movements recorded by product_variant
+		if _, err := tx.Exec(ctx, `SELECT set_config('pms.movement_reason', 'return_restock', true)`); err != nil {
+			return err
+		}
+
+		if len(serials) > 0 {
+			sql = `
+            UPDATE "public"."serial_number" "s"
+            SET "status" = "d"."status"
+            FROM UNNEST($2::int[], $3::text[], $4::text[]) "d"("product_variant_id", "serial", "status"),
+                "public"."return_authorization" "ra"
+            WHERE "ra"."id" = $1
+                AND "s"."product_variant_id" = "d"."product_variant_id"
+                AND "s"."serial" = "d"."serial"
+                AND "s"."status" = 'sold'
+                AND ("ra"."sales_order_id" IS NULL OR EXISTS (
+                    SELECT 1
+                    FROM "public"."sales_order_line_serial" "a"
+                    JOIN "public"."sales_order_line" "sol" ON "sol"."id" = "a"."sales_order_line_id"
+                    WHERE "sol"."sales_order_id" = "ra"."sales_order_id"
+                        AND "a"."serial_number_id" = "s"."id"
+                ))
+            `
+			if cmd, err := tx.Exec(ctx, sql, dto.ReturnAuthorizationID, serialVariantIDs, serials, statuses); err != nil {
+				return err
+			} else if int(cmd.RowsAffected()) != len(serials) {
+				// an unknown serial, one that was not sold, or one listed twice
+				return common.ErrBadParamInput
+			}
+		}
+
+		sql = `
+        UPDATE "public"."product_variant" "pv"
+        SET "stock" = "pv"."stock" + "l"."restocked"
+        FROM "public"."return_authorization_line" "l"
+        WHERE "l"."return_authorization_id" = $1
+            AND "l"."restocked" > 0
+            AND "pv"."id" = "l"."product_variant_id"
+            AND NOT "pv"."serialized"
+            AND NOT EXISTS (SELECT 1 FROM "public"."lot" "lot" WHERE "lot"."product_variant_id" = "pv"."id")
+        `
+		if _, err := tx.Exec(ctx, sql, dto.ReturnAuthorizationID); err != nil {
+			return err
+		}
+
+		// a loose return has no order lots to take the expiry from
+		sql = `
+        SELECT EXISTS (
+            SELECT 1
+            FROM "public"."return_authorization_line" "l"
+            JOIN "public"."return_authorization" "ra" ON "ra"."id" = "l"."return_authorization_id"
+            JOIN "public"."product_variant" "pv" ON "pv"."id" = "l"."product_variant_id"
+            WHERE "l"."return_authorization_id" = $1
+                AND "ra"."sales_order_id" IS NULL
+                AND "l"."restocked" > 0
+                AND NOT "pv"."serialized"
+                AND EXISTS (SELECT 1 FROM "public"."lot" "lot" WHERE "lot"."product_variant_id" = "l"."product_variant_id")
+                AND "l"."product_variant_id" <> ALL($2::int[])
+        )
+        `
+		var undated bool
+		if err := tx.QueryRow(ctx, sql, dto.ReturnAuthorizationID, expiryVariantIDs).Scan(&undated); err != nil {
+			return err
+		}
+		if undated {
+			return common.ErrBadParamInput
+		}
+
+		sql = `
+        INSERT INTO "public"."lot" ("product_variant_id", "lot_number", "expires_at", "quantity")
+        SELECT "l"."product_variant_id",
+                'RMA-' || $1::text,
+                COALESCE(
+                    (SELECT MIN("e"."expires_at")
+                        FROM UNNEST($2::int[], $3::date[]) "e"("product_variant_id", "expires_at")
+                        WHERE "e"."product_variant_id" = "l"."product_variant_id"),
+                    (SELECT MIN("lot"."expires_at")
+                        FROM "public"."sales_order_line_lot" "a"
+                        JOIN "public"."sales_order_line" "sol" ON "sol"."id" = "a"."sales_order_line_id"
+                        JOIN "public"."lot" "lot" ON "lot"."id" = "a"."lot_id"
+                        WHERE "sol"."sales_order_id" = "ra"."sales_order_id"
+                            AND "sol"."product_variant_id" = "l"."product_variant_id")),
+                "l"."restocked"
+        FROM "public"."return_authorization_line" "l"
+        JOIN "public"."return_authorization" "ra" ON "ra"."id" = "l"."return_authorization_id"
+        JOIN "public"."product_variant" "pv" ON "pv"."id" = "l"."product_variant_id"
+        WHERE "l"."return_authorization_id" = $1
+            AND "l"."restocked" > 0
+            AND NOT "pv"."serialized"
+            AND EXISTS (SELECT 1 FROM "public"."lot" "lot" WHERE "lot"."product_variant_id" = "l"."product_variant_id")
+        `
+		if _, err := tx.Exec(ctx, sql, dto.ReturnAuthorizationID, expiryVariantIDs, expiries); err != nil {
+			return err
+		}
+
+		sql = `
+        UPDATE "public"."return_authorization"
+        SET "status" = 'inspected',
+            "inspected_at" = NOW()
+        WHERE "id" = $1
+        `
+		if _, err := tx.Exec(ctx, sql, dto.ReturnAuthorizationID); err != nil {
+			return err
+		}
+
+		return r.event(ctx, tx, dto.ReturnAuthorizationID, "inspected", dto.Actor, dto.Note)
+	})
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.CheckViolation {
+		// more dispositions than received, or an unknown unit
+		return common.ErrBadParamInput
+	}
+	return err
+}
+
+// lock locks the return for the rest of the transaction and reports
+// common.ErrNotFound, or common.ErrConflict if it is not in status.
+func (r *ReturnAuthorizationRepository) lock(ctx context.Context, tx pgx.Tx, id int, status string) error {
+	sql := `
+    SELECT "status"
+    FROM "public"."return_authorization"
+    WHERE "id" = $1
+    FOR UPDATE
+    `
+	var current string
+	if err := tx.QueryRow(ctx, sql, id).Scan(&current); err != nil {
+		if err == pgx.ErrNoRows {
+			return common.ErrNotFound
+		}
+		return err
+	}
+	if current != status {
+		return common.ErrConflict
+	}
+	return nil
+}
+
+// transition runs a status transition of the return and records it, and
+// reports common.ErrNotFound or common.ErrConflict when nothing was
+// transitioned.
+func (r *ReturnAuthorizationRepository) transition(ctx context.Context, sql string, id int, status string, actor string, note *string) error {
+	return r.dbConn.BeginFunc(ctx, func(tx pgx.Tx) error {
+		if cmd, err := tx.Exec(ctx, sql, id); err != nil {
+			return err
+		} else if cmd.RowsAffected() > 0 {
+			return r.event(ctx, tx, id, status, actor, note)
+		}
+
+		sql = `
+        SELECT EXISTS (
+            SELECT 1
+            FROM "public"."return_authorization"
+            WHERE "id" = $1
+        )
+        `
+		var exists bool
+		if err := tx.QueryRow(ctx, sql, id).Scan(&exists); err != nil {
+			return err
+		}
+		if !exists {
+			return common.ErrNotFound
+		}
+		return common.ErrConflict
+	})
+}
+
+// event appends to the audit trail of the return.
+func (r *ReturnAuthorizationRepository) event(ctx context.Context, tx pgx.Tx, id int, status string, actor string, note *string) error {
+	sql := `
+    INSERT INTO "public"."return_authorization_event" ("return_authorization_id", "status", "actor", "note")
+    VALUES ($1, $2, $3, $4)
+    `
+	_, err := tx.Exec(ctx, sql, id, status, actor, note)
+	return err
+}
@@ -0,0 +1,130 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/ysfada/product-management-system/domain/common"
+	"github.com/ysfada/product-management-system/domain/dtos"
+)
+
+// receiveReturn requests, approves and receives a loose return of a variant.
+func receiveReturn(t *testing.T, repository *ReturnAuthorizationRepository, variantID int, quantity float64) int {
+	ctx := context.Background()
+	id, err := repository.Create(ctx, &dtos.CreateReturnAuthorizationDto{
+		Customer:    "test",
+		RequestedBy: "test",
+		Lines: []*dtos.CreateReturnAuthorizationLineDto{
+			{ProductVariantID: variantID, Quantity: quantity, Reason: "test"},
+		},
+	}, "")
+	if err != nil {
+		t.Fatalf("Unable to request return: %v", err)
+	}
+	if err := repository.Approve(ctx, &dtos.ReviewReturnAuthorizationDto{ReturnAuthorizationID: id, Actor: "test"}); err != nil {
+		t.Fatalf("Unable to approve return: %v", err)
+	}
+	if err := repository.Receive(ctx, &dtos.ReceiveReturnAuthorizationDto{
+		ReturnAuthorizationID: id,
+		Actor:                 "test",
+		Lines: []*dtos.ReceiveReturnAuthorizationLineDto{
+			{ProductVariantID: variantID, Quantity: quantity},
+		},
+	}); err != nil {
+		t.Fatalf("Unable to receive return: %v", err)
+	}
+	return id
+}
+
+func TestInspectRestocksLotTrackedVariant(t *testing.T) {
+	ctx := context.Background()
+	tx := testTx(t)
+	repository := NewReturnAuthorizationRepository(tx)
+	variantID := testVariant(t, tx, 0)
+	testLot(t, tx, variantID, "A", 5)
+
+	id := receiveReturn(t, repository, variantID, 3)
+	expiresAt := time.Now().AddDate(0, 1, 0)
+	assert.NoError(t, repository.Inspect(ctx, &dtos.InspectReturnAuthorizationDto{
+		ReturnAuthorizationID: id,
+		Actor:                 "test",
+		Lines: []*dtos.InspectReturnAuthorizationLineDto{
+			{ProductVariantID: variantID, Restock: 2, Scrap: 1, ExpiresAt: &expiresAt},
+		},
+	}))
+	assert.Equal(t, 7.0, stockOf(t, tx, variantID))
+
+	var expires bool
+	err := tx.QueryRow(ctx, `
+    SELECT "expires_at" IS NOT NULL FROM "public"."lot" WHERE "product_variant_id" = $1 AND "lot_number" = 'RMA-' || $2::text
+    `, variantID, id).Scan(&expires)
+	if assert.NoError(t, err) {
+		assert.True(t, expires)
+	}
+}
+
+func TestInspectRequiresExpiryOfLooseLotReturn(t *testing.T) {
+	ctx := context.Background()
+	tx := testTx(t)
+	repository := NewReturnAuthorizationRepository(tx)
+	variantID := testVariant(t, tx, 0)
+	testLot(t, tx, variantID, "A", 5)
+
+	id := receiveReturn(t, repository, variantID, 1)
+	err := repository.Inspect(ctx, &dtos.InspectReturnAuthorizationDto{
+		ReturnAuthorizationID: id,
+		Actor:                 "test",
+		Lines: []*dtos.InspectReturnAuthorizationLineDto{
+			{ProductVariantID: variantID, Restock: 1},
+		},
+	})
+	assert.ErrorIs(t, err, common.ErrBadParamInput)
+	assert.Equal(t, 5.0, stockOf(t, tx, variantID))
+}
+
+func TestInspectMovesSerials(t *testing.T) {
+	ctx := context.Background()
+	tx := testTx(t)
+	repository := NewReturnAuthorizationRepository(tx)
+	variantID := testVariant(t, tx, 0)
+
+	_, err := tx.Exec(ctx, `
+    UPDATE "public"."product_variant" SET "serialized" = true WHERE "id" = $1
+    `, variantID)
+	if !assert.NoError(t, err) {
+		return
+	}
+	_, err = tx.Exec(ctx, `
+    INSERT INTO "public"."serial_number" ("product_variant_id", "serial", "status")
+    SELECT $1, 'test-' || $1::text || '-' || "n"::text, 'sold'
+    FROM GENERATE_SERIES(1, 2) "n"
+    `, variantID)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	id := receiveReturn(t, repository, variantID, 2)
+	first := fmt.Sprintf("test-%d-1", variantID)
+	second := fmt.Sprintf("test-%d-2", variantID)
+
+	// every unit needs its serial
+	assert.Error(t, repository.Inspect(ctx, &dtos.InspectReturnAuthorizationDto{
+		ReturnAuthorizationID: id,
+		Actor:                 "test",
+		Lines: []*dtos.InspectReturnAuthorizationLineDto{
+			{ProductVariantID: variantID, Restock: 1, Scrap: 1, RestockSerials: []string{first}},
+		},
+	}))
+
+	assert.NoError(t, repository.Inspect(ctx, &dtos.InspectReturnAuthorizationDto{
+		ReturnAuthorizationID: id,
+		Actor:                 "test",
+		Lines: []*dtos.InspectReturnAuthorizationLineDto{
+			{ProductVariantID: variantID, Restock: 1, Scrap: 1, RestockSerials: []string{first}, ScrapSerials: []string{second}},
+		},
+	}))
+	assert.Equal(t, 1.0, stockOf(t, tx, variantID))
+}
@@ -0,0 +1,543 @@
+package repositories
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v4"
+	"github.com/ysfada/product-management-system/domain/common"
+	"github.com/ysfada/product-management-system/domain/dtos"
+	"github.com/ysfada/product-management-system/domain/entities"
+	"github.com/ysfada/product-management-system/domain/interfaces"
+)
+
+type SalesOrderRepository struct {
+	dbConn DBTX
+}
+
+var _ interfaces.ISalesOrderRepository = (*SalesOrderRepository)(nil)
+
+func NewSalesOrderRepository(dbConn DBTX) *SalesOrderRepository {
+	return &SalesOrderRepository{
+		dbConn: dbConn,
+	}
+}
+
+// Fetch lists the orders without their lines, newest first. An empty
+// customer or status does not filter.
+func (r *SalesOrderRepository) Fetch(ctx context.Context, customer string, status string, page int, size int) (*entities.SalesOrderPaginated, error) {
+	sql := `
+    WITH "so" AS (
+        SELECT "so"."id",
+                "so"."customer",
+                "so"."status",
+                "so"."note",
+                "so"."paid_at",
+                "so"."fulfilled_at",
+                "so"."cancelled_at",
+                (SELECT COALESCE(SUM("l"."quantity" * "l"."unit_price"), 0)
+                    FROM "public"."sales_order_line" "l"
+                    WHERE "l"."sales_order_id" = "so"."id") "total",
+                "so"."created_at",
+                "so"."updated_at",
+                "so"."deleted_at"
+        FROM "public"."sales_order" "so"
+        WHERE ($1 = '' OR "so"."customer" = $1)
+            AND ($2 = '' OR "so"."status" = $2)
+    )
+    SELECT
+        (SELECT COUNT(*)
+            FROM "so") "count",
+
+        (SELECT COALESCE(JSONB_AGG("result".*), '[]')
+            FROM
+                (SELECT *
+                    FROM "so"
+                    ORDER BY "so"."id" DESC
+                    OFFSET $3 ROWS FETCH NEXT $4 ROWS ONLY) "result") "sales_orders"
+    `
+
+	var salesOrders entities.SalesOrderPaginated
+	var rows json.RawMessage
+	if err := r.dbConn.QueryRow(ctx, sql, customer, status, (page-1)*size, size).Scan(
+		&salesOrders.Count,
+		&rows,
+	); err != nil {
+		switch err {
+		case pgx.ErrNoRows:
+			return nil, common.ErrNotFound
+		default:
+			return nil, err
+		}
+	}
+
+	if err := json.Unmarshal([]byte(rows), &salesOrders.SalesOrders); err != nil {
+		return nil, err
+	}
+
+	salesOrders.Size = size
+	salesOrders.TotalPage = int(math.Ceil(float64(salesOrders.Count) / float64(size)))
+	salesOrders.CurrentPage = page
+	if salesOrders.CurrentPage <= salesOrders.TotalPage && salesOrders.CurrentPage > 1 {
+		salesOrders.PreviousPage = salesOrders.CurrentPage - 1
+	} else {
+		salesOrders.PreviousPage = -1
+	}
+	if salesOrders.CurrentPage < salesOrders.TotalPage {
+		salesOrders.NextPage = salesOrders.CurrentPage + 1
+	} else {
+		salesOrders.NextPage = -1
+	}
+
+	return &salesOrders, nil
+}
+
+func (r *SalesOrderRepository) GetByID(ctx context.Context, id int) (*entities.SalesOrder, error) {
+	sql := `
+    SELECT  "so"."id",
+            "so"."customer",
+            "so"."status",
+            "so"."note",
+            "so"."paid_at",
+            "so"."fulfilled_at",
+            "so"."cancelled_at",
+            (SELECT COALESCE(SUM("l"."quantity" * "l"."unit_price"), 0)
+                FROM "public"."sales_order_line" "l"
+                WHERE "l"."sales_order_id" = "so"."id") "total",
+            "so"."created_at",
+            "so"."updated_at",
+            "so"."deleted_at"
+    FROM "public"."sales_order" "so"
+    WHERE "so"."id" = $1
+    LIMIT 1
+    `
+	var salesOrder entities.SalesOrder
+	if err := r.dbConn.QueryRow(ctx, sql, id).Scan(
+		&salesOrder.ID,
+		&salesOrder.Customer,
+		&salesOrder.Status,
+		&salesOrder.Note,
+		&salesOrder.PaidAt,
+		&salesOrder.FulfilledAt,
+		&salesOrder.CancelledAt,
+		&salesOrder.Total,
+		&salesOrder.CreatedAt,
+		&salesOrder.UpdatedAt,
+		&salesOrder.DeletedAt,
+	); err != nil {
+		switch err {
+		case pgx.ErrNoRows:
+			return nil, common.ErrNotFound
+		default:
+			return nil, err
+		}
+	}
+
+	sql = `
+    SELECT  "l"."id",
+            "l"."product_variant_id",
+            "l"."name",
+            "l"."quantity",
+            "l"."unit_price"
+    FROM "public"."sales_order_line" "l"
+    WHERE "l"."sales_order_id" = $1
+    ORDER BY "l"."id" ASC
+    `
+	rows, err := r.dbConn.Query(ctx, sql, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var line entities.SalesOrderLine
+		if err := rows.Scan(
+			&line.ID,
+			&line.ProductVariantID,
+			&line.Name,
+			&line.Quantity,
+			&line.UnitPrice,
+		); err != nil {
+			return nil, err
+		}
+		salesOrder.Lines = append(salesOrder.Lines, &line)
+	}
+
+	return &salesOrder, rows.Err()
+}
+
+// Create places an order at the current prices of its variants and takes the
+// ordered quantities out of their available stock in the same transaction.
+// Lot tracked variants are taken from their lots first-expired-first-out and
+// serialized variants by their oldest serials in stock, both are recorded so
+// a cancellation puts back the same lots and serials. If any variant does not
+// have the quantity available, in unexpired lots if it is lot tracked,
+// nothing is placed and common.ErrInsufficientStock is returned. Lines that
+// repeat a variant are rejected with common.ErrBadParamInput.
+func (r *SalesOrderRepository) Create(ctx context.Context, dto *dtos.CreateSalesOrderDto) (int, error) {
+	variantIDs := make([]int, 0, len(dto.Lines))
+	quantities := make([]float64, 0, len(dto.Lines))
+	units := make([]string, 0, len(dto.Lines))
+	variants := make(map[int]bool, len(dto.Lines))
+	for _, line := range dto.Lines {
+		if variants[line.ProductVariantID] {
+			return 0, common.ErrBadParamInput
+		}
+		variants[line.ProductVariantID] = true
+		variantIDs = append(variantIDs, line.ProductVariantID)
+		quantities = append(quantities, line.Quantity)
+		units = append(units, line.Unit)
+	}
+
+	var id int
+	err := r.dbConn.BeginFunc(ctx, func(tx pgx.Tx) error {
+		// the reason of the stock movements recorded by product_variant
+		if _, err := tx.Exec(ctx, `SELECT set_config('pms.movement_reason', 'sales_order', true)`); err != nil {
+			return err
+		}
+
+		sql := `
+        INSERT INTO "public"."sales_order" ("customer", "note")
+        VALUES ($1, $2)
+        RETURNING "id"
+        `
+		if err := tx.QueryRow(ctx, sql, dto.Customer, dto.Note).Scan(&id); err != nil {
+			return err
+		}
+
+		// the lots before the order consumes them, what each line took of
+		// them is the difference afterwards
+		sql = `
+        SELECT "l"."id", "l"."quantity"::text
+        FROM "public"."lot" "l"
+        WHERE "l"."product_variant_id" = ANY($1)
+        ORDER BY "l"."id" ASC
+        FOR UPDATE
+        `
+		rows, err := tx.Query(ctx, sql, variantIDs)
+		if err != nil {
+			return err
+		}
+		var lotIDs []int
+		var lotQuantities []string
+		for rows.Next() {
+			var lotID int
+			var quantity string
+			if err := rows.Scan(&lotID, &quantity); err != nil {
+				rows.Close()
+				return err
+			}
+			lotIDs = append(lotIDs, lotID)
+			lotQuantities = append(lotQuantities, quantity)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return err
+		}
+
+		sql = `
+        WITH "l" AS (
+            SELECT "l"."product_variant_id",
+                    "l"."quantity" * "public"."unit_factor"("l"."product_variant_id", "l"."unit") "quantity"
+            FROM UNNEST($2::int[], $3::float8[], $4::text[]) "l"("product_variant_id", "quantity", "unit")
+        ), "pv" AS (
+            UPDATE "public"."product_variant" "pv"
+            SET "stock" = "pv"."stock" - "l"."quantity"
+            FROM "l"
+            WHERE "pv"."id" = "l"."product_variant_id"
+                AND NOT "pv"."serialized"
+                AND "pv"."stock" - "pv"."reserved" >= "l"."quantity"
+                -- expired lots are not consumed
+                AND (NOT EXISTS (SELECT 1 FROM "public"."lot" "lot" WHERE "lot"."product_variant_id" = "pv"."id")
+                    OR (SELECT SUM("lot"."quantity")
+                        FROM "public"."lot" "lot"
+                        WHERE "lot"."product_variant_id" = "pv"."id"
+                            AND ("lot"."expires_at" IS NULL OR "lot"."expires_at" >= CURRENT_DATE)) >= "l"."quantity")
+            RETURNING "pv"."id", "pv"."name", "pv"."price", "l"."quantity"
+        )
+        INSERT INTO "public"."sales_order_line" ("sales_order_id", "product_variant_id", "name", "quantity", "unit_price")
+        SELECT $1, "pv"."id", "pv"."name", "pv"."quantity", "pv"."price"
+        FROM "pv"
+        `
+		var placed int64
+		if cmd, err := tx.Exec(ctx, sql, id, variantIDs, quantities, units); err != nil {
+			return err
+		} else {
+			placed += cmd.RowsAffected()
+		}
+
+		// the stock of serialized variants follows their serials, which are
+		// sold below
+		sql = `
+        WITH "l" AS (
+            SELECT "l"."product_variant_id",
+                    "l"."quantity" * "public"."unit_factor"("l"."product_variant_id", "l"."unit") "quantity"
+            FROM UNNEST($2::int[], $3::float8[], $4::text[]) "l"("product_variant_id", "quantity", "unit")
+        ), "pv" AS (
+            SELECT "pv"."id", "pv"."name", "pv"."price", "l"."quantity"
+            FROM "public"."product_variant" "pv"
+            JOIN "l" ON "l"."product_variant_id" = "pv"."id"
+            WHERE "pv"."serialized"
+                AND "pv"."stock" - "pv"."reserved" >= "l"."quantity"
+            FOR UPDATE OF "pv"
+        )
+        INSERT INTO "public"."sales_order_line" ("sales_order_id", "product_variant_id", "name", "quantity", "unit_price")
+        SELECT $1, "pv"."id", "pv"."name", "pv"."quantity", "pv"."price"
+        FROM "pv"
+        `
+		if cmd, err := tx.Exec(ctx, sql, id, variantIDs, quantities, units); err != nil {
+			return err
+		} else {
+			placed += cmd.RowsAffected()
+		}
+
+		if int(placed) != len(dto.Lines) {
+			sql = `
+            SELECT COUNT(*)
+            FROM "public"."product_variant"
+            WHERE "id" = ANY($1)
+            `
+			var found int
+			if err := tx.QueryRow(ctx, sql, variantIDs).Scan(&found); err != nil {
+				return err
+			}
+			if found < len(dto.Lines) {
+				return common.ErrNotFound
+			}
+			return common.ErrInsufficientStock
+		}
+
+		sql = `
+        WITH "picked" AS (
+            SELECT "l"."id" "sales_order_line_id", "s"."id" "serial_number_id"
+            FROM "public"."sales_order_line" "l"
+            JOIN "public"."product_variant" "pv" ON "pv"."id" = "l"."product_variant_id"
+            CROSS JOIN LATERAL (
+                SELECT "s"."id"
+                FROM "public"."serial_number" "s"
+                WHERE "s"."product_variant_id" = "l"."product_variant_id"
+                    AND "s"."status" = 'in_stock'
+                ORDER BY "s"."id" ASC
+                LIMIT CEIL("l"."quantity")::int
+                FOR UPDATE
+            ) "s"
+            WHERE "l"."sales_order_id" = $1
+                AND "pv"."serialized"
+        ), "sold" AS (
+            UPDATE "public"."serial_number" "s"
+            SET "status" = 'sold'
+            FROM "picked"
+            WHERE "s"."id" = "picked"."serial_number_id"
+        )
+        INSERT INTO "public"."sales_order_line_serial" ("sales_order_line_id", "serial_number_id")
+        SELECT "picked"."sales_order_line_id", "picked"."serial_number_id"
+        FROM "picked"
+        `
+		if _, err := tx.Exec(ctx, sql, id); err != nil {
+			return err
+		}
+
+		// serials are sold whole
+		sql = `
+        SELECT EXISTS (
+            SELECT 1
+            FROM "public"."sales_order_line" "l"
+            JOIN "public"."product_variant" "pv" ON "pv"."id" = "l"."product_variant_id"
+            WHERE "l"."sales_order_id" = $1
+                AND "pv"."serialized"
+                AND "l"."quantity" <> (
+                    SELECT COUNT(*)
+                    FROM "public"."sales_order_line_serial" "s"
+                    WHERE "s"."sales_order_line_id" = "l"."id"
+                )
+        )
+        `
+		var partial bool
+		if err := tx.QueryRow(ctx, sql, id).Scan(&partial); err != nil {
+			return err
+		}
+		if partial {
+			return common.ErrBadParamInput
+		}
+
+		if len(lotIDs) == 0 {
+			return nil
+		}
+
+		sql = `
+        INSERT INTO "public"."sales_order_line_lot" ("sales_order_line_id", "lot_id", "quantity")
+        SELECT "l"."id", "lot"."id", "b"."quantity"::decimal - "lot"."quantity"
+        FROM UNNEST($2::int[], $3::text[]) "b"("lot_id", "quantity")
+        JOIN "public"."lot" "lot" ON "lot"."id" = "b"."lot_id"
+        JOIN "public"."sales_order_line" "l" ON "l"."product_variant_id" = "lot"."product_variant_id"
+        WHERE "l"."sales_order_id" = $1
+            AND "b"."quantity"::decimal > "lot"."quantity"
+        `
+		_, err = tx.Exec(ctx, sql, id, lotIDs, lotQuantities)
+		return err
+	})
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.CheckViolation {
+		// an unknown unit, or a fraction of a variant that is not fractional
+		return 0, common.ErrBadParamInput
+	}
+	if err != nil {
+		return 0, err
+	}
+	return id, nil
+}
+
+func (r *SalesOrderRepository) Pay(ctx context.Context, id int) error {
+	sql := `
+    UPDATE "public"."sales_order"
+    SET "status" = 'paid',
+        "paid_at" = NOW()
+    WHERE "id" = $1
+        AND "status" = 'pending'
+    `
+	return r.transition(ctx, sql, id)
+}
+
+func (r *SalesOrderRepository) Fulfill(ctx context.Context, id int) error {
+	sql := `
+    UPDATE "public"."sales_order"
+    SET "status" = 'fulfilled',
+        "fulfilled_at" = NOW()
+    WHERE "id" = $1
+        AND "status" = 'paid'
+    `
+	return r.transition(ctx, sql, id)
+}
+
+// Cancel cancels a pending or paid order and puts its quantities back into
+// stock in one transaction. A non-empty customer only cancels its own
+// orders. Lot tracked variants go back into the lots they were taken from,
+// or into a lot of the order if it took none, and the serials sold are back
+// in stock.
+func (r *SalesOrderRepository) Cancel(ctx context.Context, id int, customer string) error {
+	err := r.dbConn.BeginFunc(ctx, func(tx pgx.Tx) error {
+		sql := `
+        SELECT "status"
+        FROM "public"."sales_order"
+        WHERE "id" = $1
+            AND ($2 = '' OR "customer" = $2)
+        FOR UPDATE
+        `
+		var status string
+		if err := tx.QueryRow(ctx, sql, id, customer).Scan(&status); err != nil {
+			if err == pgx.ErrNoRows {
+				return common.ErrNotFound
+			}
+			return err
+		}
+		if status != "pending" && status != "paid" {
+			return common.ErrConflict
+		}
+
+		// the reason of the stock movements recorded by product_variant
+		if _, err := tx.Exec(ctx, `SELECT set_config('pms.movement_reason', 'sales_order_cancel', true)`); err != nil {
+			return err
+		}
+
+		sql = `
+        UPDATE "public"."lot"
+        SET "quantity" = "lot"."quantity" + "a"."quantity"
+        FROM "public"."sales_order_line_lot" "a"
+        JOIN "public"."sales_order_line" "l" ON "l"."id" = "a"."sales_order_line_id"
+        WHERE "l"."sales_order_id" = $1
+            AND "lot"."id" = "a"."lot_id"
+        `
+		if _, err := tx.Exec(ctx, sql, id); err != nil {
+			return err
+		}
+
+		sql = `
+        UPDATE "public"."serial_number" "s"
+        SET "status" = 'in_stock'
+        FROM "public"."sales_order_line_serial" "a"
+        JOIN "public"."sales_order_line" "l" ON "l"."id" = "a"."sales_order_line_id"
+        WHERE "l"."sales_order_id" = $1
+            AND "s"."id" = "a"."serial_number_id"
+            AND "s"."status" = 'sold'
+        `
+		if _, err := tx.Exec(ctx, sql, id); err != nil {
+			return err
+		}
+
+		sql = `
+        UPDATE "public"."product_variant" "pv"
+        SET "stock" = "pv"."stock" + "l"."quantity"
+        FROM "public"."sales_order_line" "l"
+        WHERE "l"."sales_order_id" = $1
+            AND "pv"."id" = "l"."product_variant_id"
+            AND NOT "pv"."serialized"
+            AND NOT EXISTS (SELECT 1 FROM "public"."lot" "lot" WHERE "lot"."product_variant_id" = "pv"."id")
+        `
+		if _, err := tx.Exec(ctx, sql, id); err != nil {
+			return err
+		}
+
+		// variants that became lot tracked after the order was placed
+		sql = `
+        INSERT INTO "public"."lot" ("product_variant_id", "lot_number", "quantity")
+        SELECT "l"."product_variant_id", 'SO-' || $1::text, "l"."quantity"
+        FROM "public"."sales_order_line" "l"
+        JOIN "public"."product_variant" "pv" ON "pv"."id" = "l"."product_variant_id"
+        WHERE "l"."sales_order_id" = $1
+            AND NOT "pv"."serialized"
+            AND NOT EXISTS (SELECT 1 FROM "public"."sales_order_line_lot" "a" WHERE "a"."sales_order_line_id" = "l"."id")
+            AND EXISTS (SELECT 1 FROM "public"."lot" "lot" WHERE "lot"."product_variant_id" = "l"."product_variant_id")
+        ON CONFLICT ("product_variant_id", "lot_number") DO UPDATE
+        SET "quantity" = "lot"."quantity" + excluded."quantity"
+        `
+		if _, err := tx.Exec(ctx, sql, id); err != nil {
+			return err
+		}
+
+		sql = `
+        UPDATE "public"."sales_order"
+        SET "status" = 'cancelled',
+            "cancelled_at" = NOW()
+        WHERE "id" = $1
+        `
+		_, err := tx.Exec(ctx, sql, id)
+		return err
+	})
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.CheckViolation {
+		return common.ErrConflict
+	}
+	return err
+}
+
+// transition runs a status change of an order and reports
+// common.ErrNotFound or common.ErrConflict when nothing was changed.
+func (r *SalesOrderRepository) transition(ctx context.Context, sql string, id int) error {
+	if cmd, err := r.dbConn.Exec(ctx, sql, id); err != nil {
+		return err
+	} else if cmd.RowsAffected() > 0 {
+		return nil
+	}
+
+	sql = `
+    SELECT EXISTS (
+        SELECT 1
+        FROM "public"."sales_order"
+        WHERE "id" = $1
+    )
+    `
+	var exists bool
+	if err := r.dbConn.QueryRow(ctx, sql, id).Scan(&exists); err != nil {
+		return err
+	}
+	if !exists {
+		return common.ErrNotFound
+	}
+	return common.ErrConflict
+}
@@ -0,0 +1,135 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/ysfada/product-management-system/domain/common"
+	"github.com/ysfada/product-management-system/domain/dtos"
+)
+
+// testLot adds a lot to a variant, which makes it lot tracked.
+func testLot(t *testing.T, tx pgx.Tx, variantID int, lotNumber string, quantity float64) int {
+	var id int
+	err := tx.QueryRow(context.Background(), `
+    INSERT INTO "public"."lot" ("product_variant_id", "lot_number", "quantity")
+    VALUES ($1, $2, $3)
+    RETURNING "id"
+    `, variantID, lotNumber, quantity).Scan(&id)
+	if err != nil {
+		t.Fatalf("Unable to add lot: %v", err)
+	}
+	return id
+}
+
+func lotQuantityOf(t *testing.T, tx pgx.Tx, id int) float64 {
+	var quantity float64
+	err := tx.QueryRow(context.Background(), `
+    SELECT "quantity"::float8 FROM "public"."lot" WHERE "id" = $1
+    `, id).Scan(&quantity)
+	assert.NoError(t, err)
+	return quantity
+}
+
+func order(t *testing.T, repository *SalesOrderRepository, variantID int, quantity float64) int {
+	id, err := repository.Create(context.Background(), &dtos.CreateSalesOrderDto{
+		Customer: "test",
+		Lines: []*dtos.CreateSalesOrderLineDto{
+			{ProductVariantID: variantID, Quantity: quantity},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Unable to place order: %v", err)
+	}
+	return id
+}
+
+func TestCancelRestocksLots(t *testing.T) {
+	ctx := context.Background()
+	tx := testTx(t)
+	repository := NewSalesOrderRepository(tx)
+	variantID := testVariant(t, tx, 0)
+	first := testLot(t, tx, variantID, "A", 3)
+	second := testLot(t, tx, variantID, "B", 5)
+
+	id := order(t, repository, variantID, 4)
+	assert.Equal(t, 4.0, stockOf(t, tx, variantID))
+	assert.Equal(t, 0.0, lotQuantityOf(t, tx, first))
+	assert.Equal(t, 4.0, lotQuantityOf(t, tx, second))
+
+	assert.NoError(t, repository.Cancel(ctx, id, ""))
+	assert.Equal(t, 8.0, stockOf(t, tx, variantID))
+	assert.Equal(t, 3.0, lotQuantityOf(t, tx, first))
+	assert.Equal(t, 5.0, lotQuantityOf(t, tx, second))
+}
+
+func TestCancelRestocksSerials(t *testing.T) {
+	ctx := context.Background()
+	tx := testTx(t)
+	repository := NewSalesOrderRepository(tx)
+	variantID := testVariant(t, tx, 0)
+
+	_, err := tx.Exec(ctx, `
+    UPDATE "public"."product_variant" SET "serialized" = true WHERE "id" = $1
+    `, variantID)
+	if !assert.NoError(t, err) {
+		return
+	}
+	_, err = tx.Exec(ctx, `
+    INSERT INTO "public"."serial_number" ("product_variant_id", "serial")
+    SELECT $1, 'test-' || $1::text || '-' || "n"::text
+    FROM GENERATE_SERIES(1, 3) "n"
+    `, variantID)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, 3.0, stockOf(t, tx, variantID))
+
+	id := order(t, repository, variantID, 2)
+	assert.Equal(t, 1.0, stockOf(t, tx, variantID))
+
+	assert.NoError(t, repository.Cancel(ctx, id, ""))
+	assert.Equal(t, 3.0, stockOf(t, tx, variantID))
+}
+
+func TestCreateFailsOnExpiredLots(t *testing.T) {
+	ctx := context.Background()
+	tx := testTx(t)
+	repository := NewSalesOrderRepository(tx)
+	variantID := testVariant(t, tx, 0)
+	testLot(t, tx, variantID, "fresh", 1)
+
+	_, err := tx.Exec(ctx, `
+    INSERT INTO "public"."lot" ("product_variant_id", "lot_number", "expires_at", "quantity")
+    VALUES ($1, 'expired', CURRENT_DATE - 1, 5)
+    `, variantID)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	_, err = repository.Create(ctx, &dtos.CreateSalesOrderDto{
+		Customer: "test",
+		Lines: []*dtos.CreateSalesOrderLineDto{
+			{ProductVariantID: variantID, Quantity: 3},
+		},
+	})
+	assert.ErrorIs(t, err, common.ErrInsufficientStock)
+	assert.Equal(t, 6.0, stockOf(t, tx, variantID))
+}
+
+func TestCreateRejectsRepeatedVariants(t *testing.T) {
+	tx := testTx(t)
+	repository := NewSalesOrderRepository(tx)
+	variantID := testVariant(t, tx, 10)
+
+	_, err := repository.Create(context.Background(), &dtos.CreateSalesOrderDto{
+		Customer: "test",
+		Lines: []*dtos.CreateSalesOrderLineDto{
+			{ProductVariantID: variantID, Quantity: 1},
+			{ProductVariantID: variantID, Quantity: 2},
+		},
+	})
+	assert.ErrorIs(t, err, common.ErrBadParamInput)
+}
@@ -0,0 +1,231 @@
+package repositories
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v4"
+	"github.com/ysfada/product-management-system/domain/common"
+	"github.com/ysfada/product-management-system/domain/dtos"
+	"github.com/ysfada/product-management-system/domain/entities"
+	"github.com/ysfada/product-management-system/domain/interfaces"
+)
+
+type SerialNumberRepository struct {
+	dbConn DBTX
+}
+
+var _ interfaces.ISerialNumberRepository = (*SerialNumberRepository)(nil)
+
+func NewSerialNumberRepository(dbConn DBTX) *SerialNumberRepository {
+	return &SerialNumberRepository{
+		dbConn: dbConn,
+	}
+}
+
+// Fetch lists the serials of a variant. An empty status lists every serial.
+func (r *SerialNumberRepository) Fetch(ctx context.Context, id int, variantID int, status string) ([]*entities.SerialNumber, error) {
+	sql := `
+    SELECT  "s"."id",
+            "s"."product_variant_id",
+            "s"."serial",
+            "s"."status",
+            "s"."note",
+            "s"."created_at",
+            "s"."updated_at",
+            "s"."deleted_at"
+    FROM "public"."serial_number" "s"
+    JOIN "public"."product_variant" "pv" ON "pv"."id" = "s"."product_variant_id"
+    WHERE "s"."product_variant_id" = $2
+        AND "pv"."product_id" = $1
+        AND ($3 = '' OR "s"."status" = $3)
+    ORDER BY "s"."serial" ASC
+    `
+	rows, err := r.dbConn.Query(ctx, sql, id, variantID, status)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var serials []*entities.SerialNumber
+	for rows.Next() {
+		var serial entities.SerialNumber
+		if err := rows.Scan(
+			&serial.ID,
+			&serial.ProductVariantID,
+			&serial.Serial,
+			&serial.Status,
+			&serial.Note,
+			&serial.CreatedAt,
+			&serial.UpdatedAt,
+			&serial.DeletedAt,
+		); err != nil {
+			return nil, err
+		}
+		serials = append(serials, &serial)
+	}
+
+	return serials, rows.Err()
+}
+
+// GetBySerial returns a serial with its variant and its status history.
+func (r *SerialNumberRepository) GetBySerial(ctx context.Context, serial string) (*entities.SerialNumberLookup, error) {
+	sql := `
+    SELECT  "s"."id",
+            "s"."product_variant_id",
+            "s"."serial",
+            "s"."status",
+            "s"."note",
+            "s"."created_at",
+            "s"."updated_at",
+            "s"."deleted_at",
+            JSONB_BUILD_OBJECT(
+                'id', "pv"."id",
+                'name', "pv"."name",
+                'product_id', "pv"."product_id",
+                'product', JSONB_BUILD_OBJECT(
+                    'id', "p"."id",
+                    'name', "p"."name",
+                    'description', COALESCE("p"."description", ''),
+                    'category_id', "p"."category_id"
+                ),
+                'price', "pv"."price",
+                'stock', "pv"."stock",
+                'reserved', "pv"."reserved",
+                'serialized', "pv"."serialized"
+            ) "product_variant",
+            (SELECT COALESCE(JSONB_AGG(
+                JSONB_BUILD_OBJECT(
+                    'id', "h"."id",
+                    'from_status', "h"."from_status",
+                    'to_status', "h"."to_status",
+                    'note', "h"."note",
+                    'created_at', "h"."created_at"
+                ) ORDER BY "h"."created_at" ASC, "h"."id" ASC), '[]')
+                FROM "public"."serial_number_history" "h"
+                WHERE "h"."serial_number_id" = "s"."id") "history"
+    FROM "public"."serial_number" "s"
+    JOIN "public"."product_variant" "pv" ON "pv"."id" = "s"."product_variant_id"
+    JOIN "public"."product" "p" ON "p"."id" = "pv"."product_id"
+    WHERE "s"."serial" = $1
+    LIMIT 1
+    `
+	var lookup entities.SerialNumberLookup
+	var productVariant, history json.RawMessage
+	if err := r.dbConn.QueryRow(ctx, sql, serial).Scan(
+		&lookup.ID,
+		&lookup.ProductVariantID,
+		&lookup.Serial,
+		&lookup.Status,
+		&lookup.Note,
+		&lookup.CreatedAt,
+		&lookup.UpdatedAt,
+		&lookup.DeletedAt,
+		&productVariant,
+		&history,
+	); err != nil {
+		switch err {
+		case pgx.ErrNoRows:
+			return nil, common.ErrNotFound
+		default:
+			return nil, err
+		}
+	}
+
+	if err := json.Unmarshal([]byte(productVariant), &lookup.ProductVariant); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal([]byte(history), &lookup.History); err != nil {
+		return nil, err
+	}
+
+	return &lookup, nil
+}
+
+// Create registers the received serials of a serialized variant as in stock.
+// The "_status" trigger of serial_number adds them to the stock.
+func (r *SerialNumberRepository) Create(ctx context.Context, dto *dtos.CreateSerialNumbersDto) error {
+	sql := `
+    INSERT INTO "public"."serial_number" ("product_variant_id", "serial", "note")
+    SELECT "pv"."id", "s"."serial", $4
+    FROM "public"."product_variant" "pv",
+        UNNEST($3::text[]) "s"("serial")
+    WHERE "pv"."id" = $2
+        AND "pv"."product_id" = $1
+        AND "pv"."serialized"
+    `
+	cmd, err := r.dbConn.Exec(ctx, sql, dto.ProductID, dto.ProductVariantID, dto.Serials, dto.Note)
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		switch pgErr.Code {
+		case pgerrcode.UniqueViolation:
+			return common.ErrConflict
+		case pgerrcode.CheckViolation:
+			return common.ErrBadParamInput
+		default:
+			return err
+		}
+	}
+	if err != nil {
+		return err
+	}
+	if cmd.RowsAffected() > 0 {
+		return nil
+	}
+
+	sql = `
+    SELECT EXISTS (
+        SELECT 1
+        FROM "public"."product_variant"
+        WHERE "id" = $2
+            AND "product_id" = $1
+    )
+    `
+	var exists bool
+	if err := r.dbConn.QueryRow(ctx, sql, dto.ProductID, dto.ProductVariantID).Scan(&exists); err != nil {
+		return err
+	}
+	if !exists {
+		return common.ErrNotFound
+	}
+	// the variant is not serialized
+	return common.ErrBadParamInput
+}
+
+// UpdateStatus moves a serial to a new status if its current status is one
+// of from.
+func (r *SerialNumberRepository) UpdateStatus(ctx context.Context, dto *dtos.UpdateSerialNumberStatusDto, from []string) error {
+	sql := `
+    UPDATE "public"."serial_number"
+    SET "status" = $2,
+        "note" = $3
+    WHERE "serial" = $1
+        AND "status" = ANY($4::text[])
+    `
+	cmd, err := r.dbConn.Exec(ctx, sql, dto.Serial, dto.Status, dto.Note, from)
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		switch pgErr.Code {
+		case pgerrcode.CheckViolation:
+			return common.ErrBadParamInput
+		default:
+			return err
+		}
+	}
+	if err != nil {
+		return err
+	}
+	if cmd.RowsAffected() > 0 {
+		return nil
+	}
+
+	if _, err := r.GetBySerial(ctx, dto.Serial); err != nil {
+		return err
+	}
+	return common.ErrConflict
+}
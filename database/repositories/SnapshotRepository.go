@@ -0,0 +1,595 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"github.com/ysfada/product-management-system/domain/common"
+	"github.com/ysfada/product-management-system/domain/entities"
+	"github.com/ysfada/product-management-system/domain/interfaces"
+)
+
+// snapshotNameLength is the longest name of a category, product or image.
+const snapshotNameLength = 32
+
+type SnapshotRepository struct {
+	dbConn DBTX
+}
+
+var _ interfaces.ISnapshotRepository = (*SnapshotRepository)(nil)
+
+func NewSnapshotRepository(dbConn DBTX) *SnapshotRepository {
+	return &SnapshotRepository{
+		dbConn: dbConn,
+	}
+}
+
+// Export reads the whole catalog as of one moment.
+func (r *SnapshotRepository) Export(ctx context.Context) (*entities.Snapshot, error) {
+	snapshot := entities.Snapshot{
+		Categories: []*entities.SnapshotCategory{},
+		Attributes: []*entities.SnapshotAttribute{},
+		Images:     []*entities.SnapshotImage{},
+		Products:   []*entities.SnapshotProduct{},
+	}
+	err := r.dbConn.BeginFunc(ctx, func(tx pgx.Tx) error {
+		if _, err := tx.Exec(ctx, `SET TRANSACTION ISOLATION LEVEL REPEATABLE READ, READ ONLY`); err != nil {
+			return err
+		}
+
+		sql := `
+        SELECT  "id",
+                "name"::text,
+                COALESCE("description", ''),
+                "reorder_point"::float8,
+                "reorder_quantity"::float8
+        FROM "public"."category"
+        ORDER BY "id"
+        `
+		if err := scanAll(ctx, tx, sql, func(rows pgx.Rows) error {
+			var category entities.SnapshotCategory
+			if err := rows.Scan(&category.ID, &category.Name, &category.Description, &category.ReorderPoint, &category.ReorderQuantity); err != nil {
+				return err
+			}
+			snapshot.Categories = append(snapshot.Categories, &category)
+			return nil
+		}); err != nil {
+			return err
+		}
+
+		sql = `
+        SELECT "id", "type"::text, "name"::text
+        FROM "public"."attribute"
+        ORDER BY "id"
+        `
+		if err := scanAll(ctx, tx, sql, func(rows pgx.Rows) error {
+			var attribute entities.SnapshotAttribute
+			if err := rows.Scan(&attribute.ID, &attribute.Type, &attribute.Name); err != nil {
+				return err
+			}
+			snapshot.Attributes = append(snapshot.Attributes, &attribute)
+			return nil
+		}); err != nil {
+			return err
+		}
+
+		sql = `
+        SELECT "id", COALESCE("name", ''), "image_url", "thumbnail_url"
+        FROM "public"."image"
+        ORDER BY "id"
+        `
+		if err := scanAll(ctx, tx, sql, func(rows pgx.Rows) error {
+			var image entities.SnapshotImage
+			if err := rows.Scan(&image.ID, &image.Name, &image.ImageUrl, &image.ThumbnailUrl); err != nil {
+				return err
+			}
+			snapshot.Images = append(snapshot.Images, &image)
+			return nil
+		}); err != nil {
+			return err
+		}
+
+		products := make(map[int]*entities.SnapshotProduct)
+		sql = `
+        SELECT  "p"."id",
+                "p"."name"::text,
+                COALESCE("p"."description", ''),
+                "p"."category_id",
+                ARRAY(
+                    SELECT "pi"."image_id"
+                    FROM "public"."product_images" "pi"
+                    WHERE "pi"."product_id" = "p"."id"
+                    ORDER BY "pi"."image_id"
+                )
+        FROM "public"."product" "p"
+        ORDER BY "p"."id"
+        `
+		if err := scanAll(ctx, tx, sql, func(rows pgx.Rows) error {
+			product := entities.SnapshotProduct{
+				Variants: []*entities.SnapshotProductVariant{},
+			}
+			if err := rows.Scan(&product.ID, &product.Name, &product.Description, &product.CategoryID, &product.ImageIDs); err != nil {
+				return err
+			}
+			products[product.ID] = &product
+			snapshot.Products = append(snapshot.Products, &product)
+			return nil
+		}); err != nil {
+			return err
+		}
+
+		sql = `
+        SELECT  "pv"."id",
+                "pv"."product_id",
+                "pv"."name"::text,
+                "pv"."price"::float8,
+                "pv"."stock"::float8,
+                "pv"."reorder_point"::float8,
+                "pv"."reorder_quantity"::float8,
+                "pv"."serialized",
+                "pv"."base_unit"::text,
+                "pv"."fractional",
+                ARRAY(
+                    SELECT "pa"."attribute_id"
+                    FROM "public"."product_attributes" "pa"
+                    WHERE "pa"."product_variant_id" = "pv"."id"
+                    ORDER BY "pa"."attribute_id"
+                )
+        FROM "public"."product_variant" "pv"
+        ORDER BY "pv"."product_id", "pv"."id"
+        `
+		return scanAll(ctx, tx, sql, func(rows pgx.Rows) error {
+			var variant entities.SnapshotProductVariant
+			var productID int
+			if err := rows.Scan(
+				&variant.ID,
+				&productID,
+				&variant.Name,
+				&variant.Price,
+				&variant.Stock,
+				&variant.ReorderPoint,
+				&variant.ReorderQuantity,
+				&variant.Serialized,
+				&variant.BaseUnit,
+				&variant.Fractional,
+				&variant.AttributeIDs,
+			); err != nil {
+				return err
+			}
+			products[productID].Variants = append(products[productID].Variants, &variant)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &snapshot, nil
+}
+
+// Import adds the snapshot to the catalog in one transaction, its ids are
+// mapped to new ones. A category, product or image named like an existing
+// one is resolved by strategy: skip uses the existing row, overwrite updates
+// it and rename imports it under a free name. Attributes, a type and a name,
+// always use an existing one. Variants of an overwritten product are matched
+// by name.
+func (r *SnapshotRepository) Import(ctx context.Context, snapshot *entities.Snapshot, strategy string) (*entities.SnapshotImport, error) {
+	result := entities.SnapshotImport{
+		Strategy: strategy,
+	}
+	err := r.dbConn.BeginFunc(ctx, func(tx pgx.Tx) error {
+		// the reason of the stock movements recorded by product_variant
+		if _, err := tx.Exec(ctx, `SELECT set_config('pms.movement_reason', 'snapshot', true)`); err != nil {
+			return err
+		}
+
+		categoryIDs := make(map[int]int)
+		for _, category := range snapshot.Categories {
+			if id, err := r.importCategory(ctx, tx, category, strategy, &result.Categories); err != nil {
+				return snapshotError("category", category.Name, err)
+			} else {
+				categoryIDs[category.ID] = id
+			}
+		}
+
+		attributeIDs := make(map[int]int)
+		for _, attribute := range snapshot.Attributes {
+			sql := `
+            WITH "a" AS (
+                SELECT "id"
+                FROM "public"."attribute"
+                WHERE "type" = $1
+                    AND "name" = $2
+                ORDER BY "id"
+                LIMIT 1
+            ), "i" AS (
+                INSERT INTO "public"."attribute" ("type", "name")
+                SELECT $1, $2
+                WHERE NOT EXISTS (SELECT 1 FROM "a")
+                RETURNING "id"
+            )
+            SELECT "id", false FROM "a"
+            UNION ALL
+            SELECT "id", true FROM "i"
+            `
+			var id int
+			var created bool
+			if err := tx.QueryRow(ctx, sql, attribute.Type, attribute.Name).Scan(&id, &created); err != nil {
+				return snapshotError("attribute", attribute.Type+":"+attribute.Name, err)
+			}
+			if created {
+				result.Attributes.Created++
+			} else {
+				result.Attributes.Skipped++
+			}
+			attributeIDs[attribute.ID] = id
+		}
+
+		imageIDs := make(map[int]int)
+		for _, image := range snapshot.Images {
+			if id, err := r.importImage(ctx, tx, image, strategy, &result); err != nil {
+				return snapshotError("image", image.Name, err)
+			} else {
+				imageIDs[image.ID] = id
+			}
+		}
+
+		for _, product := range snapshot.Products {
+			if err := r.importProduct(ctx, tx, product, strategy, categoryIDs, attributeIDs, imageIDs, &result); err != nil {
+				return snapshotError("product", product.Name, err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+func (r *SnapshotRepository) importCategory(ctx context.Context, tx pgx.Tx, category *entities.SnapshotCategory, strategy string, count *entities.SnapshotImportCount) (int, error) {
+	sql := `
+    SELECT "id"
+    FROM "public"."category"
+    WHERE "name" = $1
+    `
+	var id int
+	switch err := tx.QueryRow(ctx, sql, category.Name).Scan(&id); err {
+	case nil:
+	case pgx.ErrNoRows:
+		count.Created++
+		return r.createCategory(ctx, tx, category.Name, category)
+	default:
+		return 0, err
+	}
+
+	switch strategy {
+	case "overwrite":
+		sql = `
+        UPDATE "public"."category"
+        SET "description" = NULLIF($2, ''),
+            "reorder_point" = $3,
+            "reorder_quantity" = $4
+        WHERE "id" = $1
+        `
+		if _, err := tx.Exec(ctx, sql, id, category.Description, category.ReorderPoint, category.ReorderQuantity); err != nil {
+			return 0, err
+		}
+		count.Updated++
+		return id, nil
+	case "rename":
+		if name, err := freeName(ctx, tx, "category", category.Name); err != nil {
+			return 0, err
+		} else {
+			count.Renamed++
+			return r.createCategory(ctx, tx, name, category)
+		}
+	default:
+		count.Skipped++
+		return id, nil
+	}
+}
+
+func (r *SnapshotRepository) createCategory(ctx context.Context, tx pgx.Tx, name string, category *entities.SnapshotCategory) (int, error) {
+	sql := `
+    INSERT INTO "public"."category" ("name", "description", "reorder_point", "reorder_quantity")
+    VALUES ($1, NULLIF($2, ''), $3, $4)
+    RETURNING "id"
+    `
+	var id int
+	err := tx.QueryRow(ctx, sql, name, category.Description, category.ReorderPoint, category.ReorderQuantity).Scan(&id)
+	return id, err
+}
+
+func (r *SnapshotRepository) importImage(ctx context.Context, tx pgx.Tx, image *entities.SnapshotImage, strategy string, result *entities.SnapshotImport) (int, error) {
+	// unnamed images can not be told apart
+	var id int
+	if len(image.Name) > 0 {
+		sql := `
+        SELECT "id"
+        FROM "public"."image"
+        WHERE "name" = $1
+        ORDER BY "id"
+        LIMIT 1
+        `
+		switch err := tx.QueryRow(ctx, sql, image.Name).Scan(&id); err {
+		case nil:
+		case pgx.ErrNoRows:
+		default:
+			return 0, err
+		}
+	}
+	if id == 0 {
+		result.Images.Created++
+		return r.createImage(ctx, tx, image.Name, image, result)
+	}
+
+	switch strategy {
+	case "overwrite":
+		sql := `
+        WITH "old" AS (
+            SELECT "id", "image_url", "thumbnail_url", "renditions"
+            FROM "public"."image"
+            WHERE "id" = $1
+            FOR UPDATE
+        )
+        UPDATE "public"."image" "i"
+        SET "image_url" = $2,
+            "thumbnail_url" = $3,
+            "renditions" = '[]'
+        FROM "old"
+        WHERE "i"."id" = "old"."id"
+        RETURNING "old"."image_url", "old"."thumbnail_url", "old"."renditions"
+        `
+		replaced := entities.Image{ID: id}
+		if err := tx.QueryRow(ctx, sql, id, image.ImageUrl, image.ThumbnailUrl).Scan(&replaced.ImageUrl, &replaced.ThumbnailUrl, &replaced.Renditions); err != nil {
+			return 0, err
+		}
+		result.Images.Updated++
+		result.Replaced = append(result.Replaced, &replaced)
+		result.ImageUrls = append(result.ImageUrls, image.ImageUrl, image.ThumbnailUrl)
+		return id, nil
+	case "rename":
+		if name, err := freeName(ctx, tx, "image", image.Name); err != nil {
+			return 0, err
+		} else {
+			result.Images.Renamed++
+			return r.createImage(ctx, tx, name, image, result)
+		}
+	default:
+		result.Images.Skipped++
+		return id, nil
+	}
+}
+
+func (r *SnapshotRepository) createImage(ctx context.Context, tx pgx.Tx, name string, image *entities.SnapshotImage, result *entities.SnapshotImport) (int, error) {
+	sql := `
+    INSERT INTO "public"."image" ("name", "image_url", "thumbnail_url")
+    VALUES (NULLIF($1, ''), $2, $3)
+    RETURNING "id"
+    `
+	var id int
+	if err := tx.QueryRow(ctx, sql, name, image.ImageUrl, image.ThumbnailUrl).Scan(&id); err != nil {
+		return 0, err
+	}
+	result.ImageUrls = append(result.ImageUrls, image.ImageUrl, image.ThumbnailUrl)
+	return id, nil
+}
+
+func (r *SnapshotRepository) importProduct(ctx context.Context, tx pgx.Tx, product *entities.SnapshotProduct, strategy string, categoryIDs map[int]int, attributeIDs map[int]int, imageIDs map[int]int, result *entities.SnapshotImport) error {
+	categoryID, ok := categoryIDs[product.CategoryID]
+	if !ok {
+		return fmt.Errorf("%w: unknown category %d", common.ErrBadParamInput, product.CategoryID)
+	}
+
+	sql := `
+    SELECT "id"
+    FROM "public"."product"
+    WHERE "name" = $1
+    ORDER BY "id"
+    LIMIT 1
+    `
+	name := product.Name
+	var productID int
+	switch err := tx.QueryRow(ctx, sql, product.Name).Scan(&productID); err {
+	case nil:
+		switch strategy {
+		case "overwrite":
+			sql = `
+            UPDATE "public"."product"
+            SET "description" = NULLIF($2, ''),
+                "category_id" = $3
+            WHERE "id" = $1
+            `
+			if _, err := tx.Exec(ctx, sql, productID, product.Description, categoryID); err != nil {
+				return err
+			}
+			result.Products.Updated++
+		case "rename":
+			if name, err = freeName(ctx, tx, "product", product.Name); err != nil {
+				return err
+			}
+			productID = 0
+			result.Products.Renamed++
+		default:
+			// the existing product is kept as it is, variants and all
+			result.Products.Skipped++
+			result.Variants.Skipped += len(product.Variants)
+			return nil
+		}
+	case pgx.ErrNoRows:
+		result.Products.Created++
+	default:
+		return err
+	}
+
+	if productID == 0 {
+		sql = `
+        INSERT INTO "public"."product" ("name", "description", "category_id")
+        VALUES ($1, NULLIF($2, ''), $3)
+        RETURNING "id"
+        `
+		if err := tx.QueryRow(ctx, sql, name, product.Description, categoryID).Scan(&productID); err != nil {
+			return err
+		}
+	}
+
+	for _, variant := range product.Variants {
+		ids := []int{}
+		for _, attributeID := range variant.AttributeIDs {
+			if id, ok := attributeIDs[attributeID]; !ok {
+				return fmt.Errorf("%w: unknown attribute %d", common.ErrBadParamInput, attributeID)
+			} else {
+				ids = append(ids, id)
+			}
+		}
+
+		sql = `
+        SELECT "id"
+        FROM "public"."product_variant"
+        WHERE "product_id" = $1
+            AND "name" = $2
+        ORDER BY "id"
+        LIMIT 1
+        `
+		var variantID int
+		switch err := tx.QueryRow(ctx, sql, productID, variant.Name).Scan(&variantID); err {
+		case pgx.ErrNoRows:
+			// serialized variants start without stock, it is counted from
+			// their serials
+			sql = `
+            INSERT INTO "public"."product_variant" ("product_id", "name", "price", "stock", "reorder_point", "reorder_quantity", "serialized", "base_unit", "fractional")
+            VALUES ($1, $2, $3, CASE WHEN $7::bool THEN 0 ELSE $4::decimal END, $5, $6, $7, COALESCE(NULLIF($8::text, ''), 'pcs'), $9)
+            RETURNING "id"
+            `
+			if err := tx.QueryRow(ctx, sql, productID, variant.Name, variant.Price, variant.Stock, variant.ReorderPoint,
+				variant.ReorderQuantity, variant.Serialized, variant.BaseUnit, variant.Fractional).Scan(&variantID); err != nil {
+				return err
+			}
+			result.Variants.Created++
+		case nil:
+			// the stock of a serialized or lot tracked variant follows its
+			// serials or lots and is kept as is
+			sql = `
+            UPDATE "public"."product_variant" "pv"
+            SET "price" = $2,
+                "stock" = CASE
+                    WHEN "pv"."serialized"
+                        OR EXISTS (SELECT 1 FROM "public"."lot" "l" WHERE "l"."product_variant_id" = "pv"."id")
+                    THEN "pv"."stock"
+                    ELSE $3::decimal
+                END,
+                "reorder_point" = $4,
+                "reorder_quantity" = $5
+            WHERE "pv"."id" = $1
+            `
+			if _, err := tx.Exec(ctx, sql, variantID, variant.Price, variant.Stock, variant.ReorderPoint, variant.ReorderQuantity); err != nil {
+				return err
+			}
+			result.Variants.Updated++
+		default:
+			return err
+		}
+
+		// the attributes of the snapshot replace those of the variant
+		sql = `
+        DELETE FROM "public"."product_attributes"
+        WHERE "product_variant_id" = $1
+            AND NOT ("attribute_id" = ANY($2::int[]))
+        `
+		if _, err := tx.Exec(ctx, sql, variantID, ids); err != nil {
+			return err
+		}
+		sql = `
+        INSERT INTO "public"."product_attributes" ("product_variant_id", "attribute_id")
+        SELECT $1, UNNEST($2::int[])
+        ON CONFLICT DO NOTHING
+        `
+		if _, err := tx.Exec(ctx, sql, variantID, ids); err != nil {
+			return err
+		}
+	}
+
+	for _, imageID := range product.ImageIDs {
+		id, ok := imageIDs[imageID]
+		if !ok {
+			return fmt.Errorf("%w: unknown image %d", common.ErrBadParamInput, imageID)
+		}
+		sql = `
+        INSERT INTO "public"."product_images" ("product_id", "image_id")
+        SELECT $1, $2
+        WHERE NOT EXISTS (
+            SELECT 1
+            FROM "public"."product_images"
+            WHERE "product_id" = $1 AND "image_id" = $2
+        )
+        `
+		if _, err := tx.Exec(ctx, sql, productID, id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// freeName returns name with the first suffix " (n)" no row of table is
+// named, cut to snapshotNameLength characters.
+func freeName(ctx context.Context, tx pgx.Tx, table string, name string) (string, error) {
+	sql := fmt.Sprintf(`
+    SELECT EXISTS (
+        SELECT 1
+        FROM "public"."%s"
+        WHERE "name" = $1
+    )
+    `, table)
+	for n := 2; ; n++ {
+		renamed := renameTo(name, n)
+		var taken bool
+		if err := tx.QueryRow(ctx, sql, renamed).Scan(&taken); err != nil {
+			return "", err
+		}
+		if !taken {
+			return renamed, nil
+		}
+	}
+}
+
+// renameTo returns name with the suffix " (n)", name is cut so the result
+// has at most snapshotNameLength characters.
+func renameTo(name string, n int) string {
+	suffix := fmt.Sprintf(" (%d)", n)
+	for utf8.RuneCountInString(name)+len(suffix) > snapshotNameLength && len(name) > 0 {
+		_, size := utf8.DecodeLastRuneInString(name)
+		name = name[:len(name)-size]
+	}
+	return strings.TrimSpace(name) + suffix
+}
+
+// snapshotError names the row an import failed on, a constraint it violates
+// is a bad input.
+func snapshotError(kind string, name string, err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		return fmt.Errorf("%w: %s %q: %s", common.ErrBadParamInput, kind, name, pgErr.Message)
+	}
+	return fmt.Errorf("%s %q: %w", kind, name, err)
+}
+
+// scanAll calls fn with every row of the query.
+func scanAll(ctx context.Context, tx pgx.Tx, sql string, fn func(rows pgx.Rows) error) error {
+	rows, err := tx.Query(ctx, sql)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		if err := fn(rows); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
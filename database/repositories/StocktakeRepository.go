@@ -0,0 +1,307 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v4"
+	"github.com/ysfada/product-management-system/domain/common"
+	"github.com/ysfada/product-management-system/domain/dtos"
+	"github.com/ysfada/product-management-system/domain/entities"
+	"github.com/ysfada/product-management-system/domain/interfaces"
+)
+
+type StocktakeRepository struct {
+	dbConn DBTX
+}
+
+var _ interfaces.IStocktakeRepository = (*StocktakeRepository)(nil)
+
+func NewStocktakeRepository(dbConn DBTX) *StocktakeRepository {
+	return &StocktakeRepository{
+		dbConn: dbConn,
+	}
+}
+
+// GetByID returns a session with its lines. Until the session is approved
+// the counted quantity of a line is the sum of its counts so far.
+func (r *StocktakeRepository) GetByID(ctx context.Context, id int) (*entities.Stocktake, error) {
+	sql := `
+    SELECT  "s"."id",
+            "s"."category_id",
+            "s"."status",
+            "s"."created_by",
+            "s"."approved_by",
+            "s"."approved_at",
+            "s"."created_at",
+            "s"."updated_at",
+            "s"."deleted_at"
+    FROM "public"."stocktake" "s"
+    WHERE "s"."id" = $1
+    LIMIT 1
+    `
+	var stocktake entities.Stocktake
+	if err := r.dbConn.QueryRow(ctx, sql, id).Scan(
+		&stocktake.ID,
+		&stocktake.CategoryID,
+		&stocktake.Status,
+		&stocktake.CreatedBy,
+		&stocktake.ApprovedBy,
+		&stocktake.ApprovedAt,
+		&stocktake.CreatedAt,
+		&stocktake.UpdatedAt,
+		&stocktake.DeletedAt,
+	); err != nil {
+		switch err {
+		case pgx.ErrNoRows:
+			return nil, common.ErrNotFound
+		default:
+			return nil, err
+		}
+	}
+
+	sql = `
+    SELECT  "l"."id",
+            "l"."product_variant_id",
+            "pv"."name",
+            "pv"."stock",
+            "l"."expected",
+            COALESCE("l"."counted", (
+                SELECT SUM("c"."quantity")
+                FROM "public"."stocktake_count" "c"
+                WHERE "c"."stocktake_line_id" = "l"."id"
+            )) "counted",
+            "l"."adjustment"
+    FROM "public"."stocktake_line" "l"
+    JOIN "public"."product_variant" "pv" ON "pv"."id" = "l"."product_variant_id"
+    WHERE "l"."stocktake_id" = $1
+    ORDER BY "l"."product_variant_id" ASC
+    `
+	rows, err := r.dbConn.Query(ctx, sql, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var line entities.StocktakeLine
+		if err := rows.Scan(
+			&line.ID,
+			&line.ProductVariantID,
+			&line.Name,
+			&line.Stock,
+			&line.Expected,
+			&line.Counted,
+			&line.Adjustment,
+		); err != nil {
+			return nil, err
+		}
+		stocktake.Lines = append(stocktake.Lines, &line)
+	}
+
+	return &stocktake, rows.Err()
+}
+
+// Create opens a session and snapshots the stock of every variant in its
+// scope as the expected quantity. Lot tracked and serialized variants are
+// left out, they are counted through their lots and serials.
+func (r *StocktakeRepository) Create(ctx context.Context, dto *dtos.CreateStocktakeDto) (int, error) {
+	var id int
+	err := r.dbConn.BeginFunc(ctx, func(tx pgx.Tx) error {
+		sql := `
+        INSERT INTO "public"."stocktake" ("category_id", "created_by")
+        VALUES ($1, $2)
+        RETURNING "id"
+        `
+		if err := tx.QueryRow(ctx, sql, dto.CategoryID, dto.CreatedBy).Scan(&id); err != nil {
+			return err
+		}
+
+		sql = `
+        INSERT INTO "public"."stocktake_line" ("stocktake_id", "product_variant_id", "expected")
+        SELECT $1, "pv"."id", "pv"."stock"
+        FROM "public"."product_variant" "pv"
+        JOIN "public"."product" "p" ON "p"."id" = "pv"."product_id"
+        WHERE NOT "pv"."serialized"
+            AND NOT EXISTS (SELECT 1 FROM "public"."lot" "l" WHERE "l"."product_variant_id" = "pv"."id")
+            AND ($2::int IS NULL OR "p"."category_id" = $2)
+            AND (CARDINALITY($3::int[]) = 0 OR "pv"."id" = ANY($3::int[]))
+        `
+		variantIDs := dto.ProductVariantIDs
+		if variantIDs == nil {
+			variantIDs = []int{}
+		}
+		if cmd, err := tx.Exec(ctx, sql, id, dto.CategoryID, variantIDs); err != nil {
+			return err
+		} else if cmd.RowsAffected() == 0 {
+			// nothing to count in this scope
+			return common.ErrBadParamInput
+		}
+		return nil
+	})
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.ForeignKeyViolation {
+		return 0, common.ErrNotFound
+	}
+	if err != nil {
+		return 0, err
+	}
+	return id, nil
+}
+
+// AddCounts records a submission of a counter with the stock of its variants
+// at the time. Either every count is recorded or none: counts of variants
+// outside the scope of the session are rejected with common.ErrBadParamInput.
+func (r *StocktakeRepository) AddCounts(ctx context.Context, dto *dtos.CreateStocktakeCountsDto) error {
+	variantIDs := make([]int, 0, len(dto.Counts))
+	quantities := make([]float64, 0, len(dto.Counts))
+	units := make([]string, 0, len(dto.Counts))
+	for _, count := range dto.Counts {
+		variantIDs = append(variantIDs, count.ProductVariantID)
+		quantities = append(quantities, count.Quantity)
+		units = append(units, count.Unit)
+	}
+
+	err := r.dbConn.BeginFunc(ctx, func(tx pgx.Tx) error {
+		// locks the session so it can not be approved while counts are added
+		sql := `
+        SELECT "status"
+        FROM "public"."stocktake"
+        WHERE "id" = $1
+        FOR SHARE
+        `
+		var status string
+		if err := tx.QueryRow(ctx, sql, dto.StocktakeID).Scan(&status); err != nil {
+			if err == pgx.ErrNoRows {
+				return common.ErrNotFound
+			}
+			return err
+		}
+		if status != "open" {
+			return common.ErrConflict
+		}
+
+		sql = `
+        INSERT INTO "public"."stocktake_count" ("stocktake_line_id", "quantity", "counted_by", "expected")
+        SELECT "l"."id", "c"."quantity" * "public"."unit_factor"("l"."product_variant_id", "c"."unit"), $4, "pv"."stock"
+        FROM UNNEST($2::int[], $3::float8[], $5::text[]) "c"("product_variant_id", "quantity", "unit")
+        JOIN "public"."stocktake_line" "l" ON "l"."product_variant_id" = "c"."product_variant_id"
+        JOIN "public"."product_variant" "pv" ON "pv"."id" = "l"."product_variant_id"
+        WHERE "l"."stocktake_id" = $1
+        `
+		if cmd, err := tx.Exec(ctx, sql, dto.StocktakeID, variantIDs, quantities, dto.CountedBy, units); err != nil {
+			return err
+		} else if int(cmd.RowsAffected()) != len(dto.Counts) {
+			return common.ErrBadParamInput
+		}
+		return nil
+	})
+
+	// a unit the variant does not have
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.CheckViolation {
+		return common.ErrBadParamInput
+	}
+	return err
+}
+
+// Approve closes an open session and posts the variance of every counted line
+// onto the stock of its variant atomically. The variance is the counted
+// quantity less the stock when the line was last counted, and it is added to
+// the current stock instead of overwriting it, so stock that moved before or
+// after the counts is kept. Lines that were never counted are left untouched,
+// counts submitted before their stock was recorded fall back to the stock the
+// session was opened with.
+func (r *StocktakeRepository) Approve(ctx context.Context, id int, approvedBy string) error {
+	err := r.dbConn.BeginFunc(ctx, func(tx pgx.Tx) error {
+		// waits for the submissions in flight, the statement below sees them
+		sql := `
+        SELECT "status"
+        FROM "public"."stocktake"
+        WHERE "id" = $1
+        FOR UPDATE
+        `
+		var status string
+		if err := tx.QueryRow(ctx, sql, id).Scan(&status); err != nil {
+			if err == pgx.ErrNoRows {
+				return common.ErrNotFound
+			}
+			return err
+		}
+		if status != "open" {
+			return common.ErrConflict
+		}
+
+		// the reason of the stock movements recorded by product_variant
+		if _, err := tx.Exec(ctx, `SELECT set_config('pms.movement_reason', 'stocktake', true)`); err != nil {
+			return err
+		}
+
+		sql = `
+        WITH "variance" AS (
+            SELECT "l"."id",
+                    "l"."product_variant_id",
+                    SUM("c"."quantity") "counted",
+                    SUM("c"."quantity") - COALESCE((
+                        SELECT "last"."expected"
+                        FROM "public"."stocktake_count" "last"
+                        WHERE "last"."stocktake_line_id" = "l"."id"
+                        ORDER BY "last"."id" DESC
+                        LIMIT 1
+                    ), "l"."expected") "adjustment"
+            FROM "public"."stocktake_line" "l"
+            JOIN "public"."stocktake_count" "c" ON "c"."stocktake_line_id" = "l"."id"
+            WHERE "l"."stocktake_id" = $1
+            GROUP BY "l"."id"
+        ), "lines" AS (
+            UPDATE "public"."stocktake_line" "l"
+            SET "counted" = "variance"."counted",
+                "adjustment" = "variance"."adjustment"
+            FROM "variance"
+            WHERE "l"."id" = "variance"."id"
+        ), "adjusted" AS (
+            UPDATE "public"."product_variant" "pv"
+            SET "stock" = "pv"."stock" + "variance"."adjustment"
+            FROM "variance"
+            WHERE "pv"."id" = "variance"."product_variant_id"
+                AND "variance"."adjustment" <> 0
+        )
+        UPDATE "public"."stocktake"
+        SET "status" = 'approved',
+            "approved_by" = $2,
+            "approved_at" = NOW()
+        WHERE "id" = $1
+        `
+		_, err := tx.Exec(ctx, sql, id, approvedBy)
+		return err
+	})
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.CheckViolation {
+		// an adjustment would leave less stock than is reserved
+		return common.ErrInsufficientStock
+	}
+	return err
+}
+
+func (r *StocktakeRepository) Cancel(ctx context.Context, id int) error {
+	sql := `
+    UPDATE "public"."stocktake"
+    SET "status" = 'cancelled'
+    WHERE "id" = $1
+        AND "status" = 'open'
+    `
+	if cmd, err := r.dbConn.Exec(ctx, sql, id); err != nil {
+		return err
+	} else if cmd.RowsAffected() > 0 {
+		return nil
+	}
+
+	if _, err := r.GetByID(ctx, id); err != nil {
+		return err
+	}
+	return common.ErrConflict
+}
@@ -0,0 +1,51 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/ysfada/product-management-system/domain/dtos"
+)
+
+func TestStocktakeKeepsMovementsOfOpenSession(t *testing.T) {
+	for _, test := range []struct {
+		name          string
+		before, after float64
+		counted       float64
+		expected      float64
+	}{
+		// open with 10, sell 3, count the 7 left
+		{"before the count", -3, 0, 7, 7},
+		// open with 10, count 9, sell 2
+		{"after the count", 0, -2, 9, 7},
+		// open with 10, receive 5, count 14, sell 4
+		{"before and after the count", 5, -4, 14, 10},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := context.Background()
+			tx := testTx(t)
+			repository := NewStocktakeRepository(tx)
+			variantID := testVariant(t, tx, 10)
+
+			id, err := repository.Create(ctx, &dtos.CreateStocktakeDto{
+				ProductVariantIDs: []int{variantID},
+				CreatedBy:         "test",
+			})
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			adjustStock(t, tx, variantID, test.before)
+			assert.NoError(t, repository.AddCounts(ctx, &dtos.CreateStocktakeCountsDto{
+				StocktakeID: id,
+				CountedBy:   "test",
+				Counts:      []*dtos.StocktakeCountDto{{ProductVariantID: variantID, Quantity: test.counted}},
+			}))
+			adjustStock(t, tx, variantID, test.after)
+
+			assert.NoError(t, repository.Approve(ctx, id, "test"))
+			assert.Equal(t, test.expected, stockOf(t, tx, variantID))
+		})
+	}
+}
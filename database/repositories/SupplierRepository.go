@@ -0,0 +1,297 @@
+package repositories
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v4"
+	"github.com/ysfada/product-management-system/domain/common"
+	"github.com/ysfada/product-management-system/domain/dtos"
+	"github.com/ysfada/product-management-system/domain/entities"
+	"github.com/ysfada/product-management-system/domain/interfaces"
+)
+
+type SupplierRepository struct {
+	dbConn DBTX
+}
+
+var _ interfaces.ISupplierRepository = (*SupplierRepository)(nil)
+
+func NewSupplierRepository(dbConn DBTX) *SupplierRepository {
+	return &SupplierRepository{
+		dbConn: dbConn,
+	}
+}
+
+func (r *SupplierRepository) Fetch(ctx context.Context, page int, size int, sortBy string, orderBy string) (*entities.SupplierPaginated, error) {
+	sql := fmt.Sprintf(`
+    SELECT
+        (SELECT COUNT(*)
+            FROM "public"."supplier" "s") "count",
+
+        (SELECT COALESCE(JSONB_AGG("result".*), '[]')
+            FROM
+                (SELECT "s"."id",
+                        "s"."name",
+                        "s"."contact_name",
+                        "s"."email",
+                        "s"."phone",
+                        "s"."lead_time_days",
+                        "s"."currency",
+                        "s"."created_at",
+                        "s"."updated_at",
+                        "s"."deleted_at"
+                    FROM "public"."supplier" "s"
+                    ORDER BY "s"."%s" %s
+                    OFFSET $1 ROWS FETCH NEXT $2 ROWS ONLY) "result") "suppliers"
+    `, sortBy, orderBy)
+
+	var suppliers entities.SupplierPaginated
+	var rows json.RawMessage
+	if err := r.dbConn.QueryRow(ctx, sql, (page-1)*size, size).Scan(
+		&suppliers.Count,
+		&rows,
+	); err != nil {
+		switch err {
+		case pgx.ErrNoRows:
+			return nil, common.ErrNotFound
+		default:
+			return nil, err
+		}
+	}
+
+	if err := json.Unmarshal([]byte(rows), &suppliers.Suppliers); err != nil {
+		return nil, err
+	}
+
+	suppliers.Size = size
+	suppliers.TotalPage = int(math.Ceil(float64(suppliers.Count) / float64(size)))
+	suppliers.CurrentPage = page
+	if suppliers.CurrentPage <= suppliers.TotalPage && suppliers.CurrentPage > 1 {
+		suppliers.PreviousPage = suppliers.CurrentPage - 1
+	} else {
+		suppliers.PreviousPage = -1
+	}
+	if suppliers.CurrentPage < suppliers.TotalPage {
+		suppliers.NextPage = suppliers.CurrentPage + 1
+	} else {
+		suppliers.NextPage = -1
+	}
+
+	return &suppliers, nil
+}
+
+func (r *SupplierRepository) GetByID(ctx context.Context, id int) (*entities.Supplier, error) {
+	sql := `
+    SELECT  "s"."id",
+            "s"."name",
+            "s"."contact_name",
+            "s"."email",
+            "s"."phone",
+            "s"."lead_time_days",
+            "s"."currency",
+            "s"."created_at",
+            "s"."updated_at",
+            "s"."deleted_at"
+    FROM "public"."supplier" "s"
+    WHERE "s"."id" = $1
+    LIMIT 1
+    `
+	var supplier entities.Supplier
+	if err := r.dbConn.QueryRow(ctx, sql, id).Scan(
+		&supplier.ID,
+		&supplier.Name,
+		&supplier.ContactName,
+		&supplier.Email,
+		&supplier.Phone,
+		&supplier.LeadTimeDays,
+		&supplier.Currency,
+		&supplier.CreatedAt,
+		&supplier.UpdatedAt,
+		&supplier.DeletedAt,
+	); err != nil {
+		switch err {
+		case pgx.ErrNoRows:
+			return nil, common.ErrNotFound
+		default:
+			return nil, err
+		}
+	}
+
+	return &supplier, nil
+}
+
+func (r *SupplierRepository) Create(ctx context.Context, dto *dtos.CreateSupplierDto) error {
+	sql := `
+    INSERT INTO "public"."supplier" ("name", "contact_name", "email", "phone", "lead_time_days", "currency")
+    VALUES ($1, $2, $3, $4, $5, UPPER($6))
+    `
+	_, err := r.dbConn.Exec(ctx, sql, dto.Name, dto.ContactName, dto.Email, dto.Phone, dto.LeadTimeDays, dto.Currency)
+	return supplierError(err)
+}
+
+func (r *SupplierRepository) Update(ctx context.Context, dto *dtos.UpdateSupplierDto) error {
+	sql := `
+    UPDATE "public"."supplier"
+    SET "name" = $2,
+        "contact_name" = $3,
+        "email" = $4,
+        "phone" = $5,
+        "lead_time_days" = $6,
+        "currency" = UPPER($7)
+    WHERE "id" = $1
+    `
+	cmd, err := r.dbConn.Exec(ctx, sql, dto.ID, dto.Name, dto.ContactName, dto.Email, dto.Phone, dto.LeadTimeDays, dto.Currency)
+	if err := supplierError(err); err != nil {
+		return err
+	}
+	if cmd.RowsAffected() == 0 {
+		return common.ErrNotFound
+	}
+	return nil
+}
+
+// Delete removes a supplier with its variants. A supplier with purchase
+// orders can not be deleted and common.ErrConflict is returned.
+func (r *SupplierRepository) Delete(ctx context.Context, id int) error {
+	err := r.dbConn.BeginFunc(ctx, func(tx pgx.Tx) error {
+		sql := `
+        DELETE
+        FROM "public"."supplier_variant"
+        WHERE "supplier_id" = $1
+        `
+		if _, err := tx.Exec(ctx, sql, id); err != nil {
+			return err
+		}
+
+		sql = `
+        DELETE
+        FROM "public"."supplier"
+        WHERE "id" = $1
+        `
+		if cmd, err := tx.Exec(ctx, sql, id); err != nil {
+			return err
+		} else if cmd.RowsAffected() == 0 {
+			return common.ErrNotFound
+		}
+		return nil
+	})
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.ForeignKeyViolation {
+		return common.ErrConflict
+	}
+	return err
+}
+
+func (r *SupplierRepository) FetchVariants(ctx context.Context, id int) ([]*entities.SupplierVariant, error) {
+	sql := `
+    SELECT  "sv"."id",
+            "sv"."supplier_id",
+            "sv"."product_variant_id",
+            "pv"."name",
+            "sv"."supplier_sku",
+            "sv"."unit_cost",
+            "sv"."preferred"
+    FROM "public"."supplier_variant" "sv"
+    JOIN "public"."product_variant" "pv" ON "pv"."id" = "sv"."product_variant_id"
+    WHERE "sv"."supplier_id" = $1
+    ORDER BY "sv"."product_variant_id" ASC
+    `
+	rows, err := r.dbConn.Query(ctx, sql, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var variants []*entities.SupplierVariant
+	for rows.Next() {
+		var variant entities.SupplierVariant
+		if err := rows.Scan(
+			&variant.ID,
+			&variant.SupplierID,
+			&variant.ProductVariantID,
+			&variant.Name,
+			&variant.SupplierSKU,
+			&variant.UnitCost,
+			&variant.Preferred,
+		); err != nil {
+			return nil, err
+		}
+		variants = append(variants, &variant)
+	}
+
+	return variants, rows.Err()
+}
+
+// SetVariant links a variant to a supplier or updates the link. Making the
+// supplier preferred takes the preference away from the other suppliers of
+// the variant in the same transaction.
+func (r *SupplierRepository) SetVariant(ctx context.Context, dto *dtos.SetSupplierVariantDto) error {
+	err := r.dbConn.BeginFunc(ctx, func(tx pgx.Tx) error {
+		if dto.Preferred {
+			sql := `
+            UPDATE "public"."supplier_variant"
+            SET "preferred" = FALSE
+            WHERE "product_variant_id" = $1
+                AND "supplier_id" <> $2
+                AND "preferred"
+            `
+			if _, err := tx.Exec(ctx, sql, dto.ProductVariantID, dto.SupplierID); err != nil {
+				return err
+			}
+		}
+
+		sql := `
+        INSERT INTO "public"."supplier_variant" ("supplier_id", "product_variant_id", "supplier_sku", "unit_cost", "preferred")
+        VALUES ($1, $2, $3, $4, $5)
+        ON CONFLICT ("supplier_id", "product_variant_id") DO UPDATE
+        SET "supplier_sku" = EXCLUDED."supplier_sku",
+            "unit_cost" = EXCLUDED."unit_cost",
+            "preferred" = EXCLUDED."preferred"
+        `
+		_, err := tx.Exec(ctx, sql, dto.SupplierID, dto.ProductVariantID, dto.SupplierSKU, dto.UnitCost, dto.Preferred)
+		return err
+	})
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.ForeignKeyViolation {
+		return common.ErrNotFound
+	}
+	return supplierError(err)
+}
+
+func (r *SupplierRepository) DeleteVariant(ctx context.Context, id int, variantID int) error {
+	sql := `
+    DELETE
+    FROM "public"."supplier_variant"
+    WHERE "supplier_id" = $1
+        AND "product_variant_id" = $2
+    `
+	if cmd, err := r.dbConn.Exec(ctx, sql, id, variantID); err != nil {
+		return err
+	} else if cmd.RowsAffected() == 0 {
+		return common.ErrNotFound
+	}
+	return nil
+}
+
+// supplierError maps the constraint violations of supplier and
+// supplier_variant.
+func supplierError(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		switch pgErr.Code {
+		case pgerrcode.UniqueViolation:
+			return common.ErrConflict
+		case pgerrcode.CheckViolation:
+			return common.ErrBadParamInput
+		}
+	}
+	return err
+}
@@ -0,0 +1,513 @@
+package repositories
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v4"
+	"github.com/ysfada/product-management-system/domain/common"
+	"github.com/ysfada/product-management-system/domain/dtos"
+	"github.com/ysfada/product-management-system/domain/entities"
+	"github.com/ysfada/product-management-system/domain/interfaces"
+)
+
+type TransferOrderRepository struct {
+	dbConn DBTX
+}
+
+var _ interfaces.ITransferOrderRepository = (*TransferOrderRepository)(nil)
+
+func NewTransferOrderRepository(dbConn DBTX) *TransferOrderRepository {
+	return &TransferOrderRepository{
+		dbConn: dbConn,
+	}
+}
+
+// Fetch lists the transfers without their lines, newest first. A warehouseID
+// of 0 or an empty status does not filter, a warehouse matches transfers
+// from and to it.
+func (r *TransferOrderRepository) Fetch(ctx context.Context, warehouseID int, status string, page int, size int) (*entities.TransferOrderPaginated, error) {
+	sql := `
+    WITH "t" AS (
+        SELECT "t"."id",
+                "t"."source_warehouse_id",
+                "sw"."code" "source_warehouse_code",
+                "t"."destination_warehouse_id",
+                "dw"."code" "destination_warehouse_code",
+                "t"."status",
+                "t"."note",
+                "t"."created_by",
+                "t"."dispatched_by",
+                "t"."dispatched_at",
+                "t"."closed_at",
+                "t"."created_at",
+                "t"."updated_at",
+                "t"."deleted_at"
+        FROM "public"."transfer_order" "t"
+        JOIN "public"."warehouse" "sw" ON "sw"."id" = "t"."source_warehouse_id"
+        JOIN "public"."warehouse" "dw" ON "dw"."id" = "t"."destination_warehouse_id"
+        WHERE ($1 = 0 OR $1 IN ("t"."source_warehouse_id", "t"."destination_warehouse_id"))
+            AND ($2 = '' OR "t"."status" = $2)
+    )
+    SELECT
+        (SELECT COUNT(*)
+            FROM "t") "count",
+
+        (SELECT COALESCE(JSONB_AGG("result".*), '[]')
+            FROM
+                (SELECT *
+                    FROM "t"
+                    ORDER BY "t"."id" DESC
+                    OFFSET $3 ROWS FETCH NEXT $4 ROWS ONLY) "result") "transfer_orders"
+    `
+
+	var transferOrders entities.TransferOrderPaginated
+	var rows json.RawMessage
+	if err := r.dbConn.QueryRow(ctx, sql, warehouseID, status, (page-1)*size, size).Scan(
+		&transferOrders.Count,
+		&rows,
+	); err != nil {
+		switch err {
+		case pgx.ErrNoRows:
+			return nil, common.ErrNotFound
+		default:
+			return nil, err
+		}
+	}
+
+	if err := json.Unmarshal([]byte(rows), &transferOrders.TransferOrders); err != nil {
+		return nil, err
+	}
+
+	transferOrders.Size = size
+	transferOrders.TotalPage = int(math.Ceil(float64(transferOrders.Count) / float64(size)))
+	transferOrders.CurrentPage = page
+	if transferOrders.CurrentPage <= transferOrders.TotalPage && transferOrders.CurrentPage > 1 {
+		transferOrders.PreviousPage = transferOrders.CurrentPage - 1
+	} else {
+		transferOrders.PreviousPage = -1
+	}
+	if transferOrders.CurrentPage < transferOrders.TotalPage {
+		transferOrders.NextPage = transferOrders.CurrentPage + 1
+	} else {
+		transferOrders.NextPage = -1
+	}
+
+	return &transferOrders, nil
+}
+
+func (r *TransferOrderRepository) GetByID(ctx context.Context, id int) (*entities.TransferOrder, error) {
+	sql := `
+    SELECT  "t"."id",
+            "t"."source_warehouse_id",
+            "sw"."code",
+            "t"."destination_warehouse_id",
+            "dw"."code",
+            "t"."status",
+            "t"."note",
+            "t"."created_by",
+            "t"."dispatched_by",
+            "t"."dispatched_at",
+            "t"."closed_at",
+            "t"."created_at",
+            "t"."updated_at",
+            "t"."deleted_at"
+    FROM "public"."transfer_order" "t"
+    JOIN "public"."warehouse" "sw" ON "sw"."id" = "t"."source_warehouse_id"
+    JOIN "public"."warehouse" "dw" ON "dw"."id" = "t"."destination_warehouse_id"
+    WHERE "t"."id" = $1
+    LIMIT 1
+    `
+	var transferOrder entities.TransferOrder
+	if err := r.dbConn.QueryRow(ctx, sql, id).Scan(
+		&transferOrder.ID,
+		&transferOrder.SourceWarehouseID,
+		&transferOrder.SourceWarehouseCode,
+		&transferOrder.DestinationWarehouseID,
+		&transferOrder.DestinationWarehouseCode,
+		&transferOrder.Status,
+		&transferOrder.Note,
+		&transferOrder.CreatedBy,
+		&transferOrder.DispatchedBy,
+		&transferOrder.DispatchedAt,
+		&transferOrder.ClosedAt,
+		&transferOrder.CreatedAt,
+		&transferOrder.UpdatedAt,
+		&transferOrder.DeletedAt,
+	); err != nil {
+		switch err {
+		case pgx.ErrNoRows:
+			return nil, common.ErrNotFound
+		default:
+			return nil, err
+		}
+	}
+
+	sql = `
+    SELECT  "l"."id",
+            "l"."product_variant_id",
+            "pv"."name",
+            "l"."quantity",
+            "l"."received",
+            "l"."lost"
+    FROM "public"."transfer_order_line" "l"
+    JOIN "public"."product_variant" "pv" ON "pv"."id" = "l"."product_variant_id"
+    WHERE "l"."transfer_order_id" = $1
+    ORDER BY "l"."id" ASC
+    `
+	rows, err := r.dbConn.Query(ctx, sql, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var line entities.TransferOrderLine
+		if err := rows.Scan(
+			&line.ID,
+			&line.ProductVariantID,
+			&line.Name,
+			&line.Quantity,
+			&line.Received,
+			&line.Lost,
+		); err != nil {
+			return nil, err
+		}
+		transferOrder.Lines = append(transferOrder.Lines, &line)
+	}
+
+	return &transferOrder, rows.Err()
+}
+
+// Create drafts a transfer, nothing moves until it is dispatched. Lines are
+// kept in the base unit of their variant.
+func (r *TransferOrderRepository) Create(ctx context.Context, dto *dtos.CreateTransferOrderDto) (int, error) {
+	variantIDs := make([]int, 0, len(dto.Lines))
+	quantities := make([]float64, 0, len(dto.Lines))
+	units := make([]string, 0, len(dto.Lines))
+	for _, line := range dto.Lines {
+		variantIDs = append(variantIDs, line.ProductVariantID)
+		quantities = append(quantities, line.Quantity)
+		units = append(units, line.Unit)
+	}
+
+	var id int
+	err := r.dbConn.BeginFunc(ctx, func(tx pgx.Tx) error {
+		sql := `
+        INSERT INTO "public"."transfer_order" ("source_warehouse_id", "destination_warehouse_id", "note", "created_by")
+        VALUES ($1, $2, $3, $4)
+        RETURNING "id"
+        `
+		if err := tx.QueryRow(ctx, sql, dto.SourceWarehouseID, dto.DestinationWarehouseID, dto.Note, dto.CreatedBy).Scan(&id); err != nil {
+			return err
+		}
+
+		sql = `
+        INSERT INTO "public"."transfer_order_line" ("transfer_order_id", "product_variant_id", "quantity")
+        SELECT $1, "l"."product_variant_id", "l"."quantity" * "public"."unit_factor"("l"."product_variant_id", "l"."unit")
+        FROM UNNEST($2::int[], $3::float8[], $4::text[]) "l"("product_variant_id", "quantity", "unit")
+        `
+		_, err := tx.Exec(ctx, sql, id, variantIDs, quantities, units)
+		return err
+	})
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		switch pgErr.Code {
+		case pgerrcode.ForeignKeyViolation:
+			return 0, common.ErrNotFound
+		case pgerrcode.UniqueViolation, pgerrcode.CheckViolation:
+			return 0, common.ErrBadParamInput
+		}
+	}
+	if err != nil {
+		return 0, err
+	}
+	return id, nil
+}
+
+// Dispatch takes the quantities of a draft out of its source warehouse and
+// puts them in transit. The stock of the variants does not change. A source
+// warehouse that does not hold every quantity, or a transfer that would leave
+// less in the warehouses than is reserved of a variant, is rejected with
+// common.ErrInsufficientStock and nothing moves.
+func (r *TransferOrderRepository) Dispatch(ctx context.Context, id int, dispatchedBy string) error {
+	err := r.dbConn.BeginFunc(ctx, func(tx pgx.Tx) error {
+		sql := `
+        SELECT "status", "source_warehouse_id"
+        FROM "public"."transfer_order"
+        WHERE "id" = $1
+        FOR UPDATE
+        `
+		var status string
+		var sourceWarehouseID int
+		if err := tx.QueryRow(ctx, sql, id).Scan(&status, &sourceWarehouseID); err != nil {
+			if err == pgx.ErrNoRows {
+				return common.ErrNotFound
+			}
+			return err
+		}
+		if status != "draft" {
+			return common.ErrConflict
+		}
+
+		// no reservation is placed on the variants meanwhile
+		sql = `
+        SELECT 1
+        FROM "public"."product_variant" "pv"
+        JOIN "public"."transfer_order_line" "l" ON "l"."product_variant_id" = "pv"."id"
+        WHERE "l"."transfer_order_id" = $1
+        ORDER BY "pv"."id" ASC
+        FOR UPDATE OF "pv"
+        `
+		if _, err := tx.Exec(ctx, sql, id); err != nil {
+			return err
+		}
+
+		sql = `
+        WITH "l" AS (
+            SELECT "l"."product_variant_id", "l"."quantity"
+            FROM "public"."transfer_order_line" "l"
+            WHERE "l"."transfer_order_id" = $1
+        ), "ws" AS (
+            UPDATE "public"."warehouse_stock" "ws"
+            SET "quantity" = "ws"."quantity" - "l"."quantity"
+            FROM "l"
+            WHERE "ws"."warehouse_id" = $2
+                AND "ws"."product_variant_id" = "l"."product_variant_id"
+            RETURNING "ws"."id"
+        )
+        SELECT (SELECT COUNT(*) FROM "ws") = (SELECT COUNT(*) FROM "l")
+        `
+		var dispatched bool
+		if err := tx.QueryRow(ctx, sql, id, sourceWarehouseID).Scan(&dispatched); err != nil {
+			return err
+		}
+		if !dispatched {
+			// the source warehouse never held some of the variants
+			return common.ErrInsufficientStock
+		}
+
+		// reserved units are taken from the warehouses when the reservation is
+		// confirmed, they can not be in transit
+		sql = `
+        SELECT COUNT(*)
+        FROM "public"."product_variant" "pv"
+        WHERE "pv"."id" IN (
+                SELECT "l"."product_variant_id"
+                FROM "public"."transfer_order_line" "l"
+                WHERE "l"."transfer_order_id" = $1
+            )
+            AND "pv"."reserved" > (
+                SELECT COALESCE(SUM("ws"."quantity"), 0)
+                FROM "public"."warehouse_stock" "ws"
+                WHERE "ws"."product_variant_id" = "pv"."id"
+            )
+        `
+		var overReserved int
+		if err := tx.QueryRow(ctx, sql, id).Scan(&overReserved); err != nil {
+			return err
+		}
+		if overReserved > 0 {
+			return common.ErrInsufficientStock
+		}
+
+		sql = `
+        UPDATE "public"."transfer_order"
+        SET "status" = 'in_transit',
+            "dispatched_by" = $2,
+            "dispatched_at" = NOW()
+        WHERE "id" = $1
+        `
+		_, err := tx.Exec(ctx, sql, id, dispatchedBy)
+		return err
+	})
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.CheckViolation {
+		return common.ErrInsufficientStock
+	}
+	return err
+}
+
+// Receive books an arrival against the lines of a dispatched transfer and
+// adds it to the destination warehouse, the order becomes partially received
+// or received. Receiving more than is in transit on a line, or a variant that
+// is not on the transfer, is rejected with common.ErrBadParamInput.
+func (r *TransferOrderRepository) Receive(ctx context.Context, dto *dtos.CreateTransferOrderReceiptDto) error {
+	err := r.dbConn.BeginFunc(ctx, func(tx pgx.Tx) error {
+		// serializes the arrivals of the transfer
+		sql := `
+        SELECT "status", "destination_warehouse_id"
+        FROM "public"."transfer_order"
+        WHERE "id" = $1
+        FOR UPDATE
+        `
+		var status string
+		var destinationWarehouseID int
+		if err := tx.QueryRow(ctx, sql, dto.TransferOrderID).Scan(&status, &destinationWarehouseID); err != nil {
+			if err == pgx.ErrNoRows {
+				return common.ErrNotFound
+			}
+			return err
+		}
+		if status != "in_transit" && status != "partially_received" {
+			return common.ErrConflict
+		}
+
+		for _, line := range dto.Lines {
+			sql = `
+            WITH "q" AS (
+                SELECT $3::decimal * "public"."unit_factor"($2, $6) "quantity"
+            ), "l" AS (
+                UPDATE "public"."transfer_order_line"
+                SET "received" = "received" + "q"."quantity"
+                FROM "q"
+                WHERE "transfer_order_id" = $1
+                    AND "product_variant_id" = $2
+                RETURNING "id", "product_variant_id", "q"."quantity"
+            ), "receipt" AS (
+                INSERT INTO "public"."transfer_order_receipt" ("transfer_order_line_id", "quantity", "received_by")
+                SELECT "l"."id", "l"."quantity", $5
+                FROM "l"
+            )
+            INSERT INTO "public"."warehouse_stock" ("warehouse_id", "product_variant_id", "quantity")
+            SELECT $4, "l"."product_variant_id", "l"."quantity"
+            FROM "l"
+            ON CONFLICT ("warehouse_id", "product_variant_id") DO UPDATE
+            SET "quantity" = "warehouse_stock"."quantity" + EXCLUDED."quantity"
+            `
+			if cmd, err := tx.Exec(ctx, sql, dto.TransferOrderID, line.ProductVariantID, line.Quantity, destinationWarehouseID, dto.ReceivedBy, line.Unit); err != nil {
+				return err
+			} else if cmd.RowsAffected() == 0 {
+				return common.ErrBadParamInput
+			}
+		}
+
+		sql = `
+        UPDATE "public"."transfer_order" "t"
+        SET "status" = CASE
+                WHEN EXISTS (
+                    SELECT 1
+                    FROM "public"."transfer_order_line" "l"
+                    WHERE "l"."transfer_order_id" = "t"."id"
+                        AND "l"."received" + "l"."lost" < "l"."quantity"
+                ) THEN 'partially_received'
+                ELSE 'received'
+            END
+        WHERE "t"."id" = $1
+        `
+		_, err := tx.Exec(ctx, sql, dto.TransferOrderID)
+		return err
+	})
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.CheckViolation {
+		// over-receipt
+		return common.ErrBadParamInput
+	}
+	return err
+}
+
+// Close finishes a dispatched transfer. Whatever is still in transit is
+// recorded as lost and removed from the stock of its variant, so the
+// discrepancy shows up as a stock movement.
+func (r *TransferOrderRepository) Close(ctx context.Context, id int) error {
+	err := r.dbConn.BeginFunc(ctx, func(tx pgx.Tx) error {
+		sql := `
+        SELECT "status"
+        FROM "public"."transfer_order"
+        WHERE "id" = $1
+        FOR UPDATE
+        `
+		var status string
+		if err := tx.QueryRow(ctx, sql, id).Scan(&status); err != nil {
+			if err == pgx.ErrNoRows {
+				return common.ErrNotFound
+			}
+			return err
+		}
+		if status != "in_transit" && status != "partially_received" && status != "received" {
+			return common.ErrConflict
+		}
+
+		// the lost units are in no warehouse, see "_warehouse_stock" of
+		// product_variant
+		sql = `
+        SELECT set_config('pms.movement_reason', 'transfer_loss', true),
+                set_config('pms.warehouse_id', 'none', true)
+        `
+		if _, err := tx.Exec(ctx, sql); err != nil {
+			return err
+		}
+
+		sql = `
+        WITH "l" AS (
+            UPDATE "public"."transfer_order_line"
+            SET "lost" = "quantity" - "received"
+            WHERE "transfer_order_id" = $1
+                AND "received" + "lost" < "quantity"
+            RETURNING "product_variant_id", "lost"
+        )
+        UPDATE "public"."product_variant" "pv"
+        SET "stock" = "pv"."stock" - "l"."lost"
+        FROM "l"
+        WHERE "pv"."id" = "l"."product_variant_id"
+        `
+		if _, err := tx.Exec(ctx, sql, id); err != nil {
+			return err
+		}
+
+		sql = `
+        UPDATE "public"."transfer_order"
+        SET "status" = 'closed',
+            "closed_at" = NOW()
+        WHERE "id" = $1
+        `
+		_, err := tx.Exec(ctx, sql, id)
+		return err
+	})
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.CheckViolation {
+		// a serialized variant loses its serials one by one
+		return common.ErrBadParamInput
+	}
+	return err
+}
+
+// Cancel drops a draft that was never dispatched.
+func (r *TransferOrderRepository) Cancel(ctx context.Context, id int) error {
+	sql := `
+    UPDATE "public"."transfer_order"
+    SET "status" = 'cancelled',
+        "closed_at" = NOW()
+    WHERE "id" = $1
+        AND "status" = 'draft'
+    `
+	if cmd, err := r.dbConn.Exec(ctx, sql, id); err != nil {
+		return err
+	} else if cmd.RowsAffected() > 0 {
+		return nil
+	}
+
+	sql = `
+    SELECT EXISTS (
+        SELECT 1
+        FROM "public"."transfer_order"
+        WHERE "id" = $1
+    )
+    `
+	var exists bool
+	if err := r.dbConn.QueryRow(ctx, sql, id).Scan(&exists); err != nil {
+		return err
+	}
+	if !exists {
+		return common.ErrNotFound
+	}
+	return common.ErrConflict
+}
@@ -0,0 +1,113 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/ysfada/product-management-system/domain/common"
+	"github.com/ysfada/product-management-system/domain/dtos"
+)
+
+// testWarehouse adds a warehouse that is not the default one.
+func testWarehouse(t *testing.T, tx pgx.Tx) int {
+	code := fmt.Sprintf("T%d", time.Now().UnixNano()%1e9)
+
+	var id int
+	err := tx.QueryRow(context.Background(), `
+    INSERT INTO "public"."warehouse" ("code", "name")
+    VALUES ($1, $1)
+    RETURNING "id"
+    `, code).Scan(&id)
+	if err != nil {
+		t.Fatalf("Unable to add warehouse: %v", err)
+	}
+	return id
+}
+
+func defaultWarehouse(t *testing.T, tx pgx.Tx) int {
+	var id int
+	err := tx.QueryRow(context.Background(), `
+    SELECT "id" FROM "public"."warehouse" WHERE "is_default"
+    `).Scan(&id)
+	if err != nil {
+		t.Fatalf("Unable to find default warehouse: %v", err)
+	}
+	return id
+}
+
+func warehouseStockOf(t *testing.T, tx pgx.Tx, warehouseID int, variantID int) float64 {
+	var quantity float64
+	err := tx.QueryRow(context.Background(), `
+    SELECT COALESCE(SUM("quantity"), 0)::float8
+    FROM "public"."warehouse_stock"
+    WHERE "warehouse_id" = $1 AND "product_variant_id" = $2
+    `, warehouseID, variantID).Scan(&quantity)
+	assert.NoError(t, err)
+	return quantity
+}
+
+// transfer drafts a transfer of one variant out of the default warehouse.
+func transfer(t *testing.T, tx pgx.Tx, repository *TransferOrderRepository, destinationID int, variantID int, quantity float64) int {
+	id, err := repository.Create(context.Background(), &dtos.CreateTransferOrderDto{
+		SourceWarehouseID:      defaultWarehouse(t, tx),
+		DestinationWarehouseID: destinationID,
+		CreatedBy:              "test",
+		Lines: []*dtos.CreateTransferOrderLineDto{
+			{ProductVariantID: variantID, Quantity: quantity},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Unable to create transfer: %v", err)
+	}
+	return id
+}
+
+func TestSaleAfterTransferSpreadsOverWarehouses(t *testing.T) {
+	ctx := context.Background()
+	tx := testTx(t)
+	repository := NewTransferOrderRepository(tx)
+	variantID := testVariant(t, tx, 10)
+	mainID := defaultWarehouse(t, tx)
+	branchID := testWarehouse(t, tx)
+
+	id := transfer(t, tx, repository, branchID, variantID, 8)
+	assert.NoError(t, repository.Dispatch(ctx, id, "test"))
+	assert.NoError(t, repository.Receive(ctx, &dtos.CreateTransferOrderReceiptDto{
+		TransferOrderID: id,
+		ReceivedBy:      "test",
+		Lines: []*dtos.CreateTransferOrderReceiptLineDto{
+			{ProductVariantID: variantID, Quantity: 8},
+		},
+	}))
+
+	// 2 are left at the default warehouse, the other 3 come from the branch
+	adjustStock(t, tx, variantID, -5)
+	assert.Equal(t, 5.0, stockOf(t, tx, variantID))
+	assert.Equal(t, 0.0, warehouseStockOf(t, tx, mainID, variantID))
+	assert.Equal(t, 5.0, warehouseStockOf(t, tx, branchID, variantID))
+}
+
+func TestDispatchKeepsReservedUnits(t *testing.T) {
+	ctx := context.Background()
+	tx := testTx(t)
+	repository := NewTransferOrderRepository(tx)
+	variantID := testVariant(t, tx, 10)
+	branchID := testWarehouse(t, tx)
+
+	_, err := tx.Exec(ctx, `
+    UPDATE "public"."product_variant" SET "reserved" = 6 WHERE "id" = $1
+    `, variantID)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	id := transfer(t, tx, repository, branchID, variantID, 5)
+	assert.ErrorIs(t, repository.Dispatch(ctx, id, "test"), common.ErrInsufficientStock)
+
+	id = transfer(t, tx, repository, branchID, variantID, 4)
+	assert.NoError(t, repository.Dispatch(ctx, id, "test"))
+}
@@ -0,0 +1,111 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgerrcode"
+	"github.com/ysfada/product-management-system/domain/common"
+	"github.com/ysfada/product-management-system/domain/dtos"
+	"github.com/ysfada/product-management-system/domain/entities"
+	"github.com/ysfada/product-management-system/domain/interfaces"
+)
+
+type UnitOfMeasureRepository struct {
+	dbConn DBTX
+}
+
+var _ interfaces.IUnitOfMeasureRepository = (*UnitOfMeasureRepository)(nil)
+
+func NewUnitOfMeasureRepository(dbConn DBTX) *UnitOfMeasureRepository {
+	return &UnitOfMeasureRepository{
+		dbConn: dbConn,
+	}
+}
+
+// Fetch lists the alternative units of a variant, smallest first.
+func (r *UnitOfMeasureRepository) Fetch(ctx context.Context, id int, variantID int) ([]*entities.UnitOfMeasure, error) {
+	sql := `
+    SELECT  "u"."id",
+            "u"."product_variant_id",
+            "u"."name",
+            "u"."factor",
+            "u"."created_at",
+            "u"."updated_at",
+            "u"."deleted_at"
+    FROM "public"."unit_of_measure" "u"
+    JOIN "public"."product_variant" "pv" ON "pv"."id" = "u"."product_variant_id"
+    WHERE "u"."product_variant_id" = $2
+        AND "pv"."product_id" = $1
+    ORDER BY "u"."factor" ASC, "u"."name" ASC
+    `
+	rows, err := r.dbConn.Query(ctx, sql, id, variantID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var units []*entities.UnitOfMeasure
+	for rows.Next() {
+		var unit entities.UnitOfMeasure
+		if err := rows.Scan(
+			&unit.ID,
+			&unit.ProductVariantID,
+			&unit.Name,
+			&unit.Factor,
+			&unit.CreatedAt,
+			&unit.UpdatedAt,
+			&unit.DeletedAt,
+		); err != nil {
+			return nil, err
+		}
+		units = append(units, &unit)
+	}
+
+	return units, rows.Err()
+}
+
+// Set adds a unit to a variant or changes its factor. Quantities already
+// stored are in the base unit and are not affected.
+func (r *UnitOfMeasureRepository) Set(ctx context.Context, dto *dtos.SetUnitOfMeasureDto) error {
+	sql := `
+    INSERT INTO "public"."unit_of_measure" ("product_variant_id", "name", "factor")
+    SELECT "pv"."id", $3, $4
+    FROM "public"."product_variant" "pv"
+    WHERE "pv"."id" = $2
+        AND "pv"."product_id" = $1
+    ON CONFLICT ("product_variant_id", "name") DO UPDATE
+    SET "factor" = EXCLUDED."factor"
+    `
+	cmd, err := r.dbConn.Exec(ctx, sql, dto.ProductID, dto.ProductVariantID, dto.Name, dto.Factor)
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.CheckViolation {
+		return common.ErrBadParamInput
+	}
+	if err != nil {
+		return err
+	}
+	if cmd.RowsAffected() == 0 {
+		return common.ErrNotFound
+	}
+	return nil
+}
+
+func (r *UnitOfMeasureRepository) Delete(ctx context.Context, id int, variantID int, name string) error {
+	sql := `
+    DELETE
+    FROM "public"."unit_of_measure" "u"
+    USING "public"."product_variant" "pv"
+    WHERE "u"."name" = $3
+        AND "u"."product_variant_id" = $2
+        AND "pv"."id" = "u"."product_variant_id"
+        AND "pv"."product_id" = $1
+    `
+	if cmd, err := r.dbConn.Exec(ctx, sql, id, variantID, name); err != nil {
+		return err
+	} else if cmd.RowsAffected() == 0 {
+		return common.ErrNotFound
+	}
+	return nil
+}
@@ -7,7 +7,6 @@ import (
 	"github.com/jackc/pgconn"
 	"github.com/jackc/pgerrcode"
 	"github.com/jackc/pgx/v4"
-	"github.com/jackc/pgx/v4/pgxpool"
 	"github.com/ysfada/product-management-system/domain/common"
 	"github.com/ysfada/product-management-system/domain/dtos"
 	"github.com/ysfada/product-management-system/domain/entities"
@@ -15,21 +14,22 @@ import (
 )
 
 type UserRepository struct {
-	dbConn *pgxpool.Pool
+	dbConn DBTX
 }
 
 var _ interfaces.IUserRepository = (*UserRepository)(nil)
 
-func NewUserRepository(dbConn *pgxpool.Pool) *UserRepository {
+func NewUserRepository(dbConn DBTX) *UserRepository {
 	return &UserRepository{
 		dbConn: dbConn,
 	}
 }
 
+// Create creates an active user who is neither staff nor superuser.
 func (r *UserRepository) Create(ctx context.Context, dto *dtos.SignupDto) error {
 	sql := `
-        INSERT INTO "public"."user" ("username", "password")
-        VALUES ($1, $2)
+        INSERT INTO "public"."user" ("username", "password", "is_active")
+        VALUES ($1, $2, true)
     `
 	_, err := r.dbConn.Exec(ctx, sql, dto.Username, dto.Password)
 
@@ -45,6 +45,43 @@ func (r *UserRepository) Create(ctx context.Context, dto *dtos.SignupDto) error
 	return err
 }
 
+// CreateSuperuser creates an active user who is staff and superuser.
+func (r *UserRepository) CreateSuperuser(ctx context.Context, dto *dtos.SignupDto) error {
+	sql := `
+        INSERT INTO "public"."user" ("username", "password", "is_active", "is_staff", "is_superuser")
+        VALUES ($1, $2, true, true, true)
+    `
+	_, err := r.dbConn.Exec(ctx, sql, dto.Username, dto.Password)
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		if pgErr.Code == pgerrcode.CheckViolation {
+			return common.ErrBadParamInput
+		}
+		if pgErr.Code == pgerrcode.UniqueViolation {
+			return common.ErrConflict
+		}
+	}
+	return err
+}
+
+func (r *UserRepository) SetActive(ctx context.Context, username string, active bool) error {
+	sql := `
+    UPDATE "public"."user"
+    SET "is_active" = $2
+    WHERE "username" = $1
+    `
+	if cmd, err := r.dbConn.Exec(ctx, sql, username, active); err != nil {
+		return err
+	} else {
+		if cmd.RowsAffected() > 0 {
+			return nil
+		} else {
+			return common.ErrNotFound
+		}
+	}
+}
+
 func (r *UserRepository) GetByUsername(ctx context.Context, username string) (res *entities.User, err error) {
 	sql := `
     SELECT  "u"."id",
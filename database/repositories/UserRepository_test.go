@@ -0,0 +1,32 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/ysfada/product-management-system/domain/dtos"
+)
+
+func TestSignedUpUserIsActive(t *testing.T) {
+	ctx := context.Background()
+	tx := testTx(t)
+	repository := NewUserRepository(tx)
+	username := fmt.Sprintf("t%d", time.Now().UnixNano()%1e9)
+
+	if !assert.NoError(t, repository.Create(ctx, &dtos.SignupDto{Username: username, Password: "password"})) {
+		return
+	}
+	user, err := repository.GetByUsername(ctx, username)
+	if assert.NoError(t, err) && assert.NotNil(t, user) {
+		assert.True(t, user.IsActive)
+	}
+
+	assert.NoError(t, repository.SetActive(ctx, username, false))
+	user, err = repository.GetByUsername(ctx, username)
+	if assert.NoError(t, err) && assert.NotNil(t, user) {
+		assert.False(t, user.IsActive)
+	}
+}
@@ -0,0 +1,252 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v4"
+	"github.com/ysfada/product-management-system/domain/common"
+	"github.com/ysfada/product-management-system/domain/dtos"
+	"github.com/ysfada/product-management-system/domain/entities"
+	"github.com/ysfada/product-management-system/domain/interfaces"
+)
+
+type WarehouseRepository struct {
+	dbConn DBTX
+}
+
+var _ interfaces.IWarehouseRepository = (*WarehouseRepository)(nil)
+
+func NewWarehouseRepository(dbConn DBTX) *WarehouseRepository {
+	return &WarehouseRepository{
+		dbConn: dbConn,
+	}
+}
+
+func (r *WarehouseRepository) Fetch(ctx context.Context) ([]*entities.Warehouse, error) {
+	sql := `
+    SELECT  "w"."id",
+            "w"."code",
+            "w"."name",
+            "w"."is_default",
+            "w"."created_at",
+            "w"."updated_at",
+            "w"."deleted_at"
+    FROM "public"."warehouse" "w"
+    ORDER BY "w"."id" ASC
+    `
+	rows, err := r.dbConn.Query(ctx, sql)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var warehouses []*entities.Warehouse
+	for rows.Next() {
+		var warehouse entities.Warehouse
+		if err := rows.Scan(
+			&warehouse.ID,
+			&warehouse.Code,
+			&warehouse.Name,
+			&warehouse.IsDefault,
+			&warehouse.CreatedAt,
+			&warehouse.UpdatedAt,
+			&warehouse.DeletedAt,
+		); err != nil {
+			return nil, err
+		}
+		warehouses = append(warehouses, &warehouse)
+	}
+
+	return warehouses, rows.Err()
+}
+
+func (r *WarehouseRepository) GetByID(ctx context.Context, id int) (*entities.Warehouse, error) {
+	sql := `
+    SELECT  "w"."id",
+            "w"."code",
+            "w"."name",
+            "w"."is_default",
+            "w"."created_at",
+            "w"."updated_at",
+            "w"."deleted_at"
+    FROM "public"."warehouse" "w"
+    WHERE "w"."id" = $1
+    LIMIT 1
+    `
+	var warehouse entities.Warehouse
+	if err := r.dbConn.QueryRow(ctx, sql, id).Scan(
+		&warehouse.ID,
+		&warehouse.Code,
+		&warehouse.Name,
+		&warehouse.IsDefault,
+		&warehouse.CreatedAt,
+		&warehouse.UpdatedAt,
+		&warehouse.DeletedAt,
+	); err != nil {
+		switch err {
+		case pgx.ErrNoRows:
+			return nil, common.ErrNotFound
+		default:
+			return nil, err
+		}
+	}
+
+	return &warehouse, nil
+}
+
+func (r *WarehouseRepository) Create(ctx context.Context, dto *dtos.CreateWarehouseDto) error {
+	sql := `
+    INSERT INTO "public"."warehouse" ("code", "name")
+    VALUES (UPPER($1), $2)
+    `
+	_, err := r.dbConn.Exec(ctx, sql, dto.Code, dto.Name)
+	return warehouseError(err)
+}
+
+func (r *WarehouseRepository) Update(ctx context.Context, dto *dtos.UpdateWarehouseDto) error {
+	sql := `
+    UPDATE "public"."warehouse"
+    SET "code" = UPPER($2),
+        "name" = $3
+    WHERE "id" = $1
+    `
+	cmd, err := r.dbConn.Exec(ctx, sql, dto.ID, dto.Code, dto.Name)
+	if err := warehouseError(err); err != nil {
+		return err
+	}
+	if cmd.RowsAffected() == 0 {
+		return common.ErrNotFound
+	}
+	return nil
+}
+
+// Delete removes an empty warehouse. The default warehouse, a warehouse that
+// holds stock or one that has transfers can not be deleted and
+// common.ErrConflict is returned.
+func (r *WarehouseRepository) Delete(ctx context.Context, id int) error {
+	err := r.dbConn.BeginFunc(ctx, func(tx pgx.Tx) error {
+		sql := `
+        SELECT "is_default"
+        FROM "public"."warehouse"
+        WHERE "id" = $1
+        FOR UPDATE
+        `
+		var isDefault bool
+		if err := tx.QueryRow(ctx, sql, id).Scan(&isDefault); err != nil {
+			if err == pgx.ErrNoRows {
+				return common.ErrNotFound
+			}
+			return err
+		}
+		if isDefault {
+			return common.ErrConflict
+		}
+
+		// the stock left behind by transfers, nonzero stock keeps the
+		// warehouse
+		sql = `
+        DELETE
+        FROM "public"."warehouse_stock"
+        WHERE "warehouse_id" = $1
+            AND "quantity" = 0
+        `
+		if _, err := tx.Exec(ctx, sql, id); err != nil {
+			return err
+		}
+
+		sql = `
+        DELETE
+        FROM "public"."warehouse"
+        WHERE "id" = $1
+        `
+		_, err := tx.Exec(ctx, sql, id)
+		return err
+	})
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.ForeignKeyViolation {
+		return common.ErrConflict
+	}
+	return err
+}
+
+// VariantStock breaks the stock of a variant down by warehouse, listing every
+// warehouse including the ones without stock of it.
+func (r *WarehouseRepository) VariantStock(ctx context.Context, id int, variantID int) (*entities.VariantStock, error) {
+	sql := `
+    SELECT  "pv"."id",
+            "pv"."name",
+            "pv"."stock",
+            (SELECT COALESCE(SUM("l"."quantity" - "l"."received" - "l"."lost"), 0)
+                FROM "public"."transfer_order_line" "l"
+                JOIN "public"."transfer_order" "t" ON "t"."id" = "l"."transfer_order_id"
+                WHERE "l"."product_variant_id" = "pv"."id"
+                    AND "t"."status" IN ('in_transit', 'partially_received')) "in_transit"
+    FROM "public"."product_variant" "pv"
+    WHERE "pv"."id" = $2
+        AND "pv"."product_id" = $1
+    LIMIT 1
+    `
+	var stock entities.VariantStock
+	if err := r.dbConn.QueryRow(ctx, sql, id, variantID).Scan(
+		&stock.ProductVariantID,
+		&stock.Name,
+		&stock.Stock,
+		&stock.InTransit,
+	); err != nil {
+		switch err {
+		case pgx.ErrNoRows:
+			return nil, common.ErrNotFound
+		default:
+			return nil, err
+		}
+	}
+
+	sql = `
+    SELECT  "w"."id",
+            "w"."code",
+            "w"."name",
+            COALESCE("ws"."quantity", 0)
+    FROM "public"."warehouse" "w"
+    LEFT JOIN "public"."warehouse_stock" "ws" ON "ws"."warehouse_id" = "w"."id"
+        AND "ws"."product_variant_id" = $1
+    ORDER BY "w"."id" ASC
+    `
+	rows, err := r.dbConn.Query(ctx, sql, variantID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var warehouse entities.WarehouseStock
+		if err := rows.Scan(
+			&warehouse.WarehouseID,
+			&warehouse.Code,
+			&warehouse.Name,
+			&warehouse.Quantity,
+		); err != nil {
+			return nil, err
+		}
+		stock.Warehouses = append(stock.Warehouses, &warehouse)
+	}
+
+	return &stock, rows.Err()
+}
+
+// warehouseError maps the constraint violations of warehouse.
+func warehouseError(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		switch pgErr.Code {
+		case pgerrcode.UniqueViolation:
+			return common.ErrConflict
+		case pgerrcode.CheckViolation:
+			return common.ErrBadParamInput
+		}
+	}
+	return err
+}
@@ -0,0 +1,32 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v4"
+	"github.com/ysfada/product-management-system/domain/common"
+)
+
+// DBTX is what a repository runs its queries on, the pool or a transaction.
+// A repository on a transaction takes part in it, the transactions it starts
+// itself become savepoints.
+type DBTX interface {
+	Exec(ctx context.Context, sql string, arguments ...interface{}) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row
+	Begin(ctx context.Context) (pgx.Tx, error)
+	BeginFunc(ctx context.Context, f func(pgx.Tx) error) error
+}
+
+// stillReferenced maps the foreign key violation of a DELETE, the row is
+// still referenced by another, to common.ErrConflict.
+func stillReferenced(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.ForeignKeyViolation {
+		return common.ErrConflict
+	}
+	return err
+}
@@ -0,0 +1,84 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+	"github.com/stretchr/testify/assert"
+	"github.com/ysfada/product-management-system/database/migrations"
+)
+
+// testTx migrates the database of TEST_DATABASE_URL and returns a
+// transaction that is rolled back when the test ends, the repositories run
+// their own transactions as savepoints of it.
+func testTx(t *testing.T) pgx.Tx {
+	databaseURL := os.Getenv("TEST_DATABASE_URL")
+	if len(databaseURL) == 0 {
+		t.Skip("TEST_DATABASE_URL is not set")
+	}
+	if err := migrations.Up("file://../migrations", databaseURL, 0); err != nil {
+		t.Fatalf("Unable to run migrations: %v", err)
+	}
+
+	ctx := context.Background()
+	pool, err := pgxpool.Connect(ctx, databaseURL)
+	if err != nil {
+		t.Fatalf("Unable to connect to database: %v", err)
+	}
+	tx, err := pool.Begin(ctx)
+	if err != nil {
+		pool.Close()
+		t.Fatalf("Unable to begin transaction: %v", err)
+	}
+	t.Cleanup(func() {
+		tx.Rollback(ctx)
+		pool.Close()
+	})
+	return tx
+}
+
+// testVariant adds a variant with stock in a category of its own.
+func testVariant(t *testing.T, tx pgx.Tx, stock float64) int {
+	ctx := context.Background()
+	name := fmt.Sprintf("test-%d", time.Now().UnixNano())
+
+	var id int
+	err := tx.QueryRow(ctx, `
+    WITH "c" AS (
+        INSERT INTO "public"."category" ("name") VALUES ($1) RETURNING "id"
+    ), "p" AS (
+        INSERT INTO "public"."product" ("name", "category_id") SELECT $1, "id" FROM "c" RETURNING "id"
+    )
+    INSERT INTO "public"."product_variant" ("product_id", "name", "price", "stock")
+    SELECT "id", $1, 1, $2 FROM "p"
+    RETURNING "id"
+    `, name, stock).Scan(&id)
+	if err != nil {
+		t.Fatalf("Unable to add variant: %v", err)
+	}
+	return id
+}
+
+// adjustStock changes the stock of a variant like a sale or a receipt does.
+func adjustStock(t *testing.T, tx pgx.Tx, id int, delta float64) {
+	_, err := tx.Exec(context.Background(), `
+    UPDATE "public"."product_variant"
+    SET "stock" = "stock" + $2
+    WHERE "id" = $1
+    `, id, delta)
+	assert.NoError(t, err)
+}
+
+func stockOf(t *testing.T, tx pgx.Tx, id int) float64 {
+	var stock float64
+	err := tx.QueryRow(context.Background(), `
+    SELECT "stock"::float8 FROM "public"."product_variant" WHERE "id" = $1
+    `, id).Scan(&stock)
+	assert.NoError(t, err)
+	return stock
+}
@@ -0,0 +1,50 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ysfada/product-management-system/domain/common"
+)
+
+// staleOrNotFound explains why a versioned UPDATE or DELETE of the row with
+// the given id in table did not affect any row. It returns common.ErrNotFound
+// if the row does not exist and common.ErrPreconditionFailed if versions were
+// expected and its version is not one of them.
+func staleOrNotFound(ctx context.Context, dbConn DBTX, table string, id int, versions []int) error {
+	return staleOrNotFoundOf(ctx, dbConn, table, "", 0, id, versions)
+}
+
+// staleOrNotFoundOf is staleOrNotFound for a row that must also belong to the
+// parent row with parentID in column, like a variant of a product.
+func staleOrNotFoundOf(ctx context.Context, dbConn DBTX, table string, column string, parentID int, id int, versions []int) error {
+	if versions == nil {
+		return common.ErrNotFound
+	}
+
+	parent := ""
+	if column != "" {
+		parent = fmt.Sprintf(`AND "%s" = $2`, column)
+	}
+	sql := fmt.Sprintf(`
+    SELECT EXISTS (
+        SELECT 1
+        FROM "public"."%s"
+        WHERE "id" = $1
+            %s
+    )
+    `, table, parent)
+	args := []interface{}{id}
+	if column != "" {
+		args = append(args, parentID)
+	}
+	var exists bool
+	if err := dbConn.QueryRow(ctx, sql, args...).Scan(&exists); err != nil {
+		return err
+	}
+
+	if exists {
+		return common.ErrPreconditionFailed
+	}
+	return common.ErrNotFound
+}
@@ -0,0 +1,7 @@
+DELETE
+FROM "public"."user"
+WHERE "username" IN (
+    'admin',
+    'staff',
+    'user'
+)
@@ -0,0 +1,8 @@
+DELETE
+FROM "public"."category"
+WHERE "name" IN (
+    'phone',
+    'camera',
+    'television',
+    'clothing'
+)
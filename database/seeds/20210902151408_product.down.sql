@@ -0,0 +1,19 @@
+DELETE
+FROM "public"."product"
+WHERE ("name", "category_id") IN (
+VALUES
+    ('IPhone X', 1),
+    ('LG G5', 1),
+    ('Samsung Note 6', 1),
+    ('Samsung Galaxy S21', 1),
+    ('Google Pixel 3', 1),
+    ('Canon EOS 90D', 2),
+    ('Canon EOS 7D Mark II', 2),
+    ('Samsung Smart Neo QLED TV', 3),
+    ('Sony Android Smart OLED TV', 3),
+    ('LG NanoCell Smart LED TV', 3),
+    ('Shirt', 4),
+    ('Coat', 4),
+    ('T-Shirt', 4),
+    ('Dress', 4)
+)
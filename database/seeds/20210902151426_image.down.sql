@@ -0,0 +1,19 @@
+DELETE
+FROM "public"."image"
+WHERE ("name", "image_url") IN (
+VALUES
+    ('IPhone X', '/public/images/placeholder.png'),
+    ('LG G5', '/public/images/placeholder.png'),
+    ('Samsung Note 6', '/public/images/placeholder.png'),
+    ('Samsung Galaxy S21', '/public/images/placeholder.png'),
+    ('Google Pixel 3', '/public/images/placeholder.png'),
+    ('Canon EOS 90D', '/public/images/placeholder.png'),
+    ('Canon EOS 7D Mark II', '/public/images/placeholder.png'),
+    ('Samsung Smart Neo QLED TV', '/public/images/placeholder.png'),
+    ('Sony Android Smart OLED TV', '/public/images/placeholder.png'),
+    ('LG NanoCell Smart LED TV', '/public/images/placeholder.png'),
+    ('Shirt', '/public/images/placeholder.png'),
+    ('Coat', '/public/images/placeholder.png'),
+    ('T-Shirt', '/public/images/placeholder.png'),
+    ('Dress', '/public/images/placeholder.png')
+)
@@ -0,0 +1,19 @@
+DELETE
+FROM "public"."product_images"
+WHERE ("product_id", "image_id") IN (
+VALUES
+    (1, 1),
+    (2, 2),
+    (3, 3),
+    (4, 4),
+    (5, 5),
+    (6, 6),
+    (7, 7),
+    (8, 8),
+    (9, 9),
+    (10, 10),
+    (11, 11),
+    (12, 12),
+    (13, 13),
+    (14, 14)
+)
@@ -0,0 +1,72 @@
+-- the opening stock of the seeded variants was recorded as a movement
+DELETE
+FROM "public"."stock_movement"
+WHERE "product_variant_id" IN (
+    SELECT "id"
+    FROM "public"."product_variant"
+    WHERE ("product_id", "name", "price") IN (
+    VALUES
+        (1, 'IPhone X', 11250.00),
+        (1, 'IPhone X', 12350.00),
+        (2, 'LG G5', 8450.00),
+        (2, 'LG G5', 8830.00),
+        (3, 'Samsung Note 6', 6500.00),
+        (3, 'Samsung Note 6', 6450.00),
+        (4, 'Samsung Galaxy S21', 9800.00),
+        (4, 'Samsung Galaxy S21', 9750.00),
+        (5, 'Google Pixel 3', 14500.00),
+        (5, 'Google Pixel 3', 17500.00),
+        (6, 'Canon EOS 90D', 22500.00),
+        (6, 'Canon EOS 90D', 35750.00),
+        (7, 'Canon EOS 7D Mark II', 45000.00),
+        (7, 'Canon EOS 7D Mark II', 55000.00),
+        (8, 'Samsung Smart Neo QLED TV', 18650.00),
+        (8, 'Samsung Smart Neo QLED TV', 22800.00),
+        (9, 'Sony Android Smart OLED TV', 18400.00),
+        (9, 'Sony Android Smart OLED TV', 21550.00),
+        (10, 'LG NanoCell Smart LED TV', 17650.00),
+        (10, 'LG NanoCell Smart LED TV', 18550.00),
+        (11, 'Shirt', 30.50),
+        (11, 'Shirt', 36.00),
+        (12, 'Coat', 600.00),
+        (12, 'Coat', 450.00),
+        (13, 'T-Shirt', 85.00),
+        (13, 'T-Shirt', 80.00),
+        (14, 'Dress', 675.00),
+        (14, 'Dress', 500.00)
+    )
+);
+
+DELETE
+FROM "public"."product_variant"
+WHERE ("product_id", "name", "price") IN (
+VALUES
+    (1, 'IPhone X', 11250.00),
+    (1, 'IPhone X', 12350.00),
+    (2, 'LG G5', 8450.00),
+    (2, 'LG G5', 8830.00),
+    (3, 'Samsung Note 6', 6500.00),
+    (3, 'Samsung Note 6', 6450.00),
+    (4, 'Samsung Galaxy S21', 9800.00),
+    (4, 'Samsung Galaxy S21', 9750.00),
+    (5, 'Google Pixel 3', 14500.00),
+    (5, 'Google Pixel 3', 17500.00),
+    (6, 'Canon EOS 90D', 22500.00),
+    (6, 'Canon EOS 90D', 35750.00),
+    (7, 'Canon EOS 7D Mark II', 45000.00),
+    (7, 'Canon EOS 7D Mark II', 55000.00),
+    (8, 'Samsung Smart Neo QLED TV', 18650.00),
+    (8, 'Samsung Smart Neo QLED TV', 22800.00),
+    (9, 'Sony Android Smart OLED TV', 18400.00),
+    (9, 'Sony Android Smart OLED TV', 21550.00),
+    (10, 'LG NanoCell Smart LED TV', 17650.00),
+    (10, 'LG NanoCell Smart LED TV', 18550.00),
+    (11, 'Shirt', 30.50),
+    (11, 'Shirt', 36.00),
+    (12, 'Coat', 600.00),
+    (12, 'Coat', 450.00),
+    (13, 'T-Shirt', 85.00),
+    (13, 'T-Shirt', 80.00),
+    (14, 'Dress', 675.00),
+    (14, 'Dress', 500.00)
+);
@@ -0,0 +1,37 @@
+DELETE
+FROM "public"."attribute"
+WHERE ("name", "type") IN (
+VALUES
+    ('black', 'color'),
+    ('gold', 'color'),
+    ('silver', 'color'),
+    ('white', 'color'),
+    ('pink', 'color'),
+    ('ash', 'color'),
+    ('yellow', 'color'),
+    ('red', 'color'),
+    ('blue', 'color'),
+    ('green', 'color'),
+    ('64', 'memory'),
+    ('128', 'memory'),
+    ('256', 'memory'),
+    ('512', 'memory'),
+    ('tele', 'lens'),
+    ('macro', 'lens'),
+    ('black', 'lens'),
+    ('32"', 'size'),
+    ('40"', 'size'),
+    ('42"', 'size'),
+    ('56"', 'size'),
+    ('82"', 'size'),
+    ('XXL', 'size'),
+    ('XL', 'size'),
+    ('L', 'size'),
+    ('M', 'size'),
+    ('S', 'size'),
+    ('36', 'size'),
+    ('38', 'size'),
+    ('40', 'size'),
+    ('42', 'size'),
+    ('44', 'size')
+)
@@ -0,0 +1,51 @@
+DELETE
+FROM "public"."product_attributes"
+WHERE ("product_variant_id", "attribute_id") IN (
+VALUES
+    (1, 1),
+    (1, 12),
+    (2, 2),
+    (2, 13),
+    (3, 1),
+    (3, 11),
+    (4, 1),
+    (4, 12),
+    (5, 3),
+    (5, 12),
+    (6, 3),
+    (6, 13),
+    (7, 3),
+    (7, 12),
+    (8, 4),
+    (8, 13),
+    (9, 3),
+    (9, 14),
+    (10, 2),
+    (10, 14),
+    (11, 15),
+    (12, 16),
+    (13, 15),
+    (14, 17),
+    (15, 18),
+    (16, 19),
+    (17, 20),
+    (18, 21),
+    (19, 19),
+    (20, 20),
+    (21, 1),
+    (21, 23),
+    (22, 2),
+    (22, 24),
+    (23, 3),
+    (23, 24),
+    (24, 4),
+    (24, 28),
+    (25, 2),
+    (25, 29),
+    (26, 1),
+    (26, 28),
+    (27, 7),
+    (27, 28),
+    (28, 8),
+    (28, 29)
+)
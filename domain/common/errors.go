@@ -14,6 +14,14 @@ var (
 	ErrConflict = errors.New("your item already exist")
 	// ErrBadParamInput will throw if the given request-body or params is not valid
 	ErrBadParamInput = errors.New("given param is not valid")
+	// ErrInsufficientStock will throw if the available stock can not cover the requested quantity
+	ErrInsufficientStock = errors.New("insufficient stock")
+	// ErrPreconditionFailed will throw if the item was changed since the version the client has seen
+	ErrPreconditionFailed = errors.New("your item was modified by someone else")
+	// ErrTooLarge will throw if the given file exceeds the allowed size
+	ErrTooLarge = errors.New("your item is too large")
+	// ErrUnsupportedMediaType will throw if the given file is not of an allowed format
+	ErrUnsupportedMediaType = errors.New("your item is not of a supported format")
 )
 
 type AppErr struct {
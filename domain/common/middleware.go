@@ -3,9 +3,23 @@ package common
 import (
 	"os"
 
+	"github.com/gofiber/fiber/v2"
 	jwtware "github.com/gofiber/jwt/v3"
 )
 
 var JwtMiddleware = jwtware.New(jwtware.Config{
 	SigningKey: []byte(os.Getenv("SECRET")),
 })
+
+// OptionalJwtMiddleware authenticates the request only if it carries an
+// Authorization header, so public routes can tell staff users apart. A
+// missing, expired or invalid token leaves the request anonymous.
+var OptionalJwtMiddleware = jwtware.New(jwtware.Config{
+	SigningKey: []byte(os.Getenv("SECRET")),
+	Filter: func(c *fiber.Ctx) bool {
+		return len(c.Get(fiber.HeaderAuthorization)) == 0
+	},
+	ErrorHandler: func(c *fiber.Ctx, err error) error {
+		return c.Next()
+	},
+})
@@ -0,0 +1,54 @@
+package common
+
+import (
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOptionalJwtMiddleware(t *testing.T) {
+	app := fiber.New()
+	app.Get("/", OptionalJwtMiddleware, func(c *fiber.Ctx) error {
+		if _, ok := c.Locals("user").(*jwt.Token); ok {
+			return c.SendString("user")
+		}
+		return c.SendString("anonymous")
+	})
+
+	sign := func(expiresAt time.Time) string {
+		token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+			"username": "someone",
+			"exp":      expiresAt.Unix(),
+		}).SignedString([]byte(os.Getenv("SECRET")))
+		assert.NoError(t, err)
+		return token
+	}
+
+	for _, test := range []struct {
+		authorization string
+		expected      string
+	}{
+		{"", "anonymous"},
+		{"Bearer " + sign(time.Now().Add(time.Hour)), "user"},
+		{"Bearer " + sign(time.Now().Add(-time.Hour)), "anonymous"},
+		{"Bearer invalid", "anonymous"},
+		{"Basic c29tZW9uZQ==", "anonymous"},
+	} {
+		req := httptest.NewRequest(fiber.MethodGet, "/", nil)
+		if len(test.authorization) > 0 {
+			req.Header.Set(fiber.HeaderAuthorization, test.authorization)
+		}
+		resp, err := app.Test(req)
+		if assert.NoError(t, err) {
+			assert.Equal(t, fiber.StatusOK, resp.StatusCode, test.authorization)
+			body := make([]byte, 16)
+			n, _ := resp.Body.Read(body)
+			assert.Equal(t, test.expected, string(body[:n]), test.authorization)
+		}
+	}
+}
@@ -0,0 +1,28 @@
+package dtos
+
+import "encoding/json"
+
+// BatchDto is an ordered list of catalog writes run in one transaction.
+type BatchDto struct {
+	Operations []*BatchOperationDto `json:"operations" validate:"required,min=1,max=1000,dive,required"`
+}
+
+// BatchOperationDto is a write of one row. Data is the body of the matching
+// single request, e.g. a CreateProductDto to create a product, or a
+// BatchDeleteDto to delete. Ref names the id a create returns, later
+// operations use it in their data as {"$ref": "name"}.
+type BatchOperationDto struct {
+	Ref    string `json:"ref" validate:"omitempty,max=64"`
+	Action string `json:"action" validate:"required,oneof=create update delete"`
+	Type   string `json:"type" validate:"required,oneof=category product variant attribute attribute_link"`
+	// Version is the expected row version of an update or delete
+	Version *int            `json:"version"`
+	Data    json.RawMessage `json:"data" swaggertype:"object"`
+}
+
+// BatchDeleteDto is the data of a delete, ProductID is the product of a
+// variant.
+type BatchDeleteDto struct {
+	ID        int `json:"id" validate:"required"`
+	ProductID int `json:"product_id"`
+}
@@ -0,0 +1,20 @@
+package dtos
+
+// BatchResultDto has a result for every operation of the batch. If an
+// operation failed nothing was committed, the operations after it are
+// skipped.
+type BatchResultDto struct {
+	Committed bool                       `json:"committed"`
+	Results   []*BatchOperationResultDto `json:"results"`
+}
+
+type BatchOperationResultDto struct {
+	Index  int    `json:"index"`
+	Ref    string `json:"ref,omitempty"`
+	Type   string `json:"type"`
+	Action string `json:"action"`
+	// Status is created, updated, deleted, failed or skipped
+	Status string `json:"status"`
+	ID     *int   `json:"id,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
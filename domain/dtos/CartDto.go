@@ -0,0 +1,27 @@
+package dtos
+
+import "time"
+
+type CartDto struct {
+	// Token identifies an anonymous cart, send it in the X-Cart-Token header
+	Token     string         `json:"token,omitempty"`
+	Owner     *string        `json:"owner"`
+	ExpiresAt time.Time      `json:"expires_at"`
+	Lines     []*CartLineDto `json:"lines"`
+	Quantity  float64        `json:"quantity"`
+	Total     float64        `json:"total"`
+	// InStock is false if a line asks for more than is available
+	InStock bool `json:"in_stock"`
+}
+
+type CartLineDto struct {
+	ProductVariantID int     `json:"product_variant_id"`
+	ProductID        int     `json:"product_id"`
+	Name             string  `json:"name"`
+	BaseUnit         string  `json:"base_unit"`
+	Quantity         float64 `json:"quantity"`
+	UnitPrice        float64 `json:"unit_price"`
+	Subtotal         float64 `json:"subtotal"`
+	Available        float64 `json:"available"`
+	InStock          bool    `json:"in_stock"`
+}
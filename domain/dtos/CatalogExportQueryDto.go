@@ -0,0 +1,11 @@
+package dtos
+
+// CatalogExportQueryDto filters the products by name and category and their
+// variants by attributes like the product and variant searches.
+type CatalogExportQueryDto struct {
+	Q          string
+	CategoryID int
+	Attrs      []*AttributeSearchQueryDto
+	SortBy     string
+	OrderBy    string
+}
@@ -0,0 +1,21 @@
+package dtos
+
+// CatalogExportRowDto is a line of a JSON Lines catalog export, Stock is in
+// the base unit of the variant.
+type CatalogExportRowDto struct {
+	ProductID   int                          `json:"product_id"`
+	Product     string                       `json:"product"`
+	Description string                       `json:"description"`
+	Category    string                       `json:"category"`
+	VariantID   int                          `json:"variant_id"`
+	Variant     string                       `json:"variant"`
+	Price       float64                      `json:"price"`
+	Stock       float64                      `json:"stock"`
+	Attributes  []*CatalogExportAttributeDto `json:"attributes"`
+	Images      []string                     `json:"images"`
+}
+
+type CatalogExportAttributeDto struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
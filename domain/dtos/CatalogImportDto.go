@@ -0,0 +1,36 @@
+package dtos
+
+import "time"
+
+// CatalogImportDto Created and Updated count variants, Failed counts rows.
+// Plan is only returned by the import itself.
+type CatalogImportDto struct {
+	ID        int                      `json:"id"`
+	FileName  string                   `json:"file_name"`
+	DryRun    bool                     `json:"dry_run"`
+	Rows      int                      `json:"rows"`
+	Created   int                      `json:"created"`
+	Updated   int                      `json:"updated"`
+	Failed    int                      `json:"failed"`
+	Errors    []*CatalogImportErrorDto `json:"errors"`
+	Plan      []*CatalogImportPlanDto  `json:"plan,omitempty"`
+	CreatedBy string                   `json:"created_by"`
+	CreatedAt time.Time                `json:"created_at"`
+}
+
+// CatalogImportErrorDto an empty Column means the whole row.
+type CatalogImportErrorDto struct {
+	Line    int    `json:"line"`
+	Column  string `json:"column"`
+	Message string `json:"message"`
+}
+
+// CatalogImportPlanDto actions are create or update, a row without a variant
+// has no variant action.
+type CatalogImportPlanDto struct {
+	Line          int    `json:"line"`
+	Product       string `json:"product"`
+	ProductAction string `json:"product_action"`
+	Variant       string `json:"variant"`
+	VariantAction string `json:"variant_action"`
+}
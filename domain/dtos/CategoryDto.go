@@ -1,9 +1,12 @@
 package dtos
 
 type CategoryDto struct {
-	ID          int    `json:"id" validate:"required"`
-	Name        string `json:"name" validate:"required,min=2,max=32"`
-	Description string `json:"description"`
+	ID              int      `json:"id" validate:"required"`
+	Name            string   `json:"name" validate:"required,min=2,max=32"`
+	Description     string   `json:"description"`
+	ReorderPoint    *float64 `json:"reorder_point"`
+	ReorderQuantity *float64 `json:"reorder_quantity"`
+	Version         int      `json:"version"`
 }
 
 type CategoryPaginatedDto struct {
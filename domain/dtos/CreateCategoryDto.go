@@ -1,6 +1,8 @@
 package dtos
 
 type CreateCategoryDto struct {
-	Name        string `json:"name" validate:"required,min=2,max=32"`
-	Description string `json:"description"`
+	Name            string   `json:"name" validate:"required,min=2,max=32"`
+	Description     string   `json:"description"`
+	ReorderPoint    *float64 `json:"reorder_point" validate:"omitempty,min=0"`
+	ReorderQuantity *float64 `json:"reorder_quantity" validate:"omitempty,gt=0"`
 }
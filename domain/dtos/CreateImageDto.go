@@ -1,7 +1,8 @@
 package dtos
 
 type CreateImageDto struct {
-	Name         string `json:"name"`
-	ImageUrl     string `json:"image_url"`
-	ThumbnailUrl string `json:"thumbnail_url"`
+	Name         string               `json:"name"`
+	ImageUrl     string               `json:"image_url"`
+	ThumbnailUrl string               `json:"thumbnail_url"`
+	Renditions   []*ImageRenditionDto `json:"renditions"`
 }
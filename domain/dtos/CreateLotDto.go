@@ -0,0 +1,14 @@
+package dtos
+
+import "time"
+
+type CreateLotDto struct {
+	ProductID        int        `json:"product_id" validate:"required,number"`
+	ProductVariantID int        `json:"product_variant_id" validate:"required,number"`
+	LotNumber        string     `json:"lot_number" validate:"required,min=1,max=64"`
+	ExpiresAt        *time.Time `json:"expires_at"`
+	ManufacturedAt   *time.Time `json:"manufactured_at"`
+	Quantity         float64    `json:"quantity" validate:"min=0"`
+	// Unit is the unit of Quantity, the base unit of the variant if empty
+	Unit string `json:"unit" validate:"omitempty,max=16"`
+}
@@ -1,6 +1,6 @@
 package dtos
 
 type CreateProductVariantAttributeDto struct {
-	ProductVariantID int `json:"product_variant_id"`
-	AttributeID      int `json:"attribute_id"`
+	ProductVariantID int `json:"product_variant_id" validate:"required"`
+	AttributeID      int `json:"attribute_id" validate:"required"`
 }
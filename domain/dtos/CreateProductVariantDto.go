@@ -1,8 +1,15 @@
 package dtos
 
 type CreateProductVariantDto struct {
-	Name      string  `json:"name" validate:"required,min=2,max=16"`
-	ProductId int     `json:"product_id" validate:"required,number"`
-	Price     float64 `json:"price" validate:"required,number"`
-	Stock     int     `json:"stock" validate:"required,number"`
+	Name            string   `json:"name" validate:"required,min=2,max=16"`
+	ProductId       int      `json:"product_id" validate:"required,number"`
+	Price           float64  `json:"price" validate:"required,number"`
+	Stock           float64  `json:"stock" validate:"required,number"`
+	ReorderPoint    *float64 `json:"reorder_point" validate:"omitempty,min=0"`
+	ReorderQuantity *float64 `json:"reorder_quantity" validate:"omitempty,gt=0"`
+	// Serialized variants start without stock, it is counted from their serials
+	Serialized bool `json:"serialized"`
+	// BaseUnit defaults to pcs, stock and reorder levels are in the base unit
+	BaseUnit   string `json:"base_unit" validate:"omitempty,max=16"`
+	Fractional bool   `json:"fractional"`
 }
@@ -0,0 +1,34 @@
+package dtos
+
+import "time"
+
+type CreatePromotionDto struct {
+	Name string `json:"name" validate:"required,min=2,max=64"`
+	// Code is the coupon code of the promotion, a promotion without a code
+	// applies automatically
+	Code *string `json:"code" validate:"omitempty,min=1,max=32"`
+	// Kind is percent_off, buy_x_get_y or amount_off_order
+	Kind string `json:"kind" validate:"required,oneof=percent_off buy_x_get_y amount_off_order"`
+	// Percent is taken off the targeted lines of a percent_off promotion
+	Percent *float64 `json:"percent" validate:"omitempty,gt=0,max=100"`
+	// GetQuantity of every BuyQuantity + GetQuantity units of a targeted
+	// line are free with a buy_x_get_y promotion
+	BuyQuantity *int `json:"buy_quantity" validate:"omitempty,min=1"`
+	GetQuantity *int `json:"get_quantity" validate:"omitempty,min=1"`
+	// Amount is taken off the order by an amount_off_order promotion once
+	// the order is worth MinSubtotal after line discounts
+	Amount      *float64 `json:"amount" validate:"omitempty,gt=0"`
+	MinSubtotal float64  `json:"min_subtotal" validate:"min=0"`
+	// the targets of a line promotion, every variant if none is given
+	CategoryID       *int       `json:"category_id" validate:"omitempty,min=1"`
+	ProductID        *int       `json:"product_id" validate:"omitempty,min=1"`
+	ProductVariantID *int       `json:"product_variant_id" validate:"omitempty,min=1"`
+	AttributeID      *int       `json:"attribute_id" validate:"omitempty,min=1"`
+	StartsAt         *time.Time `json:"starts_at"`
+	EndsAt           *time.Time `json:"ends_at"`
+	UsageLimit       *int       `json:"usage_limit" validate:"omitempty,min=1"`
+	// Exclusive promotions are never combined with other promotions
+	Exclusive bool `json:"exclusive"`
+	// Priority orders the promotions of a kind, higher first
+	Priority int `json:"priority"`
+}
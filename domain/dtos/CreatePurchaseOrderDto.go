@@ -0,0 +1,21 @@
+package dtos
+
+import "time"
+
+type CreatePurchaseOrderDto struct {
+	SupplierID int                           `json:"supplier_id" validate:"required,min=1"`
+	ExpectedAt *time.Time                    `json:"expected_at"`
+	Note       *string                       `json:"note"`
+	CreatedBy  string                        `json:"-"`
+	Lines      []*CreatePurchaseOrderLineDto `json:"lines" validate:"required,min=1,dive"`
+}
+
+type CreatePurchaseOrderLineDto struct {
+	ProductVariantID int     `json:"product_variant_id" validate:"required,min=1"`
+	Quantity         float64 `json:"quantity" validate:"required,gt=0"`
+	// Unit is the unit of Quantity and UnitCost, the base unit of the
+	// variant if empty
+	Unit string `json:"unit" validate:"omitempty,max=16"`
+	// UnitCost defaults to the cost of the variant at the supplier
+	UnitCost *float64 `json:"unit_cost" validate:"omitempty,min=0"`
+}
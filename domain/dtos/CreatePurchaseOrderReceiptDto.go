@@ -0,0 +1,23 @@
+package dtos
+
+import "time"
+
+type CreatePurchaseOrderReceiptDto struct {
+	PurchaseOrderID int                                  `json:"-"`
+	ReceivedBy      string                               `json:"-"`
+	Lines           []*CreatePurchaseOrderReceiptLineDto `json:"lines" validate:"required,min=1,dive"`
+}
+
+type CreatePurchaseOrderReceiptLineDto struct {
+	ProductVariantID int     `json:"product_variant_id" validate:"required,min=1"`
+	Quantity         float64 `json:"quantity" validate:"required,gt=0"`
+	// Unit is the unit of Quantity, the base unit of the variant if empty
+	Unit string `json:"unit" validate:"omitempty,max=16"`
+	// LotNumber and ExpiresAt are the lot the units are received into,
+	// required for a lot tracked variant
+	LotNumber string     `json:"lot_number" validate:"omitempty,max=64"`
+	ExpiresAt *time.Time `json:"expires_at"`
+	// Serials are the serials of the units of a serialized variant, one per
+	// unit
+	Serials []string `json:"serials" validate:"omitempty,dive,min=1,max=128"`
+}
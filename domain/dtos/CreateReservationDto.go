@@ -0,0 +1,11 @@
+package dtos
+
+type CreateReservationDto struct {
+	ProductVariantID int     `json:"product_variant_id" validate:"required,number"`
+	Quantity         float64 `json:"quantity" validate:"required,gt=0"`
+	// Unit is the unit of Quantity, the base unit of the variant if empty
+	Unit     string `json:"unit" validate:"omitempty,max=16"`
+	OwnerRef string `json:"owner_ref" validate:"required,min=1,max=64"`
+	// TTL is the lifetime of the hold in seconds, defaults to RESERVATION_TTL
+	TTL int `json:"ttl" validate:"min=0"`
+}
@@ -0,0 +1,20 @@
+package dtos
+
+type CreateReturnAuthorizationDto struct {
+	// Customer is the customer the return is for, staff only, the current
+	// user if empty. A return against a sales order is always for the
+	// customer of the order
+	Customer     string                              `json:"customer" validate:"omitempty,max=64"`
+	RequestedBy  string                              `json:"-"`
+	SalesOrderID *int                                `json:"sales_order_id" validate:"omitempty,min=1"`
+	Note         *string                             `json:"note" validate:"omitempty,max=256"`
+	Lines        []*CreateReturnAuthorizationLineDto `json:"lines" validate:"required,min=1,dive"`
+}
+
+type CreateReturnAuthorizationLineDto struct {
+	ProductVariantID int     `json:"product_variant_id" validate:"required,min=1"`
+	Quantity         float64 `json:"quantity" validate:"required,gt=0"`
+	// Unit is the unit of Quantity, the base unit of the variant if empty
+	Unit   string `json:"unit" validate:"omitempty,max=16"`
+	Reason string `json:"reason" validate:"required,min=1,max=64"`
+}
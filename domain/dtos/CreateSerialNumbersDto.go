@@ -0,0 +1,8 @@
+package dtos
+
+type CreateSerialNumbersDto struct {
+	ProductID        int      `json:"product_id" validate:"required,number"`
+	ProductVariantID int      `json:"product_variant_id" validate:"required,number"`
+	Serials          []string `json:"serials" validate:"required,min=1,dive,min=1,max=128"`
+	Note             *string  `json:"note" validate:"omitempty,max=256"`
+}
@@ -0,0 +1,10 @@
+package dtos
+
+type CreateStockReceiptDto struct {
+	ProductVariantID int     `json:"product_variant_id" validate:"required,number"`
+	Quantity         float64 `json:"quantity" validate:"required,gt=0"`
+	UnitCost         float64 `json:"unit_cost" validate:"min=0"`
+	// Unit is the unit of Quantity and UnitCost, the base unit of the
+	// variant if empty
+	Unit string `json:"unit" validate:"omitempty,max=16"`
+}
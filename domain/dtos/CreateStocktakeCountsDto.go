@@ -0,0 +1,16 @@
+package dtos
+
+type CreateStocktakeCountsDto struct {
+	StocktakeID int                  `json:"-"`
+	CountedBy   string               `json:"-"`
+	Counts      []*StocktakeCountDto `json:"counts" validate:"required,min=1,dive"`
+}
+
+type StocktakeCountDto struct {
+	ProductVariantID int `json:"product_variant_id" validate:"required,number"`
+	// Quantity is added to the earlier counts of the variant, a negative
+	// quantity corrects them
+	Quantity float64 `json:"quantity"`
+	// Unit is the unit of Quantity, the base unit of the variant if empty
+	Unit string `json:"unit" validate:"omitempty,max=16"`
+}
@@ -0,0 +1,9 @@
+package dtos
+
+// CreateStocktakeDto scopes a session to a category or a list of variants,
+// a session without a scope counts every variant.
+type CreateStocktakeDto struct {
+	CategoryID        *int   `json:"category_id" validate:"omitempty,min=1"`
+	ProductVariantIDs []int  `json:"product_variant_ids" validate:"omitempty,dive,min=1"`
+	CreatedBy         string `json:"-"`
+}
@@ -0,0 +1,11 @@
+package dtos
+
+type CreateSupplierDto struct {
+	Name         string  `json:"name" validate:"required,min=2,max=64"`
+	ContactName  *string `json:"contact_name"`
+	Email        *string `json:"email" validate:"omitempty,email"`
+	Phone        *string `json:"phone"`
+	LeadTimeDays int     `json:"lead_time_days" validate:"min=0"`
+	// Currency is the ISO 4217 code the supplier invoices in
+	Currency string `json:"currency" validate:"required,len=3,alpha"`
+}
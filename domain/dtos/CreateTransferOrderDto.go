@@ -0,0 +1,16 @@
+package dtos
+
+type CreateTransferOrderDto struct {
+	SourceWarehouseID      int                           `json:"source_warehouse_id" validate:"required,min=1"`
+	DestinationWarehouseID int                           `json:"destination_warehouse_id" validate:"required,min=1,nefield=SourceWarehouseID"`
+	Note                   *string                       `json:"note"`
+	CreatedBy              string                        `json:"-"`
+	Lines                  []*CreateTransferOrderLineDto `json:"lines" validate:"required,min=1,dive"`
+}
+
+type CreateTransferOrderLineDto struct {
+	ProductVariantID int     `json:"product_variant_id" validate:"required,min=1"`
+	Quantity         float64 `json:"quantity" validate:"required,gt=0"`
+	// Unit is the unit of Quantity, the base unit of the variant if empty
+	Unit string `json:"unit" validate:"omitempty,max=16"`
+}
@@ -0,0 +1,6 @@
+package dtos
+
+type CreateWarehouseDto struct {
+	Code string `json:"code" validate:"required,min=1,max=16"`
+	Name string `json:"name" validate:"required,min=2,max=64"`
+}
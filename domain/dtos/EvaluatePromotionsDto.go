@@ -0,0 +1,15 @@
+package dtos
+
+type EvaluatePromotionsDto struct {
+	// Codes are the coupon codes to apply on top of the automatic
+	// promotions
+	Codes []string                     `json:"codes" validate:"omitempty,max=10,dive,min=1,max=32"`
+	Lines []*EvaluatePromotionsLineDto `json:"lines" validate:"required,min=1,dive"`
+}
+
+type EvaluatePromotionsLineDto struct {
+	ProductVariantID int     `json:"product_variant_id" validate:"required,min=1"`
+	Quantity         float64 `json:"quantity" validate:"required,gt=0"`
+	// Unit is the unit of Quantity, the base unit of the variant if empty
+	Unit string `json:"unit" validate:"omitempty,max=16"`
+}
@@ -0,0 +1,18 @@
+package dtos
+
+// FeedDiagnosticsDto lists the variants left out of the feed for missing
+// required fields and the products left out for having no variants.
+type FeedDiagnosticsDto struct {
+	Items       int                  `json:"items"`
+	Listed      int                  `json:"listed"`
+	Omitted     []*FeedDiagnosticDto `json:"omitted"`
+	Variantless []*FeedDiagnosticDto `json:"variantless"`
+}
+
+type FeedDiagnosticDto struct {
+	ProductID int      `json:"product_id"`
+	Product   string   `json:"product"`
+	VariantID int      `json:"variant_id"`
+	Variant   string   `json:"variant"`
+	Missing   []string `json:"missing"`
+}
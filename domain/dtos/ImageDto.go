@@ -1,8 +1,21 @@
 package dtos
 
+// ImageDto ThumbnailUrl is the url of the thumbnail rendition, or of the
+// image itself before its renditions are rendered.
 type ImageDto struct {
-	ID           int    `json:"id"`
-	Name         string `json:"name"`
-	ImageUrl     string `json:"image_url"`
-	ThumbnailUrl string `json:"thumbnail_url"`
+	ID           int                  `json:"id"`
+	Name         string               `json:"name"`
+	ImageUrl     string               `json:"image_url"`
+	ThumbnailUrl string               `json:"thumbnail_url"`
+	Renditions   []*ImageRenditionDto `json:"renditions"`
+}
+
+// ImageRenditionDto is a resized copy of an image, Format is jpeg, png or
+// webp.
+type ImageRenditionDto struct {
+	Name   string `json:"name"`
+	Format string `json:"format"`
+	Url    string `json:"url"`
+	Width  int    `json:"width"`
+	Height int    `json:"height"`
 }
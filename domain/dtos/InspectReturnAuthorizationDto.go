@@ -0,0 +1,29 @@
+package dtos
+
+import "time"
+
+// InspectReturnAuthorizationDto gives every received unit of the return a
+// disposition, the dispositions of a line must add up to what was received.
+type InspectReturnAuthorizationDto struct {
+	ReturnAuthorizationID int                                  `json:"-"`
+	Actor                 string                               `json:"-"`
+	Note                  *string                              `json:"note" validate:"omitempty,max=256"`
+	Lines                 []*InspectReturnAuthorizationLineDto `json:"lines" validate:"required,min=1,dive"`
+}
+
+type InspectReturnAuthorizationLineDto struct {
+	ProductVariantID int     `json:"product_variant_id" validate:"required,min=1"`
+	Restock          float64 `json:"restock" validate:"min=0"`
+	Refurbish        float64 `json:"refurbish" validate:"min=0"`
+	Scrap            float64 `json:"scrap" validate:"min=0"`
+	// Unit is the unit of the quantities, the base unit of the variant if
+	// empty
+	Unit string `json:"unit" validate:"omitempty,max=16"`
+	// the serials of every disposition of a serialized variant, one per unit
+	RestockSerials   []string `json:"restock_serials" validate:"omitempty,dive,min=1,max=128"`
+	RefurbishSerials []string `json:"refurbish_serials" validate:"omitempty,dive,min=1,max=128"`
+	ScrapSerials     []string `json:"scrap_serials" validate:"omitempty,dive,min=1,max=128"`
+	// ExpiresAt is the expiry of the restocked units of a lot tracked
+	// variant, required if the return is not against an order
+	ExpiresAt *time.Time `json:"expires_at"`
+}
@@ -0,0 +1,27 @@
+package dtos
+
+import "time"
+
+type LotDto struct {
+	ID               int        `json:"id"`
+	ProductVariantID int        `json:"product_variant_id"`
+	LotNumber        string     `json:"lot_number"`
+	ExpiresAt        *time.Time `json:"expires_at"`
+	ManufacturedAt   *time.Time `json:"manufactured_at"`
+	Quantity         float64    `json:"quantity"`
+}
+
+type ExpiringLotDto struct {
+	ID             int                `json:"id"`
+	LotNumber      string             `json:"lot_number"`
+	ExpiresAt      time.Time          `json:"expires_at"`
+	DaysLeft       int                `json:"days_left"`
+	Quantity       float64            `json:"quantity"`
+	ProductVariant *ProductVariantDto `json:"product_variant"`
+	Product        *ProductDto        `json:"product"`
+}
+
+type ExpiringLotPaginatedDto struct {
+	PaginationDto
+	Lots []*ExpiringLotDto `json:"lots"`
+}
@@ -0,0 +1,29 @@
+package dtos
+
+type LowStockVariantDto struct {
+	ID              int          `json:"id"`
+	Name            string       `json:"name"`
+	Stock           float64      `json:"stock"`
+	Reserved        float64      `json:"reserved"`
+	Available       float64      `json:"available"`
+	ReorderPoint    float64      `json:"reorder_point"`
+	ReorderQuantity *float64     `json:"reorder_quantity"`
+	Product         *ProductDto  `json:"product"`
+	Category        *CategoryDto `json:"category"`
+}
+
+type LowStockPaginatedDto struct {
+	PaginationDto
+	Variants []*LowStockVariantDto `json:"variants"`
+}
+
+type LowStockEventDto struct {
+	ProductVariantID int      `json:"product_variant_id"`
+	ProductID        int      `json:"product_id"`
+	Name             string   `json:"name"`
+	Stock            float64  `json:"stock"`
+	Reserved         float64  `json:"reserved"`
+	Available        float64  `json:"available"`
+	ReorderPoint     float64  `json:"reorder_point"`
+	ReorderQuantity  *float64 `json:"reorder_quantity"`
+}
@@ -0,0 +1,16 @@
+package dtos
+
+import "time"
+
+type PickDto struct {
+	LotID     int        `json:"lot_id"`
+	LotNumber string     `json:"lot_number"`
+	ExpiresAt *time.Time `json:"expires_at"`
+	Quantity  float64    `json:"quantity"`
+}
+
+type PickingDto struct {
+	ProductVariantID int        `json:"product_variant_id"`
+	Quantity         float64    `json:"quantity"`
+	Picks            []*PickDto `json:"picks"`
+}
@@ -8,6 +8,7 @@ type ProductDto struct {
 	Category    *CategoryDto         `json:"category,omitempty"`
 	Images      []*ImageDto          `json:"images,omitempty"`
 	Variants    []*ProductVariantDto `json:"variants,omitempty"`
+	Version     int                  `json:"version"`
 }
 
 type ProductPaginatedDto struct {
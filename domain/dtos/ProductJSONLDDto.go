@@ -0,0 +1,41 @@
+package dtos
+
+// ProductJSONLDDto is a schema.org Product with an Offer per variant, for
+// structured data in product pages.
+type ProductJSONLDDto struct {
+	Context            string                    `json:"@context"`
+	Type               string                    `json:"@type"`
+	ProductID          string                    `json:"productID"`
+	Name               string                    `json:"name"`
+	Description        string                    `json:"description,omitempty"`
+	URL                string                    `json:"url,omitempty"`
+	Image              []string                  `json:"image,omitempty"`
+	Category           string                    `json:"category,omitempty"`
+	AdditionalProperty []*PropertyValueJSONLDDto `json:"additionalProperty,omitempty"`
+	Offers             []*OfferJSONLDDto         `json:"offers"`
+}
+
+// OfferJSONLDDto offers a variant, ItemOffered holds the variant with its
+// attributes.
+type OfferJSONLDDto struct {
+	Type          string            `json:"@type"`
+	SKU           string            `json:"sku"`
+	Price         string            `json:"price"`
+	PriceCurrency string            `json:"priceCurrency"`
+	Availability  string            `json:"availability"`
+	URL           string            `json:"url,omitempty"`
+	ItemOffered   *VariantJSONLDDto `json:"itemOffered"`
+}
+
+type VariantJSONLDDto struct {
+	Type               string                    `json:"@type"`
+	SKU                string                    `json:"sku"`
+	Name               string                    `json:"name"`
+	AdditionalProperty []*PropertyValueJSONLDDto `json:"additionalProperty,omitempty"`
+}
+
+type PropertyValueJSONLDDto struct {
+	Type  string `json:"@type"`
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
@@ -1,13 +1,28 @@
 package dtos
 
 type ProductVariantDto struct {
-	ID         int             `json:"id" validate:"required"`
-	Name       string          `json:"name" validate:"required,min=2,max=16"`
-	ProductId  int             `json:"product_id" validate:"required,number"`
-	Product    *ProductDto     `json:"product,omitempty"`
-	Price      float64         `json:"price" validate:"required,number"`
-	Stock      int             `json:"stock" validate:"required,number"`
-	Attributes []*AttributeDto `json:"attributes"`
+	ID              int         `json:"id" validate:"required"`
+	Name            string      `json:"name" validate:"required,min=2,max=16"`
+	ProductId       int         `json:"product_id" validate:"required,number"`
+	Product         *ProductDto `json:"product,omitempty"`
+	Price           float64     `json:"price" validate:"required,number"`
+	Stock           float64     `json:"stock" validate:"required,number"`
+	Reserved        float64     `json:"reserved"`
+	Available       float64     `json:"available"`
+	ReorderPoint    *float64    `json:"reorder_point"`
+	ReorderQuantity *float64    `json:"reorder_quantity"`
+	Serialized      bool        `json:"serialized"`
+	// BaseUnit is the unit of every quantity of the variant, Fractional
+	// variants can hold fractions of it
+	BaseUnit   string          `json:"base_unit"`
+	Fractional bool            `json:"fractional"`
+	Units      []*UnitStockDto `json:"units"`
+	// UnitCost, Margin and MarginPercent are only shown to staff users
+	UnitCost      *float64        `json:"unit_cost,omitempty"`
+	Margin        *float64        `json:"margin,omitempty"`
+	MarginPercent *float64        `json:"margin_percent,omitempty"`
+	Attributes    []*AttributeDto `json:"attributes"`
+	Version       int             `json:"version"`
 }
 
 type ProductVariantPaginatedDto struct {
@@ -0,0 +1,30 @@
+package dtos
+
+import "time"
+
+type PromotionDto struct {
+	ID               int        `json:"id"`
+	Name             string     `json:"name"`
+	Code             *string    `json:"code"`
+	Kind             string     `json:"kind"`
+	Percent          *float64   `json:"percent"`
+	BuyQuantity      *int       `json:"buy_quantity"`
+	GetQuantity      *int       `json:"get_quantity"`
+	Amount           *float64   `json:"amount"`
+	MinSubtotal      float64    `json:"min_subtotal"`
+	CategoryID       *int       `json:"category_id"`
+	ProductID        *int       `json:"product_id"`
+	ProductVariantID *int       `json:"product_variant_id"`
+	AttributeID      *int       `json:"attribute_id"`
+	StartsAt         *time.Time `json:"starts_at"`
+	EndsAt           *time.Time `json:"ends_at"`
+	UsageLimit       *int       `json:"usage_limit"`
+	Used             int        `json:"used"`
+	Exclusive        bool       `json:"exclusive"`
+	Priority         int        `json:"priority"`
+}
+
+type PromotionPaginatedDto struct {
+	PaginationDto
+	Promotions []*PromotionDto `json:"promotions"`
+}
@@ -0,0 +1,35 @@
+package dtos
+
+type PromotionEvaluationDto struct {
+	Lines    []*PromotionEvaluationLineDto `json:"lines"`
+	Codes    []*PromotionCodeDto           `json:"codes"`
+	Subtotal float64                       `json:"subtotal"`
+	Discount float64                       `json:"discount"`
+	Total    float64                       `json:"total"`
+}
+
+// PromotionEvaluationLineDto Quantity is in the base unit of the variant and
+// UnitPrice is its current price per base unit.
+type PromotionEvaluationLineDto struct {
+	ProductVariantID int                     `json:"product_variant_id"`
+	Name             string                  `json:"name"`
+	Quantity         float64                 `json:"quantity"`
+	UnitPrice        float64                 `json:"unit_price"`
+	Subtotal         float64                 `json:"subtotal"`
+	Discounts        []*PromotionDiscountDto `json:"discounts"`
+	Total            float64                 `json:"total"`
+}
+
+type PromotionDiscountDto struct {
+	PromotionID int     `json:"promotion_id"`
+	Amount      float64 `json:"amount"`
+	Explanation string  `json:"explanation"`
+}
+
+type PromotionCodeDto struct {
+	Code string `json:"code"`
+	// Status is applied, not_applied, unknown, not_started, expired or
+	// exhausted
+	Status  string `json:"status"`
+	Message string `json:"message"`
+}
@@ -0,0 +1,35 @@
+package dtos
+
+import "time"
+
+type PurchaseOrderDto struct {
+	ID           int                     `json:"id"`
+	SupplierID   int                     `json:"supplier_id"`
+	SupplierName string                  `json:"supplier_name"`
+	Status       string                  `json:"status"`
+	Currency     string                  `json:"currency"`
+	ExpectedAt   *time.Time              `json:"expected_at"`
+	Note         *string                 `json:"note"`
+	CreatedBy    string                  `json:"created_by"`
+	SentAt       *time.Time              `json:"sent_at"`
+	ClosedAt     *time.Time              `json:"closed_at"`
+	Lines        []*PurchaseOrderLineDto `json:"lines,omitempty"`
+	// Total is the ordered value of the lines in the currency of the order
+	Total float64 `json:"total"`
+}
+
+type PurchaseOrderLineDto struct {
+	ProductVariantID int     `json:"product_variant_id"`
+	Name             string  `json:"name"`
+	SupplierSKU      *string `json:"supplier_sku"`
+	Quantity         float64 `json:"quantity"`
+	UnitCost         float64 `json:"unit_cost"`
+	Received         float64 `json:"received"`
+	// Outstanding is the quantity still to be received
+	Outstanding float64 `json:"outstanding"`
+}
+
+type PurchaseOrderPaginatedDto struct {
+	PaginationDto
+	PurchaseOrders []*PurchaseOrderDto `json:"purchase_orders"`
+}
@@ -0,0 +1,17 @@
+package dtos
+
+// ReceiveReturnAuthorizationDto lists what arrived of each line, lines which
+// are not listed arrived with nothing.
+type ReceiveReturnAuthorizationDto struct {
+	ReturnAuthorizationID int                                  `json:"-"`
+	Actor                 string                               `json:"-"`
+	Note                  *string                              `json:"note" validate:"omitempty,max=256"`
+	Lines                 []*ReceiveReturnAuthorizationLineDto `json:"lines" validate:"required,min=1,dive"`
+}
+
+type ReceiveReturnAuthorizationLineDto struct {
+	ProductVariantID int     `json:"product_variant_id" validate:"required,min=1"`
+	Quantity         float64 `json:"quantity" validate:"required,gt=0"`
+	// Unit is the unit of Quantity, the base unit of the variant if empty
+	Unit string `json:"unit" validate:"omitempty,max=16"`
+}
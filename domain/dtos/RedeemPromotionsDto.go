@@ -0,0 +1,10 @@
+package dtos
+
+// RedeemPromotionsDto redeems the promotions that apply to the lines of a
+// sales order.
+type RedeemPromotionsDto struct {
+	SalesOrderID int `json:"sales_order_id" validate:"required,min=1"`
+	// Codes are the coupon codes to apply on top of the automatic
+	// promotions
+	Codes []string `json:"codes" validate:"omitempty,max=10,dive,min=1,max=32"`
+}
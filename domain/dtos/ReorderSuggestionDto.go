@@ -0,0 +1,22 @@
+package dtos
+
+// ReorderSuggestionDto is a proposed purchase order, Supplier is nil for the
+// variants without a preferred supplier.
+type ReorderSuggestionDto struct {
+	Supplier *SupplierDto                `json:"supplier"`
+	Lines    []*ReorderSuggestionLineDto `json:"lines"`
+	// Total is the value of the lines at the cost of the supplier
+	Total float64 `json:"total"`
+}
+
+type ReorderSuggestionLineDto struct {
+	ProductVariantID int     `json:"product_variant_id"`
+	Name             string  `json:"name"`
+	Available        float64 `json:"available"`
+	// OnOrder is the quantity of open purchase orders not received yet
+	OnOrder      float64  `json:"on_order"`
+	ReorderPoint float64  `json:"reorder_point"`
+	Quantity     float64  `json:"quantity"`
+	SupplierSKU  *string  `json:"supplier_sku"`
+	UnitCost     *float64 `json:"unit_cost"`
+}
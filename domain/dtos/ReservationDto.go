@@ -0,0 +1,12 @@
+package dtos
+
+import "time"
+
+type ReservationDto struct {
+	ID               int       `json:"id"`
+	ProductVariantID int       `json:"product_variant_id"`
+	Quantity         float64   `json:"quantity"`
+	OwnerRef         string    `json:"owner_ref"`
+	Status           string    `json:"status"`
+	ExpiresAt        time.Time `json:"expires_at"`
+}
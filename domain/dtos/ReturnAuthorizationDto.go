@@ -0,0 +1,41 @@
+package dtos
+
+import "time"
+
+type ReturnAuthorizationDto struct {
+	ID           int                            `json:"id"`
+	Customer     string                         `json:"customer"`
+	SalesOrderID *int                           `json:"sales_order_id"`
+	Status       string                         `json:"status"`
+	Note         *string                        `json:"note"`
+	ApprovedAt   *time.Time                     `json:"approved_at"`
+	RejectedAt   *time.Time                     `json:"rejected_at"`
+	ReceivedAt   *time.Time                     `json:"received_at"`
+	InspectedAt  *time.Time                     `json:"inspected_at"`
+	Lines        []*ReturnAuthorizationLineDto  `json:"lines,omitempty"`
+	Events       []*ReturnAuthorizationEventDto `json:"events,omitempty"`
+}
+
+// ReturnAuthorizationLineDto quantities are in the base unit of the variant.
+// Only Restocked went back into stock.
+type ReturnAuthorizationLineDto struct {
+	ProductVariantID int     `json:"product_variant_id"`
+	Quantity         float64 `json:"quantity"`
+	Reason           string  `json:"reason"`
+	Received         float64 `json:"received"`
+	Restocked        float64 `json:"restocked"`
+	Refurbished      float64 `json:"refurbished"`
+	Scrapped         float64 `json:"scrapped"`
+}
+
+type ReturnAuthorizationEventDto struct {
+	Status    string    `json:"status"`
+	Actor     string    `json:"actor"`
+	Note      *string   `json:"note"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type ReturnAuthorizationPaginatedDto struct {
+	PaginationDto
+	ReturnAuthorizations []*ReturnAuthorizationDto `json:"return_authorizations"`
+}
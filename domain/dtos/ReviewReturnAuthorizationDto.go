@@ -0,0 +1,7 @@
+package dtos
+
+type ReviewReturnAuthorizationDto struct {
+	ReturnAuthorizationID int     `json:"-"`
+	Actor                 string  `json:"-"`
+	Note                  *string `json:"note" validate:"omitempty,max=256"`
+}
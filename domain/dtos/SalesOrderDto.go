@@ -0,0 +1,30 @@
+package dtos
+
+import "time"
+
+type SalesOrderDto struct {
+	ID          int                  `json:"id"`
+	Customer    string               `json:"customer"`
+	Status      string               `json:"status"`
+	Note        *string              `json:"note"`
+	PaidAt      *time.Time           `json:"paid_at"`
+	FulfilledAt *time.Time           `json:"fulfilled_at"`
+	CancelledAt *time.Time           `json:"cancelled_at"`
+	Lines       []*SalesOrderLineDto `json:"lines,omitempty"`
+	Total       float64              `json:"total"`
+}
+
+type SalesOrderLineDto struct {
+	ProductVariantID int    `json:"product_variant_id"`
+	Name             string `json:"name"`
+	// Quantity is in the base unit of the variant and UnitPrice is per base
+	// unit, both as of when the order was placed
+	Quantity  float64 `json:"quantity"`
+	UnitPrice float64 `json:"unit_price"`
+	Subtotal  float64 `json:"subtotal"`
+}
+
+type SalesOrderPaginatedDto struct {
+	PaginationDto
+	SalesOrders []*SalesOrderDto `json:"sales_orders"`
+}
@@ -0,0 +1,24 @@
+package dtos
+
+import "time"
+
+type SerialNumberDto struct {
+	ID               int     `json:"id"`
+	ProductVariantID int     `json:"product_variant_id"`
+	Serial           string  `json:"serial"`
+	Status           string  `json:"status"`
+	Note             *string `json:"note"`
+}
+
+type SerialNumberHistoryDto struct {
+	FromStatus *string   `json:"from_status"`
+	ToStatus   string    `json:"to_status"`
+	Note       *string   `json:"note"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+type SerialNumberLookupDto struct {
+	SerialNumberDto
+	ProductVariant *ProductVariantDto        `json:"product_variant"`
+	History        []*SerialNumberHistoryDto `json:"history"`
+}
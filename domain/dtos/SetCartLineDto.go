@@ -0,0 +1,10 @@
+package dtos
+
+type SetCartLineDto struct {
+	Owner            string  `json:"-"`
+	Token            string  `json:"-"`
+	ProductVariantID int     `json:"product_variant_id" validate:"required,min=1"`
+	Quantity         float64 `json:"quantity" validate:"required,gt=0"`
+	// Unit is the unit of Quantity, the base unit of the variant if empty
+	Unit string `json:"unit" validate:"omitempty,max=16"`
+}
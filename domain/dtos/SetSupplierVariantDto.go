@@ -0,0 +1,11 @@
+package dtos
+
+type SetSupplierVariantDto struct {
+	SupplierID       int     `json:"-"`
+	ProductVariantID int     `json:"-"`
+	SupplierSKU      *string `json:"supplier_sku"`
+	UnitCost         float64 `json:"unit_cost" validate:"min=0"`
+	// Preferred makes the supplier the one reorder suggestions order the
+	// variant from, replacing the previous preferred supplier
+	Preferred bool `json:"preferred"`
+}
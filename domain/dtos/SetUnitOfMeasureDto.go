@@ -0,0 +1,9 @@
+package dtos
+
+type SetUnitOfMeasureDto struct {
+	ProductID        int    `json:"-"`
+	ProductVariantID int    `json:"-"`
+	Name             string `json:"-" validate:"required,min=1,max=16"`
+	// Factor is the number of base units in one unit
+	Factor float64 `json:"factor" validate:"required,gt=0"`
+}
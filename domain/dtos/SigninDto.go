@@ -3,4 +3,7 @@ package dtos
 type SigninDto struct {
 	Username string `json:"username" validate:"required"`
 	Password string `json:"password" validate:"required"`
+	// CartToken is the token of an anonymous cart to merge into the cart of
+	// the user
+	CartToken string `json:"cart_token"`
 }
@@ -0,0 +1,19 @@
+package dtos
+
+// SnapshotImportDto counts what an import did with the rows of a snapshot,
+// Strategy is how rows named like existing ones were resolved.
+type SnapshotImportDto struct {
+	Strategy   string                  `json:"strategy"`
+	Categories *SnapshotImportCountDto `json:"categories"`
+	Attributes *SnapshotImportCountDto `json:"attributes"`
+	Images     *SnapshotImportCountDto `json:"images"`
+	Products   *SnapshotImportCountDto `json:"products"`
+	Variants   *SnapshotImportCountDto `json:"variants"`
+}
+
+type SnapshotImportCountDto struct {
+	Created int `json:"created"`
+	Updated int `json:"updated"`
+	Skipped int `json:"skipped"`
+	Renamed int `json:"renamed"`
+}
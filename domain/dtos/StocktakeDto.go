@@ -0,0 +1,31 @@
+package dtos
+
+import "time"
+
+type StocktakeDto struct {
+	ID         int                 `json:"id"`
+	CategoryID *int                `json:"category_id"`
+	Status     string              `json:"status"`
+	CreatedBy  string              `json:"created_by"`
+	ApprovedBy *string             `json:"approved_by"`
+	ApprovedAt *time.Time          `json:"approved_at"`
+	Lines      []*StocktakeLineDto `json:"lines"`
+	// CountedLines is the number of lines with at least one count
+	CountedLines int `json:"counted_lines"`
+	// TotalVariance is the sum of the variances of the counted lines
+	TotalVariance float64 `json:"total_variance"`
+}
+
+type StocktakeLineDto struct {
+	ProductVariantID int    `json:"product_variant_id"`
+	Name             string `json:"name"`
+	// Stock is the current stock of the variant
+	Stock float64 `json:"stock"`
+	// Expected is the stock of the variant when the session was opened
+	Expected float64  `json:"expected"`
+	Counted  *float64 `json:"counted"`
+	// Variance is counted - expected, nil until the line is counted
+	Variance *float64 `json:"variance"`
+	// Adjustment is the change posted onto the stock on approval
+	Adjustment *float64 `json:"adjustment"`
+}
@@ -0,0 +1,10 @@
+package dtos
+
+// StorageMigrationDto counts the files a migration copied between storage
+// backends and those the target already had.
+type StorageMigrationDto struct {
+	From    string `json:"from"`
+	To      string `json:"to"`
+	Copied  int    `json:"copied"`
+	Skipped int    `json:"skipped"`
+}
@@ -0,0 +1,25 @@
+package dtos
+
+type SupplierDto struct {
+	ID           int     `json:"id"`
+	Name         string  `json:"name"`
+	ContactName  *string `json:"contact_name"`
+	Email        *string `json:"email"`
+	Phone        *string `json:"phone"`
+	LeadTimeDays int     `json:"lead_time_days"`
+	Currency     string  `json:"currency"`
+}
+
+type SupplierPaginatedDto struct {
+	PaginationDto
+	Suppliers []*SupplierDto `json:"suppliers"`
+}
+
+type SupplierVariantDto struct {
+	SupplierID       int     `json:"supplier_id"`
+	ProductVariantID int     `json:"product_variant_id"`
+	Name             string  `json:"name"`
+	SupplierSKU      *string `json:"supplier_sku"`
+	UnitCost         float64 `json:"unit_cost"`
+	Preferred        bool    `json:"preferred"`
+}
@@ -0,0 +1,34 @@
+package dtos
+
+import "time"
+
+type TransferOrderDto struct {
+	ID                       int                     `json:"id"`
+	SourceWarehouseID        int                     `json:"source_warehouse_id"`
+	SourceWarehouseCode      string                  `json:"source_warehouse_code"`
+	DestinationWarehouseID   int                     `json:"destination_warehouse_id"`
+	DestinationWarehouseCode string                  `json:"destination_warehouse_code"`
+	Status                   string                  `json:"status"`
+	Note                     *string                 `json:"note"`
+	CreatedBy                string                  `json:"created_by"`
+	DispatchedBy             *string                 `json:"dispatched_by"`
+	DispatchedAt             *time.Time              `json:"dispatched_at"`
+	ClosedAt                 *time.Time              `json:"closed_at"`
+	Lines                    []*TransferOrderLineDto `json:"lines,omitempty"`
+}
+
+type TransferOrderLineDto struct {
+	ProductVariantID int     `json:"product_variant_id"`
+	Name             string  `json:"name"`
+	Quantity         float64 `json:"quantity"`
+	Received         float64 `json:"received"`
+	// Lost is what never arrived when the transfer was closed
+	Lost float64 `json:"lost"`
+	// InTransit is the quantity still to be received
+	InTransit float64 `json:"in_transit"`
+}
+
+type TransferOrderPaginatedDto struct {
+	PaginationDto
+	TransferOrders []*TransferOrderDto `json:"transfer_orders"`
+}
@@ -0,0 +1,15 @@
+package dtos
+
+type UnitOfMeasureDto struct {
+	Name   string  `json:"name"`
+	Factor float64 `json:"factor"`
+}
+
+// UnitStockDto is the stock of a variant expressed in one of its alternative
+// units.
+type UnitStockDto struct {
+	Name      string  `json:"name"`
+	Factor    float64 `json:"factor"`
+	Stock     float64 `json:"stock"`
+	Available float64 `json:"available"`
+}
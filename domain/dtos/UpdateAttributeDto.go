@@ -4,4 +4,7 @@ type UpdateAttributeDto struct {
 	ID   int    `json:"id"`
 	Name string `json:"name"`
 	Type string `json:"type"`
+	// Versions are the row versions expected by the If-Match header, any of
+	// them matches
+	Versions []int `json:"-"`
 }
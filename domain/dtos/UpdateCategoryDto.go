@@ -1,7 +1,12 @@
 package dtos
 
 type UpdateCategoryDto struct {
-	ID          int    `json:"id" validate:"required"`
-	Name        string `json:"name" validate:"required,min=2,max=32"`
-	Description string `json:"description"`
+	ID              int      `json:"id" validate:"required"`
+	Name            string   `json:"name" validate:"required,min=2,max=32"`
+	Description     string   `json:"description"`
+	ReorderPoint    *float64 `json:"reorder_point" validate:"omitempty,min=0"`
+	ReorderQuantity *float64 `json:"reorder_quantity" validate:"omitempty,gt=0"`
+	// Versions are the row versions expected by the If-Match header, any of
+	// them matches
+	Versions []int `json:"-"`
 }
@@ -5,4 +5,7 @@ type UpdateProductDto struct {
 	Name        string `json:"name" validate:"required,min=2,max=16"`
 	Description string `json:"description"`
 	CategoryID  int    `json:"category_id" validate:"required,number"`
+	// Versions are the row versions expected by the If-Match header, any of
+	// them matches
+	Versions []int `json:"-"`
 }
@@ -1,9 +1,20 @@
 package dtos
 
 type UpdateProductVariantDto struct {
-	ID        int     `json:"id" validate:"required"`
-	Name      string  `json:"name" validate:"required,min=2,max=16"`
-	ProductId int     `json:"product_id" validate:"required,number"`
-	Price     float64 `json:"price" validate:"required,number"`
-	Stock     int     `json:"stock" validate:"required,number"`
+	ID              int      `json:"id" validate:"required"`
+	Name            string   `json:"name" validate:"required,min=2,max=16"`
+	ProductId       int      `json:"product_id" validate:"required,number"`
+	Price           float64  `json:"price" validate:"required,number"`
+	Stock           float64  `json:"stock" validate:"required,number"`
+	ReorderPoint    *float64 `json:"reorder_point" validate:"omitempty,min=0"`
+	ReorderQuantity *float64 `json:"reorder_quantity" validate:"omitempty,gt=0"`
+	// StockUnit is the unit of Stock, the base unit if empty
+	StockUnit string `json:"stock_unit" validate:"omitempty,max=16"`
+	// Serialized, BaseUnit and Fractional are kept if omitted
+	Serialized *bool  `json:"serialized"`
+	BaseUnit   string `json:"base_unit" validate:"omitempty,max=16"`
+	Fractional *bool  `json:"fractional"`
+	// Versions are the row versions expected by the If-Match header, any of
+	// them matches
+	Versions []int `json:"-"`
 }
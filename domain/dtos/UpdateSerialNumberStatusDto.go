@@ -0,0 +1,7 @@
+package dtos
+
+type UpdateSerialNumberStatusDto struct {
+	Serial string  `json:"serial" validate:"required,min=1,max=128"`
+	Status string  `json:"status" validate:"required,oneof=in_stock reserved sold returned scrapped"`
+	Note   *string `json:"note" validate:"omitempty,max=256"`
+}
@@ -0,0 +1,7 @@
+package dtos
+
+type UpdateWarehouseDto struct {
+	ID   int    `json:"id" validate:"required"`
+	Code string `json:"code" validate:"required,min=1,max=16"`
+	Name string `json:"name" validate:"required,min=2,max=64"`
+}
@@ -0,0 +1,28 @@
+package dtos
+
+import "time"
+
+type ValuationDto struct {
+	Method     string                  `json:"method"`
+	AsOf       time.Time               `json:"as_of"`
+	Quantity   float64                 `json:"quantity"`
+	Value      float64                 `json:"value"`
+	Categories []*CategoryValuationDto `json:"categories"`
+}
+
+type CategoryValuationDto struct {
+	ID       int                    `json:"id"`
+	Name     string                 `json:"name"`
+	Quantity float64                `json:"quantity"`
+	Value    float64                `json:"value"`
+	Variants []*VariantValuationDto `json:"variants"`
+}
+
+type VariantValuationDto struct {
+	ID        int     `json:"id"`
+	Name      string  `json:"name"`
+	ProductID int     `json:"product_id"`
+	Quantity  float64 `json:"quantity"`
+	Value     float64 `json:"value"`
+	UnitCost  float64 `json:"unit_cost"`
+}
@@ -0,0 +1,25 @@
+package dtos
+
+type WarehouseDto struct {
+	ID        int    `json:"id"`
+	Code      string `json:"code"`
+	Name      string `json:"name"`
+	IsDefault bool   `json:"is_default"`
+}
+
+type VariantStockDto struct {
+	ProductVariantID int     `json:"product_variant_id"`
+	Name             string  `json:"name"`
+	Stock            float64 `json:"stock"`
+	// InTransit is dispatched from a warehouse but not yet received at
+	// another, it is part of Stock
+	InTransit  float64              `json:"in_transit"`
+	Warehouses []*WarehouseStockDto `json:"warehouses"`
+}
+
+type WarehouseStockDto struct {
+	WarehouseID int     `json:"warehouse_id"`
+	Code        string  `json:"code"`
+	Name        string  `json:"name"`
+	Quantity    float64 `json:"quantity"`
+}
@@ -1,9 +1,10 @@
 package entities
 
 type Attribute struct {
-	ID   int    `json:"id"`
-	Name string `json:"name"`
-	Type string `json:"type"`
+	ID      int    `json:"id"`
+	Name    string `json:"name"`
+	Type    string `json:"type"`
+	Version int    `json:"version"`
 	Timestamps
 }
 
@@ -0,0 +1,24 @@
+package entities
+
+import "time"
+
+type Cart struct {
+	ID        int         `json:"id"`
+	Token     string      `json:"token"`
+	Owner     *string     `json:"owner"`
+	ExpiresAt time.Time   `json:"expires_at"`
+	Lines     []*CartLine `json:"lines"`
+	Timestamps
+}
+
+// CartLine is a line of a cart with the current price and available stock of
+// its variant.
+type CartLine struct {
+	ProductVariantID int     `json:"product_variant_id"`
+	ProductID        int     `json:"product_id"`
+	Name             string  `json:"name"`
+	BaseUnit         string  `json:"base_unit"`
+	Quantity         float64 `json:"quantity"`
+	Price            float64 `json:"price"`
+	Available        float64 `json:"available"`
+}
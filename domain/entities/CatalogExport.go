@@ -0,0 +1,16 @@
+package entities
+
+// CatalogExportRow is a variant with its product, category, attributes and
+// the images of its product, the row of a catalog import.
+type CatalogExportRow struct {
+	ProductID   int
+	Product     string
+	Description string
+	Category    string
+	VariantID   int
+	Variant     string
+	Price       float64
+	Stock       float64
+	Attributes  []*Attribute
+	Images      []string
+}
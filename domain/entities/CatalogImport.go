@@ -0,0 +1,49 @@
+package entities
+
+// CatalogImport is the outcome of importing a catalog file. Created and
+// Updated count variants, Failed counts rows.
+type CatalogImport struct {
+	ID        int                   `json:"id"`
+	FileName  string                `json:"file_name"`
+	DryRun    bool                  `json:"dry_run"`
+	Rows      int                   `json:"rows"`
+	Created   int                   `json:"created"`
+	Updated   int                   `json:"updated"`
+	Failed    int                   `json:"failed"`
+	Errors    []*CatalogImportError `json:"errors"`
+	CreatedBy string                `json:"created_by"`
+	Timestamps
+}
+
+// CatalogImportError is an error of a row, an empty Column means the whole
+// row.
+type CatalogImportError struct {
+	Line    int    `json:"line"`
+	Column  string `json:"column"`
+	Message string `json:"message"`
+}
+
+// CatalogImportRow is a row of a catalog file, a variant with its product,
+// category, attributes and images. A nil Stock keeps the stock of an
+// existing variant.
+type CatalogImportRow struct {
+	Line        int
+	Product     string
+	Description string
+	Category    string
+	Variant     string
+	Price       float64
+	Stock       *float64
+	Attributes  []*Attribute
+	Images      []string
+}
+
+// CatalogImportPlan is what importing a row does to its product and variant,
+// create or update.
+type CatalogImportPlan struct {
+	Line          int    `json:"line"`
+	Product       string `json:"product"`
+	ProductAction string `json:"product_action"`
+	Variant       string `json:"variant"`
+	VariantAction string `json:"variant_action"`
+}
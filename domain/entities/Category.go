@@ -1,9 +1,12 @@
 package entities
 
 type Category struct {
-	ID          int    `json:"id"`
-	Name        string `json:"name"`
-	Description string `json:"description"`
+	ID              int      `json:"id"`
+	Name            string   `json:"name"`
+	Description     string   `json:"description"`
+	ReorderPoint    *float64 `json:"reorder_point"`
+	ReorderQuantity *float64 `json:"reorder_quantity"`
+	Version         int      `json:"version"`
 	Timestamps
 }
 
@@ -0,0 +1,16 @@
+package entities
+
+// FeedItem is a variant with its product as listed in a product feed.
+// Available is the stock less the reservations, in the base unit.
+type FeedItem struct {
+	ProductID   int
+	Product     string
+	Description string
+	Category    string
+	VariantID   int
+	Variant     string
+	Price       float64
+	Available   float64
+	Attributes  []*Attribute
+	ImageURLs   []string
+}
@@ -1,9 +1,18 @@
 package entities
 
 type Image struct {
-	ID           int    `json:"id"`
-	Name         string `json:"name"`
-	ImageUrl     string `json:"image_url"`
-	ThumbnailUrl string `json:"thumbnail_url"`
+	ID           int               `json:"id"`
+	Name         string            `json:"name"`
+	ImageUrl     string            `json:"image_url"`
+	ThumbnailUrl string            `json:"thumbnail_url"`
+	Renditions   []*ImageRendition `json:"renditions"`
 	Timestamps
 }
+
+type ImageRendition struct {
+	Name   string `json:"name"`
+	Format string `json:"format"`
+	Url    string `json:"url"`
+	Width  int    `json:"width"`
+	Height int    `json:"height"`
+}
@@ -0,0 +1,28 @@
+package entities
+
+import "time"
+
+type Lot struct {
+	ID               int        `json:"id"`
+	ProductVariantID int        `json:"product_variant_id"`
+	LotNumber        string     `json:"lot_number"`
+	ExpiresAt        *time.Time `json:"expires_at"`
+	ManufacturedAt   *time.Time `json:"manufactured_at"`
+	Quantity         float64    `json:"quantity"`
+	Timestamps
+}
+
+type ExpiringLot struct {
+	ID             int             `json:"id"`
+	LotNumber      string          `json:"lot_number"`
+	ExpiresAt      time.Time       `json:"expires_at"`
+	DaysLeft       int             `json:"days_left"`
+	Quantity       float64         `json:"quantity"`
+	ProductVariant *ProductVariant `json:"product_variant"`
+	Product        *Product        `json:"product"`
+}
+
+type ExpiringLotPaginated struct {
+	Pagination
+	Lots []*ExpiringLot `json:"lots"`
+}
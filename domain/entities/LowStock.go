@@ -0,0 +1,28 @@
+package entities
+
+type LowStockVariant struct {
+	ID              int       `json:"id"`
+	Name            string    `json:"name"`
+	Stock           float64   `json:"stock"`
+	Reserved        float64   `json:"reserved"`
+	ReorderPoint    float64   `json:"reorder_point"`
+	ReorderQuantity *float64  `json:"reorder_quantity"`
+	Product         *Product  `json:"product"`
+	Category        *Category `json:"category"`
+}
+
+type LowStockPaginated struct {
+	Pagination
+	Variants []*LowStockVariant `json:"variants"`
+}
+
+type LowStockEvent struct {
+	ProductVariantID int      `json:"product_variant_id"`
+	ProductID        int      `json:"product_id"`
+	Name             string   `json:"name"`
+	Stock            float64  `json:"stock"`
+	Reserved         float64  `json:"reserved"`
+	Available        float64  `json:"available"`
+	ReorderPoint     float64  `json:"reorder_point"`
+	ReorderQuantity  *float64 `json:"reorder_quantity"`
+}
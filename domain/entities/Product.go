@@ -8,6 +8,7 @@ type Product struct {
 	Category        *Category         `json:"category"`
 	Images          []*Image          `json:"images"`
 	ProductVariants []*ProductVariant `json:"product_variants"`
+	Version         int               `json:"version"`
 	Timestamps
 }
 
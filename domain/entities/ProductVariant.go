@@ -1,13 +1,22 @@
 package entities
 
 type ProductVariant struct {
-	ID         int          `json:"id"`
-	Name       string       `json:"name"`
-	ProductId  int          `json:"product_id"`
-	Product    *Product     `json:"product"`
-	Price      float64      `json:"price"`
-	Stock      int          `json:"stock"`
-	Attributes []*Attribute `json:"attributes"`
+	ID              int              `json:"id"`
+	Name            string           `json:"name"`
+	ProductId       int              `json:"product_id"`
+	Product         *Product         `json:"product"`
+	Price           float64          `json:"price"`
+	Stock           float64          `json:"stock"`
+	Reserved        float64          `json:"reserved"`
+	ReorderPoint    *float64         `json:"reorder_point"`
+	ReorderQuantity *float64         `json:"reorder_quantity"`
+	Serialized      bool             `json:"serialized"`
+	BaseUnit        string           `json:"base_unit"`
+	Fractional      bool             `json:"fractional"`
+	Units           []*UnitOfMeasure `json:"units"`
+	UnitCost        *float64         `json:"unit_cost"`
+	Attributes      []*Attribute     `json:"attributes"`
+	Version         int              `json:"version"`
 	Timestamps
 }
 
@@ -0,0 +1,43 @@
+package entities
+
+import "time"
+
+type Promotion struct {
+	ID               int        `json:"id"`
+	Name             string     `json:"name"`
+	Code             *string    `json:"code"`
+	Kind             string     `json:"kind"`
+	Percent          *float64   `json:"percent"`
+	BuyQuantity      *int       `json:"buy_quantity"`
+	GetQuantity      *int       `json:"get_quantity"`
+	Amount           *float64   `json:"amount"`
+	MinSubtotal      float64    `json:"min_subtotal"`
+	CategoryID       *int       `json:"category_id"`
+	ProductID        *int       `json:"product_id"`
+	ProductVariantID *int       `json:"product_variant_id"`
+	AttributeID      *int       `json:"attribute_id"`
+	StartsAt         *time.Time `json:"starts_at"`
+	EndsAt           *time.Time `json:"ends_at"`
+	UsageLimit       *int       `json:"usage_limit"`
+	Used             int        `json:"used"`
+	Exclusive        bool       `json:"exclusive"`
+	Priority         int        `json:"priority"`
+	Timestamps
+}
+
+type PromotionPaginated struct {
+	Pagination
+	Promotions []*Promotion `json:"promotions"`
+}
+
+// PromotionLine is a line to evaluate promotions on, with what the
+// promotions target and the current price of its variant.
+type PromotionLine struct {
+	ProductVariantID int     `json:"product_variant_id"`
+	ProductID        int     `json:"product_id"`
+	CategoryID       int     `json:"category_id"`
+	AttributeIDs     []int   `json:"attribute_ids"`
+	Name             string  `json:"name"`
+	Quantity         float64 `json:"quantity"`
+	UnitPrice        float64 `json:"unit_price"`
+}
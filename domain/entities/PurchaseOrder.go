@@ -0,0 +1,51 @@
+package entities
+
+import "time"
+
+type PurchaseOrder struct {
+	ID           int                  `json:"id"`
+	SupplierID   int                  `json:"supplier_id"`
+	SupplierName string               `json:"supplier_name"`
+	Status       string               `json:"status"`
+	Currency     string               `json:"currency"`
+	ExpectedAt   *time.Time           `json:"expected_at"`
+	Note         *string              `json:"note"`
+	CreatedBy    string               `json:"created_by"`
+	SentAt       *time.Time           `json:"sent_at"`
+	ClosedAt     *time.Time           `json:"closed_at"`
+	Total        float64              `json:"total"`
+	Lines        []*PurchaseOrderLine `json:"lines"`
+	Timestamps
+}
+
+type PurchaseOrderLine struct {
+	ID               int     `json:"id"`
+	ProductVariantID int     `json:"product_variant_id"`
+	Name             string  `json:"name"`
+	SupplierSKU      *string `json:"supplier_sku"`
+	Quantity         float64 `json:"quantity"`
+	UnitCost         float64 `json:"unit_cost"`
+	Received         float64 `json:"received"`
+}
+
+type PurchaseOrderPaginated struct {
+	Pagination
+	PurchaseOrders []*PurchaseOrder `json:"purchase_orders"`
+}
+
+// ReorderSuggestion is a variant at or below its reorder point together with
+// its preferred supplier, the supplier fields are nil if it has none.
+type ReorderSuggestion struct {
+	ProductVariantID int      `json:"product_variant_id"`
+	Name             string   `json:"name"`
+	Available        float64  `json:"available"`
+	OnOrder          float64  `json:"on_order"`
+	ReorderPoint     float64  `json:"reorder_point"`
+	ReorderQuantity  *float64 `json:"reorder_quantity"`
+	SupplierID       *int     `json:"supplier_id"`
+	SupplierName     *string  `json:"supplier_name"`
+	Currency         *string  `json:"currency"`
+	LeadTimeDays     *int     `json:"lead_time_days"`
+	SupplierSKU      *string  `json:"supplier_sku"`
+	UnitCost         *float64 `json:"unit_cost"`
+}
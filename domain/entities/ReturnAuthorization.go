@@ -0,0 +1,41 @@
+package entities
+
+import "time"
+
+type ReturnAuthorization struct {
+	ID           int                         `json:"id"`
+	Customer     string                      `json:"customer"`
+	SalesOrderID *int                        `json:"sales_order_id"`
+	Status       string                      `json:"status"`
+	Note         *string                     `json:"note"`
+	ApprovedAt   *time.Time                  `json:"approved_at"`
+	RejectedAt   *time.Time                  `json:"rejected_at"`
+	ReceivedAt   *time.Time                  `json:"received_at"`
+	InspectedAt  *time.Time                  `json:"inspected_at"`
+	Lines        []*ReturnAuthorizationLine  `json:"lines"`
+	Events       []*ReturnAuthorizationEvent `json:"events"`
+	Timestamps
+}
+
+type ReturnAuthorizationLine struct {
+	ID               int     `json:"id"`
+	ProductVariantID int     `json:"product_variant_id"`
+	Quantity         float64 `json:"quantity"`
+	Reason           string  `json:"reason"`
+	Received         float64 `json:"received"`
+	Restocked        float64 `json:"restocked"`
+	Refurbished      float64 `json:"refurbished"`
+	Scrapped         float64 `json:"scrapped"`
+}
+
+type ReturnAuthorizationEvent struct {
+	Status    string    `json:"status"`
+	Actor     string    `json:"actor"`
+	Note      *string   `json:"note"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type ReturnAuthorizationPaginated struct {
+	Pagination
+	ReturnAuthorizations []*ReturnAuthorization `json:"return_authorizations"`
+}
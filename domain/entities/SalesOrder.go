@@ -0,0 +1,29 @@
+package entities
+
+import "time"
+
+type SalesOrder struct {
+	ID          int               `json:"id"`
+	Customer    string            `json:"customer"`
+	Status      string            `json:"status"`
+	Note        *string           `json:"note"`
+	PaidAt      *time.Time        `json:"paid_at"`
+	FulfilledAt *time.Time        `json:"fulfilled_at"`
+	CancelledAt *time.Time        `json:"cancelled_at"`
+	Total       float64           `json:"total"`
+	Lines       []*SalesOrderLine `json:"lines"`
+	Timestamps
+}
+
+type SalesOrderLine struct {
+	ID               int     `json:"id"`
+	ProductVariantID int     `json:"product_variant_id"`
+	Name             string  `json:"name"`
+	Quantity         float64 `json:"quantity"`
+	UnitPrice        float64 `json:"unit_price"`
+}
+
+type SalesOrderPaginated struct {
+	Pagination
+	SalesOrders []*SalesOrder `json:"sales_orders"`
+}
@@ -0,0 +1,26 @@
+package entities
+
+import "time"
+
+type SerialNumber struct {
+	ID               int     `json:"id"`
+	ProductVariantID int     `json:"product_variant_id"`
+	Serial           string  `json:"serial"`
+	Status           string  `json:"status"`
+	Note             *string `json:"note"`
+	Timestamps
+}
+
+type SerialNumberHistory struct {
+	ID         int       `json:"id"`
+	FromStatus *string   `json:"from_status"`
+	ToStatus   string    `json:"to_status"`
+	Note       *string   `json:"note"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+type SerialNumberLookup struct {
+	SerialNumber
+	ProductVariant *ProductVariant        `json:"product_variant"`
+	History        []*SerialNumberHistory `json:"history"`
+}
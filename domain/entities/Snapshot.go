@@ -0,0 +1,79 @@
+package entities
+
+// Snapshot is a whole catalog, the documents of a snapshot archive. The ids
+// are those of the exporting database, relations refer to them.
+type Snapshot struct {
+	Categories []*SnapshotCategory
+	Attributes []*SnapshotAttribute
+	Images     []*SnapshotImage
+	Products   []*SnapshotProduct
+}
+
+type SnapshotCategory struct {
+	ID              int      `json:"id"`
+	Name            string   `json:"name"`
+	Description     string   `json:"description"`
+	ReorderPoint    *float64 `json:"reorder_point"`
+	ReorderQuantity *float64 `json:"reorder_quantity"`
+}
+
+type SnapshotAttribute struct {
+	ID   int    `json:"id"`
+	Type string `json:"type"`
+	Name string `json:"name"`
+}
+
+// SnapshotImage is an image, File and ThumbnailFile name its files in the
+// archive. An image without files keeps its urls.
+type SnapshotImage struct {
+	ID            int    `json:"id"`
+	Name          string `json:"name"`
+	ImageUrl      string `json:"image_url"`
+	ThumbnailUrl  string `json:"thumbnail_url"`
+	File          string `json:"file,omitempty"`
+	ThumbnailFile string `json:"thumbnail_file,omitempty"`
+}
+
+type SnapshotProduct struct {
+	ID          int                       `json:"id"`
+	Name        string                    `json:"name"`
+	Description string                    `json:"description"`
+	CategoryID  int                       `json:"category_id"`
+	ImageIDs    []int                     `json:"image_ids"`
+	Variants    []*SnapshotProductVariant `json:"variants"`
+}
+
+type SnapshotProductVariant struct {
+	ID              int      `json:"id"`
+	Name            string   `json:"name"`
+	Price           float64  `json:"price"`
+	Stock           float64  `json:"stock"`
+	ReorderPoint    *float64 `json:"reorder_point"`
+	ReorderQuantity *float64 `json:"reorder_quantity"`
+	Serialized      bool     `json:"serialized"`
+	BaseUnit        string   `json:"base_unit"`
+	Fractional      bool     `json:"fractional"`
+	AttributeIDs    []int    `json:"attribute_ids"`
+}
+
+// SnapshotImport is the outcome of importing a snapshot. ImageUrls are the
+// urls of the images it created or pointed to new files, files imported for
+// other images are not used. Replaced are the images it overwrote, with the
+// files they had before.
+type SnapshotImport struct {
+	Strategy   string
+	Categories SnapshotImportCount
+	Attributes SnapshotImportCount
+	Images     SnapshotImportCount
+	Products   SnapshotImportCount
+	Variants   SnapshotImportCount
+	ImageUrls  []string
+	Replaced   []*Image
+}
+
+type SnapshotImportCount struct {
+	Created int
+	Updated int
+	Skipped int
+	Renamed int
+}
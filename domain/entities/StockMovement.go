@@ -0,0 +1,21 @@
+package entities
+
+type StockMovement struct {
+	ID               int      `json:"id"`
+	ProductVariantID int      `json:"product_variant_id"`
+	Quantity         float64  `json:"quantity"`
+	UnitCost         *float64 `json:"unit_cost"`
+	Reason           string   `json:"reason"`
+	Timestamps
+}
+
+// VariantMovements are the stock movements of a variant in the order they
+// happened.
+type VariantMovements struct {
+	ProductVariantID int              `json:"product_variant_id"`
+	Name             string           `json:"name"`
+	ProductID        int              `json:"product_id"`
+	CategoryID       int              `json:"category_id"`
+	CategoryName     string           `json:"category_name"`
+	Movements        []*StockMovement `json:"movements"`
+}
@@ -0,0 +1,24 @@
+package entities
+
+import "time"
+
+type Stocktake struct {
+	ID         int              `json:"id"`
+	CategoryID *int             `json:"category_id"`
+	Status     string           `json:"status"`
+	CreatedBy  string           `json:"created_by"`
+	ApprovedBy *string          `json:"approved_by"`
+	ApprovedAt *time.Time       `json:"approved_at"`
+	Lines      []*StocktakeLine `json:"lines"`
+	Timestamps
+}
+
+type StocktakeLine struct {
+	ID               int      `json:"id"`
+	ProductVariantID int      `json:"product_variant_id"`
+	Name             string   `json:"name"`
+	Stock            float64  `json:"stock"`
+	Expected         float64  `json:"expected"`
+	Counted          *float64 `json:"counted"`
+	Adjustment       *float64 `json:"adjustment"`
+}
@@ -0,0 +1,27 @@
+package entities
+
+type Supplier struct {
+	ID           int     `json:"id"`
+	Name         string  `json:"name"`
+	ContactName  *string `json:"contact_name"`
+	Email        *string `json:"email"`
+	Phone        *string `json:"phone"`
+	LeadTimeDays int     `json:"lead_time_days"`
+	Currency     string  `json:"currency"`
+	Timestamps
+}
+
+type SupplierPaginated struct {
+	Pagination
+	Suppliers []*Supplier `json:"suppliers"`
+}
+
+type SupplierVariant struct {
+	ID               int     `json:"id"`
+	SupplierID       int     `json:"supplier_id"`
+	ProductVariantID int     `json:"product_variant_id"`
+	Name             string  `json:"name"`
+	SupplierSKU      *string `json:"supplier_sku"`
+	UnitCost         float64 `json:"unit_cost"`
+	Preferred        bool    `json:"preferred"`
+}
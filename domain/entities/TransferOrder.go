@@ -0,0 +1,33 @@
+package entities
+
+import "time"
+
+type TransferOrder struct {
+	ID                       int                  `json:"id"`
+	SourceWarehouseID        int                  `json:"source_warehouse_id"`
+	SourceWarehouseCode      string               `json:"source_warehouse_code"`
+	DestinationWarehouseID   int                  `json:"destination_warehouse_id"`
+	DestinationWarehouseCode string               `json:"destination_warehouse_code"`
+	Status                   string               `json:"status"`
+	Note                     *string              `json:"note"`
+	CreatedBy                string               `json:"created_by"`
+	DispatchedBy             *string              `json:"dispatched_by"`
+	DispatchedAt             *time.Time           `json:"dispatched_at"`
+	ClosedAt                 *time.Time           `json:"closed_at"`
+	Lines                    []*TransferOrderLine `json:"lines"`
+	Timestamps
+}
+
+type TransferOrderLine struct {
+	ID               int     `json:"id"`
+	ProductVariantID int     `json:"product_variant_id"`
+	Name             string  `json:"name"`
+	Quantity         float64 `json:"quantity"`
+	Received         float64 `json:"received"`
+	Lost             float64 `json:"lost"`
+}
+
+type TransferOrderPaginated struct {
+	Pagination
+	TransferOrders []*TransferOrder `json:"transfer_orders"`
+}
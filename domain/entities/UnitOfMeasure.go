@@ -0,0 +1,11 @@
+package entities
+
+// UnitOfMeasure is an alternative unit of a variant, one unit is Factor base
+// units.
+type UnitOfMeasure struct {
+	ID               int     `json:"id"`
+	ProductVariantID int     `json:"product_variant_id"`
+	Name             string  `json:"name"`
+	Factor           float64 `json:"factor"`
+	Timestamps
+}
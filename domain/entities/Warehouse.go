@@ -0,0 +1,26 @@
+package entities
+
+type Warehouse struct {
+	ID        int    `json:"id"`
+	Code      string `json:"code"`
+	Name      string `json:"name"`
+	IsDefault bool   `json:"is_default"`
+	Timestamps
+}
+
+// VariantStock is the stock of a variant broken down by warehouse, what was
+// dispatched but not yet received or written off is in transit.
+type VariantStock struct {
+	ProductVariantID int               `json:"product_variant_id"`
+	Name             string            `json:"name"`
+	Stock            float64           `json:"stock"`
+	InTransit        float64           `json:"in_transit"`
+	Warehouses       []*WarehouseStock `json:"warehouses"`
+}
+
+type WarehouseStock struct {
+	WarehouseID int     `json:"warehouse_id"`
+	Code        string  `json:"code"`
+	Name        string  `json:"name"`
+	Quantity    float64 `json:"quantity"`
+}
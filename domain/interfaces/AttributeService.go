@@ -11,6 +11,6 @@ type IAttributeService interface {
 	GetByID(ctx context.Context, id int) (res *dtos.AttributeDto, err error)
 	Update(ctx context.Context, dto *dtos.UpdateAttributeDto) error
 	Create(ctx context.Context, dto *dtos.CreateAttributeDto) error
-	Delete(ctx context.Context, id int) error
+	Delete(ctx context.Context, id int, versions []int) error
 	Search(ctx context.Context, q string, page int, size int, sortBy string, orderBy string) (*dtos.AttributePaginatedDto, error)
 }
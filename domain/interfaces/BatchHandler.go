@@ -0,0 +1,7 @@
+package interfaces
+
+import "github.com/gofiber/fiber/v2"
+
+type IBatchHandler interface {
+	Execute(c *fiber.Ctx) error
+}
@@ -0,0 +1,9 @@
+package interfaces
+
+import "context"
+
+type IBatchRepository interface {
+	// Transaction calls fn with the catalog repositories on one transaction,
+	// it is committed if fn returns nil and rolled back otherwise.
+	Transaction(ctx context.Context, fn func(categories ICategoryRepository, products IProductRepository, attributes IAttributeRepository) error) error
+}
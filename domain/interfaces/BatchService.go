@@ -0,0 +1,11 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/ysfada/product-management-system/domain/dtos"
+)
+
+type IBatchService interface {
+	Execute(ctx context.Context, dto *dtos.BatchDto) (*dtos.BatchResultDto, error)
+}
@@ -0,0 +1,12 @@
+package interfaces
+
+import "github.com/gofiber/fiber/v2"
+
+type ICartHandler interface {
+	Get(c *fiber.Ctx) error
+	AddLine(c *fiber.Ctx) error
+	UpdateLine(c *fiber.Ctx) error
+	RemoveLine(c *fiber.Ctx) error
+	Delete(c *fiber.Ctx) error
+	Merge(c *fiber.Ctx) error
+}
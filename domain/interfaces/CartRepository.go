@@ -0,0 +1,18 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+
+	"github.com/ysfada/product-management-system/domain/entities"
+)
+
+type ICartRepository interface {
+	Get(ctx context.Context, owner string, token string) (*entities.Cart, error)
+	Create(ctx context.Context, owner string, token string, expiresAt time.Time) (int, error)
+	SetLine(ctx context.Context, id int, variantID int, quantity float64, unit string, add bool, expiresAt time.Time) error
+	RemoveLine(ctx context.Context, id int, variantID int, expiresAt time.Time) error
+	Delete(ctx context.Context, id int) error
+	Merge(ctx context.Context, token string, owner string, expiresAt time.Time) error
+	DeleteExpired(ctx context.Context) (int, error)
+}
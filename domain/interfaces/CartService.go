@@ -0,0 +1,19 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+
+	"github.com/ysfada/product-management-system/domain/dtos"
+)
+
+type ICartService interface {
+	Get(ctx context.Context, owner string, token string) (*dtos.CartDto, error)
+	AddLine(ctx context.Context, dto *dtos.SetCartLineDto) (*dtos.CartDto, error)
+	UpdateLine(ctx context.Context, dto *dtos.SetCartLineDto) (*dtos.CartDto, error)
+	RemoveLine(ctx context.Context, owner string, token string, variantID int) (*dtos.CartDto, error)
+	Delete(ctx context.Context, owner string, token string) error
+	Merge(ctx context.Context, token string, owner string) (*dtos.CartDto, error)
+	DeleteExpired(ctx context.Context) (int, error)
+	Sweep(ctx context.Context, interval time.Duration)
+}
@@ -0,0 +1,12 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/ysfada/product-management-system/domain/dtos"
+	"github.com/ysfada/product-management-system/domain/entities"
+)
+
+type ICatalogExportRepository interface {
+	Export(ctx context.Context, query *dtos.CatalogExportQueryDto, fn func(row *entities.CatalogExportRow) error) error
+}
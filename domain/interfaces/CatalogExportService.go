@@ -0,0 +1,12 @@
+package interfaces
+
+import (
+	"context"
+	"io"
+
+	"github.com/ysfada/product-management-system/domain/dtos"
+)
+
+type ICatalogExportService interface {
+	Export(ctx context.Context, query *dtos.CatalogExportQueryDto, format string, w io.Writer) error
+}
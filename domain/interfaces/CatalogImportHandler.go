@@ -0,0 +1,9 @@
+package interfaces
+
+import "github.com/gofiber/fiber/v2"
+
+type ICatalogImportHandler interface {
+	Import(c *fiber.Ctx) error
+	GetByID(c *fiber.Ctx) error
+	ExportErrors(c *fiber.Ctx) error
+}
@@ -0,0 +1,13 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/ysfada/product-management-system/domain/entities"
+)
+
+type ICatalogImportRepository interface {
+	Apply(ctx context.Context, batches [][]*entities.CatalogImportRow, dryRun bool) ([]*entities.CatalogImportPlan, []*entities.CatalogImportError, error)
+	GetByID(ctx context.Context, id int) (*entities.CatalogImport, error)
+	Create(ctx context.Context, catalogImport *entities.CatalogImport) error
+}
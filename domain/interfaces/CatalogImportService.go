@@ -0,0 +1,15 @@
+package interfaces
+
+import (
+	"context"
+	"io"
+	"mime/multipart"
+
+	"github.com/ysfada/product-management-system/domain/dtos"
+)
+
+type ICatalogImportService interface {
+	Import(ctx context.Context, fileheader *multipart.FileHeader, dryRun bool, batchSize int, createdBy string) (*dtos.CatalogImportDto, error)
+	GetByID(ctx context.Context, id int) (*dtos.CatalogImportDto, error)
+	ExportErrors(ctx context.Context, id int, w io.Writer) error
+}
@@ -11,7 +11,7 @@ type ICategoryService interface {
 	GetByID(ctx context.Context, id int) (res *dtos.CategoryDto, err error)
 	Update(ctx context.Context, dto *dtos.UpdateCategoryDto) error
 	Create(ctx context.Context, dto *dtos.CreateCategoryDto) error
-	Delete(ctx context.Context, id int) error
+	Delete(ctx context.Context, id int, versions []int) error
 	Search(ctx context.Context, q string, page int, size int, sortBy string, orderBy string) (*dtos.CategoryPaginatedDto, error)
 	GetProducts(ctx context.Context, id int, page int, size int, sortBy string, orderBy string) (*dtos.CategoryProductsPaginatedDto, error)
 }
@@ -0,0 +1,9 @@
+package interfaces
+
+import "github.com/gofiber/fiber/v2"
+
+type IFeedHandler interface {
+	GoogleXML(c *fiber.Ctx) error
+	GoogleTSV(c *fiber.Ctx) error
+	Diagnostics(c *fiber.Ctx) error
+}
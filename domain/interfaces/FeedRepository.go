@@ -0,0 +1,11 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/ysfada/product-management-system/domain/entities"
+)
+
+type IFeedRepository interface {
+	Items(ctx context.Context, fn func(item *entities.FeedItem) error) error
+}
@@ -0,0 +1,13 @@
+package interfaces
+
+import (
+	"context"
+	"io"
+
+	"github.com/ysfada/product-management-system/domain/dtos"
+)
+
+type IFeedService interface {
+	Write(ctx context.Context, format string, w io.Writer) error
+	Diagnostics(ctx context.Context) (*dtos.FeedDiagnosticsDto, error)
+}
@@ -4,10 +4,15 @@ import (
 	"context"
 
 	"github.com/ysfada/product-management-system/domain/dtos"
+	"github.com/ysfada/product-management-system/domain/entities"
 )
 
 type IImageRepository interface {
 	Update(ctx context.Context, dto *dtos.UpdateImageDto) error
 	Create(ctx context.Context, dto *dtos.CreateImageDto) (int, error)
-	Delete(ctx context.Context, id int) (string, error)
+	Delete(ctx context.Context, id int) (*entities.Image, error)
+	Fetch(ctx context.Context, withoutRenditions bool) ([]*entities.Image, error)
+	SetRenditions(ctx context.Context, id int, thumbnailUrl string, renditions []*dtos.ImageRenditionDto) error
+	FetchOrphans(ctx context.Context) ([]*entities.Image, error)
+	IsURLInUse(ctx context.Context, url string) (bool, error)
 }
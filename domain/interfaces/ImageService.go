@@ -3,9 +3,16 @@ package interfaces
 import (
 	"context"
 	"mime/multipart"
+
+	"github.com/ysfada/product-management-system/domain/dtos"
+	"github.com/ysfada/product-management-system/domain/entities"
 )
 
 type IImageService interface {
 	Save(ctx context.Context, id int, fileheader *multipart.FileHeader) (int, error)
 	Remove(ctx context.Context, imageID int) error
+	PurgeOrphans(ctx context.Context, dryRun bool) ([]*dtos.ImageDto, error)
+	RenderAll(ctx context.Context, all bool) ([]*dtos.ImageDto, error)
+	RemoveFiles(ctx context.Context, image *entities.Image) error
+	ToImageDto(ctx context.Context, image *entities.Image) *dtos.ImageDto
 }
@@ -0,0 +1,10 @@
+package interfaces
+
+import "github.com/gofiber/fiber/v2"
+
+type IInventoryHandler interface {
+	LowStock(c *fiber.Ctx) error
+	LowStockEvents(c *fiber.Ctx) error
+	Receive(c *fiber.Ctx) error
+	Valuation(c *fiber.Ctx) error
+}
@@ -0,0 +1,16 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+
+	"github.com/ysfada/product-management-system/domain/dtos"
+	"github.com/ysfada/product-management-system/domain/entities"
+)
+
+type IInventoryRepository interface {
+	LowStock(ctx context.Context, categoryID int, page int, size int, sortBy string, orderBy string) (*entities.LowStockPaginated, error)
+	ListenLowStock(ctx context.Context, handler func(event *entities.LowStockEvent)) error
+	Receive(ctx context.Context, dto *dtos.CreateStockReceiptDto) error
+	Movements(ctx context.Context, categoryID int, asOf time.Time) ([]*entities.VariantMovements, error)
+}
@@ -0,0 +1,18 @@
+package interfaces
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/ysfada/product-management-system/domain/dtos"
+)
+
+type IInventoryService interface {
+	LowStock(ctx context.Context, categoryID int, page int, size int, sortBy string, orderBy string) (*dtos.LowStockPaginatedDto, error)
+	ExportLowStock(ctx context.Context, categoryID int, w io.Writer) error
+	Subscribe(subscriber func(event *dtos.LowStockEventDto)) (unsubscribe func())
+	Listen(ctx context.Context)
+	Receive(ctx context.Context, dto *dtos.CreateStockReceiptDto) error
+	Valuation(ctx context.Context, categoryID int, method string, asOf time.Time) (*dtos.ValuationDto, error)
+}
@@ -0,0 +1,13 @@
+package interfaces
+
+import "github.com/gofiber/fiber/v2"
+
+type ILotHandler interface {
+	Fetch(c *fiber.Ctx) error
+	GetByID(c *fiber.Ctx) error
+	Create(c *fiber.Ctx) error
+	Update(c *fiber.Ctx) error
+	Delete(c *fiber.Ctx) error
+	Expiring(c *fiber.Ctx) error
+	Pick(c *fiber.Ctx) error
+}
@@ -0,0 +1,17 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/ysfada/product-management-system/domain/dtos"
+	"github.com/ysfada/product-management-system/domain/entities"
+)
+
+type ILotRepository interface {
+	Fetch(ctx context.Context, id int, variantID int) ([]*entities.Lot, error)
+	GetByID(ctx context.Context, id int, variantID int, lotID int) (*entities.Lot, error)
+	Create(ctx context.Context, dto *dtos.CreateLotDto) error
+	Update(ctx context.Context, dto *dtos.UpdateLotDto) error
+	Delete(ctx context.Context, id int, variantID int, lotID int) error
+	Expiring(ctx context.Context, days int, page int, size int) (*entities.ExpiringLotPaginated, error)
+}
@@ -0,0 +1,17 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/ysfada/product-management-system/domain/dtos"
+)
+
+type ILotService interface {
+	Fetch(ctx context.Context, id int, variantID int) ([]*dtos.LotDto, error)
+	GetByID(ctx context.Context, id int, variantID int, lotID int) (*dtos.LotDto, error)
+	Create(ctx context.Context, dto *dtos.CreateLotDto) error
+	Update(ctx context.Context, dto *dtos.UpdateLotDto) error
+	Delete(ctx context.Context, id int, variantID int, lotID int) error
+	Expiring(ctx context.Context, days int, page int, size int) (*dtos.ExpiringLotPaginatedDto, error)
+	Pick(ctx context.Context, id int, variantID int, quantity float64) (*dtos.PickingDto, error)
+}
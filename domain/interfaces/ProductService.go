@@ -10,9 +10,10 @@ import (
 type IProductService interface {
 	Fetch(ctx context.Context, page int, size int, sortBy string, orderBy string) (*dtos.ProductPaginatedDto, error)
 	GetByID(ctx context.Context, id int) (res *dtos.ProductDto, err error)
+	GetJSONLD(ctx context.Context, id int) (*dtos.ProductJSONLDDto, error)
 	Update(ctx context.Context, dto *dtos.UpdateProductDto) error
 	Create(ctx context.Context, dto *dtos.CreateProductDto) error
-	Delete(ctx context.Context, id int) error
+	Delete(ctx context.Context, id int, versions []int) error
 	Search(ctx context.Context, q string, page int, size int, sortBy string, orderBy string) (*dtos.ProductPaginatedDto, error)
 	GetImages(ctx context.Context, id int) ([]*dtos.ImageDto, error)
 	AddImage(ctx context.Context, id int, fileheader *multipart.FileHeader) error
@@ -22,8 +23,9 @@ type IProductService interface {
 	GetVariantByID(ctx context.Context, id int, variantID int) (*dtos.ProductVariantDto, error)
 	CreateVariant(ctx context.Context, dto *dtos.CreateProductVariantDto) error
 	UpdateVariant(ctx context.Context, dto *dtos.UpdateProductVariantDto) error
-	DeleteVariant(ctx context.Context, id int, variantID int) error
+	DeleteVariant(ctx context.Context, id int, variantID int, versions []int) error
 	GetAttributes(ctx context.Context, id int, variantID int) ([]*dtos.AttributeDto, error)
 	AddAttribute(ctx context.Context, dto *dtos.CreateProductVariantAttributeDto) error
 	RemoveAttribute(ctx context.Context, id int, variantID int, attributeID int) error
+	Reindex(ctx context.Context) error
 }
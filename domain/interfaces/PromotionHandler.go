@@ -0,0 +1,13 @@
+package interfaces
+
+import "github.com/gofiber/fiber/v2"
+
+type IPromotionHandler interface {
+	Fetch(c *fiber.Ctx) error
+	GetByID(c *fiber.Ctx) error
+	Create(c *fiber.Ctx) error
+	Update(c *fiber.Ctx) error
+	Delete(c *fiber.Ctx) error
+	Evaluate(c *fiber.Ctx) error
+	Redeem(c *fiber.Ctx) error
+}
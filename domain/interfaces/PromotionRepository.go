@@ -0,0 +1,20 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/ysfada/product-management-system/domain/dtos"
+	"github.com/ysfada/product-management-system/domain/entities"
+)
+
+type IPromotionRepository interface {
+	Fetch(ctx context.Context, page int, size int) (*entities.PromotionPaginated, error)
+	GetByID(ctx context.Context, id int) (*entities.Promotion, error)
+	Create(ctx context.Context, dto *dtos.CreatePromotionDto) error
+	Update(ctx context.Context, dto *dtos.UpdatePromotionDto) error
+	Delete(ctx context.Context, id int) error
+	FetchByCodes(ctx context.Context, codes []string) ([]*entities.Promotion, error)
+	Lines(ctx context.Context, lines []*dtos.EvaluatePromotionsLineDto) ([]*entities.PromotionLine, error)
+	OrderLines(ctx context.Context, salesOrderID int) ([]*dtos.EvaluatePromotionsLineDto, error)
+	Redeem(ctx context.Context, salesOrderID int, ids []int) error
+}
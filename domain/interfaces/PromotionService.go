@@ -0,0 +1,17 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/ysfada/product-management-system/domain/dtos"
+)
+
+type IPromotionService interface {
+	Fetch(ctx context.Context, page int, size int) (*dtos.PromotionPaginatedDto, error)
+	GetByID(ctx context.Context, id int) (*dtos.PromotionDto, error)
+	Create(ctx context.Context, dto *dtos.CreatePromotionDto) error
+	Update(ctx context.Context, dto *dtos.UpdatePromotionDto) error
+	Delete(ctx context.Context, id int) error
+	Evaluate(ctx context.Context, dto *dtos.EvaluatePromotionsDto) (*dtos.PromotionEvaluationDto, error)
+	Redeem(ctx context.Context, dto *dtos.RedeemPromotionsDto) (*dtos.PromotionEvaluationDto, error)
+}
@@ -0,0 +1,13 @@
+package interfaces
+
+import "github.com/gofiber/fiber/v2"
+
+type IPurchaseOrderHandler interface {
+	Fetch(c *fiber.Ctx) error
+	GetByID(c *fiber.Ctx) error
+	Create(c *fiber.Ctx) error
+	Send(c *fiber.Ctx) error
+	Receive(c *fiber.Ctx) error
+	Close(c *fiber.Ctx) error
+	Suggestions(c *fiber.Ctx) error
+}
@@ -0,0 +1,18 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/ysfada/product-management-system/domain/dtos"
+	"github.com/ysfada/product-management-system/domain/entities"
+)
+
+type IPurchaseOrderRepository interface {
+	Fetch(ctx context.Context, supplierID int, status string, page int, size int) (*entities.PurchaseOrderPaginated, error)
+	GetByID(ctx context.Context, id int) (*entities.PurchaseOrder, error)
+	Create(ctx context.Context, dto *dtos.CreatePurchaseOrderDto) (int, error)
+	Send(ctx context.Context, id int) error
+	Receive(ctx context.Context, dto *dtos.CreatePurchaseOrderReceiptDto) error
+	Close(ctx context.Context, id int) error
+	Suggestions(ctx context.Context) ([]*entities.ReorderSuggestion, error)
+}
@@ -0,0 +1,17 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/ysfada/product-management-system/domain/dtos"
+)
+
+type IPurchaseOrderService interface {
+	Fetch(ctx context.Context, supplierID int, status string, page int, size int) (*dtos.PurchaseOrderPaginatedDto, error)
+	GetByID(ctx context.Context, id int) (*dtos.PurchaseOrderDto, error)
+	Create(ctx context.Context, dto *dtos.CreatePurchaseOrderDto) (*dtos.PurchaseOrderDto, error)
+	Send(ctx context.Context, id int) error
+	Receive(ctx context.Context, dto *dtos.CreatePurchaseOrderReceiptDto) (*dtos.PurchaseOrderDto, error)
+	Close(ctx context.Context, id int) error
+	Suggestions(ctx context.Context) ([]*dtos.ReorderSuggestionDto, error)
+}
@@ -0,0 +1,10 @@
+package interfaces
+
+import "github.com/gofiber/fiber/v2"
+
+type IReservationHandler interface {
+	GetByID(c *fiber.Ctx) error
+	Create(c *fiber.Ctx) error
+	Confirm(c *fiber.Ctx) error
+	Release(c *fiber.Ctx) error
+}
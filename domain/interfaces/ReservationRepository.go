@@ -0,0 +1,17 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+
+	"github.com/ysfada/product-management-system/domain/dtos"
+	"github.com/ysfada/product-management-system/domain/entities"
+)
+
+type IReservationRepository interface {
+	GetByID(ctx context.Context, id int) (*entities.Reservation, error)
+	Create(ctx context.Context, dto *dtos.CreateReservationDto, ttl time.Duration) (*entities.Reservation, error)
+	Confirm(ctx context.Context, id int) error
+	Release(ctx context.Context, id int) error
+	ExpireStale(ctx context.Context) (int, error)
+}
@@ -0,0 +1,17 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+
+	"github.com/ysfada/product-management-system/domain/dtos"
+)
+
+type IReservationService interface {
+	GetByID(ctx context.Context, id int) (*dtos.ReservationDto, error)
+	Create(ctx context.Context, dto *dtos.CreateReservationDto) (*dtos.ReservationDto, error)
+	Confirm(ctx context.Context, id int) error
+	Release(ctx context.Context, id int) error
+	ExpireStale(ctx context.Context) (int, error)
+	Sweep(ctx context.Context, interval time.Duration)
+}
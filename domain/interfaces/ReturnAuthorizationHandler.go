@@ -0,0 +1,13 @@
+package interfaces
+
+import "github.com/gofiber/fiber/v2"
+
+type IReturnAuthorizationHandler interface {
+	Fetch(c *fiber.Ctx) error
+	GetByID(c *fiber.Ctx) error
+	Create(c *fiber.Ctx) error
+	Approve(c *fiber.Ctx) error
+	Reject(c *fiber.Ctx) error
+	Receive(c *fiber.Ctx) error
+	Inspect(c *fiber.Ctx) error
+}
@@ -0,0 +1,18 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/ysfada/product-management-system/domain/dtos"
+	"github.com/ysfada/product-management-system/domain/entities"
+)
+
+type IReturnAuthorizationRepository interface {
+	Fetch(ctx context.Context, customer string, status string, page int, size int) (*entities.ReturnAuthorizationPaginated, error)
+	GetByID(ctx context.Context, id int) (*entities.ReturnAuthorization, error)
+	Create(ctx context.Context, dto *dtos.CreateReturnAuthorizationDto, customer string) (int, error)
+	Approve(ctx context.Context, dto *dtos.ReviewReturnAuthorizationDto) error
+	Reject(ctx context.Context, dto *dtos.ReviewReturnAuthorizationDto) error
+	Receive(ctx context.Context, dto *dtos.ReceiveReturnAuthorizationDto) error
+	Inspect(ctx context.Context, dto *dtos.InspectReturnAuthorizationDto) error
+}
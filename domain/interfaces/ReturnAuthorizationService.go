@@ -0,0 +1,17 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/ysfada/product-management-system/domain/dtos"
+)
+
+type IReturnAuthorizationService interface {
+	Fetch(ctx context.Context, customer string, status string, page int, size int) (*dtos.ReturnAuthorizationPaginatedDto, error)
+	GetByID(ctx context.Context, id int) (*dtos.ReturnAuthorizationDto, error)
+	Create(ctx context.Context, dto *dtos.CreateReturnAuthorizationDto, customer string) (*dtos.ReturnAuthorizationDto, error)
+	Approve(ctx context.Context, dto *dtos.ReviewReturnAuthorizationDto) error
+	Reject(ctx context.Context, dto *dtos.ReviewReturnAuthorizationDto) error
+	Receive(ctx context.Context, dto *dtos.ReceiveReturnAuthorizationDto) error
+	Inspect(ctx context.Context, dto *dtos.InspectReturnAuthorizationDto) error
+}
@@ -0,0 +1,12 @@
+package interfaces
+
+import "github.com/gofiber/fiber/v2"
+
+type ISalesOrderHandler interface {
+	Fetch(c *fiber.Ctx) error
+	GetByID(c *fiber.Ctx) error
+	Create(c *fiber.Ctx) error
+	Pay(c *fiber.Ctx) error
+	Fulfill(c *fiber.Ctx) error
+	Cancel(c *fiber.Ctx) error
+}
@@ -0,0 +1,17 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/ysfada/product-management-system/domain/dtos"
+	"github.com/ysfada/product-management-system/domain/entities"
+)
+
+type ISalesOrderRepository interface {
+	Fetch(ctx context.Context, customer string, status string, page int, size int) (*entities.SalesOrderPaginated, error)
+	GetByID(ctx context.Context, id int) (*entities.SalesOrder, error)
+	Create(ctx context.Context, dto *dtos.CreateSalesOrderDto) (int, error)
+	Pay(ctx context.Context, id int) error
+	Fulfill(ctx context.Context, id int) error
+	Cancel(ctx context.Context, id int, customer string) error
+}
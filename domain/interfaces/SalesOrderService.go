@@ -0,0 +1,16 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/ysfada/product-management-system/domain/dtos"
+)
+
+type ISalesOrderService interface {
+	Fetch(ctx context.Context, customer string, status string, page int, size int) (*dtos.SalesOrderPaginatedDto, error)
+	GetByID(ctx context.Context, id int) (*dtos.SalesOrderDto, error)
+	Create(ctx context.Context, dto *dtos.CreateSalesOrderDto) (*dtos.SalesOrderDto, error)
+	Pay(ctx context.Context, id int) error
+	Fulfill(ctx context.Context, id int) error
+	Cancel(ctx context.Context, id int, customer string) error
+}
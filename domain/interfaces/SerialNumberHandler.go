@@ -0,0 +1,10 @@
+package interfaces
+
+import "github.com/gofiber/fiber/v2"
+
+type ISerialNumberHandler interface {
+	Fetch(c *fiber.Ctx) error
+	GetBySerial(c *fiber.Ctx) error
+	Create(c *fiber.Ctx) error
+	UpdateStatus(c *fiber.Ctx) error
+}
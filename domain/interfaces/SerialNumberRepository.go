@@ -0,0 +1,15 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/ysfada/product-management-system/domain/dtos"
+	"github.com/ysfada/product-management-system/domain/entities"
+)
+
+type ISerialNumberRepository interface {
+	Fetch(ctx context.Context, id int, variantID int, status string) ([]*entities.SerialNumber, error)
+	GetBySerial(ctx context.Context, serial string) (*entities.SerialNumberLookup, error)
+	Create(ctx context.Context, dto *dtos.CreateSerialNumbersDto) error
+	UpdateStatus(ctx context.Context, dto *dtos.UpdateSerialNumberStatusDto, from []string) error
+}
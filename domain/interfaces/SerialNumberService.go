@@ -0,0 +1,14 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/ysfada/product-management-system/domain/dtos"
+)
+
+type ISerialNumberService interface {
+	Fetch(ctx context.Context, id int, variantID int, status string) ([]*dtos.SerialNumberDto, error)
+	GetBySerial(ctx context.Context, serial string) (*dtos.SerialNumberLookupDto, error)
+	Create(ctx context.Context, dto *dtos.CreateSerialNumbersDto) error
+	UpdateStatus(ctx context.Context, dto *dtos.UpdateSerialNumberStatusDto) error
+}
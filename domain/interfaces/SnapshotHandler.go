@@ -0,0 +1,8 @@
+package interfaces
+
+import "github.com/gofiber/fiber/v2"
+
+type ISnapshotHandler interface {
+	Export(c *fiber.Ctx) error
+	Import(c *fiber.Ctx) error
+}
@@ -0,0 +1,12 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/ysfada/product-management-system/domain/entities"
+)
+
+type ISnapshotRepository interface {
+	Export(ctx context.Context) (*entities.Snapshot, error)
+	Import(ctx context.Context, snapshot *entities.Snapshot, strategy string) (*entities.SnapshotImport, error)
+}
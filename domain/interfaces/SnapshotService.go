@@ -0,0 +1,13 @@
+package interfaces
+
+import (
+	"context"
+	"io"
+
+	"github.com/ysfada/product-management-system/domain/dtos"
+)
+
+type ISnapshotService interface {
+	Export(ctx context.Context, w io.Writer) error
+	Import(ctx context.Context, r io.Reader, strategy string) (*dtos.SnapshotImportDto, error)
+}
@@ -0,0 +1,11 @@
+package interfaces
+
+import "github.com/gofiber/fiber/v2"
+
+type IStocktakeHandler interface {
+	GetByID(c *fiber.Ctx) error
+	Create(c *fiber.Ctx) error
+	AddCounts(c *fiber.Ctx) error
+	Approve(c *fiber.Ctx) error
+	Cancel(c *fiber.Ctx) error
+}
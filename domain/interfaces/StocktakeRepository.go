@@ -0,0 +1,16 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/ysfada/product-management-system/domain/dtos"
+	"github.com/ysfada/product-management-system/domain/entities"
+)
+
+type IStocktakeRepository interface {
+	GetByID(ctx context.Context, id int) (*entities.Stocktake, error)
+	Create(ctx context.Context, dto *dtos.CreateStocktakeDto) (int, error)
+	AddCounts(ctx context.Context, dto *dtos.CreateStocktakeCountsDto) error
+	Approve(ctx context.Context, id int, approvedBy string) error
+	Cancel(ctx context.Context, id int) error
+}
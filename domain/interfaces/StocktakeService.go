@@ -0,0 +1,15 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/ysfada/product-management-system/domain/dtos"
+)
+
+type IStocktakeService interface {
+	GetByID(ctx context.Context, id int) (*dtos.StocktakeDto, error)
+	Create(ctx context.Context, dto *dtos.CreateStocktakeDto) (*dtos.StocktakeDto, error)
+	AddCounts(ctx context.Context, dto *dtos.CreateStocktakeCountsDto) error
+	Approve(ctx context.Context, id int, approvedBy string) error
+	Cancel(ctx context.Context, id int) error
+}
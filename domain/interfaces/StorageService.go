@@ -0,0 +1,12 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/ysfada/product-management-system/domain/dtos"
+)
+
+type IStorageService interface {
+	URL(ctx context.Context, url string) (string, error)
+	Migrate(ctx context.Context, from string, to string, overwrite bool) (*dtos.StorageMigrationDto, error)
+}
@@ -0,0 +1,14 @@
+package interfaces
+
+import "github.com/gofiber/fiber/v2"
+
+type ISupplierHandler interface {
+	Fetch(c *fiber.Ctx) error
+	GetByID(c *fiber.Ctx) error
+	Create(c *fiber.Ctx) error
+	Update(c *fiber.Ctx) error
+	Delete(c *fiber.Ctx) error
+	FetchVariants(c *fiber.Ctx) error
+	SetVariant(c *fiber.Ctx) error
+	DeleteVariant(c *fiber.Ctx) error
+}
@@ -0,0 +1,19 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/ysfada/product-management-system/domain/dtos"
+	"github.com/ysfada/product-management-system/domain/entities"
+)
+
+type ISupplierRepository interface {
+	Fetch(ctx context.Context, page int, size int, sortBy string, orderBy string) (*entities.SupplierPaginated, error)
+	GetByID(ctx context.Context, id int) (*entities.Supplier, error)
+	Create(ctx context.Context, dto *dtos.CreateSupplierDto) error
+	Update(ctx context.Context, dto *dtos.UpdateSupplierDto) error
+	Delete(ctx context.Context, id int) error
+	FetchVariants(ctx context.Context, id int) ([]*entities.SupplierVariant, error)
+	SetVariant(ctx context.Context, dto *dtos.SetSupplierVariantDto) error
+	DeleteVariant(ctx context.Context, id int, variantID int) error
+}
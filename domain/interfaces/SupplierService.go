@@ -0,0 +1,18 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/ysfada/product-management-system/domain/dtos"
+)
+
+type ISupplierService interface {
+	Fetch(ctx context.Context, page int, size int, sortBy string, orderBy string) (*dtos.SupplierPaginatedDto, error)
+	GetByID(ctx context.Context, id int) (*dtos.SupplierDto, error)
+	Create(ctx context.Context, dto *dtos.CreateSupplierDto) error
+	Update(ctx context.Context, dto *dtos.UpdateSupplierDto) error
+	Delete(ctx context.Context, id int) error
+	FetchVariants(ctx context.Context, id int) ([]*dtos.SupplierVariantDto, error)
+	SetVariant(ctx context.Context, dto *dtos.SetSupplierVariantDto) error
+	DeleteVariant(ctx context.Context, id int, variantID int) error
+}
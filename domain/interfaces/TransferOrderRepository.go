@@ -0,0 +1,18 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/ysfada/product-management-system/domain/dtos"
+	"github.com/ysfada/product-management-system/domain/entities"
+)
+
+type ITransferOrderRepository interface {
+	Fetch(ctx context.Context, warehouseID int, status string, page int, size int) (*entities.TransferOrderPaginated, error)
+	GetByID(ctx context.Context, id int) (*entities.TransferOrder, error)
+	Create(ctx context.Context, dto *dtos.CreateTransferOrderDto) (int, error)
+	Dispatch(ctx context.Context, id int, dispatchedBy string) error
+	Receive(ctx context.Context, dto *dtos.CreateTransferOrderReceiptDto) error
+	Close(ctx context.Context, id int) error
+	Cancel(ctx context.Context, id int) error
+}
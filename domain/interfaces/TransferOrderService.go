@@ -0,0 +1,17 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/ysfada/product-management-system/domain/dtos"
+)
+
+type ITransferOrderService interface {
+	Fetch(ctx context.Context, warehouseID int, status string, page int, size int) (*dtos.TransferOrderPaginatedDto, error)
+	GetByID(ctx context.Context, id int) (*dtos.TransferOrderDto, error)
+	Create(ctx context.Context, dto *dtos.CreateTransferOrderDto) (*dtos.TransferOrderDto, error)
+	Dispatch(ctx context.Context, id int, dispatchedBy string) error
+	Receive(ctx context.Context, dto *dtos.CreateTransferOrderReceiptDto) (*dtos.TransferOrderDto, error)
+	Close(ctx context.Context, id int) error
+	Cancel(ctx context.Context, id int) error
+}
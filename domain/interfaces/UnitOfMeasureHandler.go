@@ -0,0 +1,9 @@
+package interfaces
+
+import "github.com/gofiber/fiber/v2"
+
+type IUnitOfMeasureHandler interface {
+	Fetch(c *fiber.Ctx) error
+	Set(c *fiber.Ctx) error
+	Delete(c *fiber.Ctx) error
+}
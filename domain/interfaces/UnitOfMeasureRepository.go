@@ -0,0 +1,14 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/ysfada/product-management-system/domain/dtos"
+	"github.com/ysfada/product-management-system/domain/entities"
+)
+
+type IUnitOfMeasureRepository interface {
+	Fetch(ctx context.Context, id int, variantID int) ([]*entities.UnitOfMeasure, error)
+	Set(ctx context.Context, dto *dtos.SetUnitOfMeasureDto) error
+	Delete(ctx context.Context, id int, variantID int, name string) error
+}
@@ -0,0 +1,13 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/ysfada/product-management-system/domain/dtos"
+)
+
+type IUnitOfMeasureService interface {
+	Fetch(ctx context.Context, id int, variantID int) ([]*dtos.UnitOfMeasureDto, error)
+	Set(ctx context.Context, dto *dtos.SetUnitOfMeasureDto) error
+	Delete(ctx context.Context, id int, variantID int, name string) error
+}
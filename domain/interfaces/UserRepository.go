@@ -10,6 +10,8 @@ import (
 type IUserRepository interface {
 	GetByUsername(ctx context.Context, username string) (res *entities.User, err error)
 	Create(ctx context.Context, dto *dtos.SignupDto) error
+	CreateSuperuser(ctx context.Context, dto *dtos.SignupDto) error
+	SetActive(ctx context.Context, username string, active bool) error
 	Delete(ctx context.Context, username string) error
 	ChangeUsername(ctx context.Context, username string, dto *dtos.ChangeUsernameDto) error
 	ChangePassword(ctx context.Context, username string, dto *dtos.ChangePasswordDto) error
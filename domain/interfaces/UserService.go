@@ -8,6 +8,8 @@ import (
 
 type IUserService interface {
 	Signup(ctx context.Context, dto *dtos.SignupDto) error
+	CreateSuperuser(ctx context.Context, dto *dtos.SignupDto) error
+	SetActive(ctx context.Context, username string, active bool) error
 	Signin(ctx context.Context, tdo *dtos.SigninDto) (token string, err error)
 	Me(ctx context.Context, username string) (res *dtos.UserDto, err error)
 	Delete(ctx context.Context, username string) error
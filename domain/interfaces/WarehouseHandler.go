@@ -0,0 +1,12 @@
+package interfaces
+
+import "github.com/gofiber/fiber/v2"
+
+type IWarehouseHandler interface {
+	Fetch(c *fiber.Ctx) error
+	GetByID(c *fiber.Ctx) error
+	Create(c *fiber.Ctx) error
+	Update(c *fiber.Ctx) error
+	Delete(c *fiber.Ctx) error
+	VariantStock(c *fiber.Ctx) error
+}
@@ -0,0 +1,17 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/ysfada/product-management-system/domain/dtos"
+	"github.com/ysfada/product-management-system/domain/entities"
+)
+
+type IWarehouseRepository interface {
+	Fetch(ctx context.Context) ([]*entities.Warehouse, error)
+	GetByID(ctx context.Context, id int) (*entities.Warehouse, error)
+	Create(ctx context.Context, dto *dtos.CreateWarehouseDto) error
+	Update(ctx context.Context, dto *dtos.UpdateWarehouseDto) error
+	Delete(ctx context.Context, id int) error
+	VariantStock(ctx context.Context, id int, variantID int) (*entities.VariantStock, error)
+}
@@ -0,0 +1,16 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/ysfada/product-management-system/domain/dtos"
+)
+
+type IWarehouseService interface {
+	Fetch(ctx context.Context) ([]*dtos.WarehouseDto, error)
+	GetByID(ctx context.Context, id int) (*dtos.WarehouseDto, error)
+	Create(ctx context.Context, dto *dtos.CreateWarehouseDto) error
+	Update(ctx context.Context, dto *dtos.UpdateWarehouseDto) error
+	Delete(ctx context.Context, id int) error
+	VariantStock(ctx context.Context, id int, variantID int) (*dtos.VariantStockDto, error)
+}
@@ -102,6 +102,7 @@ func (h *AttributeHandler) GetByID(c *fiber.Ctx) error {
 				return c.Status(fiber.StatusInternalServerError).JSON(err)
 			}
 		} else {
+			setETag(c, attribute.Version)
 			return c.JSON(attribute)
 		}
 	}
@@ -115,9 +116,12 @@ func (h *AttributeHandler) GetByID(c *fiber.Ctx) error {
 // @Produce json
 // @Success 204
 // @Failure 400 {object} string
+// @Failure 404 {object} string
+// @Failure 412 {object} string
 // @Failure 500 {object} string
 // @Param id path int true "id"
 // @Param dto body dtos.UpdateAttributeDto true "dto"
+// @Param If-Match header string false "ETag of the item"
 // @Param Authorization header string true "Bearer"
 // @Router /attributes/{id} [put]
 func (h *AttributeHandler) Update(c *fiber.Ctx) error {
@@ -129,10 +133,15 @@ func (h *AttributeHandler) Update(c *fiber.Ctx) error {
 		return c.SendStatus(fiber.StatusBadRequest)
 	}
 
+	body.Versions = ifMatch(c)
 	if err := h.service.Update(c.Context(), &body); err != nil {
 		switch err {
 		case common.ErrBadParamInput:
 			return c.SendStatus(fiber.StatusBadRequest)
+		case common.ErrNotFound:
+			return c.SendStatus(fiber.StatusNotFound)
+		case common.ErrPreconditionFailed:
+			return c.SendStatus(fiber.StatusPreconditionFailed)
 		default:
 			return c.Status(fiber.StatusInternalServerError).JSON(err)
 		}
@@ -177,18 +186,23 @@ func (h *AttributeHandler) Create(c *fiber.Ctx) error {
 // @Produce json
 // @Success 204
 // @Failure 400 {object} string
+// @Failure 404 {object} string
+// @Failure 412 {object} string
 // @Failure 500 {object} string
 // @Param id path int true "id"
+// @Param If-Match header string false "ETag of the item"
 // @Param Authorization header string true "Bearer"
 // @Router /attributes/{id} [delete]
 func (h *AttributeHandler) Delete(c *fiber.Ctx) error {
 	if id, err := c.ParamsInt("id"); err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(err)
 	} else {
-		if err := h.service.Delete(c.Context(), id); err != nil {
+		if err := h.service.Delete(c.Context(), id, ifMatch(c)); err != nil {
 			switch err {
 			case common.ErrNotFound:
 				return c.SendStatus(fiber.StatusNotFound)
+			case common.ErrPreconditionFailed:
+				return c.SendStatus(fiber.StatusPreconditionFailed)
 			default:
 				return c.Status(fiber.StatusInternalServerError).JSON(err)
 			}
@@ -0,0 +1,80 @@
+package handlers
+
+import (
+	"errors"
+
+	"github.com/go-playground/validator"
+	"github.com/gofiber/fiber/v2"
+	"github.com/ysfada/product-management-system/domain/common"
+	"github.com/ysfada/product-management-system/domain/dtos"
+	"github.com/ysfada/product-management-system/domain/interfaces"
+)
+
+type BatchHandler struct {
+	service interfaces.IBatchService
+}
+
+func NewBatchHandler(service interfaces.IBatchService) *BatchHandler {
+	return &BatchHandler{
+		service: service,
+	}
+}
+
+var _ interfaces.IBatchHandler = (*BatchHandler)(nil)
+
+func (h *BatchHandler) UseHandler(r fiber.Router) {
+	r.Post("/batch", common.JwtMiddleware, h.Execute)
+}
+
+// Batch godoc
+// @Summary Run catalog writes in one transaction
+// @Description Run an ordered list of operations, create, update or delete of a category, product, variant, attribute or attribute_link, all or nothing.
+// @Description The data of an operation is the body of the matching single request, a delete takes the id (and product_id of a variant), an attribute_link the product_variant_id and attribute_id.
+// @Description A create may name its id with ref, later operations use it in their data as {"$ref": "name"}. The version of an update or delete is the expected row version.
+// @Description If an operation fails nothing is committed, its error is in its result and the ones after it are skipped
+// @Tags batch
+// @Accept json
+// @Produce json
+// @Success 200 {object} dtos.BatchResultDto
+// @Failure 400 {object} dtos.BatchResultDto
+// @Failure 404 {object} dtos.BatchResultDto
+// @Failure 409 {object} dtos.BatchResultDto
+// @Failure 412 {object} dtos.BatchResultDto
+// @Failure 500 {object} string
+// @Param dto body dtos.BatchDto true "dto"
+// @Param Authorization header string true "Bearer"
+// @Router /batch [post]
+func (h *BatchHandler) Execute(c *fiber.Ctx) error {
+	var body dtos.BatchDto
+	if err := c.BodyParser(&body); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(err)
+	}
+
+	result, err := h.service.Execute(c.Context(), &body)
+	if err == nil {
+		return c.JSON(result)
+	}
+	if result == nil {
+		// the batch itself is invalid
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			return c.Status(fiber.StatusBadRequest).JSON(validationErrors.Error())
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(err)
+	}
+
+	if _, ok := err.(validator.ValidationErrors); ok {
+		return c.Status(fiber.StatusBadRequest).JSON(result)
+	}
+	switch {
+	case errors.Is(err, common.ErrBadParamInput):
+		return c.Status(fiber.StatusBadRequest).JSON(result)
+	case errors.Is(err, common.ErrNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(result)
+	case errors.Is(err, common.ErrConflict):
+		return c.Status(fiber.StatusConflict).JSON(result)
+	case errors.Is(err, common.ErrPreconditionFailed):
+		return c.Status(fiber.StatusPreconditionFailed).JSON(result)
+	default:
+		return c.Status(fiber.StatusInternalServerError).JSON(result)
+	}
+}
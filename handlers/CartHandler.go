@@ -0,0 +1,246 @@
+package handlers
+
+import (
+	"github.com/go-playground/validator"
+	"github.com/gofiber/fiber/v2"
+	"github.com/ysfada/product-management-system/domain/common"
+	"github.com/ysfada/product-management-system/domain/dtos"
+	"github.com/ysfada/product-management-system/domain/interfaces"
+)
+
+// cartTokenHeader carries the token of an anonymous cart in both directions.
+const cartTokenHeader = "X-Cart-Token"
+
+type CartHandler struct {
+	service interfaces.ICartService
+}
+
+func NewCartHandler(service interfaces.ICartService) *CartHandler {
+	return &CartHandler{
+		service: service,
+	}
+}
+
+var _ interfaces.ICartHandler = (*CartHandler)(nil)
+
+func (h *CartHandler) UseHandler(r fiber.Router) {
+	cartRouter := r.Group("cart")
+
+	cartRouter.Get("/", common.OptionalJwtMiddleware, h.Get)
+	cartRouter.Delete("/", common.OptionalJwtMiddleware, h.Delete)
+	cartRouter.Post("/lines", common.OptionalJwtMiddleware, h.AddLine)
+	cartRouter.Put("/lines/:variantID", common.OptionalJwtMiddleware, h.UpdateLine)
+	cartRouter.Delete("/lines/:variantID", common.OptionalJwtMiddleware, h.RemoveLine)
+	cartRouter.Post("/merge", common.JwtMiddleware, h.Merge)
+}
+
+// Cart godoc
+// @Summary Get cart
+// @Description Get the cart of the current user, or the anonymous cart of the X-Cart-Token header, with current prices, available stock and totals
+// @Tags cart
+// @Accept json
+// @Produce json
+// @Success 200 {object} dtos.CartDto
+// @Failure 404 {object} string
+// @Failure 500 {object} string
+// @Param X-Cart-Token header string false "token of an anonymous cart"
+// @Param Authorization header string false "Bearer"
+// @Router /cart [get]
+func (h *CartHandler) Get(c *fiber.Ctx) error {
+	owner, _, _ := currentUser(c)
+
+	if cart, err := h.service.Get(c.Context(), owner, c.Get(cartTokenHeader)); err != nil {
+		switch err {
+		case common.ErrNotFound:
+			return c.SendStatus(fiber.StatusNotFound)
+		default:
+			return c.Status(fiber.StatusInternalServerError).JSON(err)
+		}
+	} else {
+		return h.send(c, fiber.StatusOK, cart)
+	}
+}
+
+// Cart godoc
+// @Summary Add to cart
+// @Description Add a quantity of a variant to the cart, the cart is created if there is none. A new anonymous cart returns its token in the X-Cart-Token header
+// @Tags cart
+// @Accept json
+// @Produce json
+// @Success 200 {object} dtos.CartDto
+// @Failure 400 {object} string
+// @Failure 404 {object} string
+// @Failure 409 {object} string
+// @Failure 500 {object} string
+// @Param dto body dtos.SetCartLineDto true "dto"
+// @Param X-Cart-Token header string false "token of an anonymous cart"
+// @Param Authorization header string false "Bearer"
+// @Router /cart/lines [post]
+func (h *CartHandler) AddLine(c *fiber.Ctx) error {
+	var body dtos.SetCartLineDto
+	if err := c.BodyParser(&body); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(err)
+	}
+	body.Owner, _, _ = currentUser(c)
+	body.Token = c.Get(cartTokenHeader)
+
+	cart, err := h.service.AddLine(c.Context(), &body)
+	return h.sendLine(c, cart, err)
+}
+
+// Cart godoc
+// @Summary Update cart line
+// @Description Replace the quantity of a variant in the cart, the cart is created if there is none
+// @Tags cart
+// @Accept json
+// @Produce json
+// @Success 200 {object} dtos.CartDto
+// @Failure 400 {object} string
+// @Failure 404 {object} string
+// @Failure 409 {object} string
+// @Failure 500 {object} string
+// @Param variantID path int true "variant id"
+// @Param dto body dtos.SetCartLineDto true "dto"
+// @Param X-Cart-Token header string false "token of an anonymous cart"
+// @Param Authorization header string false "Bearer"
+// @Router /cart/lines/{variantID} [put]
+func (h *CartHandler) UpdateLine(c *fiber.Ctx) error {
+	variantID, err := c.ParamsInt("variantID")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(err)
+	}
+
+	var body dtos.SetCartLineDto
+	if err := c.BodyParser(&body); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(err)
+	}
+	body.ProductVariantID = variantID
+	body.Owner, _, _ = currentUser(c)
+	body.Token = c.Get(cartTokenHeader)
+
+	cart, err := h.service.UpdateLine(c.Context(), &body)
+	return h.sendLine(c, cart, err)
+}
+
+// Cart godoc
+// @Summary Remove cart line
+// @Description Remove a variant from the cart
+// @Tags cart
+// @Accept json
+// @Produce json
+// @Success 200 {object} dtos.CartDto
+// @Failure 400 {object} string
+// @Failure 404 {object} string
+// @Failure 500 {object} string
+// @Param variantID path int true "variant id"
+// @Param X-Cart-Token header string false "token of an anonymous cart"
+// @Param Authorization header string false "Bearer"
+// @Router /cart/lines/{variantID} [delete]
+func (h *CartHandler) RemoveLine(c *fiber.Ctx) error {
+	owner, _, _ := currentUser(c)
+
+	if variantID, err := c.ParamsInt("variantID"); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(err)
+	} else {
+		if cart, err := h.service.RemoveLine(c.Context(), owner, c.Get(cartTokenHeader), variantID); err != nil {
+			switch err {
+			case common.ErrNotFound:
+				return c.SendStatus(fiber.StatusNotFound)
+			default:
+				return c.Status(fiber.StatusInternalServerError).JSON(err)
+			}
+		} else {
+			return h.send(c, fiber.StatusOK, cart)
+		}
+	}
+}
+
+// Cart godoc
+// @Summary Delete cart
+// @Description Delete the cart with all its lines
+// @Tags cart
+// @Accept json
+// @Produce json
+// @Success 204
+// @Failure 404 {object} string
+// @Failure 500 {object} string
+// @Param X-Cart-Token header string false "token of an anonymous cart"
+// @Param Authorization header string false "Bearer"
+// @Router /cart [delete]
+func (h *CartHandler) Delete(c *fiber.Ctx) error {
+	owner, _, _ := currentUser(c)
+
+	if err := h.service.Delete(c.Context(), owner, c.Get(cartTokenHeader)); err != nil {
+		switch err {
+		case common.ErrNotFound:
+			return c.SendStatus(fiber.StatusNotFound)
+		default:
+			return c.Status(fiber.StatusInternalServerError).JSON(err)
+		}
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// Cart godoc
+// @Summary Merge cart
+// @Description Merge the anonymous cart of the X-Cart-Token header into the cart of the current user, quantities of the same variant are added up. Signin merges the cart given in its body the same way
+// @Tags cart
+// @Accept json
+// @Produce json
+// @Success 200 {object} dtos.CartDto
+// @Failure 403 {object} string
+// @Failure 404 {object} string
+// @Failure 409 {object} string
+// @Failure 500 {object} string
+// @Param X-Cart-Token header string true "token of an anonymous cart"
+// @Param Authorization header string true "Bearer"
+// @Router /cart/merge [post]
+func (h *CartHandler) Merge(c *fiber.Ctx) error {
+	owner, _, ok := currentUser(c)
+	if !ok {
+		return c.SendStatus(fiber.StatusForbidden)
+	}
+
+	if cart, err := h.service.Merge(c.Context(), c.Get(cartTokenHeader), owner); err != nil {
+		switch err {
+		case common.ErrNotFound:
+			return c.SendStatus(fiber.StatusNotFound)
+		case common.ErrConflict:
+			return c.SendStatus(fiber.StatusConflict)
+		default:
+			return c.Status(fiber.StatusInternalServerError).JSON(err)
+		}
+	} else {
+		return h.send(c, fiber.StatusOK, cart)
+	}
+}
+
+// sendLine answers a request that added or changed a line.
+func (h *CartHandler) sendLine(c *fiber.Ctx, cart *dtos.CartDto, err error) error {
+	if err != nil {
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			return c.Status(fiber.StatusBadRequest).JSON(validationErrors.Error())
+		}
+		switch err {
+		case common.ErrBadParamInput:
+			return c.SendStatus(fiber.StatusBadRequest)
+		case common.ErrNotFound:
+			return c.SendStatus(fiber.StatusNotFound)
+		case common.ErrConflict:
+			return c.SendStatus(fiber.StatusConflict)
+		case common.ErrInsufficientStock:
+			return c.Status(fiber.StatusConflict).JSON(err.Error())
+		default:
+			return c.Status(fiber.StatusInternalServerError).JSON(err)
+		}
+	}
+	return h.send(c, fiber.StatusOK, cart)
+}
+
+// send writes the cart and the token of an anonymous cart.
+func (h *CartHandler) send(c *fiber.Ctx, status int, cart *dtos.CartDto) error {
+	if len(cart.Token) > 0 {
+		c.Set(cartTokenHeader, cart.Token)
+	}
+	return c.Status(status).JSON(cart)
+}
@@ -0,0 +1,100 @@
+package handlers
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/ysfada/product-management-system/domain/common"
+	"github.com/ysfada/product-management-system/domain/dtos"
+	"github.com/ysfada/product-management-system/domain/interfaces"
+	"github.com/ysfada/product-management-system/services"
+)
+
+type CatalogExportHandler struct {
+	service interfaces.ICatalogExportService
+}
+
+func NewCatalogExportHandler(service interfaces.ICatalogExportService) *CatalogExportHandler {
+	return &CatalogExportHandler{
+		service: service,
+	}
+}
+
+var _ interfaces.ICatalogExportHandler = (*CatalogExportHandler)(nil)
+
+func (h *CatalogExportHandler) UseHandler(r fiber.Router) {
+	exportsRouter := r.Group("exports")
+
+	exportsRouter.Get("/catalog", common.JwtMiddleware, h.Export)
+}
+
+// CatalogExport godoc
+// @Summary Export catalog
+// @Description Stream every variant with its product, category, attributes and product images, one row or line per variant.
+// @Description csv and xlsx have the columns of a catalog import, an exported csv file can be imported as is. ndjson has a JSON object per line
+// @Tags exports
+// @Produce text/csv,application/vnd.openxmlformats-officedocument.spreadsheetml.sheet,application/x-ndjson
+// @Success 200 {object} dtos.CatalogExportRowDto
+// @Failure 400 {object} string
+// @Param format query string false "csv, xlsx or ndjson, csv by default"
+// @Param q query string false "query string to search in product name"
+// @Param categoryID query int false "only products of this category"
+// @Param attrs query string false "only variants with these attributes, ex: [{'type':'color', 'names': ['red', 'yellow']}, {'type':'size', 'names': ['36']}]"
+// @Param sortBy query string false "product name or id"
+// @Param orderBy query string false "ASC or DESC"
+// @Param Authorization header string true "Bearer"
+// @Router /exports/catalog [get]
+func (h *CatalogExportHandler) Export(c *fiber.Ctx) error {
+	format := strings.ToLower(c.Query("format", services.CatalogExportCSV))
+	var contentType string
+	switch format {
+	case services.CatalogExportCSV:
+		contentType = "text/csv"
+	case services.CatalogExportXLSX:
+		contentType = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	case services.CatalogExportNDJSON:
+		contentType = "application/x-ndjson"
+	default:
+		return c.SendStatus(fiber.StatusBadRequest)
+	}
+
+	query := dtos.CatalogExportQueryDto{
+		Q: c.Query("q"),
+	}
+	categoryID, err := strconv.Atoi(c.Query("categoryID", "0"))
+	if err != nil {
+		return c.SendStatus(fiber.StatusBadRequest)
+	}
+	query.CategoryID = categoryID
+	if attrs := c.Query("attrs"); len(attrs) > 0 {
+		if err := json.Unmarshal([]byte(attrs), &query.Attrs); err != nil {
+			return c.SendStatus(fiber.StatusBadRequest)
+		}
+	}
+	query.SortBy = strings.ToLower(c.Query("sortBy", "id"))
+	if query.SortBy != "id" && query.SortBy != "name" {
+		query.SortBy = "id"
+	}
+	query.OrderBy = strings.ToUpper(c.Query("orderBy", "ASC"))
+	if query.OrderBy != "ASC" && query.OrderBy != "DESC" {
+		query.OrderBy = "ASC"
+	}
+
+	c.Attachment("catalog." + format)
+	c.Set(fiber.HeaderContentType, contentType)
+
+	// the body is written after the handler returns, an error can only cut
+	// the file short
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		if err := h.service.Export(context.Background(), &query, format, w); err != nil {
+			log.Printf("Unable to export catalog: %v\n", err)
+		}
+	})
+
+	return nil
+}
@@ -0,0 +1,143 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/ysfada/product-management-system/domain/common"
+	"github.com/ysfada/product-management-system/domain/interfaces"
+)
+
+type CatalogImportHandler struct {
+	service interfaces.ICatalogImportService
+}
+
+func NewCatalogImportHandler(service interfaces.ICatalogImportService) *CatalogImportHandler {
+	return &CatalogImportHandler{
+		service: service,
+	}
+}
+
+var _ interfaces.ICatalogImportHandler = (*CatalogImportHandler)(nil)
+
+func (h *CatalogImportHandler) UseHandler(r fiber.Router) {
+	importsRouter := r.Group("imports")
+
+	importsRouter.Post("/", common.JwtMiddleware, h.Import)
+	importsRouter.Get("/:id", common.JwtMiddleware, h.GetByID)
+	importsRouter.Get("/:id/errors", common.JwtMiddleware, h.ExportErrors)
+}
+
+// CatalogImport godoc
+// @Summary Import catalog
+// @Description Create or update products, variants, categories and attributes from a CSV file with a header naming its columns:
+// @Description product, category, variant and price are required, description, stock (in the base unit, empty keeps the stock of an existing variant),
+// @Description attributes (type:value pairs separated by ";") and images (names of uploaded images separated by ";") are optional.
+// @Description Products are matched by name and variants by product and name. The attributes of a row replace those of its variant, the stock of serialized or lot tracked variants is kept.
+// @Description Rows are imported in transactions of batch_size rows, a row with errors is left out and reported without failing the others.
+// @Description A dry run reports what the import would do without changing anything
+// @Tags imports
+// @Accept multipart/form-data
+// @Produce json
+// @Success 201 {object} dtos.CatalogImportDto
+// @Failure 400 {object} string
+// @Failure 403 {object} string
+// @Failure 500 {object} string
+// @Param file formData file true "catalog csv"
+// @Param dry_run query bool false "only report what the import would do"
+// @Param batch_size query int false "rows per transaction, 500 by default, at most 10000"
+// @Param Authorization header string true "Bearer"
+// @Router /imports [post]
+func (h *CatalogImportHandler) Import(c *fiber.Ctx) error {
+	username, _, ok := currentUser(c)
+	if !ok {
+		return c.SendStatus(fiber.StatusForbidden)
+	}
+	dryRun, err := strconv.ParseBool(c.Query("dry_run", "false"))
+	if err != nil {
+		return c.SendStatus(fiber.StatusBadRequest)
+	}
+	batchSize, err := strconv.Atoi(c.Query("batch_size", "0"))
+	if err != nil {
+		return c.SendStatus(fiber.StatusBadRequest)
+	}
+
+	file, err := c.FormFile("file")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(err.Error())
+	}
+
+	if catalogImport, err := h.service.Import(c.Context(), file, dryRun, batchSize, username); err != nil {
+		if errors.Is(err, common.ErrBadParamInput) {
+			// the header of the file is invalid
+			return c.Status(fiber.StatusBadRequest).JSON(err.Error())
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(err)
+	} else {
+		return c.Status(fiber.StatusCreated).JSON(catalogImport)
+	}
+}
+
+// CatalogImport godoc
+// @Summary Get catalog import by id
+// @Description Get the report of a catalog import, dry runs included
+// @Tags imports
+// @Accept json
+// @Produce json
+// @Success 200 {object} dtos.CatalogImportDto
+// @Failure 400 {object} string
+// @Failure 404 {object} string
+// @Failure 500 {object} string
+// @Param id path int true "id"
+// @Param Authorization header string true "Bearer"
+// @Router /imports/{id} [get]
+func (h *CatalogImportHandler) GetByID(c *fiber.Ctx) error {
+	if id, err := c.ParamsInt("id"); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(err)
+	} else {
+		if catalogImport, err := h.service.GetByID(c.Context(), id); err != nil {
+			switch err {
+			case common.ErrNotFound:
+				return c.SendStatus(fiber.StatusNotFound)
+			default:
+				return c.Status(fiber.StatusInternalServerError).JSON(err)
+			}
+		} else {
+			return c.JSON(catalogImport)
+		}
+	}
+}
+
+// CatalogImport godoc
+// @Summary Get catalog import errors
+// @Description Download the errors of a catalog import as CSV with the columns line, column and message. An empty column means the whole row
+// @Tags imports
+// @Accept json
+// @Produce text/csv
+// @Success 200 {object} string
+// @Failure 400 {object} string
+// @Failure 404 {object} string
+// @Failure 500 {object} string
+// @Param id path int true "id"
+// @Param Authorization header string true "Bearer"
+// @Router /imports/{id}/errors [get]
+func (h *CatalogImportHandler) ExportErrors(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(err)
+	}
+
+	c.Set(fiber.HeaderContentType, "text/csv")
+	c.Attachment(fmt.Sprintf("import-%d-errors.csv", id))
+	if err := h.service.ExportErrors(c.Context(), id, c); err != nil {
+		switch err {
+		case common.ErrNotFound:
+			return c.SendStatus(fiber.StatusNotFound)
+		default:
+			return c.Status(fiber.StatusInternalServerError).JSON(err)
+		}
+	}
+	return nil
+}
@@ -103,6 +103,7 @@ func (h *CategoryHandler) GetByID(c *fiber.Ctx) error {
 				return c.Status(fiber.StatusInternalServerError).JSON(err)
 			}
 		} else {
+			setETag(c, category.Version)
 			return c.JSON(category)
 		}
 	}
@@ -116,9 +117,12 @@ func (h *CategoryHandler) GetByID(c *fiber.Ctx) error {
 // @Produce json
 // @Success 204
 // @Failure 400 {object} string
+// @Failure 404 {object} string
+// @Failure 412 {object} string
 // @Failure 500 {object} string
 // @Param id path int true "id"
 // @Param dto body dtos.UpdateCategoryDto true "dto"
+// @Param If-Match header string false "ETag of the item"
 // @Param Authorization header string true "Bearer"
 // @Router /categories/{id} [put]
 func (h *CategoryHandler) Update(c *fiber.Ctx) error {
@@ -130,10 +134,15 @@ func (h *CategoryHandler) Update(c *fiber.Ctx) error {
 		return c.SendStatus(fiber.StatusBadRequest)
 	}
 
+	body.Versions = ifMatch(c)
 	if err := h.service.Update(c.Context(), &body); err != nil {
 		switch err {
 		case common.ErrBadParamInput:
 			return c.SendStatus(fiber.StatusBadRequest)
+		case common.ErrNotFound:
+			return c.SendStatus(fiber.StatusNotFound)
+		case common.ErrPreconditionFailed:
+			return c.SendStatus(fiber.StatusPreconditionFailed)
 		default:
 			return c.Status(fiber.StatusInternalServerError).JSON(err)
 		}
@@ -178,18 +187,23 @@ func (h *CategoryHandler) Create(c *fiber.Ctx) error {
 // @Produce json
 // @Success 204
 // @Failure 400 {object} string
+// @Failure 404 {object} string
+// @Failure 412 {object} string
 // @Failure 500 {object} string
 // @Param id path int true "id"
+// @Param If-Match header string false "ETag of the item"
 // @Param Authorization header string true "Bearer"
 // @Router /categories/{id} [delete]
 func (h *CategoryHandler) Delete(c *fiber.Ctx) error {
 	if id, err := c.ParamsInt("id"); err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(err)
 	} else {
-		if err := h.service.Delete(c.Context(), id); err != nil {
+		if err := h.service.Delete(c.Context(), id, ifMatch(c)); err != nil {
 			switch err {
 			case common.ErrNotFound:
 				return c.SendStatus(fiber.StatusNotFound)
+			case common.ErrPreconditionFailed:
+				return c.SendStatus(fiber.StatusPreconditionFailed)
 			default:
 				return c.Status(fiber.StatusInternalServerError).JSON(err)
 			}
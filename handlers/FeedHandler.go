@@ -0,0 +1,87 @@
+package handlers
+
+import (
+	"bufio"
+	"context"
+	"log"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/ysfada/product-management-system/domain/common"
+	"github.com/ysfada/product-management-system/domain/interfaces"
+	"github.com/ysfada/product-management-system/services"
+)
+
+type FeedHandler struct {
+	service interfaces.IFeedService
+}
+
+func NewFeedHandler(service interfaces.IFeedService) *FeedHandler {
+	return &FeedHandler{
+		service: service,
+	}
+}
+
+var _ interfaces.IFeedHandler = (*FeedHandler)(nil)
+
+func (h *FeedHandler) UseHandler(r fiber.Router) {
+	feedsRouter := r.Group("feeds")
+
+	feedsRouter.Get("/google.xml", h.GoogleXML)
+	feedsRouter.Get("/google.tsv", h.GoogleTSV)
+	feedsRouter.Get("/google/diagnostics", common.JwtMiddleware, h.Diagnostics)
+}
+
+// Feed godoc
+// @Summary Get Google Merchant feed
+// @Description Get every variant as an RSS 2.0 item with the g: namespace, grouped into its product by item_group_id.
+// @Description Variants missing a required field are left out, see the diagnostics
+// @Tags feeds
+// @Produce xml
+// @Success 200 {object} string
+// @Router /feeds/google.xml [get]
+func (h *FeedHandler) GoogleXML(c *fiber.Ctx) error {
+	c.Set(fiber.HeaderContentType, "application/xml; charset=utf-8")
+	return h.stream(c, services.FeedXML)
+}
+
+// Feed godoc
+// @Summary Get Google Merchant feed as TSV
+// @Description Get every variant as a row of a tab separated feed with a header of the field names.
+// @Description Variants missing a required field are left out, see the diagnostics
+// @Tags feeds
+// @Produce text/tab-separated-values
+// @Success 200 {object} string
+// @Router /feeds/google.tsv [get]
+func (h *FeedHandler) GoogleTSV(c *fiber.Ctx) error {
+	c.Set(fiber.HeaderContentType, "text/tab-separated-values; charset=utf-8")
+	return h.stream(c, services.FeedTSV)
+}
+
+// Feed godoc
+// @Summary Get feed diagnostics
+// @Description Get the variants left out of the feeds and the required fields they are missing
+// @Tags feeds
+// @Accept json
+// @Produce json
+// @Success 200 {object} dtos.FeedDiagnosticsDto
+// @Failure 500 {object} string
+// @Param Authorization header string true "Bearer"
+// @Router /feeds/google/diagnostics [get]
+func (h *FeedHandler) Diagnostics(c *fiber.Ctx) error {
+	if diagnostics, err := h.service.Diagnostics(c.Context()); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(err)
+	} else {
+		return c.JSON(diagnostics)
+	}
+}
+
+func (h *FeedHandler) stream(c *fiber.Ctx, format string) error {
+	// the body is written after the handler returns, an error can only cut
+	// the feed short
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		if err := h.service.Write(context.Background(), format, w); err != nil {
+			log.Printf("Unable to write %s feed: %v\n", format, err)
+		}
+	})
+	return nil
+}
@@ -0,0 +1,237 @@
+package handlers
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator"
+	"github.com/gofiber/fiber/v2"
+	"github.com/ysfada/product-management-system/domain/common"
+	"github.com/ysfada/product-management-system/domain/dtos"
+	"github.com/ysfada/product-management-system/domain/interfaces"
+)
+
+const lowStockEventsHeartbeat = 15 * time.Second
+
+type InventoryHandler struct {
+	service interfaces.IInventoryService
+}
+
+func NewInventoryHandler(service interfaces.IInventoryService) *InventoryHandler {
+	return &InventoryHandler{
+		service: service,
+	}
+}
+
+var _ interfaces.IInventoryHandler = (*InventoryHandler)(nil)
+
+func (h *InventoryHandler) UseHandler(r fiber.Router) {
+	inventoryRouter := r.Group("inventory")
+
+	inventoryRouter.Get("/low-stock", common.JwtMiddleware, h.LowStock)
+	inventoryRouter.Get("/low-stock/events", common.JwtMiddleware, h.LowStockEvents)
+	inventoryRouter.Post("/receipts", common.JwtMiddleware, h.Receive)
+	inventoryRouter.Get("/valuation", common.JwtMiddleware, h.Valuation)
+}
+
+// Inventory godoc
+// @Summary Get low stock report
+// @Description Get variants whose available stock is at or below their reorder point
+// @Tags inventory
+// @Accept json
+// @Produce json,text/csv
+// @Success 200 {object} dtos.LowStockPaginatedDto
+// @Failure 400 {object} string
+// @Failure 500 {object} string
+// @Param categoryID query int false "only variants of this category"
+// @Param format query string false "json or csv, csv exports every page"
+// @Param page query int false "page number"
+// @Param size query int false "rows per page"
+// @Param sortBy query string false "id, name or available"
+// @Param orderBy query string false "ASC or DESC"
+// @Param Authorization header string true "Bearer"
+// @Router /inventory/low-stock [get]
+func (h *InventoryHandler) LowStock(c *fiber.Ctx) error {
+	categoryID, err := strconv.Atoi(c.Query("categoryID", "0"))
+	if err != nil {
+		return c.SendStatus(fiber.StatusBadRequest)
+	}
+
+	if strings.ToLower(c.Query("format", "json")) == "csv" {
+		c.Set(fiber.HeaderContentType, "text/csv")
+		c.Attachment("low-stock.csv")
+		if err := h.service.ExportLowStock(c.Context(), categoryID, c); err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(err)
+		}
+		return nil
+	}
+
+	page, err := strconv.Atoi(c.Query("page", "1"))
+	if err != nil {
+		return c.SendStatus(fiber.StatusBadRequest)
+	}
+	size, err := strconv.Atoi(c.Query("size", "10"))
+	if err != nil {
+		return c.SendStatus(fiber.StatusBadRequest)
+	}
+	sortBy := strings.ToLower(c.Query("sortBy", "id"))
+	if sortBy != "id" && sortBy != "name" && sortBy != "available" {
+		sortBy = "id"
+	}
+	orderBy := strings.ToUpper(c.Query("orderBy", "ASC"))
+	if orderBy != "ASC" && orderBy != "DESC" {
+		orderBy = "ASC"
+	}
+
+	if variants, err := h.service.LowStock(c.Context(), categoryID, page, size, sortBy, orderBy); err != nil {
+		switch err {
+		default:
+			return c.Status(fiber.StatusInternalServerError).JSON(err)
+		}
+	} else {
+		return c.JSON(variants)
+	}
+}
+
+// Inventory godoc
+// @Summary Subscribe to low stock events
+// @Description Stream a server-sent event whenever a variant crosses its reorder point
+// @Tags inventory
+// @Produce text/event-stream
+// @Success 200 {object} dtos.LowStockEventDto
+// @Param Authorization header string true "Bearer"
+// @Router /inventory/low-stock/events [get]
+func (h *InventoryHandler) LowStockEvents(c *fiber.Ctx) error {
+	c.Set(fiber.HeaderContentType, "text/event-stream")
+	c.Set(fiber.HeaderCacheControl, "no-cache")
+	c.Set(fiber.HeaderConnection, "keep-alive")
+
+	events := make(chan *dtos.LowStockEventDto, 16)
+	unsubscribe := h.service.Subscribe(func(event *dtos.LowStockEventDto) {
+		select {
+		case events <- event:
+		default:
+			// drop the event instead of blocking the other subscribers
+		}
+	})
+
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		defer unsubscribe()
+
+		heartbeat := time.NewTicker(lowStockEventsHeartbeat)
+		defer heartbeat.Stop()
+
+		for {
+			select {
+			case event := <-events:
+				data, err := json.Marshal(event)
+				if err != nil {
+					continue
+				}
+				fmt.Fprintf(w, "event: low_stock\ndata: %s\n\n", data)
+			case <-heartbeat.C:
+				fmt.Fprint(w, ": heartbeat\n\n")
+			}
+
+			// the client is gone once the flush fails
+			if err := w.Flush(); err != nil {
+				return
+			}
+		}
+	})
+
+	return nil
+}
+
+// Inventory godoc
+// @Summary Receive stock
+// @Description Add received units to the stock of a variant at their purchase cost
+// @Tags inventory
+// @Accept json
+// @Produce json
+// @Success 201
+// @Failure 400 {object} string
+// @Failure 403 {object} string
+// @Failure 404 {object} string
+// @Failure 500 {object} string
+// @Param dto body dtos.CreateStockReceiptDto true "dto"
+// @Param Authorization header string true "Bearer"
+// @Router /inventory/receipts [post]
+func (h *InventoryHandler) Receive(c *fiber.Ctx) error {
+	if _, isStaff, ok := currentUser(c); !ok || !isStaff {
+		return c.SendStatus(fiber.StatusForbidden)
+	}
+
+	var body dtos.CreateStockReceiptDto
+	if err := c.BodyParser(&body); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(err)
+	}
+
+	if err := h.service.Receive(c.Context(), &body); err != nil {
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			return c.Status(fiber.StatusBadRequest).JSON(validationErrors.Error())
+		}
+		switch err {
+		case common.ErrBadParamInput:
+			return c.SendStatus(fiber.StatusBadRequest)
+		case common.ErrNotFound:
+			return c.SendStatus(fiber.StatusNotFound)
+		default:
+			return c.Status(fiber.StatusInternalServerError).JSON(err)
+		}
+	}
+
+	return c.SendStatus(fiber.StatusCreated)
+}
+
+// Inventory godoc
+// @Summary Get inventory valuation
+// @Description Get the value of the stock per variant, per category and overall as of a date
+// @Tags inventory
+// @Accept json
+// @Produce json
+// @Success 200 {object} dtos.ValuationDto
+// @Failure 400 {object} string
+// @Failure 403 {object} string
+// @Failure 500 {object} string
+// @Param categoryID query int false "only variants of this category"
+// @Param method query string false "fifo or average, defaults to INVENTORY_COSTING_METHOD"
+// @Param asOf query string false "RFC 3339 time or YYYY-MM-DD date, defaults to now"
+// @Param Authorization header string true "Bearer"
+// @Router /inventory/valuation [get]
+func (h *InventoryHandler) Valuation(c *fiber.Ctx) error {
+	if _, isStaff, ok := currentUser(c); !ok || !isStaff {
+		return c.SendStatus(fiber.StatusForbidden)
+	}
+
+	categoryID, err := strconv.Atoi(c.Query("categoryID", "0"))
+	if err != nil {
+		return c.SendStatus(fiber.StatusBadRequest)
+	}
+	asOf := time.Now()
+	if value := c.Query("asOf"); len(value) > 0 {
+		if asOf, err = time.Parse(time.RFC3339, value); err != nil {
+			// a date values the stock at the end of that day
+			date, err := time.Parse("2006-01-02", value)
+			if err != nil {
+				return c.SendStatus(fiber.StatusBadRequest)
+			}
+			asOf = date.AddDate(0, 0, 1).Add(-time.Nanosecond)
+		}
+	}
+
+	if valuation, err := h.service.Valuation(c.Context(), categoryID, c.Query("method"), asOf); err != nil {
+		switch err {
+		case common.ErrBadParamInput:
+			return c.SendStatus(fiber.StatusBadRequest)
+		default:
+			return c.Status(fiber.StatusInternalServerError).JSON(err)
+		}
+	} else {
+		return c.JSON(valuation)
+	}
+}
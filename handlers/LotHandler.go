@@ -0,0 +1,349 @@
+package handlers
+
+import (
+	"strconv"
+
+	"github.com/go-playground/validator"
+	"github.com/gofiber/fiber/v2"
+	"github.com/ysfada/product-management-system/domain/common"
+	"github.com/ysfada/product-management-system/domain/dtos"
+	"github.com/ysfada/product-management-system/domain/interfaces"
+)
+
+type LotHandler struct {
+	service interfaces.ILotService
+}
+
+func NewLotHandler(service interfaces.ILotService) *LotHandler {
+	return &LotHandler{
+		service: service,
+	}
+}
+
+var _ interfaces.ILotHandler = (*LotHandler)(nil)
+
+func (h *LotHandler) UseHandler(r fiber.Router) {
+	lotsRouter := r.Group("lots")
+
+	lotsRouter.Get("/expiring", common.JwtMiddleware, h.Expiring)
+
+	variantLotsRouter := r.Group("products/:id/variants/:variantID/lots")
+
+	variantLotsRouter.Get("/", common.JwtMiddleware, h.Fetch)
+	variantLotsRouter.Post("/", common.JwtMiddleware, h.Create)
+	variantLotsRouter.Get("/picking", common.JwtMiddleware, h.Pick)
+	variantLotsRouter.Get("/:lotID", common.JwtMiddleware, h.GetByID)
+	variantLotsRouter.Put("/:lotID", common.JwtMiddleware, h.Update)
+	variantLotsRouter.Delete("/:lotID", common.JwtMiddleware, h.Delete)
+}
+
+// Lot godoc
+// @Summary Get product variant lots
+// @Description Get the lots of a product variant, first expired first
+// @Tags lots
+// @Accept json
+// @Produce json
+// @Success 200 {array} dtos.LotDto
+// @Failure 400 {object} string
+// @Failure 500 {object} string
+// @Param id path int true "id"
+// @Param variantID path int true "variantID"
+// @Param Authorization header string true "Bearer"
+// @Router /products/{id}/variants/{variantID}/lots [get]
+func (h *LotHandler) Fetch(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(err)
+	}
+	variantID, err := c.ParamsInt("variantID")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(err)
+	}
+
+	if lots, err := h.service.Fetch(c.Context(), id, variantID); err != nil {
+		switch err {
+		default:
+			return c.Status(fiber.StatusInternalServerError).JSON(err)
+		}
+	} else {
+		return c.JSON(lots)
+	}
+}
+
+// Lot godoc
+// @Summary Get lot by id
+// @Description Get lot of a product variant by id
+// @Tags lots
+// @Accept json
+// @Produce json
+// @Success 200 {object} dtos.LotDto
+// @Failure 400 {object} string
+// @Failure 404 {object} string
+// @Failure 500 {object} string
+// @Param id path int true "id"
+// @Param variantID path int true "variantID"
+// @Param lotID path int true "lotID"
+// @Param Authorization header string true "Bearer"
+// @Router /products/{id}/variants/{variantID}/lots/{lotID} [get]
+func (h *LotHandler) GetByID(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(err)
+	}
+	variantID, err := c.ParamsInt("variantID")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(err)
+	}
+	lotID, err := c.ParamsInt("lotID")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(err)
+	}
+
+	if lot, err := h.service.GetByID(c.Context(), id, variantID, lotID); err != nil {
+		switch err {
+		case common.ErrNotFound:
+			return c.SendStatus(fiber.StatusNotFound)
+		default:
+			return c.Status(fiber.StatusInternalServerError).JSON(err)
+		}
+	} else {
+		return c.JSON(lot)
+	}
+}
+
+// Lot godoc
+// @Summary Create lot
+// @Description Receive a new lot of a product variant, its quantity is added to the stock
+// @Tags lots
+// @Accept json
+// @Produce json
+// @Success 201 {object} string
+// @Failure 400 {object} string
+// @Failure 404 {object} string
+// @Failure 409 {object} string
+// @Failure 500 {object} string
+// @Param id path int true "id"
+// @Param variantID path int true "variantID"
+// @Param dto body dtos.CreateLotDto true "dto"
+// @Param Authorization header string true "Bearer"
+// @Router /products/{id}/variants/{variantID}/lots [post]
+func (h *LotHandler) Create(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(err)
+	}
+	variantID, err := c.ParamsInt("variantID")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(err)
+	}
+
+	var body dtos.CreateLotDto
+	if err := c.BodyParser(&body); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(err)
+	}
+
+	if id != body.ProductID || variantID != body.ProductVariantID {
+		return c.SendStatus(fiber.StatusBadRequest)
+	}
+
+	if err := h.service.Create(c.Context(), &body); err != nil {
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			return c.Status(fiber.StatusBadRequest).JSON(validationErrors.Error())
+		}
+		switch err {
+		case common.ErrBadParamInput:
+			return c.SendStatus(fiber.StatusBadRequest)
+		case common.ErrNotFound:
+			return c.SendStatus(fiber.StatusNotFound)
+		case common.ErrConflict:
+			return c.SendStatus(fiber.StatusConflict)
+		default:
+			return c.Status(fiber.StatusInternalServerError).JSON(err)
+		}
+	}
+	return c.SendStatus(fiber.StatusCreated)
+}
+
+// Lot godoc
+// @Summary Update lot
+// @Description Update lot of a product variant by id, the stock follows its quantity
+// @Tags lots
+// @Accept json
+// @Produce json
+// @Success 204
+// @Failure 400 {object} string
+// @Failure 404 {object} string
+// @Failure 409 {object} string
+// @Failure 500 {object} string
+// @Param id path int true "id"
+// @Param variantID path int true "variantID"
+// @Param lotID path int true "lotID"
+// @Param dto body dtos.UpdateLotDto true "dto"
+// @Param Authorization header string true "Bearer"
+// @Router /products/{id}/variants/{variantID}/lots/{lotID} [put]
+func (h *LotHandler) Update(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(err)
+	}
+	variantID, err := c.ParamsInt("variantID")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(err)
+	}
+	lotID, err := c.ParamsInt("lotID")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(err)
+	}
+
+	var body dtos.UpdateLotDto
+	if err := c.BodyParser(&body); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(err)
+	}
+
+	if id != body.ProductID || variantID != body.ProductVariantID || lotID != body.ID {
+		return c.SendStatus(fiber.StatusBadRequest)
+	}
+
+	if err := h.service.Update(c.Context(), &body); err != nil {
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			return c.Status(fiber.StatusBadRequest).JSON(validationErrors.Error())
+		}
+		switch err {
+		case common.ErrBadParamInput:
+			return c.SendStatus(fiber.StatusBadRequest)
+		case common.ErrNotFound:
+			return c.SendStatus(fiber.StatusNotFound)
+		case common.ErrConflict:
+			return c.SendStatus(fiber.StatusConflict)
+		default:
+			return c.Status(fiber.StatusInternalServerError).JSON(err)
+		}
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// Lot godoc
+// @Summary Delete lot
+// @Description Delete lot of a product variant by id, its quantity is removed from the stock
+// @Tags lots
+// @Accept json
+// @Produce json
+// @Success 204
+// @Failure 400 {object} string
+// @Failure 404 {object} string
+// @Failure 500 {object} string
+// @Param id path int true "id"
+// @Param variantID path int true "variantID"
+// @Param lotID path int true "lotID"
+// @Param Authorization header string true "Bearer"
+// @Router /products/{id}/variants/{variantID}/lots/{lotID} [delete]
+func (h *LotHandler) Delete(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(err)
+	}
+	variantID, err := c.ParamsInt("variantID")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(err)
+	}
+	lotID, err := c.ParamsInt("lotID")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(err)
+	}
+
+	if err := h.service.Delete(c.Context(), id, variantID, lotID); err != nil {
+		switch err {
+		case common.ErrBadParamInput:
+			return c.SendStatus(fiber.StatusBadRequest)
+		case common.ErrNotFound:
+			return c.SendStatus(fiber.StatusNotFound)
+		default:
+			return c.Status(fiber.StatusInternalServerError).JSON(err)
+		}
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// Lot godoc
+// @Summary Get expiring lots
+// @Description Get the lots in stock that expire within the given number of days, expired lots included
+// @Tags lots
+// @Accept json
+// @Produce json
+// @Success 200 {object} dtos.ExpiringLotPaginatedDto
+// @Failure 400 {object} string
+// @Failure 500 {object} string
+// @Param days query int false "days from today, defaults to 30"
+// @Param page query int false "page number"
+// @Param size query int false "rows per page"
+// @Param Authorization header string true "Bearer"
+// @Router /lots/expiring [get]
+func (h *LotHandler) Expiring(c *fiber.Ctx) error {
+	days, err := strconv.Atoi(c.Query("days", "30"))
+	if err != nil || days < 0 {
+		return c.SendStatus(fiber.StatusBadRequest)
+	}
+	page, err := strconv.Atoi(c.Query("page", "1"))
+	if err != nil {
+		return c.SendStatus(fiber.StatusBadRequest)
+	}
+	size, err := strconv.Atoi(c.Query("size", "10"))
+	if err != nil {
+		return c.SendStatus(fiber.StatusBadRequest)
+	}
+
+	if lots, err := h.service.Expiring(c.Context(), days, page, size); err != nil {
+		switch err {
+		default:
+			return c.Status(fiber.StatusInternalServerError).JSON(err)
+		}
+	} else {
+		return c.JSON(lots)
+	}
+}
+
+// Lot godoc
+// @Summary Suggest lots to pick
+// @Description Allocate a quantity of a product variant from its lots first-expired-first-out, expired lots are skipped
+// @Tags lots
+// @Accept json
+// @Produce json
+// @Success 200 {object} dtos.PickingDto
+// @Failure 400 {object} string
+// @Failure 404 {object} string
+// @Failure 409 {object} string
+// @Failure 500 {object} string
+// @Param id path int true "id"
+// @Param variantID path int true "variantID"
+// @Param quantity query number true "quantity to pick"
+// @Param Authorization header string true "Bearer"
+// @Router /products/{id}/variants/{variantID}/lots/picking [get]
+func (h *LotHandler) Pick(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(err)
+	}
+	variantID, err := c.ParamsInt("variantID")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(err)
+	}
+	quantity, err := strconv.ParseFloat(c.Query("quantity"), 64)
+	if err != nil {
+		return c.SendStatus(fiber.StatusBadRequest)
+	}
+
+	if picking, err := h.service.Pick(c.Context(), id, variantID, quantity); err != nil {
+		switch err {
+		case common.ErrBadParamInput:
+			return c.SendStatus(fiber.StatusBadRequest)
+		case common.ErrNotFound:
+			return c.SendStatus(fiber.StatusNotFound)
+		case common.ErrInsufficientStock:
+			return c.Status(fiber.StatusConflict).JSON(err.Error())
+		default:
+			return c.Status(fiber.StatusInternalServerError).JSON(err)
+		}
+	} else {
+		return c.JSON(picking)
+	}
+}
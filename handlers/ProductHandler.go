@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"strconv"
 	"strings"
@@ -36,10 +37,10 @@ func (h *ProductHandler) UseHandler(r fiber.Router) {
 	productsRouter.Get("/:id/images", h.GetImages)
 	productsRouter.Post("/:id/images", common.JwtMiddleware, h.AddImage)
 	productsRouter.Delete("/:id/images/:imageID", common.JwtMiddleware, h.RemoveImage)
-	productsRouter.Get("/:id/variants", h.FetchVariants)
+	productsRouter.Get("/:id/variants", common.OptionalJwtMiddleware, h.FetchVariants)
 	productsRouter.Post("/:id/variants", common.JwtMiddleware, h.CreateVariant)
-	productsRouter.Get("/:id/variants/search", h.SearchVariants)
-	productsRouter.Get("/:id/variants/:variantID", h.GetVariantByID)
+	productsRouter.Get("/:id/variants/search", common.OptionalJwtMiddleware, h.SearchVariants)
+	productsRouter.Get("/:id/variants/:variantID", common.OptionalJwtMiddleware, h.GetVariantByID)
 	productsRouter.Put("/:id/variants/:variantID", common.JwtMiddleware, h.UpdateVariant)
 	productsRouter.Delete("/:id/variants/:variantID", common.JwtMiddleware, h.DeleteVariant)
 	productsRouter.Get("/:id/variants/:variantID/attributes", h.GetAttributes)
@@ -93,20 +94,26 @@ func (h *ProductHandler) Fetch(c *fiber.Ctx) error {
 
 // Product godoc
 // @Summary Get product by id
-// @Description Get product by id
+// @Description Get product by id. With format=jsonld or an Accept header preferring application/ld+json
+// @Description the product is a schema.org Product with an Offer per variant, see dtos.ProductJSONLDDto
 // @Tags products
 // @Accept json
-// @Produce json
+// @Produce json,application/ld+json
 // @Success 200 {object} dtos.ProductDto
 // @Failure 400 {object} string
 // @Failure 404 {object} string
 // @Failure 500 {object} string
 // @Param id path int true "id"
+// @Param format query string false "json or jsonld"
 // @Router /products/{id} [get]
 func (h *ProductHandler) GetByID(c *fiber.Ctx) error {
 	if id, err := c.ParamsInt("id"); err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(err)
 	} else {
+		if c.Query("format") == "jsonld" || c.Accepts(fiber.MIMEApplicationJSON, "application/ld+json") == "application/ld+json" {
+			return h.getJSONLD(c, id)
+		}
+
 		if product, err := h.service.GetByID(c.Context(), id); err != nil {
 			switch err {
 			case common.ErrNotFound:
@@ -115,11 +122,29 @@ func (h *ProductHandler) GetByID(c *fiber.Ctx) error {
 				return c.Status(fiber.StatusInternalServerError).JSON(err)
 			}
 		} else {
+			setETag(c, product.Version)
 			return c.JSON(product)
 		}
 	}
 }
 
+func (h *ProductHandler) getJSONLD(c *fiber.Ctx, id int) error {
+	if product, err := h.service.GetJSONLD(c.Context(), id); err != nil {
+		switch err {
+		case common.ErrNotFound:
+			return c.SendStatus(fiber.StatusNotFound)
+		default:
+			return c.Status(fiber.StatusInternalServerError).JSON(err)
+		}
+	} else {
+		if err := c.JSON(product); err != nil {
+			return err
+		}
+		c.Set(fiber.HeaderContentType, "application/ld+json")
+		return nil
+	}
+}
+
 // Product godoc
 // @Summary Update product
 // @Description Update product by id
@@ -128,9 +153,12 @@ func (h *ProductHandler) GetByID(c *fiber.Ctx) error {
 // @Produce json
 // @Success 204
 // @Failure 400 {object} string
+// @Failure 404 {object} string
+// @Failure 412 {object} string
 // @Failure 500 {object} string
 // @Param id path int true "id"
 // @Param dto body dtos.UpdateProductDto true "dto"
+// @Param If-Match header string false "ETag of the item"
 // @Param Authorization header string true "Bearer"
 // @Router /products/{id} [put]
 func (h *ProductHandler) Update(c *fiber.Ctx) error {
@@ -142,10 +170,15 @@ func (h *ProductHandler) Update(c *fiber.Ctx) error {
 		return c.SendStatus(fiber.StatusBadRequest)
 	}
 
+	body.Versions = ifMatch(c)
 	if err := h.service.Update(c.Context(), &body); err != nil {
 		switch err {
 		case common.ErrBadParamInput:
 			return c.SendStatus(fiber.StatusBadRequest)
+		case common.ErrNotFound:
+			return c.SendStatus(fiber.StatusNotFound)
+		case common.ErrPreconditionFailed:
+			return c.SendStatus(fiber.StatusPreconditionFailed)
 		default:
 			return c.Status(fiber.StatusInternalServerError).JSON(err)
 		}
@@ -190,18 +223,23 @@ func (h *ProductHandler) Create(c *fiber.Ctx) error {
 // @Produce json
 // @Success 204
 // @Failure 400 {object} string
+// @Failure 404 {object} string
+// @Failure 412 {object} string
 // @Failure 500 {object} string
 // @Param id path int true "id"
+// @Param If-Match header string false "ETag of the item"
 // @Param Authorization header string true "Bearer"
 // @Router /products/{id} [delete]
 func (h *ProductHandler) Delete(c *fiber.Ctx) error {
 	if id, err := c.ParamsInt("id"); err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(err)
 	} else {
-		if err := h.service.Delete(c.Context(), id); err != nil {
+		if err := h.service.Delete(c.Context(), id, ifMatch(c)); err != nil {
 			switch err {
 			case common.ErrNotFound:
 				return c.SendStatus(fiber.StatusNotFound)
+			case common.ErrPreconditionFailed:
+				return c.SendStatus(fiber.StatusPreconditionFailed)
 			default:
 				return c.Status(fiber.StatusInternalServerError).JSON(err)
 			}
@@ -290,12 +328,16 @@ func (h *ProductHandler) GetImages(c *fiber.Ctx) error {
 
 // Product godoc
 // @Summary Add image to product
-// @Description Add new image to product
+// @Description Add new image to product, a JPEG, PNG, GIF or WebP image. It is resized into the renditions of IMAGE_RENDITIONS.
+// @Description The format is sniffed from the content against IMAGE_FORMATS, the size is capped by IMAGE_MAX_BYTES and the dimensions by IMAGE_MAX_WIDTH, IMAGE_MAX_HEIGHT and IMAGE_MAX_PIXELS.
+// @Description EXIF, XMP and text metadata is stripped
 // @Tags products
-// @Accept json
+// @Accept multipart/form-data
 // @Produce json
 // @Success 201 {object} string
 // @Failure 400 {object} string
+// @Failure 413 {object} string
+// @Failure 415 {object} string
 // @Failure 500 {object} string
 // @Param image formData file true "product image"
 // @Param id path int true "id"
@@ -308,9 +350,18 @@ func (h *ProductHandler) AddImage(c *fiber.Ctx) error {
 	}
 
 	if file, err := c.FormFile("image"); err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(err)
+		return c.Status(fiber.StatusBadRequest).JSON(err.Error())
 	} else {
 		if err := h.service.AddImage(c.Context(), id, file); err != nil {
+			switch {
+			case errors.Is(err, common.ErrTooLarge):
+				return c.Status(fiber.StatusRequestEntityTooLarge).JSON(err.Error())
+			case errors.Is(err, common.ErrUnsupportedMediaType):
+				return c.Status(fiber.StatusUnsupportedMediaType).JSON(err.Error())
+			case errors.Is(err, common.ErrBadParamInput):
+				// the file is not a valid image or its dimensions are too large
+				return c.Status(fiber.StatusBadRequest).JSON(err.Error())
+			}
 			return c.Status(fiber.StatusInternalServerError).JSON(err)
 		} else {
 			return c.SendStatus(fiber.StatusCreated)
@@ -362,6 +413,7 @@ func (h *ProductHandler) RemoveImage(c *fiber.Ctx) error {
 // @Param sortBy query string false "name or id"
 // @Param orderBy query string false "ASC or DESC"
 // @Param id path int true "id"
+// @Param Authorization header string false "Bearer, staff users see costs and margins"
 // @Router /products/{id}/variants [get]
 func (h *ProductHandler) FetchVariants(c *fiber.Ctx) error {
 	id, err := c.ParamsInt("id")
@@ -393,6 +445,7 @@ func (h *ProductHandler) FetchVariants(c *fiber.Ctx) error {
 			return c.Status(fiber.StatusInternalServerError).JSON(err)
 		}
 	} else {
+		hideCosts(c, products.ProductVariants...)
 		return c.JSON(products)
 	}
 }
@@ -409,6 +462,7 @@ func (h *ProductHandler) FetchVariants(c *fiber.Ctx) error {
 // @Failure 500 {object} string
 // @Param id path int true "id"
 // @Param variantID path int true "variantID"
+// @Param Authorization header string false "Bearer, staff users see costs and margins"
 // @Router /products/{id}/variants/{variantID} [get]
 func (h *ProductHandler) GetVariantByID(c *fiber.Ctx) error {
 	id, err := c.ParamsInt("id")
@@ -428,6 +482,8 @@ func (h *ProductHandler) GetVariantByID(c *fiber.Ctx) error {
 			return c.Status(fiber.StatusInternalServerError).JSON(err)
 		}
 	} else {
+		hideCosts(c, product)
+		setETag(c, product.Version)
 		return c.JSON(product)
 	}
 }
@@ -479,10 +535,13 @@ func (h *ProductHandler) CreateVariant(c *fiber.Ctx) error {
 // @Produce json
 // @Success 204
 // @Failure 400 {object} string
+// @Failure 404 {object} string
+// @Failure 412 {object} string
 // @Failure 500 {object} string
 // @Param id path int true "id"
 // @Param variantID path int true "variantID"
 // @Param dto body dtos.UpdateProductVariantDto true "dto"
+// @Param If-Match header string false "ETag of the item"
 // @Param Authorization header string true "Bearer"
 // @Router /products/{id}/variants/{variantID} [put]
 func (h *ProductHandler) UpdateVariant(c *fiber.Ctx) error {
@@ -499,10 +558,15 @@ func (h *ProductHandler) UpdateVariant(c *fiber.Ctx) error {
 		return c.SendStatus(fiber.StatusBadRequest)
 	}
 
+	body.Versions = ifMatch(c)
 	if err := h.service.UpdateVariant(c.Context(), &body); err != nil {
 		switch err {
 		case common.ErrBadParamInput:
 			return c.SendStatus(fiber.StatusBadRequest)
+		case common.ErrNotFound:
+			return c.SendStatus(fiber.StatusNotFound)
+		case common.ErrPreconditionFailed:
+			return c.SendStatus(fiber.StatusPreconditionFailed)
 		default:
 			return c.Status(fiber.StatusInternalServerError).JSON(err)
 		}
@@ -518,9 +582,12 @@ func (h *ProductHandler) UpdateVariant(c *fiber.Ctx) error {
 // @Produce json
 // @Success 204
 // @Failure 400 {object} string
+// @Failure 404 {object} string
+// @Failure 412 {object} string
 // @Failure 500 {object} string
 // @Param id path int true "id"
 // @Param variantID path int true "variantID"
+// @Param If-Match header string false "ETag of the item"
 // @Param Authorization header string true "Bearer"
 // @Router /products/{id}/variants/{variantID} [delete]
 func (h *ProductHandler) DeleteVariant(c *fiber.Ctx) error {
@@ -534,10 +601,12 @@ func (h *ProductHandler) DeleteVariant(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusBadRequest).JSON(err)
 	}
 
-	if err := h.service.DeleteVariant(c.Context(), id, variantID); err != nil {
+	if err := h.service.DeleteVariant(c.Context(), id, variantID, ifMatch(c)); err != nil {
 		switch err {
 		case common.ErrNotFound:
 			return c.SendStatus(fiber.StatusNotFound)
+		case common.ErrPreconditionFailed:
+			return c.SendStatus(fiber.StatusPreconditionFailed)
 		default:
 			return c.Status(fiber.StatusInternalServerError).JSON(err)
 		}
@@ -676,6 +745,7 @@ func (h *ProductHandler) RemoveAttribute(c *fiber.Ctx) error {
 // @Param sortBy query string false "id, name, price"
 // @Param orderBy query string false "ASC or DESC"
 // @Param id path int true "id"
+// @Param Authorization header string false "Bearer, staff users see costs and margins"
 // @Router /products/{id}/variants/search [get]
 func (h *ProductHandler) SearchVariants(c *fiber.Ctx) error {
 	q := c.Query("q")
@@ -720,6 +790,21 @@ func (h *ProductHandler) SearchVariants(c *fiber.Ctx) error {
 			return c.Status(fiber.StatusInternalServerError).JSON(err)
 		}
 	} else {
+		hideCosts(c, products.ProductVariants...)
 		return c.JSON(products)
 	}
 }
+
+// hideCosts removes the unit cost and the margin of the variants unless the
+// user is staff.
+func hideCosts(c *fiber.Ctx, variants ...*dtos.ProductVariantDto) {
+	if _, isStaff, ok := currentUser(c); ok && isStaff {
+		return
+	}
+
+	for _, variant := range variants {
+		variant.UnitCost = nil
+		variant.Margin = nil
+		variant.MarginPercent = nil
+	}
+}
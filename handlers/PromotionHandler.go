@@ -0,0 +1,261 @@
+package handlers
+
+import (
+	"strconv"
+
+	"github.com/go-playground/validator"
+	"github.com/gofiber/fiber/v2"
+	"github.com/ysfada/product-management-system/domain/common"
+	"github.com/ysfada/product-management-system/domain/dtos"
+	"github.com/ysfada/product-management-system/domain/interfaces"
+)
+
+type PromotionHandler struct {
+	service interfaces.IPromotionService
+}
+
+func NewPromotionHandler(service interfaces.IPromotionService) *PromotionHandler {
+	return &PromotionHandler{
+		service: service,
+	}
+}
+
+var _ interfaces.IPromotionHandler = (*PromotionHandler)(nil)
+
+func (h *PromotionHandler) UseHandler(r fiber.Router) {
+	promotionsRouter := r.Group("promotions")
+
+	promotionsRouter.Get("/", common.JwtMiddleware, h.Fetch)
+	promotionsRouter.Post("/", common.JwtMiddleware, h.Create)
+	promotionsRouter.Post("/evaluate", h.Evaluate)
+	promotionsRouter.Post("/redeem", common.JwtMiddleware, h.Redeem)
+	promotionsRouter.Get("/:id", common.JwtMiddleware, h.GetByID)
+	promotionsRouter.Put("/:id", common.JwtMiddleware, h.Update)
+	promotionsRouter.Delete("/:id", common.JwtMiddleware, h.Delete)
+}
+
+// Promotion godoc
+// @Summary Get promotions
+// @Description Get all promotions, newest first
+// @Tags promotions
+// @Accept json
+// @Produce json
+// @Success 200 {object} dtos.PromotionPaginatedDto
+// @Failure 400 {object} string
+// @Failure 500 {object} string
+// @Param page query int false "page number"
+// @Param size query int false "rows per page"
+// @Param Authorization header string true "Bearer"
+// @Router /promotions [get]
+func (h *PromotionHandler) Fetch(c *fiber.Ctx) error {
+	page, err := strconv.Atoi(c.Query("page", "1"))
+	if err != nil {
+		return c.SendStatus(fiber.StatusBadRequest)
+	}
+	size, err := strconv.Atoi(c.Query("size", "10"))
+	if err != nil {
+		return c.SendStatus(fiber.StatusBadRequest)
+	}
+
+	if promotions, err := h.service.Fetch(c.Context(), page, size); err != nil {
+		switch err {
+		default:
+			return c.Status(fiber.StatusInternalServerError).JSON(err)
+		}
+	} else {
+		return c.JSON(promotions)
+	}
+}
+
+// Promotion godoc
+// @Summary Get promotion by id
+// @Description Get promotion by id
+// @Tags promotions
+// @Accept json
+// @Produce json
+// @Success 200 {object} dtos.PromotionDto
+// @Failure 400 {object} string
+// @Failure 404 {object} string
+// @Failure 500 {object} string
+// @Param id path int true "id"
+// @Param Authorization header string true "Bearer"
+// @Router /promotions/{id} [get]
+func (h *PromotionHandler) GetByID(c *fiber.Ctx) error {
+	if id, err := c.ParamsInt("id"); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(err)
+	} else {
+		if promotion, err := h.service.GetByID(c.Context(), id); err != nil {
+			switch err {
+			case common.ErrNotFound:
+				return c.SendStatus(fiber.StatusNotFound)
+			default:
+				return c.Status(fiber.StatusInternalServerError).JSON(err)
+			}
+		} else {
+			return c.JSON(promotion)
+		}
+	}
+}
+
+// Promotion godoc
+// @Summary Create promotion
+// @Description Create a percent_off, buy_x_get_y or amount_off_order promotion, with a coupon code or applied automatically
+// @Tags promotions
+// @Accept json
+// @Produce json
+// @Success 201
+// @Failure 400 {object} string
+// @Failure 404 {object} string
+// @Failure 409 {object} string
+// @Failure 500 {object} string
+// @Param dto body dtos.CreatePromotionDto true "dto"
+// @Param Authorization header string true "Bearer"
+// @Router /promotions [post]
+func (h *PromotionHandler) Create(c *fiber.Ctx) error {
+	var body dtos.CreatePromotionDto
+	if err := c.BodyParser(&body); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(err)
+	}
+
+	if err := h.service.Create(c.Context(), &body); err != nil {
+		return h.sendError(c, err)
+	}
+
+	return c.SendStatus(fiber.StatusCreated)
+}
+
+// Promotion godoc
+// @Summary Update promotion
+// @Description Update promotion by id, the uses so far are kept
+// @Tags promotions
+// @Accept json
+// @Produce json
+// @Success 204
+// @Failure 400 {object} string
+// @Failure 404 {object} string
+// @Failure 409 {object} string
+// @Failure 500 {object} string
+// @Param id path int true "id"
+// @Param dto body dtos.UpdatePromotionDto true "dto"
+// @Param Authorization header string true "Bearer"
+// @Router /promotions/{id} [put]
+func (h *PromotionHandler) Update(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(err)
+	}
+
+	var body dtos.UpdatePromotionDto
+	if err := c.BodyParser(&body); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(err)
+	}
+	body.ID = id
+
+	if err := h.service.Update(c.Context(), &body); err != nil {
+		return h.sendError(c, err)
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// Promotion godoc
+// @Summary Delete promotion
+// @Description Delete promotion by id
+// @Tags promotions
+// @Accept json
+// @Produce json
+// @Success 204
+// @Failure 400 {object} string
+// @Failure 404 {object} string
+// @Failure 500 {object} string
+// @Param id path int true "id"
+// @Param Authorization header string true "Bearer"
+// @Router /promotions/{id} [delete]
+func (h *PromotionHandler) Delete(c *fiber.Ctx) error {
+	if id, err := c.ParamsInt("id"); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(err)
+	} else {
+		if err := h.service.Delete(c.Context(), id); err != nil {
+			switch err {
+			case common.ErrNotFound:
+				return c.SendStatus(fiber.StatusNotFound)
+			default:
+				return c.Status(fiber.StatusInternalServerError).JSON(err)
+			}
+		}
+		return c.SendStatus(fiber.StatusNoContent)
+	}
+}
+
+// Promotion godoc
+// @Summary Evaluate promotions
+// @Description Apply the running automatic promotions and the promotions of the codes to the lines at the current prices. Returns the discounts line by line with explanations and the status of every code, nothing is used up
+// @Tags promotions
+// @Accept json
+// @Produce json
+// @Success 200 {object} dtos.PromotionEvaluationDto
+// @Failure 400 {object} string
+// @Failure 404 {object} string
+// @Failure 500 {object} string
+// @Param dto body dtos.EvaluatePromotionsDto true "dto"
+// @Router /promotions/evaluate [post]
+func (h *PromotionHandler) Evaluate(c *fiber.Ctx) error {
+	var body dtos.EvaluatePromotionsDto
+	if err := c.BodyParser(&body); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(err)
+	}
+
+	if evaluation, err := h.service.Evaluate(c.Context(), &body); err != nil {
+		return h.sendError(c, err)
+	} else {
+		return c.JSON(evaluation)
+	}
+}
+
+// Promotion godoc
+// @Summary Redeem promotions
+// @Description Evaluate the promotions for the lines of a sales order like evaluate does and count a use of every promotion which gave a discount, once per order, staff only
+// @Tags promotions
+// @Accept json
+// @Produce json
+// @Success 200 {object} dtos.PromotionEvaluationDto
+// @Failure 400 {object} string
+// @Failure 403 {object} string
+// @Failure 404 {object} string
+// @Failure 409 {object} string
+// @Failure 500 {object} string
+// @Param dto body dtos.RedeemPromotionsDto true "dto"
+// @Param Authorization header string true "Bearer"
+// @Router /promotions/redeem [post]
+func (h *PromotionHandler) Redeem(c *fiber.Ctx) error {
+	if _, isStaff, ok := currentUser(c); !ok || !isStaff {
+		return c.SendStatus(fiber.StatusForbidden)
+	}
+
+	var body dtos.RedeemPromotionsDto
+	if err := c.BodyParser(&body); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(err)
+	}
+
+	if evaluation, err := h.service.Redeem(c.Context(), &body); err != nil {
+		return h.sendError(c, err)
+	} else {
+		return c.JSON(evaluation)
+	}
+}
+
+func (h *PromotionHandler) sendError(c *fiber.Ctx, err error) error {
+	if validationErrors, ok := err.(validator.ValidationErrors); ok {
+		return c.Status(fiber.StatusBadRequest).JSON(validationErrors.Error())
+	}
+	switch err {
+	case common.ErrBadParamInput:
+		return c.SendStatus(fiber.StatusBadRequest)
+	case common.ErrNotFound:
+		return c.SendStatus(fiber.StatusNotFound)
+	case common.ErrConflict:
+		return c.SendStatus(fiber.StatusConflict)
+	default:
+		return c.Status(fiber.StatusInternalServerError).JSON(err)
+	}
+}
@@ -0,0 +1,286 @@
+package handlers
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/go-playground/validator"
+	"github.com/gofiber/fiber/v2"
+	"github.com/ysfada/product-management-system/domain/common"
+	"github.com/ysfada/product-management-system/domain/dtos"
+	"github.com/ysfada/product-management-system/domain/interfaces"
+)
+
+type PurchaseOrderHandler struct {
+	service interfaces.IPurchaseOrderService
+}
+
+func NewPurchaseOrderHandler(service interfaces.IPurchaseOrderService) *PurchaseOrderHandler {
+	return &PurchaseOrderHandler{
+		service: service,
+	}
+}
+
+var _ interfaces.IPurchaseOrderHandler = (*PurchaseOrderHandler)(nil)
+
+func (h *PurchaseOrderHandler) UseHandler(r fiber.Router) {
+	purchaseOrdersRouter := r.Group("purchase-orders")
+
+	purchaseOrdersRouter.Get("/", common.JwtMiddleware, h.Fetch)
+	purchaseOrdersRouter.Post("/", common.JwtMiddleware, h.Create)
+	purchaseOrdersRouter.Get("/suggestions", common.JwtMiddleware, h.Suggestions)
+	purchaseOrdersRouter.Get("/:id", common.JwtMiddleware, h.GetByID)
+	purchaseOrdersRouter.Post("/:id/send", common.JwtMiddleware, h.Send)
+	purchaseOrdersRouter.Post("/:id/receipts", common.JwtMiddleware, h.Receive)
+	purchaseOrdersRouter.Post("/:id/close", common.JwtMiddleware, h.Close)
+}
+
+// PurchaseOrder godoc
+// @Summary Get purchase orders
+// @Description Get purchase orders without their lines, newest first
+// @Tags purchase-orders
+// @Accept json
+// @Produce json
+// @Success 200 {object} dtos.PurchaseOrderPaginatedDto
+// @Failure 400 {object} string
+// @Failure 500 {object} string
+// @Param supplierID query int false "only orders of this supplier"
+// @Param status query string false "draft, sent, partially_received, received or closed"
+// @Param page query int false "page number"
+// @Param size query int false "rows per page"
+// @Param Authorization header string true "Bearer"
+// @Router /purchase-orders [get]
+func (h *PurchaseOrderHandler) Fetch(c *fiber.Ctx) error {
+	supplierID, err := strconv.Atoi(c.Query("supplierID", "0"))
+	if err != nil {
+		return c.SendStatus(fiber.StatusBadRequest)
+	}
+	page, err := strconv.Atoi(c.Query("page", "1"))
+	if err != nil {
+		return c.SendStatus(fiber.StatusBadRequest)
+	}
+	size, err := strconv.Atoi(c.Query("size", "10"))
+	if err != nil {
+		return c.SendStatus(fiber.StatusBadRequest)
+	}
+	status := strings.ToLower(c.Query("status"))
+
+	if purchaseOrders, err := h.service.Fetch(c.Context(), supplierID, status, page, size); err != nil {
+		switch err {
+		default:
+			return c.Status(fiber.StatusInternalServerError).JSON(err)
+		}
+	} else {
+		return c.JSON(purchaseOrders)
+	}
+}
+
+// PurchaseOrder godoc
+// @Summary Get purchase order by id
+// @Description Get purchase order with its lines and received quantities
+// @Tags purchase-orders
+// @Accept json
+// @Produce json
+// @Success 200 {object} dtos.PurchaseOrderDto
+// @Failure 400 {object} string
+// @Failure 404 {object} string
+// @Failure 500 {object} string
+// @Param id path int true "id"
+// @Param Authorization header string true "Bearer"
+// @Router /purchase-orders/{id} [get]
+func (h *PurchaseOrderHandler) GetByID(c *fiber.Ctx) error {
+	if id, err := c.ParamsInt("id"); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(err)
+	} else {
+		if purchaseOrder, err := h.service.GetByID(c.Context(), id); err != nil {
+			switch err {
+			case common.ErrNotFound:
+				return c.SendStatus(fiber.StatusNotFound)
+			default:
+				return c.Status(fiber.StatusInternalServerError).JSON(err)
+			}
+		} else {
+			return c.JSON(purchaseOrder)
+		}
+	}
+}
+
+// PurchaseOrder godoc
+// @Summary Create purchase order
+// @Description Draft a purchase order, lines without a unit cost take the cost of the variant at the supplier
+// @Tags purchase-orders
+// @Accept json
+// @Produce json
+// @Success 201 {object} dtos.PurchaseOrderDto
+// @Failure 400 {object} string
+// @Failure 403 {object} string
+// @Failure 404 {object} string
+// @Failure 500 {object} string
+// @Param dto body dtos.CreatePurchaseOrderDto true "dto"
+// @Param Authorization header string true "Bearer"
+// @Router /purchase-orders [post]
+func (h *PurchaseOrderHandler) Create(c *fiber.Ctx) error {
+	username, _, ok := currentUser(c)
+	if !ok {
+		return c.SendStatus(fiber.StatusForbidden)
+	}
+
+	var body dtos.CreatePurchaseOrderDto
+	if err := c.BodyParser(&body); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(err)
+	}
+	body.CreatedBy = username
+
+	if purchaseOrder, err := h.service.Create(c.Context(), &body); err != nil {
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			return c.Status(fiber.StatusBadRequest).JSON(validationErrors.Error())
+		}
+		switch err {
+		case common.ErrBadParamInput:
+			return c.SendStatus(fiber.StatusBadRequest)
+		case common.ErrNotFound:
+			return c.SendStatus(fiber.StatusNotFound)
+		default:
+			return c.Status(fiber.StatusInternalServerError).JSON(err)
+		}
+	} else {
+		return c.Status(fiber.StatusCreated).JSON(purchaseOrder)
+	}
+}
+
+// PurchaseOrder godoc
+// @Summary Send purchase order
+// @Description Mark a draft purchase order as sent to the supplier
+// @Tags purchase-orders
+// @Accept json
+// @Produce json
+// @Success 204
+// @Failure 400 {object} string
+// @Failure 404 {object} string
+// @Failure 409 {object} string
+// @Failure 500 {object} string
+// @Param id path int true "id"
+// @Param Authorization header string true "Bearer"
+// @Router /purchase-orders/{id}/send [post]
+func (h *PurchaseOrderHandler) Send(c *fiber.Ctx) error {
+	if id, err := c.ParamsInt("id"); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(err)
+	} else {
+		if err := h.service.Send(c.Context(), id); err != nil {
+			switch err {
+			case common.ErrNotFound:
+				return c.SendStatus(fiber.StatusNotFound)
+			case common.ErrConflict:
+				return c.SendStatus(fiber.StatusConflict)
+			default:
+				return c.Status(fiber.StatusInternalServerError).JSON(err)
+			}
+		}
+		return c.SendStatus(fiber.StatusNoContent)
+	}
+}
+
+// PurchaseOrder godoc
+// @Summary Receive purchase order
+// @Description Book a delivery against the lines of a sent purchase order and add it to the stock of the variants
+// @Tags purchase-orders
+// @Accept json
+// @Produce json
+// @Success 200 {object} dtos.PurchaseOrderDto
+// @Failure 400 {object} string
+// @Failure 403 {object} string
+// @Failure 404 {object} string
+// @Failure 409 {object} string
+// @Failure 500 {object} string
+// @Param id path int true "id"
+// @Param dto body dtos.CreatePurchaseOrderReceiptDto true "dto"
+// @Param Authorization header string true "Bearer"
+// @Router /purchase-orders/{id}/receipts [post]
+func (h *PurchaseOrderHandler) Receive(c *fiber.Ctx) error {
+	username, _, ok := currentUser(c)
+	if !ok {
+		return c.SendStatus(fiber.StatusForbidden)
+	}
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(err)
+	}
+
+	var body dtos.CreatePurchaseOrderReceiptDto
+	if err := c.BodyParser(&body); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(err)
+	}
+	body.PurchaseOrderID = id
+	body.ReceivedBy = username
+
+	if purchaseOrder, err := h.service.Receive(c.Context(), &body); err != nil {
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			return c.Status(fiber.StatusBadRequest).JSON(validationErrors.Error())
+		}
+		switch err {
+		case common.ErrBadParamInput:
+			return c.SendStatus(fiber.StatusBadRequest)
+		case common.ErrNotFound:
+			return c.SendStatus(fiber.StatusNotFound)
+		case common.ErrConflict:
+			return c.SendStatus(fiber.StatusConflict)
+		default:
+			return c.Status(fiber.StatusInternalServerError).JSON(err)
+		}
+	} else {
+		return c.JSON(purchaseOrder)
+	}
+}
+
+// PurchaseOrder godoc
+// @Summary Close purchase order
+// @Description Close a sent purchase order, outstanding quantities will not be received
+// @Tags purchase-orders
+// @Accept json
+// @Produce json
+// @Success 204
+// @Failure 400 {object} string
+// @Failure 404 {object} string
+// @Failure 409 {object} string
+// @Failure 500 {object} string
+// @Param id path int true "id"
+// @Param Authorization header string true "Bearer"
+// @Router /purchase-orders/{id}/close [post]
+func (h *PurchaseOrderHandler) Close(c *fiber.Ctx) error {
+	if id, err := c.ParamsInt("id"); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(err)
+	} else {
+		if err := h.service.Close(c.Context(), id); err != nil {
+			switch err {
+			case common.ErrNotFound:
+				return c.SendStatus(fiber.StatusNotFound)
+			case common.ErrConflict:
+				return c.SendStatus(fiber.StatusConflict)
+			default:
+				return c.Status(fiber.StatusInternalServerError).JSON(err)
+			}
+		}
+		return c.SendStatus(fiber.StatusNoContent)
+	}
+}
+
+// PurchaseOrder godoc
+// @Summary Get reorder suggestions
+// @Description Propose a purchase order per preferred supplier for the variants at or below their reorder point, taking open purchase orders into account
+// @Tags purchase-orders
+// @Accept json
+// @Produce json
+// @Success 200 {array} dtos.ReorderSuggestionDto
+// @Failure 500 {object} string
+// @Param Authorization header string true "Bearer"
+// @Router /purchase-orders/suggestions [get]
+func (h *PurchaseOrderHandler) Suggestions(c *fiber.Ctx) error {
+	if suggestions, err := h.service.Suggestions(c.Context()); err != nil {
+		switch err {
+		default:
+			return c.Status(fiber.StatusInternalServerError).JSON(err)
+		}
+	} else {
+		return c.JSON(suggestions)
+	}
+}
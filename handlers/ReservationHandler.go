@@ -0,0 +1,175 @@
+package handlers
+
+import (
+	"github.com/go-playground/validator"
+	"github.com/gofiber/fiber/v2"
+	"github.com/ysfada/product-management-system/domain/common"
+	"github.com/ysfada/product-management-system/domain/dtos"
+	"github.com/ysfada/product-management-system/domain/interfaces"
+)
+
+type ReservationHandler struct {
+	service interfaces.IReservationService
+}
+
+func NewReservationHandler(service interfaces.IReservationService) *ReservationHandler {
+	return &ReservationHandler{
+		service: service,
+	}
+}
+
+var _ interfaces.IReservationHandler = (*ReservationHandler)(nil)
+
+func (h *ReservationHandler) UseHandler(r fiber.Router) {
+	reservationsRouter := r.Group("reservations")
+
+	reservationsRouter.Post("/", common.JwtMiddleware, h.Create)
+	reservationsRouter.Get("/:id", common.JwtMiddleware, h.GetByID)
+	reservationsRouter.Post("/:id/confirm", common.JwtMiddleware, h.Confirm)
+	reservationsRouter.Post("/:id/release", common.JwtMiddleware, h.Release)
+}
+
+// Reservation godoc
+// @Summary Get reservation by id
+// @Description Get stock reservation by id
+// @Tags reservations
+// @Accept json
+// @Produce json
+// @Success 200 {object} dtos.ReservationDto
+// @Failure 400 {object} string
+// @Failure 404 {object} string
+// @Failure 500 {object} string
+// @Param id path int true "id"
+// @Param Authorization header string true "Bearer"
+// @Router /reservations/{id} [get]
+func (h *ReservationHandler) GetByID(c *fiber.Ctx) error {
+	if id, err := c.ParamsInt("id"); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(err)
+	} else {
+		if reservation, err := h.service.GetByID(c.Context(), id); err != nil {
+			switch err {
+			case common.ErrNotFound:
+				return c.SendStatus(fiber.StatusNotFound)
+			default:
+				return c.Status(fiber.StatusInternalServerError).JSON(err)
+			}
+		} else {
+			return c.JSON(reservation)
+		}
+	}
+}
+
+// Reservation godoc
+// @Summary Create reservation
+// @Description Hold stock of a product variant for an owner until the reservation expires
+// @Tags reservations
+// @Accept json
+// @Produce json
+// @Success 201 {object} dtos.ReservationDto
+// @Failure 400 {object} string
+// @Failure 404 {object} string
+// @Failure 409 {object} string
+// @Failure 500 {object} string
+// @Param dto body dtos.CreateReservationDto true "dto"
+// @Param Authorization header string true "Bearer"
+// @Router /reservations [post]
+func (h *ReservationHandler) Create(c *fiber.Ctx) error {
+	var body dtos.CreateReservationDto
+	if err := c.BodyParser(&body); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(err)
+	}
+
+	if reservation, err := h.service.Create(c.Context(), &body); err != nil {
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			return c.Status(fiber.StatusBadRequest).JSON(validationErrors.Error())
+		}
+		switch err {
+		case common.ErrBadParamInput:
+			return c.SendStatus(fiber.StatusBadRequest)
+		case common.ErrNotFound:
+			return c.SendStatus(fiber.StatusNotFound)
+		case common.ErrInsufficientStock:
+			return c.Status(fiber.StatusConflict).JSON(err.Error())
+		default:
+			return c.Status(fiber.StatusInternalServerError).JSON(err)
+		}
+	} else {
+		return c.Status(fiber.StatusCreated).JSON(reservation)
+	}
+}
+
+// Reservation godoc
+// @Summary Confirm reservation
+// @Description Convert an active reservation into a stock decrement, staff only
+// @Tags reservations
+// @Accept json
+// @Produce json
+// @Success 204
+// @Failure 400 {object} string
+// @Failure 403 {object} string
+// @Failure 404 {object} string
+// @Failure 409 {object} string
+// @Failure 500 {object} string
+// @Param id path int true "id"
+// @Param Authorization header string true "Bearer"
+// @Router /reservations/{id}/confirm [post]
+func (h *ReservationHandler) Confirm(c *fiber.Ctx) error {
+	if _, isStaff, ok := currentUser(c); !ok || !isStaff {
+		return c.SendStatus(fiber.StatusForbidden)
+	}
+
+	if id, err := c.ParamsInt("id"); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(err)
+	} else {
+		if err := h.service.Confirm(c.Context(), id); err != nil {
+			switch err {
+			case common.ErrNotFound:
+				return c.SendStatus(fiber.StatusNotFound)
+			case common.ErrConflict:
+				return c.Status(fiber.StatusConflict).JSON("reservation is not active")
+			case common.ErrInsufficientStock:
+				return c.Status(fiber.StatusConflict).JSON(err.Error())
+			default:
+				return c.Status(fiber.StatusInternalServerError).JSON(err)
+			}
+		}
+		return c.SendStatus(fiber.StatusNoContent)
+	}
+}
+
+// Reservation godoc
+// @Summary Release reservation
+// @Description Release an active reservation back to the available stock, staff only
+// @Tags reservations
+// @Accept json
+// @Produce json
+// @Success 204
+// @Failure 400 {object} string
+// @Failure 403 {object} string
+// @Failure 404 {object} string
+// @Failure 409 {object} string
+// @Failure 500 {object} string
+// @Param id path int true "id"
+// @Param Authorization header string true "Bearer"
+// @Router /reservations/{id}/release [post]
+func (h *ReservationHandler) Release(c *fiber.Ctx) error {
+	if _, isStaff, ok := currentUser(c); !ok || !isStaff {
+		return c.SendStatus(fiber.StatusForbidden)
+	}
+
+	if id, err := c.ParamsInt("id"); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(err)
+	} else {
+		if err := h.service.Release(c.Context(), id); err != nil {
+			switch err {
+			case common.ErrNotFound:
+				return c.SendStatus(fiber.StatusNotFound)
+			case common.ErrConflict:
+				return c.Status(fiber.StatusConflict).JSON("reservation is not active")
+			default:
+				return c.Status(fiber.StatusInternalServerError).JSON(err)
+			}
+		}
+		return c.SendStatus(fiber.StatusNoContent)
+	}
+}
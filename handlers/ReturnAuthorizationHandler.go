@@ -0,0 +1,332 @@
+package handlers
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/go-playground/validator"
+	"github.com/gofiber/fiber/v2"
+	"github.com/ysfada/product-management-system/domain/common"
+	"github.com/ysfada/product-management-system/domain/dtos"
+	"github.com/ysfada/product-management-system/domain/interfaces"
+)
+
+type ReturnAuthorizationHandler struct {
+	service interfaces.IReturnAuthorizationService
+}
+
+func NewReturnAuthorizationHandler(service interfaces.IReturnAuthorizationService) *ReturnAuthorizationHandler {
+	return &ReturnAuthorizationHandler{
+		service: service,
+	}
+}
+
+var _ interfaces.IReturnAuthorizationHandler = (*ReturnAuthorizationHandler)(nil)
+
+func (h *ReturnAuthorizationHandler) UseHandler(r fiber.Router) {
+	returnsRouter := r.Group("returns")
+
+	returnsRouter.Get("/", common.JwtMiddleware, h.Fetch)
+	returnsRouter.Post("/", common.JwtMiddleware, h.Create)
+	returnsRouter.Get("/:id", common.JwtMiddleware, h.GetByID)
+	returnsRouter.Post("/:id/approve", common.JwtMiddleware, h.Approve)
+	returnsRouter.Post("/:id/reject", common.JwtMiddleware, h.Reject)
+	returnsRouter.Post("/:id/receive", common.JwtMiddleware, h.Receive)
+	returnsRouter.Post("/:id/inspect", common.JwtMiddleware, h.Inspect)
+}
+
+// ReturnAuthorization godoc
+// @Summary Get returns
+// @Description Get returns without their lines and events, newest first. Customers only see their own returns
+// @Tags returns
+// @Accept json
+// @Produce json
+// @Success 200 {object} dtos.ReturnAuthorizationPaginatedDto
+// @Failure 400 {object} string
+// @Failure 403 {object} string
+// @Failure 500 {object} string
+// @Param customer query string false "only returns of this customer, staff only"
+// @Param status query string false "requested, approved, rejected, received or inspected"
+// @Param page query int false "page number"
+// @Param size query int false "rows per page"
+// @Param Authorization header string true "Bearer"
+// @Router /returns [get]
+func (h *ReturnAuthorizationHandler) Fetch(c *fiber.Ctx) error {
+	username, isStaff, ok := currentUser(c)
+	if !ok {
+		return c.SendStatus(fiber.StatusForbidden)
+	}
+	customer := username
+	if isStaff {
+		customer = c.Query("customer")
+	}
+
+	page, err := strconv.Atoi(c.Query("page", "1"))
+	if err != nil {
+		return c.SendStatus(fiber.StatusBadRequest)
+	}
+	size, err := strconv.Atoi(c.Query("size", "10"))
+	if err != nil {
+		return c.SendStatus(fiber.StatusBadRequest)
+	}
+	status := strings.ToLower(c.Query("status"))
+
+	if returnAuthorizations, err := h.service.Fetch(c.Context(), customer, status, page, size); err != nil {
+		switch err {
+		default:
+			return c.Status(fiber.StatusInternalServerError).JSON(err)
+		}
+	} else {
+		return c.JSON(returnAuthorizations)
+	}
+}
+
+// ReturnAuthorization godoc
+// @Summary Get return by id
+// @Description Get return with its lines and the audit trail of its status changes. Customers only see their own returns
+// @Tags returns
+// @Accept json
+// @Produce json
+// @Success 200 {object} dtos.ReturnAuthorizationDto
+// @Failure 400 {object} string
+// @Failure 403 {object} string
+// @Failure 404 {object} string
+// @Failure 500 {object} string
+// @Param id path int true "id"
+// @Param Authorization header string true "Bearer"
+// @Router /returns/{id} [get]
+func (h *ReturnAuthorizationHandler) GetByID(c *fiber.Ctx) error {
+	username, isStaff, ok := currentUser(c)
+	if !ok {
+		return c.SendStatus(fiber.StatusForbidden)
+	}
+
+	if id, err := c.ParamsInt("id"); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(err)
+	} else {
+		if returnAuthorization, err := h.service.GetByID(c.Context(), id); err != nil {
+			switch err {
+			case common.ErrNotFound:
+				return c.SendStatus(fiber.StatusNotFound)
+			default:
+				return c.Status(fiber.StatusInternalServerError).JSON(err)
+			}
+		} else {
+			if !isStaff && returnAuthorization.Customer != username {
+				return c.SendStatus(fiber.StatusNotFound)
+			}
+			return c.JSON(returnAuthorization)
+		}
+	}
+}
+
+// ReturnAuthorization godoc
+// @Summary Request return
+// @Description Request a return against a fulfilled order or for loose variants. Customers can only return their own orders, staff can request returns for any customer
+// @Tags returns
+// @Accept json
+// @Produce json
+// @Success 201 {object} dtos.ReturnAuthorizationDto
+// @Failure 400 {object} string
+// @Failure 403 {object} string
+// @Failure 404 {object} string
+// @Failure 409 {object} string
+// @Failure 500 {object} string
+// @Param dto body dtos.CreateReturnAuthorizationDto true "dto"
+// @Param Authorization header string true "Bearer"
+// @Router /returns [post]
+func (h *ReturnAuthorizationHandler) Create(c *fiber.Ctx) error {
+	username, isStaff, ok := currentUser(c)
+	if !ok {
+		return c.SendStatus(fiber.StatusForbidden)
+	}
+
+	var body dtos.CreateReturnAuthorizationDto
+	if err := c.BodyParser(&body); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(err)
+	}
+	body.RequestedBy = username
+	customer := ""
+	if !isStaff || len(body.Customer) == 0 {
+		body.Customer = username
+	}
+	if !isStaff {
+		customer = username
+	}
+
+	if returnAuthorization, err := h.service.Create(c.Context(), &body, customer); err != nil {
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			return c.Status(fiber.StatusBadRequest).JSON(validationErrors.Error())
+		}
+		switch err {
+		case common.ErrBadParamInput:
+			return c.SendStatus(fiber.StatusBadRequest)
+		case common.ErrNotFound:
+			return c.SendStatus(fiber.StatusNotFound)
+		case common.ErrConflict:
+			return c.SendStatus(fiber.StatusConflict)
+		default:
+			return c.Status(fiber.StatusInternalServerError).JSON(err)
+		}
+	} else {
+		return c.Status(fiber.StatusCreated).JSON(returnAuthorization)
+	}
+}
+
+// ReturnAuthorization godoc
+// @Summary Approve return
+// @Description Approve a requested return, staff only
+// @Tags returns
+// @Accept json
+// @Produce json
+// @Success 204
+// @Failure 400 {object} string
+// @Failure 403 {object} string
+// @Failure 404 {object} string
+// @Failure 409 {object} string
+// @Failure 500 {object} string
+// @Param id path int true "id"
+// @Param dto body dtos.ReviewReturnAuthorizationDto false "dto"
+// @Param Authorization header string true "Bearer"
+// @Router /returns/{id}/approve [post]
+func (h *ReturnAuthorizationHandler) Approve(c *fiber.Ctx) error {
+	return h.review(c, h.service.Approve)
+}
+
+// ReturnAuthorization godoc
+// @Summary Reject return
+// @Description Reject a requested return, or an approved one which never arrived, staff only
+// @Tags returns
+// @Accept json
+// @Produce json
+// @Success 204
+// @Failure 400 {object} string
+// @Failure 403 {object} string
+// @Failure 404 {object} string
+// @Failure 409 {object} string
+// @Failure 500 {object} string
+// @Param id path int true "id"
+// @Param dto body dtos.ReviewReturnAuthorizationDto false "dto"
+// @Param Authorization header string true "Bearer"
+// @Router /returns/{id}/reject [post]
+func (h *ReturnAuthorizationHandler) Reject(c *fiber.Ctx) error {
+	return h.review(c, h.service.Reject)
+}
+
+// ReturnAuthorization godoc
+// @Summary Receive return
+// @Description Record what arrived of an approved return, staff only. Nothing goes into stock before the return is inspected
+// @Tags returns
+// @Accept json
+// @Produce json
+// @Success 204
+// @Failure 400 {object} string
+// @Failure 403 {object} string
+// @Failure 404 {object} string
+// @Failure 409 {object} string
+// @Failure 500 {object} string
+// @Param id path int true "id"
+// @Param dto body dtos.ReceiveReturnAuthorizationDto true "dto"
+// @Param Authorization header string true "Bearer"
+// @Router /returns/{id}/receive [post]
+func (h *ReturnAuthorizationHandler) Receive(c *fiber.Ctx) error {
+	username, isStaff, ok := currentUser(c)
+	if !ok || !isStaff {
+		return c.SendStatus(fiber.StatusForbidden)
+	}
+
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(err)
+	}
+
+	var body dtos.ReceiveReturnAuthorizationDto
+	if err := c.BodyParser(&body); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(err)
+	}
+	body.ReturnAuthorizationID = id
+	body.Actor = username
+
+	return h.send(c, h.service.Receive(c.Context(), &body))
+}
+
+// ReturnAuthorization godoc
+// @Summary Inspect return
+// @Description Give every received unit a disposition, staff only. Restocked units go back into stock, refurbished and scrapped units do not
+// @Tags returns
+// @Accept json
+// @Produce json
+// @Success 204
+// @Failure 400 {object} string
+// @Failure 403 {object} string
+// @Failure 404 {object} string
+// @Failure 409 {object} string
+// @Failure 500 {object} string
+// @Param id path int true "id"
+// @Param dto body dtos.InspectReturnAuthorizationDto true "dto"
+// @Param Authorization header string true "Bearer"
+// @Router /returns/{id}/inspect [post]
+func (h *ReturnAuthorizationHandler) Inspect(c *fiber.Ctx) error {
+	username, isStaff, ok := currentUser(c)
+	if !ok || !isStaff {
+		return c.SendStatus(fiber.StatusForbidden)
+	}
+
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(err)
+	}
+
+	var body dtos.InspectReturnAuthorizationDto
+	if err := c.BodyParser(&body); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(err)
+	}
+	body.ReturnAuthorizationID = id
+	body.Actor = username
+
+	return h.send(c, h.service.Inspect(c.Context(), &body))
+}
+
+// review runs an approval or rejection, the note in the body is optional.
+func (h *ReturnAuthorizationHandler) review(c *fiber.Ctx, review func(ctx context.Context, dto *dtos.ReviewReturnAuthorizationDto) error) error {
+	username, isStaff, ok := currentUser(c)
+	if !ok || !isStaff {
+		return c.SendStatus(fiber.StatusForbidden)
+	}
+
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(err)
+	}
+
+	var body dtos.ReviewReturnAuthorizationDto
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&body); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(err)
+		}
+	}
+	body.ReturnAuthorizationID = id
+	body.Actor = username
+
+	return h.send(c, review(c.Context(), &body))
+}
+
+func (h *ReturnAuthorizationHandler) send(c *fiber.Ctx, err error) error {
+	if err != nil {
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			return c.Status(fiber.StatusBadRequest).JSON(validationErrors.Error())
+		}
+		switch err {
+		case common.ErrBadParamInput:
+			return c.SendStatus(fiber.StatusBadRequest)
+		case common.ErrNotFound:
+			return c.SendStatus(fiber.StatusNotFound)
+		case common.ErrConflict:
+			return c.SendStatus(fiber.StatusConflict)
+		default:
+			return c.Status(fiber.StatusInternalServerError).JSON(err)
+		}
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
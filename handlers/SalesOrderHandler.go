@@ -0,0 +1,282 @@
+package handlers
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/go-playground/validator"
+	"github.com/gofiber/fiber/v2"
+	"github.com/ysfada/product-management-system/domain/common"
+	"github.com/ysfada/product-management-system/domain/dtos"
+	"github.com/ysfada/product-management-system/domain/interfaces"
+)
+
+type SalesOrderHandler struct {
+	service interfaces.ISalesOrderService
+}
+
+func NewSalesOrderHandler(service interfaces.ISalesOrderService) *SalesOrderHandler {
+	return &SalesOrderHandler{
+		service: service,
+	}
+}
+
+var _ interfaces.ISalesOrderHandler = (*SalesOrderHandler)(nil)
+
+func (h *SalesOrderHandler) UseHandler(r fiber.Router) {
+	ordersRouter := r.Group("orders")
+
+	ordersRouter.Get("/", common.JwtMiddleware, h.Fetch)
+	ordersRouter.Post("/", common.JwtMiddleware, h.Create)
+	ordersRouter.Get("/:id", common.JwtMiddleware, h.GetByID)
+	ordersRouter.Post("/:id/pay", common.JwtMiddleware, h.Pay)
+	ordersRouter.Post("/:id/fulfill", common.JwtMiddleware, h.Fulfill)
+	ordersRouter.Post("/:id/cancel", common.JwtMiddleware, h.Cancel)
+}
+
+// SalesOrder godoc
+// @Summary Get orders
+// @Description Get orders without their lines, newest first. Customers only see their own orders
+// @Tags orders
+// @Accept json
+// @Produce json
+// @Success 200 {object} dtos.SalesOrderPaginatedDto
+// @Failure 400 {object} string
+// @Failure 403 {object} string
+// @Failure 500 {object} string
+// @Param customer query string false "only orders of this customer, staff only"
+// @Param status query string false "pending, paid, fulfilled or cancelled"
+// @Param page query int false "page number"
+// @Param size query int false "rows per page"
+// @Param Authorization header string true "Bearer"
+// @Router /orders [get]
+func (h *SalesOrderHandler) Fetch(c *fiber.Ctx) error {
+	username, isStaff, ok := currentUser(c)
+	if !ok {
+		return c.SendStatus(fiber.StatusForbidden)
+	}
+	customer := username
+	if isStaff {
+		customer = c.Query("customer")
+	}
+
+	page, err := strconv.Atoi(c.Query("page", "1"))
+	if err != nil {
+		return c.SendStatus(fiber.StatusBadRequest)
+	}
+	size, err := strconv.Atoi(c.Query("size", "10"))
+	if err != nil {
+		return c.SendStatus(fiber.StatusBadRequest)
+	}
+	status := strings.ToLower(c.Query("status"))
+
+	if salesOrders, err := h.service.Fetch(c.Context(), customer, status, page, size); err != nil {
+		switch err {
+		default:
+			return c.Status(fiber.StatusInternalServerError).JSON(err)
+		}
+	} else {
+		return c.JSON(salesOrders)
+	}
+}
+
+// SalesOrder godoc
+// @Summary Get order by id
+// @Description Get order with its lines at the prices it was placed at. Customers only see their own orders
+// @Tags orders
+// @Accept json
+// @Produce json
+// @Success 200 {object} dtos.SalesOrderDto
+// @Failure 400 {object} string
+// @Failure 403 {object} string
+// @Failure 404 {object} string
+// @Failure 500 {object} string
+// @Param id path int true "id"
+// @Param Authorization header string true "Bearer"
+// @Router /orders/{id} [get]
+func (h *SalesOrderHandler) GetByID(c *fiber.Ctx) error {
+	username, isStaff, ok := currentUser(c)
+	if !ok {
+		return c.SendStatus(fiber.StatusForbidden)
+	}
+
+	if id, err := c.ParamsInt("id"); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(err)
+	} else {
+		if salesOrder, err := h.service.GetByID(c.Context(), id); err != nil {
+			switch err {
+			case common.ErrNotFound:
+				return c.SendStatus(fiber.StatusNotFound)
+			default:
+				return c.Status(fiber.StatusInternalServerError).JSON(err)
+			}
+		} else {
+			if !isStaff && salesOrder.Customer != username {
+				return c.SendStatus(fiber.StatusNotFound)
+			}
+			return c.JSON(salesOrder)
+		}
+	}
+}
+
+// SalesOrder godoc
+// @Summary Place order
+// @Description Place an order for the current user at the current prices, the quantities are taken out of the available stock at once
+// @Tags orders
+// @Accept json
+// @Produce json
+// @Success 201 {object} dtos.SalesOrderDto
+// @Failure 400 {object} string
+// @Failure 403 {object} string
+// @Failure 404 {object} string
+// @Failure 409 {object} string
+// @Failure 500 {object} string
+// @Param dto body dtos.CreateSalesOrderDto true "dto"
+// @Param Authorization header string true "Bearer"
+// @Router /orders [post]
+func (h *SalesOrderHandler) Create(c *fiber.Ctx) error {
+	username, _, ok := currentUser(c)
+	if !ok {
+		return c.SendStatus(fiber.StatusForbidden)
+	}
+
+	var body dtos.CreateSalesOrderDto
+	if err := c.BodyParser(&body); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(err)
+	}
+	body.Customer = username
+
+	if salesOrder, err := h.service.Create(c.Context(), &body); err != nil {
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			return c.Status(fiber.StatusBadRequest).JSON(validationErrors.Error())
+		}
+		switch err {
+		case common.ErrBadParamInput:
+			return c.SendStatus(fiber.StatusBadRequest)
+		case common.ErrNotFound:
+			return c.SendStatus(fiber.StatusNotFound)
+		case common.ErrInsufficientStock:
+			return c.Status(fiber.StatusConflict).JSON(err.Error())
+		default:
+			return c.Status(fiber.StatusInternalServerError).JSON(err)
+		}
+	} else {
+		return c.Status(fiber.StatusCreated).JSON(salesOrder)
+	}
+}
+
+// SalesOrder godoc
+// @Summary Pay order
+// @Description Mark a pending order as paid, staff only
+// @Tags orders
+// @Accept json
+// @Produce json
+// @Success 204
+// @Failure 400 {object} string
+// @Failure 403 {object} string
+// @Failure 404 {object} string
+// @Failure 409 {object} string
+// @Failure 500 {object} string
+// @Param id path int true "id"
+// @Param Authorization header string true "Bearer"
+// @Router /orders/{id}/pay [post]
+func (h *SalesOrderHandler) Pay(c *fiber.Ctx) error {
+	if _, isStaff, ok := currentUser(c); !ok || !isStaff {
+		return c.SendStatus(fiber.StatusForbidden)
+	}
+
+	if id, err := c.ParamsInt("id"); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(err)
+	} else {
+		if err := h.service.Pay(c.Context(), id); err != nil {
+			switch err {
+			case common.ErrNotFound:
+				return c.SendStatus(fiber.StatusNotFound)
+			case common.ErrConflict:
+				return c.SendStatus(fiber.StatusConflict)
+			default:
+				return c.Status(fiber.StatusInternalServerError).JSON(err)
+			}
+		}
+		return c.SendStatus(fiber.StatusNoContent)
+	}
+}
+
+// SalesOrder godoc
+// @Summary Fulfill order
+// @Description Mark a paid order as fulfilled, staff only
+// @Tags orders
+// @Accept json
+// @Produce json
+// @Success 204
+// @Failure 400 {object} string
+// @Failure 403 {object} string
+// @Failure 404 {object} string
+// @Failure 409 {object} string
+// @Failure 500 {object} string
+// @Param id path int true "id"
+// @Param Authorization header string true "Bearer"
+// @Router /orders/{id}/fulfill [post]
+func (h *SalesOrderHandler) Fulfill(c *fiber.Ctx) error {
+	if _, isStaff, ok := currentUser(c); !ok || !isStaff {
+		return c.SendStatus(fiber.StatusForbidden)
+	}
+
+	if id, err := c.ParamsInt("id"); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(err)
+	} else {
+		if err := h.service.Fulfill(c.Context(), id); err != nil {
+			switch err {
+			case common.ErrNotFound:
+				return c.SendStatus(fiber.StatusNotFound)
+			case common.ErrConflict:
+				return c.SendStatus(fiber.StatusConflict)
+			default:
+				return c.Status(fiber.StatusInternalServerError).JSON(err)
+			}
+		}
+		return c.SendStatus(fiber.StatusNoContent)
+	}
+}
+
+// SalesOrder godoc
+// @Summary Cancel order
+// @Description Cancel a pending or paid order and put its quantities back into stock. Customers can only cancel their own orders
+// @Tags orders
+// @Accept json
+// @Produce json
+// @Success 204
+// @Failure 400 {object} string
+// @Failure 403 {object} string
+// @Failure 404 {object} string
+// @Failure 409 {object} string
+// @Failure 500 {object} string
+// @Param id path int true "id"
+// @Param Authorization header string true "Bearer"
+// @Router /orders/{id}/cancel [post]
+func (h *SalesOrderHandler) Cancel(c *fiber.Ctx) error {
+	username, isStaff, ok := currentUser(c)
+	if !ok {
+		return c.SendStatus(fiber.StatusForbidden)
+	}
+	customer := username
+	if isStaff {
+		customer = ""
+	}
+
+	if id, err := c.ParamsInt("id"); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(err)
+	} else {
+		if err := h.service.Cancel(c.Context(), id, customer); err != nil {
+			switch err {
+			case common.ErrNotFound:
+				return c.SendStatus(fiber.StatusNotFound)
+			case common.ErrConflict:
+				return c.SendStatus(fiber.StatusConflict)
+			default:
+				return c.Status(fiber.StatusInternalServerError).JSON(err)
+			}
+		}
+		return c.SendStatus(fiber.StatusNoContent)
+	}
+}
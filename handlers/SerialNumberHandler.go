@@ -0,0 +1,191 @@
+package handlers
+
+import (
+	"strings"
+
+	"github.com/go-playground/validator"
+	"github.com/gofiber/fiber/v2"
+	"github.com/ysfada/product-management-system/domain/common"
+	"github.com/ysfada/product-management-system/domain/dtos"
+	"github.com/ysfada/product-management-system/domain/interfaces"
+)
+
+type SerialNumberHandler struct {
+	service interfaces.ISerialNumberService
+}
+
+func NewSerialNumberHandler(service interfaces.ISerialNumberService) *SerialNumberHandler {
+	return &SerialNumberHandler{
+		service: service,
+	}
+}
+
+var _ interfaces.ISerialNumberHandler = (*SerialNumberHandler)(nil)
+
+func (h *SerialNumberHandler) UseHandler(r fiber.Router) {
+	serialsRouter := r.Group("serials")
+
+	serialsRouter.Get("/:serial", common.JwtMiddleware, h.GetBySerial)
+	serialsRouter.Put("/:serial/status", common.JwtMiddleware, h.UpdateStatus)
+
+	variantSerialsRouter := r.Group("products/:id/variants/:variantID/serials")
+
+	variantSerialsRouter.Get("/", common.JwtMiddleware, h.Fetch)
+	variantSerialsRouter.Post("/", common.JwtMiddleware, h.Create)
+}
+
+// SerialNumber godoc
+// @Summary Get product variant serials
+// @Description Get the serial numbers of a serialized product variant
+// @Tags serials
+// @Accept json
+// @Produce json
+// @Success 200 {array} dtos.SerialNumberDto
+// @Failure 400 {object} string
+// @Failure 500 {object} string
+// @Param id path int true "id"
+// @Param variantID path int true "variantID"
+// @Param status query string false "in_stock, reserved, sold, returned or scrapped"
+// @Param Authorization header string true "Bearer"
+// @Router /products/{id}/variants/{variantID}/serials [get]
+func (h *SerialNumberHandler) Fetch(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(err)
+	}
+	variantID, err := c.ParamsInt("variantID")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(err)
+	}
+	status := strings.ToLower(c.Query("status"))
+
+	if serials, err := h.service.Fetch(c.Context(), id, variantID, status); err != nil {
+		switch err {
+		default:
+			return c.Status(fiber.StatusInternalServerError).JSON(err)
+		}
+	} else {
+		return c.JSON(serials)
+	}
+}
+
+// SerialNumber godoc
+// @Summary Get serial
+// @Description Get a serial number with its product variant and status history
+// @Tags serials
+// @Accept json
+// @Produce json
+// @Success 200 {object} dtos.SerialNumberLookupDto
+// @Failure 404 {object} string
+// @Failure 500 {object} string
+// @Param serial path string true "serial"
+// @Param Authorization header string true "Bearer"
+// @Router /serials/{serial} [get]
+func (h *SerialNumberHandler) GetBySerial(c *fiber.Ctx) error {
+	if serial, err := h.service.GetBySerial(c.Context(), c.Params("serial")); err != nil {
+		switch err {
+		case common.ErrNotFound:
+			return c.SendStatus(fiber.StatusNotFound)
+		default:
+			return c.Status(fiber.StatusInternalServerError).JSON(err)
+		}
+	} else {
+		return c.JSON(serial)
+	}
+}
+
+// SerialNumber godoc
+// @Summary Receive serials
+// @Description Register received serial numbers of a serialized product variant as in stock
+// @Tags serials
+// @Accept json
+// @Produce json
+// @Success 201 {object} string
+// @Failure 400 {object} string
+// @Failure 404 {object} string
+// @Failure 409 {object} string
+// @Failure 500 {object} string
+// @Param id path int true "id"
+// @Param variantID path int true "variantID"
+// @Param dto body dtos.CreateSerialNumbersDto true "dto"
+// @Param Authorization header string true "Bearer"
+// @Router /products/{id}/variants/{variantID}/serials [post]
+func (h *SerialNumberHandler) Create(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(err)
+	}
+	variantID, err := c.ParamsInt("variantID")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(err)
+	}
+
+	var body dtos.CreateSerialNumbersDto
+	if err := c.BodyParser(&body); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(err)
+	}
+
+	if id != body.ProductID || variantID != body.ProductVariantID {
+		return c.SendStatus(fiber.StatusBadRequest)
+	}
+
+	if err := h.service.Create(c.Context(), &body); err != nil {
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			return c.Status(fiber.StatusBadRequest).JSON(validationErrors.Error())
+		}
+		switch err {
+		case common.ErrBadParamInput:
+			return c.SendStatus(fiber.StatusBadRequest)
+		case common.ErrNotFound:
+			return c.SendStatus(fiber.StatusNotFound)
+		case common.ErrConflict:
+			return c.SendStatus(fiber.StatusConflict)
+		default:
+			return c.Status(fiber.StatusInternalServerError).JSON(err)
+		}
+	}
+	return c.SendStatus(fiber.StatusCreated)
+}
+
+// SerialNumber godoc
+// @Summary Update serial status
+// @Description Move a serial number to in_stock, reserved, sold, returned or scrapped
+// @Tags serials
+// @Accept json
+// @Produce json
+// @Success 204
+// @Failure 400 {object} string
+// @Failure 404 {object} string
+// @Failure 409 {object} string
+// @Failure 500 {object} string
+// @Param serial path string true "serial"
+// @Param dto body dtos.UpdateSerialNumberStatusDto true "dto"
+// @Param Authorization header string true "Bearer"
+// @Router /serials/{serial}/status [put]
+func (h *SerialNumberHandler) UpdateStatus(c *fiber.Ctx) error {
+	var body dtos.UpdateSerialNumberStatusDto
+	if err := c.BodyParser(&body); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(err)
+	}
+
+	if c.Params("serial") != body.Serial {
+		return c.SendStatus(fiber.StatusBadRequest)
+	}
+
+	if err := h.service.UpdateStatus(c.Context(), &body); err != nil {
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			return c.Status(fiber.StatusBadRequest).JSON(validationErrors.Error())
+		}
+		switch err {
+		case common.ErrBadParamInput:
+			return c.SendStatus(fiber.StatusBadRequest)
+		case common.ErrNotFound:
+			return c.SendStatus(fiber.StatusNotFound)
+		case common.ErrConflict:
+			return c.Status(fiber.StatusConflict).JSON(err.Error())
+		default:
+			return c.Status(fiber.StatusInternalServerError).JSON(err)
+		}
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
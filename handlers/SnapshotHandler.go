@@ -0,0 +1,95 @@
+package handlers
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/ysfada/product-management-system/domain/common"
+	"github.com/ysfada/product-management-system/domain/interfaces"
+)
+
+type SnapshotHandler struct {
+	service interfaces.ISnapshotService
+}
+
+func NewSnapshotHandler(service interfaces.ISnapshotService) *SnapshotHandler {
+	return &SnapshotHandler{
+		service: service,
+	}
+}
+
+var _ interfaces.ISnapshotHandler = (*SnapshotHandler)(nil)
+
+func (h *SnapshotHandler) UseHandler(r fiber.Router) {
+	snapshotsRouter := r.Group("snapshots")
+
+	snapshotsRouter.Get("/export", common.JwtMiddleware, h.Export)
+	snapshotsRouter.Post("/import", common.JwtMiddleware, h.Import)
+}
+
+// Snapshot godoc
+// @Summary Export catalog snapshot
+// @Description Download the whole catalog, categories, attributes, images, products and their variants, as a gzipped tar archive
+// @Description of JSON documents with the files of the uploaded images. The archive can be imported into another installation
+// @Tags snapshots
+// @Produce application/gzip
+// @Success 200 {object} string
+// @Failure 500 {object} string
+// @Param Authorization header string true "Bearer"
+// @Router /snapshots/export [get]
+func (h *SnapshotHandler) Export(c *fiber.Ctx) error {
+	c.Attachment(fmt.Sprintf("catalog-snapshot-%s.tar.gz", time.Now().UTC().Format("2006-01-02")))
+	c.Set(fiber.HeaderContentType, "application/gzip")
+
+	// the body is written after the handler returns, an error can only cut
+	// the archive short
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		if err := h.service.Export(context.Background(), w); err != nil {
+			log.Printf("Unable to export snapshot: %v\n", err)
+		}
+	})
+
+	return nil
+}
+
+// Snapshot godoc
+// @Summary Import catalog snapshot
+// @Description Add the catalog of a snapshot archive in one transaction, ids are remapped and relations kept.
+// @Description Categories, products and named images named like existing ones are resolved by strategy:
+// @Description skip uses the existing one, overwrite updates it and rename imports it under a name suffixed with a number.
+// @Description Attributes use an existing one of the same type and name, variants of an overwritten product are matched by name
+// @Tags snapshots
+// @Accept multipart/form-data
+// @Produce json
+// @Success 200 {object} dtos.SnapshotImportDto
+// @Failure 400 {object} string
+// @Failure 500 {object} string
+// @Param file formData file true "snapshot archive"
+// @Param strategy query string false "skip, overwrite or rename, skip by default"
+// @Param Authorization header string true "Bearer"
+// @Router /snapshots/import [post]
+func (h *SnapshotHandler) Import(c *fiber.Ctx) error {
+	fileheader, err := c.FormFile("file")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(err.Error())
+	}
+	file, err := fileheader.Open()
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(err)
+	}
+	defer file.Close()
+
+	if snapshotImport, err := h.service.Import(c.Context(), file, c.Query("strategy")); err != nil {
+		if errors.Is(err, common.ErrBadParamInput) {
+			return c.Status(fiber.StatusBadRequest).JSON(err.Error())
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(err)
+	} else {
+		return c.JSON(snapshotImport)
+	}
+}
@@ -0,0 +1,227 @@
+package handlers
+
+import (
+	"github.com/go-playground/validator"
+	"github.com/gofiber/fiber/v2"
+	"github.com/ysfada/product-management-system/domain/common"
+	"github.com/ysfada/product-management-system/domain/dtos"
+	"github.com/ysfada/product-management-system/domain/interfaces"
+)
+
+type StocktakeHandler struct {
+	service interfaces.IStocktakeService
+}
+
+func NewStocktakeHandler(service interfaces.IStocktakeService) *StocktakeHandler {
+	return &StocktakeHandler{
+		service: service,
+	}
+}
+
+var _ interfaces.IStocktakeHandler = (*StocktakeHandler)(nil)
+
+func (h *StocktakeHandler) UseHandler(r fiber.Router) {
+	stocktakesRouter := r.Group("stocktakes")
+
+	stocktakesRouter.Post("/", common.JwtMiddleware, h.Create)
+	stocktakesRouter.Get("/:id", common.JwtMiddleware, h.GetByID)
+	stocktakesRouter.Post("/:id/counts", common.JwtMiddleware, h.AddCounts)
+	stocktakesRouter.Post("/:id/approve", common.JwtMiddleware, h.Approve)
+	stocktakesRouter.Post("/:id/cancel", common.JwtMiddleware, h.Cancel)
+}
+
+// Stocktake godoc
+// @Summary Get stocktake by id
+// @Description Get stocktake session with its variance report
+// @Tags stocktakes
+// @Accept json
+// @Produce json
+// @Success 200 {object} dtos.StocktakeDto
+// @Failure 400 {object} string
+// @Failure 404 {object} string
+// @Failure 500 {object} string
+// @Param id path int true "id"
+// @Param Authorization header string true "Bearer"
+// @Router /stocktakes/{id} [get]
+func (h *StocktakeHandler) GetByID(c *fiber.Ctx) error {
+	if id, err := c.ParamsInt("id"); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(err)
+	} else {
+		if stocktake, err := h.service.GetByID(c.Context(), id); err != nil {
+			switch err {
+			case common.ErrNotFound:
+				return c.SendStatus(fiber.StatusNotFound)
+			default:
+				return c.Status(fiber.StatusInternalServerError).JSON(err)
+			}
+		} else {
+			return c.JSON(stocktake)
+		}
+	}
+}
+
+// Stocktake godoc
+// @Summary Create stocktake
+// @Description Open a stocktake session for a category, a list of variants or every variant and snapshot their expected stock
+// @Tags stocktakes
+// @Accept json
+// @Produce json
+// @Success 201 {object} dtos.StocktakeDto
+// @Failure 400 {object} string
+// @Failure 403 {object} string
+// @Failure 404 {object} string
+// @Failure 500 {object} string
+// @Param dto body dtos.CreateStocktakeDto true "dto"
+// @Param Authorization header string true "Bearer"
+// @Router /stocktakes [post]
+func (h *StocktakeHandler) Create(c *fiber.Ctx) error {
+	username, _, ok := currentUser(c)
+	if !ok {
+		return c.SendStatus(fiber.StatusForbidden)
+	}
+
+	var body dtos.CreateStocktakeDto
+	if err := c.BodyParser(&body); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(err)
+	}
+	body.CreatedBy = username
+
+	if stocktake, err := h.service.Create(c.Context(), &body); err != nil {
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			return c.Status(fiber.StatusBadRequest).JSON(validationErrors.Error())
+		}
+		switch err {
+		case common.ErrBadParamInput:
+			return c.SendStatus(fiber.StatusBadRequest)
+		case common.ErrNotFound:
+			return c.SendStatus(fiber.StatusNotFound)
+		default:
+			return c.Status(fiber.StatusInternalServerError).JSON(err)
+		}
+	} else {
+		return c.Status(fiber.StatusCreated).JSON(stocktake)
+	}
+}
+
+// Stocktake godoc
+// @Summary Submit counts
+// @Description Add counted quantities to an open stocktake session, counts of the same variant are summed
+// @Tags stocktakes
+// @Accept json
+// @Produce json
+// @Success 204
+// @Failure 400 {object} string
+// @Failure 403 {object} string
+// @Failure 404 {object} string
+// @Failure 409 {object} string
+// @Failure 500 {object} string
+// @Param id path int true "id"
+// @Param dto body dtos.CreateStocktakeCountsDto true "dto"
+// @Param Authorization header string true "Bearer"
+// @Router /stocktakes/{id}/counts [post]
+func (h *StocktakeHandler) AddCounts(c *fiber.Ctx) error {
+	username, _, ok := currentUser(c)
+	if !ok {
+		return c.SendStatus(fiber.StatusForbidden)
+	}
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(err)
+	}
+
+	var body dtos.CreateStocktakeCountsDto
+	if err := c.BodyParser(&body); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(err)
+	}
+	body.StocktakeID = id
+	body.CountedBy = username
+
+	if err := h.service.AddCounts(c.Context(), &body); err != nil {
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			return c.Status(fiber.StatusBadRequest).JSON(validationErrors.Error())
+		}
+		switch err {
+		case common.ErrBadParamInput:
+			return c.SendStatus(fiber.StatusBadRequest)
+		case common.ErrNotFound:
+			return c.SendStatus(fiber.StatusNotFound)
+		case common.ErrConflict:
+			return c.SendStatus(fiber.StatusConflict)
+		default:
+			return c.Status(fiber.StatusInternalServerError).JSON(err)
+		}
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// Stocktake godoc
+// @Summary Approve stocktake
+// @Description Close an open stocktake session and post the variance of the counted variants onto their stock, staff only
+// @Tags stocktakes
+// @Accept json
+// @Produce json
+// @Success 204
+// @Failure 400 {object} string
+// @Failure 403 {object} string
+// @Failure 404 {object} string
+// @Failure 409 {object} string
+// @Failure 500 {object} string
+// @Param id path int true "id"
+// @Param Authorization header string true "Bearer"
+// @Router /stocktakes/{id}/approve [post]
+func (h *StocktakeHandler) Approve(c *fiber.Ctx) error {
+	username, isStaff, ok := currentUser(c)
+	if !ok || !isStaff {
+		return c.SendStatus(fiber.StatusForbidden)
+	}
+
+	if id, err := c.ParamsInt("id"); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(err)
+	} else {
+		if err := h.service.Approve(c.Context(), id, username); err != nil {
+			switch err {
+			case common.ErrNotFound:
+				return c.SendStatus(fiber.StatusNotFound)
+			case common.ErrConflict:
+				return c.SendStatus(fiber.StatusConflict)
+			case common.ErrInsufficientStock:
+				return c.Status(fiber.StatusConflict).JSON(err.Error())
+			default:
+				return c.Status(fiber.StatusInternalServerError).JSON(err)
+			}
+		}
+		return c.SendStatus(fiber.StatusNoContent)
+	}
+}
+
+// Stocktake godoc
+// @Summary Cancel stocktake
+// @Description Close an open stocktake session without touching the stock
+// @Tags stocktakes
+// @Accept json
+// @Produce json
+// @Success 204
+// @Failure 400 {object} string
+// @Failure 404 {object} string
+// @Failure 409 {object} string
+// @Failure 500 {object} string
+// @Param id path int true "id"
+// @Param Authorization header string true "Bearer"
+// @Router /stocktakes/{id}/cancel [post]
+func (h *StocktakeHandler) Cancel(c *fiber.Ctx) error {
+	if id, err := c.ParamsInt("id"); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(err)
+	} else {
+		if err := h.service.Cancel(c.Context(), id); err != nil {
+			switch err {
+			case common.ErrNotFound:
+				return c.SendStatus(fiber.StatusNotFound)
+			case common.ErrConflict:
+				return c.SendStatus(fiber.StatusConflict)
+			default:
+				return c.Status(fiber.StatusInternalServerError).JSON(err)
+			}
+		}
+		return c.SendStatus(fiber.StatusNoContent)
+	}
+}
@@ -0,0 +1,48 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/ysfada/product-management-system/domain/common"
+	"github.com/ysfada/product-management-system/domain/interfaces"
+)
+
+type StorageHandler struct {
+	service interfaces.IStorageService
+}
+
+func NewStorageHandler(service interfaces.IStorageService) *StorageHandler {
+	return &StorageHandler{
+		service: service,
+	}
+}
+
+var _ interfaces.IStorageHandler = (*StorageHandler)(nil)
+
+// UseHandler registers the urls images are saved with, r must be the app
+// and the handler must come before the static files of /public.
+func (h *StorageHandler) UseHandler(r fiber.Router) {
+	r.Get("/public/images/*", h.Redirect)
+}
+
+// Redirect redirects the url an image was saved with to the url its storage
+// serves it from, e.g. a bucket or a presigned url, so that the urls in
+// feeds and pages keep working whatever the backend is. The files of the
+// local storage are left to the static files.
+func (h *StorageHandler) Redirect(c *fiber.Ctx) error {
+	if url, err := h.service.URL(c.Context(), c.Path()); err != nil {
+		switch {
+		case errors.Is(err, common.ErrNotFound), errors.Is(err, common.ErrBadParamInput):
+			return c.SendStatus(fiber.StatusNotFound)
+		default:
+			log.Printf("Unable to resolve the url of %s: %v\n", c.Path(), err)
+			return c.SendStatus(fiber.StatusInternalServerError)
+		}
+	} else if url == c.Path() {
+		return c.Next()
+	} else {
+		return c.Redirect(url, fiber.StatusFound)
+	}
+}
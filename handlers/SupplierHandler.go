@@ -0,0 +1,342 @@
+package handlers
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/go-playground/validator"
+	"github.com/gofiber/fiber/v2"
+	"github.com/ysfada/product-management-system/domain/common"
+	"github.com/ysfada/product-management-system/domain/dtos"
+	"github.com/ysfada/product-management-system/domain/interfaces"
+)
+
+type SupplierHandler struct {
+	service interfaces.ISupplierService
+}
+
+func NewSupplierHandler(service interfaces.ISupplierService) *SupplierHandler {
+	return &SupplierHandler{
+		service: service,
+	}
+}
+
+var _ interfaces.ISupplierHandler = (*SupplierHandler)(nil)
+
+func (h *SupplierHandler) UseHandler(r fiber.Router) {
+	suppliersRouter := r.Group("suppliers")
+
+	suppliersRouter.Get("/", common.JwtMiddleware, h.Fetch)
+	suppliersRouter.Post("/", common.JwtMiddleware, h.Create)
+	suppliersRouter.Get("/:id", common.JwtMiddleware, h.GetByID)
+	suppliersRouter.Put("/:id", common.JwtMiddleware, h.Update)
+	suppliersRouter.Delete("/:id", common.JwtMiddleware, h.Delete)
+	suppliersRouter.Get("/:id/variants", common.JwtMiddleware, h.FetchVariants)
+	suppliersRouter.Put("/:id/variants/:variantID", common.JwtMiddleware, h.SetVariant)
+	suppliersRouter.Delete("/:id/variants/:variantID", common.JwtMiddleware, h.DeleteVariant)
+}
+
+// Supplier godoc
+// @Summary Get suppliers
+// @Description Get all suppliers
+// @Tags suppliers
+// @Accept json
+// @Produce json
+// @Success 200 {object} dtos.SupplierPaginatedDto
+// @Failure 400 {object} string
+// @Failure 500 {object} string
+// @Param page query int false "page number"
+// @Param size query int false "rows per page"
+// @Param sortBy query string false "name or id"
+// @Param orderBy query string false "ASC or DESC"
+// @Param Authorization header string true "Bearer"
+// @Router /suppliers [get]
+func (h *SupplierHandler) Fetch(c *fiber.Ctx) error {
+	page, err := strconv.Atoi(c.Query("page", "1"))
+	if err != nil {
+		return c.SendStatus(fiber.StatusBadRequest)
+	}
+	size, err := strconv.Atoi(c.Query("size", "10"))
+	if err != nil {
+		return c.SendStatus(fiber.StatusBadRequest)
+	}
+	sortBy := strings.ToLower(c.Query("sortBy", "id"))
+	if sortBy != "id" && sortBy != "name" {
+		sortBy = "id"
+	}
+	orderBy := strings.ToUpper(c.Query("orderBy", "ASC"))
+	if orderBy != "ASC" && orderBy != "DESC" {
+		orderBy = "ASC"
+	}
+
+	if suppliers, err := h.service.Fetch(c.Context(), page, size, sortBy, orderBy); err != nil {
+		switch err {
+		default:
+			return c.Status(fiber.StatusInternalServerError).JSON(err)
+		}
+	} else {
+		return c.JSON(suppliers)
+	}
+}
+
+// Supplier godoc
+// @Summary Get supplier by id
+// @Description Get supplier by id
+// @Tags suppliers
+// @Accept json
+// @Produce json
+// @Success 200 {object} dtos.SupplierDto
+// @Failure 400 {object} string
+// @Failure 404 {object} string
+// @Failure 500 {object} string
+// @Param id path int true "id"
+// @Param Authorization header string true "Bearer"
+// @Router /suppliers/{id} [get]
+func (h *SupplierHandler) GetByID(c *fiber.Ctx) error {
+	if id, err := c.ParamsInt("id"); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(err)
+	} else {
+		if supplier, err := h.service.GetByID(c.Context(), id); err != nil {
+			switch err {
+			case common.ErrNotFound:
+				return c.SendStatus(fiber.StatusNotFound)
+			default:
+				return c.Status(fiber.StatusInternalServerError).JSON(err)
+			}
+		} else {
+			return c.JSON(supplier)
+		}
+	}
+}
+
+// Supplier godoc
+// @Summary Create supplier
+// @Description Create supplier
+// @Tags suppliers
+// @Accept json
+// @Produce json
+// @Success 201
+// @Failure 400 {object} string
+// @Failure 409 {object} string
+// @Failure 500 {object} string
+// @Param dto body dtos.CreateSupplierDto true "dto"
+// @Param Authorization header string true "Bearer"
+// @Router /suppliers [post]
+func (h *SupplierHandler) Create(c *fiber.Ctx) error {
+	var body dtos.CreateSupplierDto
+	if err := c.BodyParser(&body); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(err)
+	}
+
+	if err := h.service.Create(c.Context(), &body); err != nil {
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			return c.Status(fiber.StatusBadRequest).JSON(validationErrors.Error())
+		}
+		switch err {
+		case common.ErrBadParamInput:
+			return c.SendStatus(fiber.StatusBadRequest)
+		case common.ErrConflict:
+			return c.SendStatus(fiber.StatusConflict)
+		default:
+			return c.Status(fiber.StatusInternalServerError).JSON(err)
+		}
+	}
+
+	return c.SendStatus(fiber.StatusCreated)
+}
+
+// Supplier godoc
+// @Summary Update supplier
+// @Description Update supplier by id
+// @Tags suppliers
+// @Accept json
+// @Produce json
+// @Success 204
+// @Failure 400 {object} string
+// @Failure 404 {object} string
+// @Failure 409 {object} string
+// @Failure 500 {object} string
+// @Param id path int true "id"
+// @Param dto body dtos.UpdateSupplierDto true "dto"
+// @Param Authorization header string true "Bearer"
+// @Router /suppliers/{id} [put]
+func (h *SupplierHandler) Update(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(err)
+	}
+
+	var body dtos.UpdateSupplierDto
+	if err := c.BodyParser(&body); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(err)
+	}
+	body.ID = id
+
+	if err := h.service.Update(c.Context(), &body); err != nil {
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			return c.Status(fiber.StatusBadRequest).JSON(validationErrors.Error())
+		}
+		switch err {
+		case common.ErrBadParamInput:
+			return c.SendStatus(fiber.StatusBadRequest)
+		case common.ErrNotFound:
+			return c.SendStatus(fiber.StatusNotFound)
+		case common.ErrConflict:
+			return c.SendStatus(fiber.StatusConflict)
+		default:
+			return c.Status(fiber.StatusInternalServerError).JSON(err)
+		}
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// Supplier godoc
+// @Summary Delete supplier
+// @Description Delete supplier by id, suppliers with purchase orders can not be deleted
+// @Tags suppliers
+// @Accept json
+// @Produce json
+// @Success 204
+// @Failure 400 {object} string
+// @Failure 404 {object} string
+// @Failure 409 {object} string
+// @Failure 500 {object} string
+// @Param id path int true "id"
+// @Param Authorization header string true "Bearer"
+// @Router /suppliers/{id} [delete]
+func (h *SupplierHandler) Delete(c *fiber.Ctx) error {
+	if id, err := c.ParamsInt("id"); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(err)
+	} else {
+		if err := h.service.Delete(c.Context(), id); err != nil {
+			switch err {
+			case common.ErrNotFound:
+				return c.SendStatus(fiber.StatusNotFound)
+			case common.ErrConflict:
+				return c.SendStatus(fiber.StatusConflict)
+			default:
+				return c.Status(fiber.StatusInternalServerError).JSON(err)
+			}
+		}
+		return c.SendStatus(fiber.StatusNoContent)
+	}
+}
+
+// Supplier godoc
+// @Summary Get supplier variants
+// @Description Get the variants a supplier sells with their supplier sku and cost
+// @Tags suppliers
+// @Accept json
+// @Produce json
+// @Success 200 {array} dtos.SupplierVariantDto
+// @Failure 400 {object} string
+// @Failure 404 {object} string
+// @Failure 500 {object} string
+// @Param id path int true "id"
+// @Param Authorization header string true "Bearer"
+// @Router /suppliers/{id}/variants [get]
+func (h *SupplierHandler) FetchVariants(c *fiber.Ctx) error {
+	if id, err := c.ParamsInt("id"); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(err)
+	} else {
+		if variants, err := h.service.FetchVariants(c.Context(), id); err != nil {
+			switch err {
+			case common.ErrNotFound:
+				return c.SendStatus(fiber.StatusNotFound)
+			default:
+				return c.Status(fiber.StatusInternalServerError).JSON(err)
+			}
+		} else {
+			return c.JSON(variants)
+		}
+	}
+}
+
+// Supplier godoc
+// @Summary Set supplier variant
+// @Description Link a variant to a supplier or update its supplier sku, cost and preference
+// @Tags suppliers
+// @Accept json
+// @Produce json
+// @Success 204
+// @Failure 400 {object} string
+// @Failure 404 {object} string
+// @Failure 409 {object} string
+// @Failure 500 {object} string
+// @Param id path int true "id"
+// @Param variantID path int true "variantID"
+// @Param dto body dtos.SetSupplierVariantDto true "dto"
+// @Param Authorization header string true "Bearer"
+// @Router /suppliers/{id}/variants/{variantID} [put]
+func (h *SupplierHandler) SetVariant(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(err)
+	}
+	variantID, err := c.ParamsInt("variantID")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(err)
+	}
+
+	var body dtos.SetSupplierVariantDto
+	if err := c.BodyParser(&body); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(err)
+	}
+	body.SupplierID = id
+	body.ProductVariantID = variantID
+
+	if err := h.service.SetVariant(c.Context(), &body); err != nil {
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			return c.Status(fiber.StatusBadRequest).JSON(validationErrors.Error())
+		}
+		switch err {
+		case common.ErrBadParamInput:
+			return c.SendStatus(fiber.StatusBadRequest)
+		case common.ErrNotFound:
+			return c.SendStatus(fiber.StatusNotFound)
+		case common.ErrConflict:
+			return c.SendStatus(fiber.StatusConflict)
+		default:
+			return c.Status(fiber.StatusInternalServerError).JSON(err)
+		}
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// Supplier godoc
+// @Summary Delete supplier variant
+// @Description Unlink a variant from a supplier
+// @Tags suppliers
+// @Accept json
+// @Produce json
+// @Success 204
+// @Failure 400 {object} string
+// @Failure 404 {object} string
+// @Failure 500 {object} string
+// @Param id path int true "id"
+// @Param variantID path int true "variantID"
+// @Param Authorization header string true "Bearer"
+// @Router /suppliers/{id}/variants/{variantID} [delete]
+func (h *SupplierHandler) DeleteVariant(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(err)
+	}
+	variantID, err := c.ParamsInt("variantID")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(err)
+	}
+
+	if err := h.service.DeleteVariant(c.Context(), id, variantID); err != nil {
+		switch err {
+		case common.ErrNotFound:
+			return c.SendStatus(fiber.StatusNotFound)
+		default:
+			return c.Status(fiber.StatusInternalServerError).JSON(err)
+		}
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
@@ -0,0 +1,307 @@
+package handlers
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/go-playground/validator"
+	"github.com/gofiber/fiber/v2"
+	"github.com/ysfada/product-management-system/domain/common"
+	"github.com/ysfada/product-management-system/domain/dtos"
+	"github.com/ysfada/product-management-system/domain/interfaces"
+)
+
+type TransferOrderHandler struct {
+	service interfaces.ITransferOrderService
+}
+
+func NewTransferOrderHandler(service interfaces.ITransferOrderService) *TransferOrderHandler {
+	return &TransferOrderHandler{
+		service: service,
+	}
+}
+
+var _ interfaces.ITransferOrderHandler = (*TransferOrderHandler)(nil)
+
+func (h *TransferOrderHandler) UseHandler(r fiber.Router) {
+	transfersRouter := r.Group("transfers")
+
+	transfersRouter.Get("/", common.JwtMiddleware, h.Fetch)
+	transfersRouter.Post("/", common.JwtMiddleware, h.Create)
+	transfersRouter.Get("/:id", common.JwtMiddleware, h.GetByID)
+	transfersRouter.Post("/:id/dispatch", common.JwtMiddleware, h.Dispatch)
+	transfersRouter.Post("/:id/receipts", common.JwtMiddleware, h.Receive)
+	transfersRouter.Post("/:id/close", common.JwtMiddleware, h.Close)
+	transfersRouter.Post("/:id/cancel", common.JwtMiddleware, h.Cancel)
+}
+
+// TransferOrder godoc
+// @Summary Get transfers
+// @Description Get transfer orders without their lines, newest first
+// @Tags transfers
+// @Accept json
+// @Produce json
+// @Success 200 {object} dtos.TransferOrderPaginatedDto
+// @Failure 400 {object} string
+// @Failure 500 {object} string
+// @Param warehouseID query int false "only transfers from or to this warehouse"
+// @Param status query string false "draft, in_transit, partially_received, received, closed or cancelled"
+// @Param page query int false "page number"
+// @Param size query int false "rows per page"
+// @Param Authorization header string true "Bearer"
+// @Router /transfers [get]
+func (h *TransferOrderHandler) Fetch(c *fiber.Ctx) error {
+	warehouseID, err := strconv.Atoi(c.Query("warehouseID", "0"))
+	if err != nil {
+		return c.SendStatus(fiber.StatusBadRequest)
+	}
+	page, err := strconv.Atoi(c.Query("page", "1"))
+	if err != nil {
+		return c.SendStatus(fiber.StatusBadRequest)
+	}
+	size, err := strconv.Atoi(c.Query("size", "10"))
+	if err != nil {
+		return c.SendStatus(fiber.StatusBadRequest)
+	}
+	status := strings.ToLower(c.Query("status"))
+
+	if transferOrders, err := h.service.Fetch(c.Context(), warehouseID, status, page, size); err != nil {
+		switch err {
+		default:
+			return c.Status(fiber.StatusInternalServerError).JSON(err)
+		}
+	} else {
+		return c.JSON(transferOrders)
+	}
+}
+
+// TransferOrder godoc
+// @Summary Get transfer by id
+// @Description Get transfer order with its lines and the quantities received, lost and in transit
+// @Tags transfers
+// @Accept json
+// @Produce json
+// @Success 200 {object} dtos.TransferOrderDto
+// @Failure 400 {object} string
+// @Failure 404 {object} string
+// @Failure 500 {object} string
+// @Param id path int true "id"
+// @Param Authorization header string true "Bearer"
+// @Router /transfers/{id} [get]
+func (h *TransferOrderHandler) GetByID(c *fiber.Ctx) error {
+	if id, err := c.ParamsInt("id"); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(err)
+	} else {
+		if transferOrder, err := h.service.GetByID(c.Context(), id); err != nil {
+			switch err {
+			case common.ErrNotFound:
+				return c.SendStatus(fiber.StatusNotFound)
+			default:
+				return c.Status(fiber.StatusInternalServerError).JSON(err)
+			}
+		} else {
+			return c.JSON(transferOrder)
+		}
+	}
+}
+
+// TransferOrder godoc
+// @Summary Create transfer
+// @Description Draft a transfer order between two warehouses, nothing moves until it is dispatched
+// @Tags transfers
+// @Accept json
+// @Produce json
+// @Success 201 {object} dtos.TransferOrderDto
+// @Failure 400 {object} string
+// @Failure 403 {object} string
+// @Failure 404 {object} string
+// @Failure 500 {object} string
+// @Param dto body dtos.CreateTransferOrderDto true "dto"
+// @Param Authorization header string true "Bearer"
+// @Router /transfers [post]
+func (h *TransferOrderHandler) Create(c *fiber.Ctx) error {
+	username, _, ok := currentUser(c)
+	if !ok {
+		return c.SendStatus(fiber.StatusForbidden)
+	}
+
+	var body dtos.CreateTransferOrderDto
+	if err := c.BodyParser(&body); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(err)
+	}
+	body.CreatedBy = username
+
+	if transferOrder, err := h.service.Create(c.Context(), &body); err != nil {
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			return c.Status(fiber.StatusBadRequest).JSON(validationErrors.Error())
+		}
+		switch err {
+		case common.ErrBadParamInput:
+			return c.SendStatus(fiber.StatusBadRequest)
+		case common.ErrNotFound:
+			return c.SendStatus(fiber.StatusNotFound)
+		default:
+			return c.Status(fiber.StatusInternalServerError).JSON(err)
+		}
+	} else {
+		return c.Status(fiber.StatusCreated).JSON(transferOrder)
+	}
+}
+
+// TransferOrder godoc
+// @Summary Dispatch transfer
+// @Description Take the quantities of a draft transfer out of its source warehouse and put them in transit
+// @Tags transfers
+// @Accept json
+// @Produce json
+// @Success 204
+// @Failure 400 {object} string
+// @Failure 403 {object} string
+// @Failure 404 {object} string
+// @Failure 409 {object} string
+// @Failure 500 {object} string
+// @Param id path int true "id"
+// @Param Authorization header string true "Bearer"
+// @Router /transfers/{id}/dispatch [post]
+func (h *TransferOrderHandler) Dispatch(c *fiber.Ctx) error {
+	username, _, ok := currentUser(c)
+	if !ok {
+		return c.SendStatus(fiber.StatusForbidden)
+	}
+
+	if id, err := c.ParamsInt("id"); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(err)
+	} else {
+		if err := h.service.Dispatch(c.Context(), id, username); err != nil {
+			switch err {
+			case common.ErrNotFound:
+				return c.SendStatus(fiber.StatusNotFound)
+			case common.ErrConflict:
+				return c.SendStatus(fiber.StatusConflict)
+			case common.ErrInsufficientStock:
+				return c.Status(fiber.StatusConflict).JSON(err.Error())
+			default:
+				return c.Status(fiber.StatusInternalServerError).JSON(err)
+			}
+		}
+		return c.SendStatus(fiber.StatusNoContent)
+	}
+}
+
+// TransferOrder godoc
+// @Summary Receive transfer
+// @Description Book an arrival against the lines of a dispatched transfer and add it to the destination warehouse
+// @Tags transfers
+// @Accept json
+// @Produce json
+// @Success 200 {object} dtos.TransferOrderDto
+// @Failure 400 {object} string
+// @Failure 403 {object} string
+// @Failure 404 {object} string
+// @Failure 409 {object} string
+// @Failure 500 {object} string
+// @Param id path int true "id"
+// @Param dto body dtos.CreateTransferOrderReceiptDto true "dto"
+// @Param Authorization header string true "Bearer"
+// @Router /transfers/{id}/receipts [post]
+func (h *TransferOrderHandler) Receive(c *fiber.Ctx) error {
+	username, _, ok := currentUser(c)
+	if !ok {
+		return c.SendStatus(fiber.StatusForbidden)
+	}
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(err)
+	}
+
+	var body dtos.CreateTransferOrderReceiptDto
+	if err := c.BodyParser(&body); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(err)
+	}
+	body.TransferOrderID = id
+	body.ReceivedBy = username
+
+	if transferOrder, err := h.service.Receive(c.Context(), &body); err != nil {
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			return c.Status(fiber.StatusBadRequest).JSON(validationErrors.Error())
+		}
+		switch err {
+		case common.ErrBadParamInput:
+			return c.SendStatus(fiber.StatusBadRequest)
+		case common.ErrNotFound:
+			return c.SendStatus(fiber.StatusNotFound)
+		case common.ErrConflict:
+			return c.SendStatus(fiber.StatusConflict)
+		default:
+			return c.Status(fiber.StatusInternalServerError).JSON(err)
+		}
+	} else {
+		return c.JSON(transferOrder)
+	}
+}
+
+// TransferOrder godoc
+// @Summary Close transfer
+// @Description Close a dispatched transfer, what is still in transit is recorded as lost and removed from the stock
+// @Tags transfers
+// @Accept json
+// @Produce json
+// @Success 204
+// @Failure 400 {object} string
+// @Failure 404 {object} string
+// @Failure 409 {object} string
+// @Failure 500 {object} string
+// @Param id path int true "id"
+// @Param Authorization header string true "Bearer"
+// @Router /transfers/{id}/close [post]
+func (h *TransferOrderHandler) Close(c *fiber.Ctx) error {
+	if id, err := c.ParamsInt("id"); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(err)
+	} else {
+		if err := h.service.Close(c.Context(), id); err != nil {
+			switch err {
+			case common.ErrBadParamInput:
+				return c.SendStatus(fiber.StatusBadRequest)
+			case common.ErrNotFound:
+				return c.SendStatus(fiber.StatusNotFound)
+			case common.ErrConflict:
+				return c.SendStatus(fiber.StatusConflict)
+			default:
+				return c.Status(fiber.StatusInternalServerError).JSON(err)
+			}
+		}
+		return c.SendStatus(fiber.StatusNoContent)
+	}
+}
+
+// TransferOrder godoc
+// @Summary Cancel transfer
+// @Description Cancel a draft transfer that was never dispatched
+// @Tags transfers
+// @Accept json
+// @Produce json
+// @Success 204
+// @Failure 400 {object} string
+// @Failure 404 {object} string
+// @Failure 409 {object} string
+// @Failure 500 {object} string
+// @Param id path int true "id"
+// @Param Authorization header string true "Bearer"
+// @Router /transfers/{id}/cancel [post]
+func (h *TransferOrderHandler) Cancel(c *fiber.Ctx) error {
+	if id, err := c.ParamsInt("id"); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(err)
+	} else {
+		if err := h.service.Cancel(c.Context(), id); err != nil {
+			switch err {
+			case common.ErrNotFound:
+				return c.SendStatus(fiber.StatusNotFound)
+			case common.ErrConflict:
+				return c.SendStatus(fiber.StatusConflict)
+			default:
+				return c.Status(fiber.StatusInternalServerError).JSON(err)
+			}
+		}
+		return c.SendStatus(fiber.StatusNoContent)
+	}
+}
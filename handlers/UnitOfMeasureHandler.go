@@ -0,0 +1,150 @@
+package handlers
+
+import (
+	"github.com/go-playground/validator"
+	"github.com/gofiber/fiber/v2"
+	"github.com/ysfada/product-management-system/domain/common"
+	"github.com/ysfada/product-management-system/domain/dtos"
+	"github.com/ysfada/product-management-system/domain/interfaces"
+)
+
+type UnitOfMeasureHandler struct {
+	service interfaces.IUnitOfMeasureService
+}
+
+func NewUnitOfMeasureHandler(service interfaces.IUnitOfMeasureService) *UnitOfMeasureHandler {
+	return &UnitOfMeasureHandler{
+		service: service,
+	}
+}
+
+var _ interfaces.IUnitOfMeasureHandler = (*UnitOfMeasureHandler)(nil)
+
+func (h *UnitOfMeasureHandler) UseHandler(r fiber.Router) {
+	unitsRouter := r.Group("products/:id/variants/:variantID/units")
+
+	unitsRouter.Get("/", common.JwtMiddleware, h.Fetch)
+	unitsRouter.Put("/:name", common.JwtMiddleware, h.Set)
+	unitsRouter.Delete("/:name", common.JwtMiddleware, h.Delete)
+}
+
+// UnitOfMeasure godoc
+// @Summary Get product variant units
+// @Description Get the alternative units of a product variant with the number of base units in each
+// @Tags units
+// @Accept json
+// @Produce json
+// @Success 200 {array} dtos.UnitOfMeasureDto
+// @Failure 400 {object} string
+// @Failure 500 {object} string
+// @Param id path int true "id"
+// @Param variantID path int true "variantID"
+// @Param Authorization header string true "Bearer"
+// @Router /products/{id}/variants/{variantID}/units [get]
+func (h *UnitOfMeasureHandler) Fetch(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(err)
+	}
+	variantID, err := c.ParamsInt("variantID")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(err)
+	}
+
+	if units, err := h.service.Fetch(c.Context(), id, variantID); err != nil {
+		switch err {
+		default:
+			return c.Status(fiber.StatusInternalServerError).JSON(err)
+		}
+	} else {
+		return c.JSON(units)
+	}
+}
+
+// UnitOfMeasure godoc
+// @Summary Set product variant unit
+// @Description Add an alternative unit to a product variant or change its factor, e.g. a box of 12 pcs
+// @Tags units
+// @Accept json
+// @Produce json
+// @Success 204
+// @Failure 400 {object} string
+// @Failure 404 {object} string
+// @Failure 500 {object} string
+// @Param id path int true "id"
+// @Param variantID path int true "variantID"
+// @Param name path string true "name"
+// @Param dto body dtos.SetUnitOfMeasureDto true "dto"
+// @Param Authorization header string true "Bearer"
+// @Router /products/{id}/variants/{variantID}/units/{name} [put]
+func (h *UnitOfMeasureHandler) Set(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(err)
+	}
+	variantID, err := c.ParamsInt("variantID")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(err)
+	}
+
+	var body dtos.SetUnitOfMeasureDto
+	if err := c.BodyParser(&body); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(err)
+	}
+	body.ProductID = id
+	body.ProductVariantID = variantID
+	body.Name = c.Params("name")
+
+	if err := h.service.Set(c.Context(), &body); err != nil {
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			return c.Status(fiber.StatusBadRequest).JSON(validationErrors.Error())
+		}
+		switch err {
+		case common.ErrBadParamInput:
+			return c.SendStatus(fiber.StatusBadRequest)
+		case common.ErrNotFound:
+			return c.SendStatus(fiber.StatusNotFound)
+		default:
+			return c.Status(fiber.StatusInternalServerError).JSON(err)
+		}
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// UnitOfMeasure godoc
+// @Summary Delete product variant unit
+// @Description Remove an alternative unit from a product variant
+// @Tags units
+// @Accept json
+// @Produce json
+// @Success 204
+// @Failure 400 {object} string
+// @Failure 404 {object} string
+// @Failure 500 {object} string
+// @Param id path int true "id"
+// @Param variantID path int true "variantID"
+// @Param name path string true "name"
+// @Param Authorization header string true "Bearer"
+// @Router /products/{id}/variants/{variantID}/units/{name} [delete]
+func (h *UnitOfMeasureHandler) Delete(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(err)
+	}
+	variantID, err := c.ParamsInt("variantID")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(err)
+	}
+
+	if err := h.service.Delete(c.Context(), id, variantID, c.Params("name")); err != nil {
+		switch err {
+		case common.ErrNotFound:
+			return c.SendStatus(fiber.StatusNotFound)
+		default:
+			return c.Status(fiber.StatusInternalServerError).JSON(err)
+		}
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
@@ -0,0 +1,234 @@
+package handlers
+
+import (
+	"github.com/go-playground/validator"
+	"github.com/gofiber/fiber/v2"
+	"github.com/ysfada/product-management-system/domain/common"
+	"github.com/ysfada/product-management-system/domain/dtos"
+	"github.com/ysfada/product-management-system/domain/interfaces"
+)
+
+type WarehouseHandler struct {
+	service interfaces.IWarehouseService
+}
+
+func NewWarehouseHandler(service interfaces.IWarehouseService) *WarehouseHandler {
+	return &WarehouseHandler{
+		service: service,
+	}
+}
+
+var _ interfaces.IWarehouseHandler = (*WarehouseHandler)(nil)
+
+func (h *WarehouseHandler) UseHandler(r fiber.Router) {
+	warehousesRouter := r.Group("warehouses")
+
+	warehousesRouter.Get("/", common.JwtMiddleware, h.Fetch)
+	warehousesRouter.Post("/", common.JwtMiddleware, h.Create)
+	warehousesRouter.Get("/:id", common.JwtMiddleware, h.GetByID)
+	warehousesRouter.Put("/:id", common.JwtMiddleware, h.Update)
+	warehousesRouter.Delete("/:id", common.JwtMiddleware, h.Delete)
+
+	r.Get("products/:id/variants/:variantID/stock", common.JwtMiddleware, h.VariantStock)
+}
+
+// Warehouse godoc
+// @Summary Get warehouses
+// @Description Get all warehouses
+// @Tags warehouses
+// @Accept json
+// @Produce json
+// @Success 200 {array} dtos.WarehouseDto
+// @Failure 500 {object} string
+// @Param Authorization header string true "Bearer"
+// @Router /warehouses [get]
+func (h *WarehouseHandler) Fetch(c *fiber.Ctx) error {
+	if warehouses, err := h.service.Fetch(c.Context()); err != nil {
+		switch err {
+		default:
+			return c.Status(fiber.StatusInternalServerError).JSON(err)
+		}
+	} else {
+		return c.JSON(warehouses)
+	}
+}
+
+// Warehouse godoc
+// @Summary Get warehouse by id
+// @Description Get warehouse by id
+// @Tags warehouses
+// @Accept json
+// @Produce json
+// @Success 200 {object} dtos.WarehouseDto
+// @Failure 400 {object} string
+// @Failure 404 {object} string
+// @Failure 500 {object} string
+// @Param id path int true "id"
+// @Param Authorization header string true "Bearer"
+// @Router /warehouses/{id} [get]
+func (h *WarehouseHandler) GetByID(c *fiber.Ctx) error {
+	if id, err := c.ParamsInt("id"); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(err)
+	} else {
+		if warehouse, err := h.service.GetByID(c.Context(), id); err != nil {
+			switch err {
+			case common.ErrNotFound:
+				return c.SendStatus(fiber.StatusNotFound)
+			default:
+				return c.Status(fiber.StatusInternalServerError).JSON(err)
+			}
+		} else {
+			return c.JSON(warehouse)
+		}
+	}
+}
+
+// Warehouse godoc
+// @Summary Create warehouse
+// @Description Create warehouse
+// @Tags warehouses
+// @Accept json
+// @Produce json
+// @Success 201
+// @Failure 400 {object} string
+// @Failure 409 {object} string
+// @Failure 500 {object} string
+// @Param dto body dtos.CreateWarehouseDto true "dto"
+// @Param Authorization header string true "Bearer"
+// @Router /warehouses [post]
+func (h *WarehouseHandler) Create(c *fiber.Ctx) error {
+	var body dtos.CreateWarehouseDto
+	if err := c.BodyParser(&body); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(err)
+	}
+
+	if err := h.service.Create(c.Context(), &body); err != nil {
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			return c.Status(fiber.StatusBadRequest).JSON(validationErrors.Error())
+		}
+		switch err {
+		case common.ErrBadParamInput:
+			return c.SendStatus(fiber.StatusBadRequest)
+		case common.ErrConflict:
+			return c.SendStatus(fiber.StatusConflict)
+		default:
+			return c.Status(fiber.StatusInternalServerError).JSON(err)
+		}
+	}
+
+	return c.SendStatus(fiber.StatusCreated)
+}
+
+// Warehouse godoc
+// @Summary Update warehouse
+// @Description Update warehouse by id
+// @Tags warehouses
+// @Accept json
+// @Produce json
+// @Success 204
+// @Failure 400 {object} string
+// @Failure 404 {object} string
+// @Failure 409 {object} string
+// @Failure 500 {object} string
+// @Param id path int true "id"
+// @Param dto body dtos.UpdateWarehouseDto true "dto"
+// @Param Authorization header string true "Bearer"
+// @Router /warehouses/{id} [put]
+func (h *WarehouseHandler) Update(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(err)
+	}
+
+	var body dtos.UpdateWarehouseDto
+	if err := c.BodyParser(&body); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(err)
+	}
+	body.ID = id
+
+	if err := h.service.Update(c.Context(), &body); err != nil {
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			return c.Status(fiber.StatusBadRequest).JSON(validationErrors.Error())
+		}
+		switch err {
+		case common.ErrBadParamInput:
+			return c.SendStatus(fiber.StatusBadRequest)
+		case common.ErrNotFound:
+			return c.SendStatus(fiber.StatusNotFound)
+		case common.ErrConflict:
+			return c.SendStatus(fiber.StatusConflict)
+		default:
+			return c.Status(fiber.StatusInternalServerError).JSON(err)
+		}
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// Warehouse godoc
+// @Summary Delete warehouse
+// @Description Delete an empty warehouse by id, the default warehouse and warehouses with stock or transfers can not be deleted
+// @Tags warehouses
+// @Accept json
+// @Produce json
+// @Success 204
+// @Failure 400 {object} string
+// @Failure 404 {object} string
+// @Failure 409 {object} string
+// @Failure 500 {object} string
+// @Param id path int true "id"
+// @Param Authorization header string true "Bearer"
+// @Router /warehouses/{id} [delete]
+func (h *WarehouseHandler) Delete(c *fiber.Ctx) error {
+	if id, err := c.ParamsInt("id"); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(err)
+	} else {
+		if err := h.service.Delete(c.Context(), id); err != nil {
+			switch err {
+			case common.ErrNotFound:
+				return c.SendStatus(fiber.StatusNotFound)
+			case common.ErrConflict:
+				return c.SendStatus(fiber.StatusConflict)
+			default:
+				return c.Status(fiber.StatusInternalServerError).JSON(err)
+			}
+		}
+		return c.SendStatus(fiber.StatusNoContent)
+	}
+}
+
+// Warehouse godoc
+// @Summary Get product variant stock
+// @Description Break the stock of a product variant down by warehouse, stock that is not moved to another warehouse is at the default warehouse
+// @Tags warehouses
+// @Accept json
+// @Produce json
+// @Success 200 {object} dtos.VariantStockDto
+// @Failure 400 {object} string
+// @Failure 404 {object} string
+// @Failure 500 {object} string
+// @Param id path int true "id"
+// @Param variantID path int true "variantID"
+// @Param Authorization header string true "Bearer"
+// @Router /products/{id}/variants/{variantID}/stock [get]
+func (h *WarehouseHandler) VariantStock(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(err)
+	}
+	variantID, err := c.ParamsInt("variantID")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(err)
+	}
+
+	if stock, err := h.service.VariantStock(c.Context(), id, variantID); err != nil {
+		switch err {
+		case common.ErrNotFound:
+			return c.SendStatus(fiber.StatusNotFound)
+		default:
+			return c.Status(fiber.StatusInternalServerError).JSON(err)
+		}
+	} else {
+		return c.JSON(stock)
+	}
+}
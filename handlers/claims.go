@@ -0,0 +1,28 @@
+package handlers
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v4"
+)
+
+// currentUser returns the username of the authenticated user and whether the
+// user is staff or superuser. ok is false if the request carries no valid
+// token.
+func currentUser(c *fiber.Ctx) (username string, isStaff bool, ok bool) {
+	user, ok := c.Locals("user").(*jwt.Token)
+	if !ok {
+		return "", false, false
+	}
+	claims, ok := user.Claims.(jwt.MapClaims)
+	if !ok {
+		return "", false, false
+	}
+	username, ok = claims["username"].(string)
+	if !ok {
+		return "", false, false
+	}
+
+	staff, _ := claims["isStaf"].(bool)
+	superuser, _ := claims["isSuperuser"].(bool)
+	return username, staff || superuser, true
+}
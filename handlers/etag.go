@@ -0,0 +1,37 @@
+package handlers
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// setETag sets the ETag header of the response to the row version of the
+// returned item.
+func setETag(c *fiber.Ctx, version int) {
+	c.Set(fiber.HeaderETag, fmt.Sprintf(`"%d"`, version))
+}
+
+// ifMatch returns the row versions the client expects from the If-Match
+// header, or nil if the header is missing or "*". Every ETag of the list is
+// honored, weak ETags and ETags that are not a version never match any row.
+func ifMatch(c *fiber.Ctx) []int {
+	header := strings.TrimSpace(c.Get(fiber.HeaderIfMatch))
+	if header == "" || header == "*" {
+		return nil
+	}
+
+	versions := []int{}
+	for _, etag := range strings.Split(header, ",") {
+		etag = strings.TrimSpace(etag)
+		if strings.HasPrefix(etag, "W/") {
+			continue
+		}
+		if version, err := strconv.Atoi(strings.Trim(etag, `"`)); err == nil {
+			versions = append(versions, version)
+		}
+	}
+	return versions
+}
@@ -1,14 +1,27 @@
 package handlers
 
 import (
+	"context"
+	"os"
+	"time"
+
 	"github.com/gofiber/fiber/v2"
 	"github.com/ysfada/product-management-system/database"
 	"github.com/ysfada/product-management-system/database/repositories"
 	"github.com/ysfada/product-management-system/services"
+	"github.com/ysfada/product-management-system/util/feed"
 	"github.com/ysfada/product-management-system/util/hasher"
+	"github.com/ysfada/product-management-system/util/storage"
+)
+
+const (
+	defaultReservationSweepInterval = time.Minute
+	defaultCartSweepInterval        = time.Hour
 )
 
-func Use(r fiber.Router) {
+// Use registers the API on r, the images are kept in imageStorage and the
+// feeds are written with feedConfig.
+func Use(r fiber.Router, imageStorage storage.Storage, feedConfig feed.Config) {
 	argon2 := hasher.NewArgon2()
 
 	userRepository := repositories.NewUserRepository(database.DbConn)
@@ -16,15 +29,89 @@ func Use(r fiber.Router) {
 	productRepository := repositories.NewProductRepository(database.DbConn)
 	attributeRepository := repositories.NewAttributeRepository(database.DbConn)
 	imageRepository := repositories.NewImageRepository(database.DbConn)
+	reservationRepository := repositories.NewReservationRepository(database.DbConn)
+	inventoryRepository := repositories.NewInventoryRepository(database.DbConn)
+	lotRepository := repositories.NewLotRepository(database.DbConn)
+	serialNumberRepository := repositories.NewSerialNumberRepository(database.DbConn)
+	stocktakeRepository := repositories.NewStocktakeRepository(database.DbConn)
+	supplierRepository := repositories.NewSupplierRepository(database.DbConn)
+	purchaseOrderRepository := repositories.NewPurchaseOrderRepository(database.DbConn)
+	unitOfMeasureRepository := repositories.NewUnitOfMeasureRepository(database.DbConn)
+	warehouseRepository := repositories.NewWarehouseRepository(database.DbConn)
+	transferOrderRepository := repositories.NewTransferOrderRepository(database.DbConn)
+	salesOrderRepository := repositories.NewSalesOrderRepository(database.DbConn)
+	cartRepository := repositories.NewCartRepository(database.DbConn)
+	returnAuthorizationRepository := repositories.NewReturnAuthorizationRepository(database.DbConn)
+	promotionRepository := repositories.NewPromotionRepository(database.DbConn)
+	catalogImportRepository := repositories.NewCatalogImportRepository(database.DbConn)
+	catalogExportRepository := repositories.NewCatalogExportRepository(database.DbConn)
+	feedRepository := repositories.NewFeedRepository(database.DbConn)
+	batchRepository := repositories.NewBatchRepository(database.DbConn)
+	snapshotRepository := repositories.NewSnapshotRepository(database.DbConn)
 
-	userService := services.NewUserService(userRepository, argon2)
-	categoryService := services.NewCategoryService(categoryRepository)
+	cartService := services.NewCartService(cartRepository)
+	userService := services.NewUserService(userRepository, argon2, cartService)
 	attributeService := services.NewAttributeService(attributeRepository)
-	imageService := services.NewImageService(imageRepository)
+	imageService := services.NewImageService(imageRepository, imageStorage)
+	categoryService := services.NewCategoryService(categoryRepository, imageService)
 	productService := services.NewProductService(productRepository, imageService)
+	reservationService := services.NewReservationService(reservationRepository)
+	inventoryService := services.NewInventoryService(inventoryRepository)
+	lotService := services.NewLotService(lotRepository)
+	serialNumberService := services.NewSerialNumberService(serialNumberRepository)
+	stocktakeService := services.NewStocktakeService(stocktakeRepository)
+	supplierService := services.NewSupplierService(supplierRepository)
+	purchaseOrderService := services.NewPurchaseOrderService(purchaseOrderRepository)
+	unitOfMeasureService := services.NewUnitOfMeasureService(unitOfMeasureRepository)
+	warehouseService := services.NewWarehouseService(warehouseRepository)
+	transferOrderService := services.NewTransferOrderService(transferOrderRepository)
+	salesOrderService := services.NewSalesOrderService(salesOrderRepository)
+	returnAuthorizationService := services.NewReturnAuthorizationService(returnAuthorizationRepository)
+	promotionService := services.NewPromotionService(promotionRepository)
+	catalogImportService := services.NewCatalogImportService(catalogImportRepository)
+	catalogExportService := services.NewCatalogExportService(catalogExportRepository)
+	feedService := services.NewFeedService(feedRepository, feedConfig)
+	batchService := services.NewBatchService(batchRepository)
+	snapshotService := services.NewSnapshotService(snapshotRepository, imageStorage, imageService)
+
+	sweepInterval, err := time.ParseDuration(os.Getenv("RESERVATION_SWEEP_INTERVAL"))
+	if err != nil || sweepInterval <= 0 {
+		sweepInterval = defaultReservationSweepInterval
+	}
+	go reservationService.Sweep(context.Background(), sweepInterval)
+
+	cartSweepInterval, err := time.ParseDuration(os.Getenv("CART_SWEEP_INTERVAL"))
+	if err != nil || cartSweepInterval <= 0 {
+		cartSweepInterval = defaultCartSweepInterval
+	}
+	go cartService.Sweep(context.Background(), cartSweepInterval)
+
+	if webhookURL := os.Getenv("LOW_STOCK_WEBHOOK_URL"); len(webhookURL) > 0 {
+		inventoryService.Subscribe(services.NewLowStockWebhook(webhookURL))
+	}
+	go inventoryService.Listen(context.Background())
 
 	NewUserHandler(userService).UseHandler(r)
 	NewCategoryHandler(categoryService).UseHandler(r)
 	NewProductHandler(productService).UseHandler(r)
 	NewAttributeHandler(attributeService).UseHandler(r)
+	NewReservationHandler(reservationService).UseHandler(r)
+	NewInventoryHandler(inventoryService).UseHandler(r)
+	NewLotHandler(lotService).UseHandler(r)
+	NewSerialNumberHandler(serialNumberService).UseHandler(r)
+	NewStocktakeHandler(stocktakeService).UseHandler(r)
+	NewSupplierHandler(supplierService).UseHandler(r)
+	NewPurchaseOrderHandler(purchaseOrderService).UseHandler(r)
+	NewUnitOfMeasureHandler(unitOfMeasureService).UseHandler(r)
+	NewWarehouseHandler(warehouseService).UseHandler(r)
+	NewTransferOrderHandler(transferOrderService).UseHandler(r)
+	NewSalesOrderHandler(salesOrderService).UseHandler(r)
+	NewCartHandler(cartService).UseHandler(r)
+	NewReturnAuthorizationHandler(returnAuthorizationService).UseHandler(r)
+	NewPromotionHandler(promotionService).UseHandler(r)
+	NewCatalogImportHandler(catalogImportService).UseHandler(r)
+	NewCatalogExportHandler(catalogExportService).UseHandler(r)
+	NewFeedHandler(feedService).UseHandler(r)
+	NewBatchHandler(batchService).UseHandler(r)
+	NewSnapshotHandler(snapshotService).UseHandler(r)
 }
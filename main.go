@@ -15,12 +15,14 @@ import (
 	"github.com/ysfada/product-management-system/database/migrations"
 	_ "github.com/ysfada/product-management-system/docs"
 	"github.com/ysfada/product-management-system/handlers"
+	"github.com/ysfada/product-management-system/services"
 )
 
 const (
 	defaultHost      = "localhost"
 	defaultPort      = "8080"
 	defaultSourceURL = "file://database/migrations"
+	defaultSeedsURL  = "file://database/seeds"
 )
 
 // @title Inventory Management
@@ -37,6 +39,10 @@ const (
 // @BasePath /api/v1
 func main() {
 	// debug := flag.Bool("debug", false, "run in debug mode")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), usage, os.Args[0])
+		flag.PrintDefaults()
+	}
 	flag.Parse()
 
 	if err := godotenv.Load(".env"); err != nil {
@@ -67,10 +73,60 @@ func main() {
 	database.CreateConnection(databaseURL)
 	defer database.DbConn.Close()
 
-	if runMigrations {
+	// the migrate command changes the schema itself
+	command := flag.Arg(0)
+	if runMigrations && command != "migrate" {
 		migrations.Run(sourceURL, databaseURL)
 	}
 
+	seedsURL := os.Getenv("SEED_SOURCE_URL")
+	if len(seedsURL) == 0 {
+		seedsURL = defaultSeedsURL
+	}
+	args := flag.Args()
+	if len(args) > 0 {
+		args = args[1:]
+	}
+	switch command {
+	case "", "serve":
+	case "seed":
+		migrations.Seed(seedsURL, databaseURL)
+		return
+	case "unseed":
+		migrations.Unseed(seedsURL, databaseURL)
+		return
+	case "generate":
+		generate(args)
+		return
+	case "migrate":
+		migrate(sourceURL, databaseURL, args)
+		return
+	case "createsuperuser":
+		createSuperuser(args)
+		return
+	case "user":
+		user(args)
+		return
+	case "reindex":
+		reindex()
+		return
+	case "purge-images":
+		purgeImages(args)
+		return
+	case "snapshot":
+		snapshot(args)
+		return
+	case "renditions":
+		renditions(args)
+		return
+	case "storage":
+		storageCommand(args)
+		return
+	default:
+		flag.Usage()
+		os.Exit(2)
+	}
+
 	fiberConf := fiber.Config{
 		Prefork: false,
 	}
@@ -87,8 +143,11 @@ func main() {
 	api := app.Group("/api")
 	v1 := api.Group("/v1")
 
-	handlers.Use(v1)
+	imageStorage := newStorage()
+	handlers.Use(v1, imageStorage, newFeedConfig())
 
+	// images of a remote storage are redirected to, before the static files
+	handlers.NewStorageHandler(services.NewStorageService(imageStorage)).UseHandler(app)
 	app.Static("/public", "./public", fiber.Static{
 		Compress: true,
 	})
@@ -26,9 +26,10 @@ func (s *AttributeService) Fetch(ctx context.Context, page int, size int, sortBy
 		var attributesDto dtos.AttributePaginatedDto
 		for _, attribute := range attributes.Attributes {
 			attributeDto := &dtos.AttributeDto{
-				ID:   attribute.ID,
-				Name: attribute.Name,
-				Type: attribute.Type,
+				ID:      attribute.ID,
+				Name:    attribute.Name,
+				Type:    attribute.Type,
+				Version: attribute.Version,
 			}
 
 			attributesDto.Attributes = append(attributesDto.Attributes, attributeDto)
@@ -51,9 +52,10 @@ func (s *AttributeService) GetByID(ctx context.Context, id int) (res *dtos.Attri
 	} else {
 		if attribute != nil {
 			return &dtos.AttributeDto{
-				ID:   attribute.ID,
-				Name: attribute.Name,
-				Type: attribute.Type,
+				ID:      attribute.ID,
+				Name:    attribute.Name,
+				Type:    attribute.Type,
+				Version: attribute.Version,
 			}, nil
 		}
 		return nil, nil
@@ -65,11 +67,12 @@ func (s *AttributeService) Update(ctx context.Context, dto *dtos.UpdateAttribute
 }
 
 func (s *AttributeService) Create(ctx context.Context, dto *dtos.CreateAttributeDto) error {
-	return s.repository.Create(ctx, dto)
+	_, err := s.repository.Create(ctx, dto)
+	return err
 }
 
-func (s *AttributeService) Delete(ctx context.Context, id int) error {
-	return s.repository.Delete(ctx, id)
+func (s *AttributeService) Delete(ctx context.Context, id int, versions []int) error {
+	return s.repository.Delete(ctx, id, versions)
 }
 
 func (s *AttributeService) Search(ctx context.Context, q string, page int, size int, sortBy string, orderBy string) (*dtos.AttributePaginatedDto, error) {
@@ -79,9 +82,10 @@ func (s *AttributeService) Search(ctx context.Context, q string, page int, size
 		var attributesDto dtos.AttributePaginatedDto
 		for _, category := range attributes.Attributes {
 			categoryDto := &dtos.AttributeDto{
-				ID:   category.ID,
-				Name: category.Name,
-				Type: category.Type,
+				ID:      category.ID,
+				Name:    category.Name,
+				Type:    category.Type,
+				Version: category.Version,
 			}
 
 			attributesDto.Attributes = append(attributesDto.Attributes, categoryDto)
@@ -0,0 +1,244 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/go-playground/validator"
+	"github.com/ysfada/product-management-system/domain/common"
+	"github.com/ysfada/product-management-system/domain/dtos"
+	"github.com/ysfada/product-management-system/domain/interfaces"
+	"github.com/ysfada/product-management-system/util/batchref"
+)
+
+const (
+	BatchCreated = "created"
+	BatchUpdated = "updated"
+	BatchDeleted = "deleted"
+	BatchFailed  = "failed"
+	BatchSkipped = "skipped"
+)
+
+type BatchService struct {
+	repository interfaces.IBatchRepository
+	validate   *validator.Validate
+}
+
+var _ interfaces.IBatchService = (*BatchService)(nil)
+
+func NewBatchService(repository interfaces.IBatchRepository) *BatchService {
+	return &BatchService{
+		repository: repository,
+		validate:   validator.New(),
+	}
+}
+
+// batchTx is the catalog on the transaction of a batch.
+type batchTx struct {
+	categories interfaces.ICategoryRepository
+	products   interfaces.IProductRepository
+	attributes interfaces.IAttributeRepository
+}
+
+// Execute runs the operations in order in one transaction. If one fails the
+// transaction is rolled back and its error is returned with the results, the
+// failed operation has the error and the ones after it are skipped.
+func (s *BatchService) Execute(ctx context.Context, dto *dtos.BatchDto) (*dtos.BatchResultDto, error) {
+	if err := s.validate.Struct(dto); err != nil {
+		return nil, err
+	}
+
+	result := &dtos.BatchResultDto{
+		Results: make([]*dtos.BatchOperationResultDto, len(dto.Operations)),
+	}
+	for i, op := range dto.Operations {
+		result.Results[i] = &dtos.BatchOperationResultDto{
+			Index:  i,
+			Ref:    op.Ref,
+			Type:   op.Type,
+			Action: op.Action,
+			Status: BatchSkipped,
+		}
+	}
+
+	// a ref names the id of a create, once
+	named := make(map[string]bool)
+	for i, op := range dto.Operations {
+		if len(op.Ref) == 0 {
+			continue
+		}
+		if op.Action != "create" || op.Type == "attribute_link" || named[op.Ref] {
+			err := fmt.Errorf("%w: ref %s", common.ErrBadParamInput, op.Ref)
+			result.Results[i].Status = BatchFailed
+			result.Results[i].Error = err.Error()
+			return result, err
+		}
+		named[op.Ref] = true
+	}
+
+	refs := make(map[string]int)
+	err := s.repository.Transaction(ctx, func(categories interfaces.ICategoryRepository, products interfaces.IProductRepository, attributes interfaces.IAttributeRepository) error {
+		tx := &batchTx{
+			categories: categories,
+			products:   products,
+			attributes: attributes,
+		}
+		for i, op := range dto.Operations {
+			id, err := s.execute(ctx, tx, op, refs)
+			if err != nil {
+				result.Results[i].Status = BatchFailed
+				result.Results[i].Error = err.Error()
+				return err
+			}
+
+			switch op.Action {
+			case "create":
+				result.Results[i].Status = BatchCreated
+			case "update":
+				result.Results[i].Status = BatchUpdated
+			case "delete":
+				result.Results[i].Status = BatchDeleted
+			}
+			if id > 0 {
+				id := id
+				result.Results[i].ID = &id
+			}
+			if len(op.Ref) > 0 {
+				refs[op.Ref] = id
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return result, err
+	}
+
+	result.Committed = true
+	return result, nil
+}
+
+// execute runs one operation, it returns the id of the row written, 0 for an
+// attribute link.
+func (s *BatchService) execute(ctx context.Context, tx *batchTx, op *dtos.BatchOperationDto, refs map[string]int) (int, error) {
+	data, err := batchref.Resolve(op.Data, refs)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %v", common.ErrBadParamInput, err)
+	}
+
+	// the version of an operation is the only one it expects
+	var versions []int
+	if op.Version != nil {
+		versions = []int{*op.Version}
+	}
+
+	switch op.Type + " " + op.Action {
+	case "category create":
+		var dto dtos.CreateCategoryDto
+		if err := s.decode(data, &dto); err != nil {
+			return 0, err
+		}
+		return tx.categories.Create(ctx, &dto)
+	case "category update":
+		var dto dtos.UpdateCategoryDto
+		if err := s.decode(data, &dto); err != nil {
+			return 0, err
+		}
+		dto.Versions = versions
+		return dto.ID, tx.categories.Update(ctx, &dto)
+	case "category delete":
+		var dto dtos.BatchDeleteDto
+		if err := s.decode(data, &dto); err != nil {
+			return 0, err
+		}
+		return dto.ID, tx.categories.Delete(ctx, dto.ID, versions)
+
+	case "product create":
+		var dto dtos.CreateProductDto
+		if err := s.decode(data, &dto); err != nil {
+			return 0, err
+		}
+		return tx.products.Create(ctx, &dto)
+	case "product update":
+		var dto dtos.UpdateProductDto
+		if err := s.decode(data, &dto); err != nil {
+			return 0, err
+		}
+		dto.Versions = versions
+		return dto.ID, tx.products.Update(ctx, &dto)
+	case "product delete":
+		var dto dtos.BatchDeleteDto
+		if err := s.decode(data, &dto); err != nil {
+			return 0, err
+		}
+		return dto.ID, tx.products.Delete(ctx, dto.ID, versions)
+
+	case "variant create":
+		var dto dtos.CreateProductVariantDto
+		if err := s.decode(data, &dto); err != nil {
+			return 0, err
+		}
+		return tx.products.CreateVariant(ctx, &dto)
+	case "variant update":
+		var dto dtos.UpdateProductVariantDto
+		if err := s.decode(data, &dto); err != nil {
+			return 0, err
+		}
+		dto.Versions = versions
+		return dto.ID, tx.products.UpdateVariant(ctx, &dto)
+	case "variant delete":
+		var dto dtos.BatchDeleteDto
+		if err := s.decode(data, &dto); err != nil {
+			return 0, err
+		}
+		if dto.ProductID == 0 {
+			return 0, fmt.Errorf("%w: product_id is required", common.ErrBadParamInput)
+		}
+		return dto.ID, tx.products.DeleteVariant(ctx, dto.ProductID, dto.ID, versions)
+
+	case "attribute create":
+		var dto dtos.CreateAttributeDto
+		if err := s.decode(data, &dto); err != nil {
+			return 0, err
+		}
+		return tx.attributes.Create(ctx, &dto)
+	case "attribute update":
+		var dto dtos.UpdateAttributeDto
+		if err := s.decode(data, &dto); err != nil {
+			return 0, err
+		}
+		dto.Versions = versions
+		return dto.ID, tx.attributes.Update(ctx, &dto)
+	case "attribute delete":
+		var dto dtos.BatchDeleteDto
+		if err := s.decode(data, &dto); err != nil {
+			return 0, err
+		}
+		return dto.ID, tx.attributes.Delete(ctx, dto.ID, versions)
+
+	case "attribute_link create":
+		var dto dtos.CreateProductVariantAttributeDto
+		if err := s.decode(data, &dto); err != nil {
+			return 0, err
+		}
+		return 0, tx.products.AddAttribute(ctx, &dto)
+	case "attribute_link delete":
+		var dto dtos.CreateProductVariantAttributeDto
+		if err := s.decode(data, &dto); err != nil {
+			return 0, err
+		}
+		// the link is found by variant and attribute alone
+		return 0, tx.products.RemoveAttribute(ctx, 0, dto.ProductVariantID, dto.AttributeID)
+
+	default:
+		return 0, fmt.Errorf("%w: cannot %s %s", common.ErrBadParamInput, op.Action, op.Type)
+	}
+}
+
+// decode reads the data of an operation into dto and validates it.
+func (s *BatchService) decode(data json.RawMessage, dto interface{}) error {
+	if err := json.Unmarshal(data, dto); err != nil {
+		return fmt.Errorf("%w: %v", common.ErrBadParamInput, err)
+	}
+	return s.validate.Struct(dto)
+}
@@ -0,0 +1,193 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"log"
+	"os"
+	"time"
+
+	"github.com/go-playground/validator"
+	"github.com/ysfada/product-management-system/domain/common"
+	"github.com/ysfada/product-management-system/domain/dtos"
+	"github.com/ysfada/product-management-system/domain/entities"
+	"github.com/ysfada/product-management-system/domain/interfaces"
+)
+
+const defaultCartIdleTimeout = 72 * time.Hour
+
+type CartService struct {
+	repository interfaces.ICartRepository
+	validate   *validator.Validate
+	idle       time.Duration
+}
+
+var _ interfaces.ICartService = (*CartService)(nil)
+
+func NewCartService(repository interfaces.ICartRepository) *CartService {
+	idle, err := time.ParseDuration(os.Getenv("CART_IDLE_TIMEOUT"))
+	if err != nil || idle <= 0 {
+		idle = defaultCartIdleTimeout
+	}
+
+	return &CartService{
+		repository: repository,
+		validate:   validator.New(),
+		idle:       idle,
+	}
+}
+
+// Get returns the cart of owner, or the anonymous cart of token if owner is
+// empty.
+func (s *CartService) Get(ctx context.Context, owner string, token string) (*dtos.CartDto, error) {
+	if cart, err := s.cart(ctx, owner, token); err != nil {
+		return nil, err
+	} else {
+		return toCartDto(cart), nil
+	}
+}
+
+// AddLine adds the quantity to the line of the variant, creating the cart if
+// there is none yet.
+func (s *CartService) AddLine(ctx context.Context, dto *dtos.SetCartLineDto) (*dtos.CartDto, error) {
+	return s.setLine(ctx, dto, true)
+}
+
+// UpdateLine replaces the quantity of the line of the variant, creating the
+// cart if there is none yet.
+func (s *CartService) UpdateLine(ctx context.Context, dto *dtos.SetCartLineDto) (*dtos.CartDto, error) {
+	return s.setLine(ctx, dto, false)
+}
+
+func (s *CartService) RemoveLine(ctx context.Context, owner string, token string, variantID int) (*dtos.CartDto, error) {
+	cart, err := s.cart(ctx, owner, token)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repository.RemoveLine(ctx, cart.ID, variantID, s.expiresAt()); err != nil {
+		return nil, err
+	}
+	return s.Get(ctx, owner, cart.Token)
+}
+
+func (s *CartService) Delete(ctx context.Context, owner string, token string) error {
+	if cart, err := s.cart(ctx, owner, token); err != nil {
+		return err
+	} else {
+		return s.repository.Delete(ctx, cart.ID)
+	}
+}
+
+// Merge moves the anonymous cart of token into the cart of owner.
+func (s *CartService) Merge(ctx context.Context, token string, owner string) (*dtos.CartDto, error) {
+	if len(token) == 0 {
+		return nil, common.ErrNotFound
+	}
+
+	if err := s.repository.Merge(ctx, token, owner, s.expiresAt()); err != nil {
+		return nil, err
+	}
+	return s.Get(ctx, owner, "")
+}
+
+func (s *CartService) DeleteExpired(ctx context.Context) (int, error) {
+	return s.repository.DeleteExpired(ctx)
+}
+
+// Sweep deletes expired carts every interval until ctx is done.
+func (s *CartService) Sweep(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if count, err := s.DeleteExpired(ctx); err != nil {
+				log.Printf("Unable to delete expired carts: %v\n", err)
+			} else if count > 0 {
+				log.Printf("Deleted %d expired carts\n", count)
+			}
+		}
+	}
+}
+
+func (s *CartService) setLine(ctx context.Context, dto *dtos.SetCartLineDto, add bool) (*dtos.CartDto, error) {
+	if err := s.validate.Struct(dto); err != nil {
+		return nil, err
+	}
+
+	var id int
+	token := dto.Token
+	if cart, err := s.cart(ctx, dto.Owner, dto.Token); err == nil {
+		id, token = cart.ID, cart.Token
+	} else if err != common.ErrNotFound {
+		return nil, err
+	} else {
+		// an unknown or expired token is never reused, the client gets a new
+		// one
+		if token, err = newCartToken(); err != nil {
+			return nil, err
+		}
+		if id, err = s.repository.Create(ctx, dto.Owner, token, s.expiresAt()); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.repository.SetLine(ctx, id, dto.ProductVariantID, dto.Quantity, dto.Unit, add, s.expiresAt()); err != nil {
+		return nil, err
+	}
+	return s.Get(ctx, dto.Owner, token)
+}
+
+func (s *CartService) cart(ctx context.Context, owner string, token string) (*entities.Cart, error) {
+	if len(owner) == 0 && len(token) == 0 {
+		return nil, common.ErrNotFound
+	}
+	return s.repository.Get(ctx, owner, token)
+}
+
+func (s *CartService) expiresAt() time.Time {
+	return time.Now().Add(s.idle)
+}
+
+func newCartToken() (string, error) {
+	b := make([]byte, 24)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+func toCartDto(cart *entities.Cart) *dtos.CartDto {
+	dto := &dtos.CartDto{
+		Owner:     cart.Owner,
+		ExpiresAt: cart.ExpiresAt,
+		Lines:     make([]*dtos.CartLineDto, 0, len(cart.Lines)),
+		InStock:   true,
+	}
+	if cart.Owner == nil {
+		dto.Token = cart.Token
+	}
+	for _, line := range cart.Lines {
+		lineDto := &dtos.CartLineDto{
+			ProductVariantID: line.ProductVariantID,
+			ProductID:        line.ProductID,
+			Name:             line.Name,
+			BaseUnit:         line.BaseUnit,
+			Quantity:         line.Quantity,
+			UnitPrice:        line.Price,
+			Subtotal:         line.Quantity * line.Price,
+			Available:        line.Available,
+			InStock:          line.Quantity <= line.Available,
+		}
+		dto.Lines = append(dto.Lines, lineDto)
+		dto.Quantity += lineDto.Quantity
+		dto.Total += lineDto.Subtotal
+		dto.InStock = dto.InStock && lineDto.InStock
+	}
+	return dto
+}
@@ -0,0 +1,129 @@
+package services
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+
+	"github.com/ysfada/product-management-system/domain/common"
+	"github.com/ysfada/product-management-system/domain/dtos"
+	"github.com/ysfada/product-management-system/domain/entities"
+	"github.com/ysfada/product-management-system/domain/interfaces"
+	"github.com/ysfada/product-management-system/util/catalogcsv"
+	"github.com/ysfada/product-management-system/util/xlsx"
+)
+
+const (
+	CatalogExportCSV    = "csv"
+	CatalogExportXLSX   = "xlsx"
+	CatalogExportNDJSON = "ndjson"
+)
+
+type CatalogExportService struct {
+	repository interfaces.ICatalogExportRepository
+}
+
+var _ interfaces.ICatalogExportService = (*CatalogExportService)(nil)
+
+func NewCatalogExportService(repository interfaces.ICatalogExportRepository) *CatalogExportService {
+	return &CatalogExportService{
+		repository: repository,
+	}
+}
+
+// Export writes every variant matching the query to w as it is read, one row
+// or line per variant or product without variants. The csv and xlsx exports
+// have the columns of a catalog import, an exported csv file can be imported
+// as is.
+func (s *CatalogExportService) Export(ctx context.Context, query *dtos.CatalogExportQueryDto, format string, w io.Writer) error {
+	switch format {
+	case CatalogExportCSV:
+		writer := csv.NewWriter(w)
+		if err := writer.Write(catalogcsv.Header()); err != nil {
+			return err
+		}
+		if err := s.repository.Export(ctx, query, func(row *entities.CatalogExportRow) error {
+			return writer.Write(catalogcsv.Record(toCatalogCSVRow(row)))
+		}); err != nil {
+			return err
+		}
+		writer.Flush()
+		return writer.Error()
+	case CatalogExportXLSX:
+		writer, err := xlsx.NewWriter(w, "Catalog")
+		if err != nil {
+			return err
+		}
+		header := make([]interface{}, 0, len(catalogcsv.Header()))
+		for _, column := range catalogcsv.Header() {
+			header = append(header, column)
+		}
+		if err := writer.WriteRow(header...); err != nil {
+			return err
+		}
+		if err := s.repository.Export(ctx, query, func(row *entities.CatalogExportRow) error {
+			record := catalogcsv.Record(toCatalogCSVRow(row))
+			values := make([]interface{}, len(record))
+			for i, field := range record {
+				values[i] = field
+			}
+			// prices and stocks as numbers
+			if row.VariantID != 0 {
+				values[4] = row.Price
+				values[5] = row.Stock
+			}
+			return writer.WriteRow(values...)
+		}); err != nil {
+			return err
+		}
+		return writer.Close()
+	case CatalogExportNDJSON:
+		encoder := json.NewEncoder(w)
+		return s.repository.Export(ctx, query, func(row *entities.CatalogExportRow) error {
+			return encoder.Encode(toCatalogExportRowDto(row))
+		})
+	default:
+		return common.ErrBadParamInput
+	}
+}
+
+func toCatalogCSVRow(row *entities.CatalogExportRow) catalogcsv.Row {
+	csvRow := catalogcsv.Row{
+		Product:     row.Product,
+		Description: row.Description,
+		Category:    row.Category,
+		Variant:     row.Variant,
+		Price:       row.Price,
+		Images:      row.Images,
+	}
+	if row.VariantID != 0 {
+		csvRow.Stock = &row.Stock
+	}
+	for _, attribute := range row.Attributes {
+		csvRow.Attributes = append(csvRow.Attributes, catalogcsv.Attribute{Type: attribute.Type, Value: attribute.Name})
+	}
+	return csvRow
+}
+
+func toCatalogExportRowDto(row *entities.CatalogExportRow) *dtos.CatalogExportRowDto {
+	rowDto := &dtos.CatalogExportRowDto{
+		ProductID:   row.ProductID,
+		Product:     row.Product,
+		Description: row.Description,
+		Category:    row.Category,
+		VariantID:   row.VariantID,
+		Variant:     row.Variant,
+		Price:       row.Price,
+		Stock:       row.Stock,
+		Attributes:  []*dtos.CatalogExportAttributeDto{},
+		Images:      row.Images,
+	}
+	for _, attribute := range row.Attributes {
+		rowDto.Attributes = append(rowDto.Attributes, &dtos.CatalogExportAttributeDto{Type: attribute.Type, Value: attribute.Name})
+	}
+	if rowDto.Images == nil {
+		rowDto.Images = []string{}
+	}
+	return rowDto
+}
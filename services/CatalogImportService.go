@@ -0,0 +1,201 @@
+package services
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"sort"
+	"strconv"
+
+	"github.com/ysfada/product-management-system/domain/common"
+	"github.com/ysfada/product-management-system/domain/dtos"
+	"github.com/ysfada/product-management-system/domain/entities"
+	"github.com/ysfada/product-management-system/domain/interfaces"
+	"github.com/ysfada/product-management-system/util/catalogcsv"
+)
+
+const (
+	defaultCatalogImportBatchSize = 500
+	maxCatalogImportBatchSize     = 10000
+)
+
+type CatalogImportService struct {
+	repository interfaces.ICatalogImportRepository
+}
+
+var _ interfaces.ICatalogImportService = (*CatalogImportService)(nil)
+
+func NewCatalogImportService(repository interfaces.ICatalogImportRepository) *CatalogImportService {
+	return &CatalogImportService{
+		repository: repository,
+	}
+}
+
+// Import imports a catalog file in batches of batchSize rows, 0 for the
+// default. Every batch is a transaction of its own, a row with errors is
+// left out and reported without failing the others. A dry run reports what
+// the import would do across all of its batches and changes nothing. The
+// report is kept either way.
+func (s *CatalogImportService) Import(ctx context.Context, fileheader *multipart.FileHeader, dryRun bool, batchSize int, createdBy string) (*dtos.CatalogImportDto, error) {
+	if batchSize == 0 {
+		batchSize = defaultCatalogImportBatchSize
+	}
+	if batchSize < 0 || batchSize > maxCatalogImportBatchSize {
+		return nil, common.ErrBadParamInput
+	}
+
+	file, err := fileheader.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	rows, rowErrors, err := catalogcsv.Read(file)
+	if err != nil {
+		if errors.Is(err, catalogcsv.ErrHeader) {
+			return nil, fmt.Errorf("%w: %v", common.ErrBadParamInput, err)
+		}
+		return nil, err
+	}
+
+	catalogImport := entities.CatalogImport{
+		FileName:  fileheader.Filename,
+		DryRun:    dryRun,
+		CreatedBy: createdBy,
+	}
+	failed := make(map[int]bool)
+	for _, rowError := range rowErrors {
+		catalogImport.Errors = append(catalogImport.Errors, &entities.CatalogImportError{Line: rowError.Line, Column: rowError.Column, Message: rowError.Message})
+		failed[rowError.Line] = true
+	}
+
+	var batches [][]*entities.CatalogImportRow
+	for start := 0; start < len(rows); start += batchSize {
+		end := start + batchSize
+		if end > len(rows) {
+			end = len(rows)
+		}
+
+		var batch []*entities.CatalogImportRow
+		for _, row := range rows[start:end] {
+			batch = append(batch, toCatalogImportRow(row))
+		}
+		batches = append(batches, batch)
+	}
+
+	plans, batchErrors, err := s.repository.Apply(ctx, batches, dryRun)
+	if err != nil {
+		return nil, err
+	}
+	for _, batchError := range batchErrors {
+		catalogImport.Errors = append(catalogImport.Errors, batchError)
+		failed[batchError.Line] = true
+	}
+
+	sort.SliceStable(catalogImport.Errors, func(i, j int) bool {
+		return catalogImport.Errors[i].Line < catalogImport.Errors[j].Line
+	})
+	for _, plan := range plans {
+		// a row without a variant creates or updates its product
+		action := plan.VariantAction
+		if len(plan.Variant) == 0 {
+			action = plan.ProductAction
+		}
+		if action == "create" {
+			catalogImport.Created++
+		} else {
+			catalogImport.Updated++
+		}
+	}
+	// a row fails once however many errors it has
+	catalogImport.Failed = len(failed)
+	catalogImport.Rows = len(plans) + catalogImport.Failed
+
+	if err := s.repository.Create(ctx, &catalogImport); err != nil {
+		return nil, err
+	}
+
+	catalogImportDto := toCatalogImportDto(&catalogImport)
+	for _, plan := range plans {
+		catalogImportDto.Plan = append(catalogImportDto.Plan, &dtos.CatalogImportPlanDto{
+			Line:          plan.Line,
+			Product:       plan.Product,
+			ProductAction: plan.ProductAction,
+			Variant:       plan.Variant,
+			VariantAction: plan.VariantAction,
+		})
+	}
+	return catalogImportDto, nil
+}
+
+func (s *CatalogImportService) GetByID(ctx context.Context, id int) (*dtos.CatalogImportDto, error) {
+	if catalogImport, err := s.repository.GetByID(ctx, id); err != nil {
+		return nil, err
+	} else {
+		return toCatalogImportDto(catalogImport), nil
+	}
+}
+
+// ExportErrors writes the errors of an import as CSV with the columns line,
+// column and message.
+func (s *CatalogImportService) ExportErrors(ctx context.Context, id int, w io.Writer) error {
+	catalogImport, err := s.repository.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	writer := csv.NewWriter(w)
+	if err := writer.Write([]string{"line", "column", "message"}); err != nil {
+		return err
+	}
+	for _, rowError := range catalogImport.Errors {
+		if err := writer.Write([]string{strconv.Itoa(rowError.Line), rowError.Column, rowError.Message}); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+func toCatalogImportRow(row catalogcsv.Row) *entities.CatalogImportRow {
+	catalogImportRow := &entities.CatalogImportRow{
+		Line:        row.Line,
+		Product:     row.Product,
+		Description: row.Description,
+		Category:    row.Category,
+		Variant:     row.Variant,
+		Price:       row.Price,
+		Stock:       row.Stock,
+		Images:      row.Images,
+	}
+	for _, attribute := range row.Attributes {
+		catalogImportRow.Attributes = append(catalogImportRow.Attributes, &entities.Attribute{Type: attribute.Type, Name: attribute.Value})
+	}
+	return catalogImportRow
+}
+
+func toCatalogImportDto(catalogImport *entities.CatalogImport) *dtos.CatalogImportDto {
+	catalogImportDto := &dtos.CatalogImportDto{
+		ID:        catalogImport.ID,
+		FileName:  catalogImport.FileName,
+		DryRun:    catalogImport.DryRun,
+		Rows:      catalogImport.Rows,
+		Created:   catalogImport.Created,
+		Updated:   catalogImport.Updated,
+		Failed:    catalogImport.Failed,
+		Errors:    []*dtos.CatalogImportErrorDto{},
+		CreatedBy: catalogImport.CreatedBy,
+		CreatedAt: catalogImport.CreatedAt,
+	}
+	for _, rowError := range catalogImport.Errors {
+		catalogImportDto.Errors = append(catalogImportDto.Errors, &dtos.CatalogImportErrorDto{
+			Line:    rowError.Line,
+			Column:  rowError.Column,
+			Message: rowError.Message,
+		})
+	}
+	return catalogImportDto
+}
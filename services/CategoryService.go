@@ -8,14 +8,16 @@ import (
 )
 
 type CategoryService struct {
-	repository interfaces.ICategoryRepository
+	repository   interfaces.ICategoryRepository
+	imageService interfaces.IImageService
 }
 
 var _ interfaces.ICategoryService = (*CategoryService)(nil)
 
-func NewCategoryService(repository interfaces.ICategoryRepository) *CategoryService {
+func NewCategoryService(repository interfaces.ICategoryRepository, imageService interfaces.IImageService) *CategoryService {
 	return &CategoryService{
-		repository: repository,
+		repository:   repository,
+		imageService: imageService,
 	}
 }
 
@@ -26,9 +28,12 @@ func (s *CategoryService) Fetch(ctx context.Context, page int, size int, sortBy
 		var categoriesDto dtos.CategoryPaginatedDto
 		for _, category := range categories.Categories {
 			categoryDto := &dtos.CategoryDto{
-				ID:          category.ID,
-				Name:        category.Name,
-				Description: category.Description,
+				ID:              category.ID,
+				Name:            category.Name,
+				Description:     category.Description,
+				ReorderPoint:    category.ReorderPoint,
+				ReorderQuantity: category.ReorderQuantity,
+				Version:         category.Version,
 			}
 
 			categoriesDto.Categories = append(categoriesDto.Categories, categoryDto)
@@ -51,9 +56,12 @@ func (s *CategoryService) GetByID(ctx context.Context, id int) (res *dtos.Catego
 	} else {
 		if category != nil {
 			return &dtos.CategoryDto{
-				ID:          category.ID,
-				Name:        category.Name,
-				Description: category.Description,
+				ID:              category.ID,
+				Name:            category.Name,
+				Description:     category.Description,
+				ReorderPoint:    category.ReorderPoint,
+				ReorderQuantity: category.ReorderQuantity,
+				Version:         category.Version,
 			}, nil
 		}
 		return nil, nil
@@ -65,11 +73,12 @@ func (s *CategoryService) Update(ctx context.Context, dto *dtos.UpdateCategoryDt
 }
 
 func (s *CategoryService) Create(ctx context.Context, dto *dtos.CreateCategoryDto) error {
-	return s.repository.Create(ctx, dto)
+	_, err := s.repository.Create(ctx, dto)
+	return err
 }
 
-func (s *CategoryService) Delete(ctx context.Context, id int) error {
-	return s.repository.Delete(ctx, id)
+func (s *CategoryService) Delete(ctx context.Context, id int, versions []int) error {
+	return s.repository.Delete(ctx, id, versions)
 }
 
 func (s *CategoryService) Search(ctx context.Context, q string, page int, size int, sortBy string, orderBy string) (*dtos.CategoryPaginatedDto, error) {
@@ -79,9 +88,12 @@ func (s *CategoryService) Search(ctx context.Context, q string, page int, size i
 		var categoriesDto dtos.CategoryPaginatedDto
 		for _, category := range categories.Categories {
 			categoryDto := &dtos.CategoryDto{
-				ID:          category.ID,
-				Name:        category.Name,
-				Description: category.Description,
+				ID:              category.ID,
+				Name:            category.Name,
+				Description:     category.Description,
+				ReorderPoint:    category.ReorderPoint,
+				ReorderQuantity: category.ReorderQuantity,
+				Version:         category.Version,
 			}
 
 			categoriesDto.Categories = append(categoriesDto.Categories, categoryDto)
@@ -114,12 +126,7 @@ func (s *CategoryService) GetProducts(ctx context.Context, id int, page int, siz
 			productsDto.Products = append(productsDto.Products, productDto)
 
 			for _, image := range product.Images {
-				imageDto := &dtos.ImageDto{
-					ID:           image.ID,
-					Name:         image.Name,
-					ImageUrl:     image.ImageUrl,
-					ThumbnailUrl: image.ThumbnailUrl,
-				}
+				imageDto := s.imageService.ToImageDto(ctx, image)
 
 				productDto.Images = append(productDto.Images, imageDto)
 			}
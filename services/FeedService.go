@@ -0,0 +1,153 @@
+package services
+
+import (
+	"context"
+	"io"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/ysfada/product-management-system/domain/common"
+	"github.com/ysfada/product-management-system/domain/dtos"
+	"github.com/ysfada/product-management-system/domain/entities"
+	"github.com/ysfada/product-management-system/domain/interfaces"
+	"github.com/ysfada/product-management-system/util/feed"
+)
+
+const (
+	FeedXML = "xml"
+	FeedTSV = "tsv"
+
+	defaultFeedCurrency = "USD"
+	defaultFeedTitle    = "Products"
+)
+
+type FeedService struct {
+	repository interfaces.IFeedRepository
+	config     feed.Config
+}
+
+var _ interfaces.IFeedService = (*FeedService)(nil)
+
+// NewFeedConfig reads the configuration of the feeds from FEED_BASE_URL, the
+// base of the links and relative image urls, FEED_CURRENCY, FEED_TITLE,
+// FEED_DESCRIPTION and FEED_FIELDS, comma separated name=template mappings
+// over the default fields, e.g. brand={attribute:brand},size=. The base url
+// and the currency are required.
+func NewFeedConfig() (feed.Config, error) {
+	config := feed.Config{
+		BaseURL:     os.Getenv("FEED_BASE_URL"),
+		Currency:    os.Getenv("FEED_CURRENCY"),
+		Title:       os.Getenv("FEED_TITLE"),
+		Description: os.Getenv("FEED_DESCRIPTION"),
+		Fields:      feed.DefaultFields(),
+	}
+	if len(config.Title) == 0 {
+		config.Title = defaultFeedTitle
+	}
+	if fields, err := feed.ParseFields(os.Getenv("FEED_FIELDS"), config.Fields); err != nil {
+		log.Printf("Unable to parse FEED_FIELDS, using the default fields: %v\n", err)
+	} else {
+		config.Fields = fields
+	}
+
+	return config, config.Validate()
+}
+
+func NewFeedService(repository interfaces.IFeedRepository, config feed.Config) *FeedService {
+	return &FeedService{
+		repository: repository,
+		config:     config,
+	}
+}
+
+// Write writes the feed in format to w as the variants are read. Variants
+// missing a required field are left out, Diagnostics lists them.
+func (s *FeedService) Write(ctx context.Context, format string, w io.Writer) error {
+	var writer feed.Writer
+	var err error
+	switch format {
+	case FeedXML:
+		writer, err = feed.NewXMLWriter(w, &s.config)
+	case FeedTSV:
+		writer, err = feed.NewTSVWriter(w, &s.config)
+	default:
+		return common.ErrBadParamInput
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := s.repository.Items(ctx, func(item *entities.FeedItem) error {
+		if item.VariantID == 0 {
+			return nil
+		}
+		values, missing := s.config.Entry(toFeedItem(item))
+		if len(missing) > 0 {
+			return nil
+		}
+		return writer.Write(values)
+	}); err != nil {
+		return err
+	}
+	return writer.Close()
+}
+
+// Diagnostics returns the variants left out of the feed and the required
+// fields they are missing, and the products without variants, which have no
+// item in the feed at all.
+func (s *FeedService) Diagnostics(ctx context.Context) (*dtos.FeedDiagnosticsDto, error) {
+	diagnostics := dtos.FeedDiagnosticsDto{
+		Omitted:     []*dtos.FeedDiagnosticDto{},
+		Variantless: []*dtos.FeedDiagnosticDto{},
+	}
+	if err := s.repository.Items(ctx, func(item *entities.FeedItem) error {
+		if item.VariantID == 0 {
+			diagnostics.Variantless = append(diagnostics.Variantless, &dtos.FeedDiagnosticDto{
+				ProductID: item.ProductID,
+				Product:   item.Product,
+				Missing:   []string{"variant"},
+			})
+			return nil
+		}
+
+		diagnostics.Items++
+		if _, missing := s.config.Entry(toFeedItem(item)); len(missing) > 0 {
+			diagnostics.Omitted = append(diagnostics.Omitted, &dtos.FeedDiagnosticDto{
+				ProductID: item.ProductID,
+				Product:   item.Product,
+				VariantID: item.VariantID,
+				Variant:   item.Variant,
+				Missing:   missing,
+			})
+		} else {
+			diagnostics.Listed++
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return &diagnostics, nil
+}
+
+func toFeedItem(item *entities.FeedItem) feed.Item {
+	feedItem := feed.Item{
+		ProductID:   item.ProductID,
+		Product:     item.Product,
+		Description: item.Description,
+		Category:    item.Category,
+		VariantID:   item.VariantID,
+		Variant:     item.Variant,
+		Price:       item.Price,
+		Available:   item.Available,
+		Attributes:  make(map[string]string),
+		Images:      item.ImageURLs,
+	}
+	for _, attribute := range item.Attributes {
+		// the first attribute of a type
+		if _, ok := feedItem.Attributes[strings.ToLower(attribute.Type)]; !ok {
+			feedItem.Attributes[strings.ToLower(attribute.Type)] = attribute.Name
+		}
+	}
+	return feedItem
+}
@@ -1,63 +1,456 @@
 package services
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
+	"image"
+	"image/jpeg"
+	"io"
+	"io/ioutil"
+	"log"
 	"mime/multipart"
 	"os"
+	"path"
+	"strconv"
 	"strings"
+	"unicode/utf8"
 
 	"github.com/google/uuid"
-	"github.com/valyala/fasthttp"
+	"github.com/ysfada/product-management-system/domain/common"
 	"github.com/ysfada/product-management-system/domain/dtos"
+	"github.com/ysfada/product-management-system/domain/entities"
 	"github.com/ysfada/product-management-system/domain/interfaces"
+	"github.com/ysfada/product-management-system/util/rendition"
+	"github.com/ysfada/product-management-system/util/storage"
+	"github.com/ysfada/product-management-system/util/upload"
+)
+
+const (
+	// thumbnailRendition is the rendition used as the thumbnail url
+	thumbnailRendition = "thumbnail"
+	// uprightQuality is the JPEG quality of uploads turned upright
+	uprightQuality = 95
+	// imageNameLength is the longest name of an image
+	imageNameLength = 64
+	// imagesKey prefixes the storage keys of the images and their renditions
+	imagesKey = "images/"
 )
 
 type ImageService struct {
 	repository interfaces.IImageRepository
+	config     rendition.Config
+	limits     upload.Limits
+	storage    storage.Storage
 }
 
 var _ interfaces.IImageService = (*ImageService)(nil)
 
-func NewImageService(repository interfaces.IImageRepository) *ImageService {
+// NewImageService configures the renditions from IMAGE_RENDITIONS, comma
+// separated name:WIDTHxHEIGHT bounding boxes, e.g.
+// thumbnail:200x200,medium:640x640,large:1280x1280, IMAGE_QUALITY, the JPEG
+// and WebP quality from 1 to 100, and IMAGE_WEBP, whether every rendition is
+// also rendered as WebP, which needs a build with cgo. Uploads are checked
+// against the limits of imageLimits. The files are kept in storage, the urls
+// of the images are publicURL followed by their storage key whatever its
+// backend is.
+func NewImageService(repository interfaces.IImageRepository, storage storage.Storage) *ImageService {
+	config := rendition.Config{
+		Specs:   rendition.DefaultSpecs(),
+		Quality: rendition.DefaultQuality,
+	}
+	if specs, err := rendition.ParseSpecs(os.Getenv("IMAGE_RENDITIONS")); err != nil {
+		log.Printf("Unable to parse IMAGE_RENDITIONS, using the default renditions: %v\n", err)
+	} else {
+		config.Specs = specs
+	}
+	if quality, err := strconv.Atoi(os.Getenv("IMAGE_QUALITY")); err == nil && quality >= 1 && quality <= 100 {
+		config.Quality = quality
+	}
+	config.WebP, _ = strconv.ParseBool(os.Getenv("IMAGE_WEBP"))
+	if config.WebP && !rendition.WebPSupported {
+		log.Printf("IMAGE_WEBP is set but this build has no WebP encoder, rendering without WebP\n")
+		config.WebP = false
+	}
+
 	return &ImageService{
 		repository: repository,
+		config:     config,
+		limits:     imageLimits(),
+		storage:    storage,
 	}
 }
 
+// imageLimits configures the uploads from IMAGE_MAX_BYTES, IMAGE_MAX_WIDTH,
+// IMAGE_MAX_HEIGHT, IMAGE_MAX_PIXELS and IMAGE_FORMATS, comma separated
+// formats out of jpeg, png, gif and webp.
+func imageLimits() upload.Limits {
+	limits := upload.DefaultLimits()
+	if maxBytes, err := strconv.ParseInt(os.Getenv("IMAGE_MAX_BYTES"), 10, 64); err == nil && maxBytes > 0 {
+		limits.MaxBytes = maxBytes
+	}
+	for env, limit := range map[string]*int{
+		"IMAGE_MAX_WIDTH":  &limits.MaxWidth,
+		"IMAGE_MAX_HEIGHT": &limits.MaxHeight,
+		"IMAGE_MAX_PIXELS": &limits.MaxPixels,
+	} {
+		if value, err := strconv.Atoi(os.Getenv(env)); err == nil && value > 0 {
+			*limit = value
+		}
+	}
+	if formats, err := upload.ParseFormats(os.Getenv("IMAGE_FORMATS")); err != nil {
+		log.Printf("Unable to parse IMAGE_FORMATS, allowing every format: %v\n", err)
+	} else {
+		limits.Formats = formats
+	}
+	return limits
+}
+
+// Save stores an upload with its renditions. The upload is checked and its
+// metadata stripped first, its extension follows its content rather than its
+// name.
 func (s *ImageService) Save(ctx context.Context, id int, fileheader *multipart.FileHeader) (int, error) {
+	if fileheader.Size > s.limits.MaxBytes {
+		return -1, fmt.Errorf("%w: %d bytes, at most %d are allowed", common.ErrTooLarge, fileheader.Size, s.limits.MaxBytes)
+	}
+	file, err := fileheader.Open()
+	if err != nil {
+		return -1, err
+	}
+	data, err := ioutil.ReadAll(io.LimitReader(file, s.limits.MaxBytes+1))
+	file.Close()
+	if err != nil {
+		return -1, err
+	}
+
+	data, img, format, err := prepareImage(data, &s.limits)
+	if err != nil {
+		return -1, err
+	}
+
 	// generate new uuid for image name
 	uniqueId := uuid.New()
 
 	// remove "- from imageName"
 	filename := strings.Replace(uniqueId.String(), "-", "", -1)
 
-	// extract image extension from original file filename
-	fileExt := strings.Split(fileheader.Filename, ".")[1]
+	// generate image from filename and the extension of its format
+	image := fmt.Sprintf("%s.%s", filename, rendition.Extension(format))
 
-	// generate image from filename and extension
-	image := fmt.Sprintf("%s.%s", filename, fileExt)
-
-	// save image to ./public/images dir
-	if err := fasthttp.SaveMultipartFile(fileheader, fmt.Sprintf("./public/images/%s", image)); err != nil {
+	// save image to the storage
+	if err := s.put(ctx, imagesKey+image, data); err != nil {
 		return -1, err
 	}
 
 	// generate image url to serve to client
-	imageUrl := fmt.Sprintf("/public/images/%s", image)
-	thumbnailUrl := fmt.Sprintf("/public/images/%s", image)
+	imageUrl := publicURL + imagesKey + image
+
+	renditions, thumbnailUrl, err := s.render(ctx, img, imageUrl)
+	if err != nil {
+		s.remove(ctx, imageUrl)
+		return -1, err
+	}
 
-	return s.repository.Create(ctx, &dtos.CreateImageDto{
-		Name:         fileheader.Filename,
+	imageID, err := s.repository.Create(ctx, &dtos.CreateImageDto{
+		Name:         imageName(fileheader.Filename),
 		ImageUrl:     imageUrl,
 		ThumbnailUrl: thumbnailUrl,
+		Renditions:   renditions,
 	})
+	if err != nil {
+		s.remove(ctx, imageUrl)
+		s.removeRenditions(ctx, renditions)
+	}
+	return imageID, err
 }
 
 func (s *ImageService) Remove(ctx context.Context, imageID int) error {
-	if imageURL, err := s.repository.Delete(ctx, imageID); err != nil {
+	if image, err := s.repository.Delete(ctx, imageID); err != nil {
 		return err
 	} else {
-		return os.Remove(fmt.Sprintf(".%s", imageURL))
+		return s.RemoveFiles(ctx, image)
+	}
+}
+
+// PurgeOrphans removes the images which belong to no product and their
+// files, unless another image shares them. A dry run only returns them.
+func (s *ImageService) PurgeOrphans(ctx context.Context, dryRun bool) ([]*dtos.ImageDto, error) {
+	images, err := s.repository.FetchOrphans(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	imagesDto := []*dtos.ImageDto{}
+	for _, image := range images {
+		if !dryRun {
+			if _, err := s.repository.Delete(ctx, image.ID); err != nil {
+				return imagesDto, err
+			}
+			if err := s.RemoveFiles(ctx, image); err != nil {
+				return imagesDto, err
+			}
+		}
+		imagesDto = append(imagesDto, &dtos.ImageDto{
+			ID:           image.ID,
+			Name:         image.Name,
+			ImageUrl:     image.ImageUrl,
+			ThumbnailUrl: image.ThumbnailUrl,
+			Renditions:   toImageRenditionDtos(image.Renditions),
+		})
+	}
+	return imagesDto, nil
+}
+
+// RenderAll renders the renditions of the images without any, or of every
+// image if all is set, replacing those they had. An image whose file can not
+// be read or decoded is logged and left as it is.
+func (s *ImageService) RenderAll(ctx context.Context, all bool) ([]*dtos.ImageDto, error) {
+	images, err := s.repository.Fetch(ctx, !all)
+	if err != nil {
+		return nil, err
+	}
+
+	imagesDto := []*dtos.ImageDto{}
+	for _, image := range images {
+		data, err := s.read(ctx, image.ImageUrl)
+		if err != nil {
+			log.Printf("Unable to read image %d: %v\n", image.ID, err)
+			continue
+		}
+		_, img, _, err := prepareImage(data, &s.limits)
+		if err != nil {
+			log.Printf("Unable to decode image %d: %v\n", image.ID, err)
+			continue
+		}
+
+		renditions, thumbnailUrl, err := s.render(ctx, img, image.ImageUrl)
+		if err != nil {
+			return imagesDto, err
+		}
+		if err := s.repository.SetRenditions(ctx, image.ID, thumbnailUrl, renditions); err != nil {
+			s.removeRenditions(ctx, renditions)
+			return imagesDto, err
+		}
+
+		// files of the previous renditions which were not rendered again
+		rendered := make(map[string]bool)
+		for _, renditionDto := range renditions {
+			rendered[renditionDto.Url] = true
+		}
+		var previous []*entities.ImageRendition
+		for _, previousRendition := range image.Renditions {
+			if !rendered[previousRendition.Url] {
+				previous = append(previous, previousRendition)
+			}
+		}
+		if err := s.RemoveFiles(ctx, &entities.Image{Renditions: previous}); err != nil {
+			return imagesDto, err
+		}
+
+		imagesDto = append(imagesDto, &dtos.ImageDto{
+			ID:           image.ID,
+			Name:         image.Name,
+			ImageUrl:     image.ImageUrl,
+			ThumbnailUrl: thumbnailUrl,
+			Renditions:   renditions,
+		})
+	}
+	return imagesDto, nil
+}
+
+// ToImageDto maps an image to its dto with the urls its files are served
+// from by the storage. A url which can not be resolved is left as it is.
+func (s *ImageService) ToImageDto(ctx context.Context, image *entities.Image) *dtos.ImageDto {
+	imageDto := &dtos.ImageDto{
+		ID:           image.ID,
+		Name:         image.Name,
+		ImageUrl:     s.url(ctx, image.ImageUrl),
+		ThumbnailUrl: s.url(ctx, image.ThumbnailUrl),
+		Renditions:   toImageRenditionDtos(image.Renditions),
+	}
+	for _, renditionDto := range imageDto.Renditions {
+		renditionDto.Url = s.url(ctx, renditionDto.Url)
+	}
+	return imageDto
+}
+
+// render stores the renditions of the image at imageUrl next to it, named
+// after it and the rendition, and returns them with the thumbnail url. The
+// thumbnail url is the image itself if no rendition is named thumbnail.
+func (s *ImageService) render(ctx context.Context, img image.Image, imageUrl string) ([]*dtos.ImageRenditionDto, string, error) {
+	renditions, err := rendition.Render(img, &s.config)
+	if err != nil {
+		return nil, "", err
+	}
+
+	base := strings.TrimSuffix(imageUrl, path.Ext(imageUrl))
+	thumbnailUrl := imageUrl
+	var renditionsDto []*dtos.ImageRenditionDto
+	for _, r := range renditions {
+		url := fmt.Sprintf("%s-%s.%s", base, r.Name, rendition.Extension(r.Format))
+		key, _ := storageKey(url)
+		if err := s.put(ctx, key, r.Data); err != nil {
+			s.removeRenditions(ctx, renditionsDto)
+			return nil, "", err
+		}
+		// the first format of a rendition is the one every browser supports
+		if r.Name == thumbnailRendition && thumbnailUrl == imageUrl {
+			thumbnailUrl = url
+		}
+		renditionsDto = append(renditionsDto, &dtos.ImageRenditionDto{
+			Name:   r.Name,
+			Format: r.Format,
+			Url:    url,
+			Width:  r.Width,
+			Height: r.Height,
+		})
+	}
+	return renditionsDto, thumbnailUrl, nil
+}
+
+// RemoveFiles removes the files of a deleted or replaced image which no
+// other image uses.
+func (s *ImageService) RemoveFiles(ctx context.Context, image *entities.Image) error {
+	urls := []string{image.ImageUrl, image.ThumbnailUrl}
+	for _, rendition := range image.Renditions {
+		urls = append(urls, rendition.Url)
+	}
+
+	removed := make(map[string]bool)
+	for _, url := range urls {
+		if len(url) == 0 || removed[url] {
+			continue
+		}
+		removed[url] = true
+		if inUse, err := s.repository.IsURLInUse(ctx, url); err != nil {
+			return err
+		} else if !inUse {
+			// the thumbnail may be the image itself
+			if err := s.remove(ctx, url); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// prepareImage checks an upload against limits, strips its metadata and
+// decodes it. A JPEG with an EXIF orientation is re-encoded upright, it
+// would not be without its metadata. The errors wrap ErrTooLarge,
+// ErrUnsupportedMediaType or ErrBadParamInput.
+func prepareImage(data []byte, limits *upload.Limits) ([]byte, image.Image, string, error) {
+	info, err := upload.Check(data, limits)
+	if err != nil {
+		switch {
+		case errors.Is(err, upload.ErrTooLarge):
+			return nil, nil, "", fmt.Errorf("%w: %v", common.ErrTooLarge, err)
+		case errors.Is(err, upload.ErrFormat):
+			return nil, nil, "", fmt.Errorf("%w: %v", common.ErrUnsupportedMediaType, err)
+		default:
+			return nil, nil, "", fmt.Errorf("%w: %v", common.ErrBadParamInput, err)
+		}
+	}
+
+	data, orientation, err := upload.Strip(data, info.Format)
+	if err != nil {
+		return nil, nil, "", fmt.Errorf("%w: %v", common.ErrBadParamInput, err)
+	}
+	img, _, err := rendition.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, nil, "", fmt.Errorf("%w: %v", common.ErrBadParamInput, err)
+	}
+
+	if orientation > 1 {
+		img = upload.Orient(img, orientation)
+		var buf bytes.Buffer
+		if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: uprightQuality}); err != nil {
+			return nil, nil, "", err
+		}
+		data = buf.Bytes()
+	}
+	return data, img, info.Format, nil
+}
+
+// imageName is the name of an upload without its directories, cut to
+// imageNameLength characters. Names shorter than 2 characters are left out.
+func imageName(filename string) string {
+	name := path.Base(strings.ReplaceAll(filename, "\\", "/"))
+	if utf8.RuneCountInString(name) > imageNameLength {
+		name = string([]rune(name)[:imageNameLength])
+	}
+	if utf8.RuneCountInString(name) < 2 {
+		return ""
+	}
+	return name
+}
+
+func (s *ImageService) removeRenditions(ctx context.Context, renditions []*dtos.ImageRenditionDto) {
+	for _, rendition := range renditions {
+		s.remove(ctx, rendition.Url)
+	}
+}
+
+func (s *ImageService) put(ctx context.Context, key string, data []byte) error {
+	return s.storage.Put(ctx, key, bytes.NewReader(data), int64(len(data)), storage.ContentType(key))
+}
+
+// read returns the file of an image url, which must be in the storage.
+func (s *ImageService) read(ctx context.Context, url string) ([]byte, error) {
+	key, ok := storageKey(url)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s is not stored", storage.ErrNotFound, url)
+	}
+	r, _, err := s.storage.Get(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	return ioutil.ReadAll(r)
+}
+
+// remove removes the file of an image url, urls of files out of the
+// storage are left alone.
+func (s *ImageService) remove(ctx context.Context, url string) error {
+	if key, ok := storageKey(url); ok {
+		return s.storage.Delete(ctx, key)
+	}
+	return nil
+}
+
+// url returns the url the file of an image url is served from.
+func (s *ImageService) url(ctx context.Context, url string) string {
+	key, ok := storageKey(url)
+	if !ok {
+		return url
+	}
+	if storageURL, err := s.storage.URL(ctx, key); err != nil {
+		log.Printf("Unable to resolve the url of %s: %v\n", url, err)
+		return url
+	} else {
+		return storageURL
+	}
+}
+
+// storageKey returns the storage key of an image url, false if the url is
+// not of a stored file, e.g. an external url.
+func storageKey(url string) (string, bool) {
+	if !strings.HasPrefix(url, publicURL) || len(url) == len(publicURL) {
+		return "", false
+	}
+	return strings.TrimPrefix(url, publicURL), true
+}
+
+func toImageRenditionDtos(renditions []*entities.ImageRendition) []*dtos.ImageRenditionDto {
+	renditionsDto := []*dtos.ImageRenditionDto{}
+	for _, rendition := range renditions {
+		renditionsDto = append(renditionsDto, &dtos.ImageRenditionDto{
+			Name:   rendition.Name,
+			Format: rendition.Format,
+			Url:    rendition.Url,
+			Width:  rendition.Width,
+			Height: rendition.Height,
+		})
 	}
+	return renditionsDto
 }
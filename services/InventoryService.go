@@ -0,0 +1,320 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-playground/validator"
+	"github.com/ysfada/product-management-system/domain/common"
+	"github.com/ysfada/product-management-system/domain/dtos"
+	"github.com/ysfada/product-management-system/domain/entities"
+	"github.com/ysfada/product-management-system/domain/interfaces"
+	"github.com/ysfada/product-management-system/util/valuation"
+)
+
+const (
+	lowStockExportPageSize = 500
+	lowStockListenBackoff  = 5 * time.Second
+	lowStockWebhookTimeout = 10 * time.Second
+)
+
+type InventoryService struct {
+	repository    interfaces.IInventoryRepository
+	validate      *validator.Validate
+	costingMethod valuation.Method
+	mu            sync.RWMutex
+	subscribers   map[int]func(event *dtos.LowStockEventDto)
+	nextID        int
+}
+
+var _ interfaces.IInventoryService = (*InventoryService)(nil)
+
+func NewInventoryService(repository interfaces.IInventoryRepository) *InventoryService {
+	costingMethod, ok := parseCostingMethod(os.Getenv("INVENTORY_COSTING_METHOD"))
+	if !ok {
+		costingMethod = valuation.FIFO
+	}
+
+	return &InventoryService{
+		repository:    repository,
+		validate:      validator.New(),
+		costingMethod: costingMethod,
+		subscribers:   make(map[int]func(event *dtos.LowStockEventDto)),
+	}
+}
+
+func (s *InventoryService) LowStock(ctx context.Context, categoryID int, page int, size int, sortBy string, orderBy string) (*dtos.LowStockPaginatedDto, error) {
+	if variants, err := s.repository.LowStock(ctx, categoryID, page, size, sortBy, orderBy); err != nil {
+		return nil, err
+	} else {
+		var variantsDto dtos.LowStockPaginatedDto
+		for _, variant := range variants.Variants {
+			variantDto := &dtos.LowStockVariantDto{
+				ID:              variant.ID,
+				Name:            variant.Name,
+				Stock:           variant.Stock,
+				Reserved:        variant.Reserved,
+				Available:       variant.Stock - variant.Reserved,
+				ReorderPoint:    variant.ReorderPoint,
+				ReorderQuantity: variant.ReorderQuantity,
+				Product: &dtos.ProductDto{
+					ID:          variant.Product.ID,
+					Name:        variant.Product.Name,
+					Description: variant.Product.Description,
+					CategoryID:  variant.Product.CategoryID,
+				},
+				Category: &dtos.CategoryDto{
+					ID:              variant.Category.ID,
+					Name:            variant.Category.Name,
+					Description:     variant.Category.Description,
+					ReorderPoint:    variant.Category.ReorderPoint,
+					ReorderQuantity: variant.Category.ReorderQuantity,
+				},
+			}
+
+			variantsDto.Variants = append(variantsDto.Variants, variantDto)
+		}
+
+		variantsDto.TotalPage = variants.TotalPage
+		variantsDto.CurrentPage = variants.CurrentPage
+		variantsDto.NextPage = variants.NextPage
+		variantsDto.PreviousPage = variants.PreviousPage
+		variantsDto.Count = variants.Count
+		variantsDto.Size = variants.Size
+
+		return &variantsDto, nil
+	}
+}
+
+// ExportLowStock writes the whole low stock report as CSV.
+func (s *InventoryService) ExportLowStock(ctx context.Context, categoryID int, w io.Writer) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write([]string{
+		"variant_id",
+		"variant_name",
+		"product_id",
+		"product_name",
+		"category_id",
+		"category_name",
+		"stock",
+		"reserved",
+		"available",
+		"reorder_point",
+		"reorder_quantity",
+	}); err != nil {
+		return err
+	}
+
+	for page := 1; page > 0; {
+		variants, err := s.LowStock(ctx, categoryID, page, lowStockExportPageSize, "id", "ASC")
+		if err != nil {
+			return err
+		}
+
+		for _, variant := range variants.Variants {
+			reorderQuantity := ""
+			if variant.ReorderQuantity != nil {
+				reorderQuantity = formatQuantity(*variant.ReorderQuantity)
+			}
+			if err := writer.Write([]string{
+				strconv.Itoa(variant.ID),
+				variant.Name,
+				strconv.Itoa(variant.Product.ID),
+				variant.Product.Name,
+				strconv.Itoa(variant.Category.ID),
+				variant.Category.Name,
+				formatQuantity(variant.Stock),
+				formatQuantity(variant.Reserved),
+				formatQuantity(variant.Available),
+				formatQuantity(variant.ReorderPoint),
+				reorderQuantity,
+			}); err != nil {
+				return err
+			}
+		}
+
+		page = variants.NextPage
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// Subscribe registers a subscriber that is called whenever a variant crosses
+// its reorder point. The returned func removes the subscriber.
+func (s *InventoryService) Subscribe(subscriber func(event *dtos.LowStockEventDto)) (unsubscribe func()) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := s.nextID
+	s.nextID++
+	s.subscribers[id] = subscriber
+
+	return func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		delete(s.subscribers, id)
+	}
+}
+
+// Listen dispatches the low stock notifications of the database to the
+// subscribers until ctx is done, reconnecting whenever the listener fails.
+func (s *InventoryService) Listen(ctx context.Context) {
+	for {
+		err := s.repository.ListenLowStock(ctx, func(event *entities.LowStockEvent) {
+			log.Printf("Low stock: variant %d has %g available, reorder point is %g\n", event.ProductVariantID, event.Available, event.ReorderPoint)
+			s.publish(&dtos.LowStockEventDto{
+				ProductVariantID: event.ProductVariantID,
+				ProductID:        event.ProductID,
+				Name:             event.Name,
+				Stock:            event.Stock,
+				Reserved:         event.Reserved,
+				Available:        event.Available,
+				ReorderPoint:     event.ReorderPoint,
+				ReorderQuantity:  event.ReorderQuantity,
+			})
+		})
+
+		if ctx.Err() != nil {
+			return
+		}
+		log.Printf("Unable to listen for low stock notifications: %v\n", err)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(lowStockListenBackoff):
+		}
+	}
+}
+
+func (s *InventoryService) publish(event *dtos.LowStockEventDto) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, subscriber := range s.subscribers {
+		subscriber(event)
+	}
+}
+
+// NewLowStockWebhook returns a subscriber that posts every low stock event as
+// JSON to url.
+func NewLowStockWebhook(url string) func(event *dtos.LowStockEventDto) {
+	client := &http.Client{Timeout: lowStockWebhookTimeout}
+
+	return func(event *dtos.LowStockEventDto) {
+		body, err := json.Marshal(event)
+		if err != nil {
+			log.Printf("Unable to encode low stock event: %v\n", err)
+			return
+		}
+
+		go func() {
+			res, err := client.Post(url, "application/json", bytes.NewReader(body))
+			if err != nil {
+				log.Printf("Unable to send low stock webhook: %v\n", err)
+				return
+			}
+			defer res.Body.Close()
+
+			if res.StatusCode >= 300 {
+				log.Printf("Unable to send low stock webhook: unexpected status %s\n", res.Status)
+			}
+		}()
+	}
+}
+
+func (s *InventoryService) Receive(ctx context.Context, dto *dtos.CreateStockReceiptDto) error {
+	if err := s.validate.Struct(dto); err != nil {
+		return err
+	}
+	return s.repository.Receive(ctx, dto)
+}
+
+// Valuation values the stock as of asOf per variant, per category and
+// overall. An empty method uses INVENTORY_COSTING_METHOD.
+func (s *InventoryService) Valuation(ctx context.Context, categoryID int, method string, asOf time.Time) (*dtos.ValuationDto, error) {
+	costingMethod := s.costingMethod
+	if len(method) > 0 {
+		var ok bool
+		if costingMethod, ok = parseCostingMethod(method); !ok {
+			return nil, common.ErrBadParamInput
+		}
+	}
+
+	variants, err := s.repository.Movements(ctx, categoryID, asOf)
+	if err != nil {
+		return nil, err
+	}
+
+	valuationDto := &dtos.ValuationDto{
+		Method: string(costingMethod),
+		AsOf:   asOf,
+	}
+	categories := make(map[int]*dtos.CategoryValuationDto)
+	for _, variant := range variants {
+		movements := make([]valuation.Movement, 0, len(variant.Movements))
+		for _, movement := range variant.Movements {
+			movements = append(movements, valuation.Movement{
+				Quantity: movement.Quantity,
+				UnitCost: movement.UnitCost,
+			})
+		}
+
+		quantity, value := valuation.Value(movements, costingMethod)
+		if quantity == 0 {
+			continue
+		}
+
+		category, ok := categories[variant.CategoryID]
+		if !ok {
+			category = &dtos.CategoryValuationDto{
+				ID:   variant.CategoryID,
+				Name: variant.CategoryName,
+			}
+			categories[variant.CategoryID] = category
+			valuationDto.Categories = append(valuationDto.Categories, category)
+		}
+
+		category.Variants = append(category.Variants, &dtos.VariantValuationDto{
+			ID:        variant.ProductVariantID,
+			Name:      variant.Name,
+			ProductID: variant.ProductID,
+			Quantity:  quantity,
+			Value:     value,
+			UnitCost:  value / float64(quantity),
+		})
+		category.Quantity += quantity
+		category.Value += value
+		valuationDto.Quantity += quantity
+		valuationDto.Value += value
+	}
+
+	return valuationDto, nil
+}
+
+func parseCostingMethod(method string) (valuation.Method, bool) {
+	switch valuation.Method(strings.ToLower(method)) {
+	case valuation.FIFO:
+		return valuation.FIFO, true
+	case valuation.WeightedAverage:
+		return valuation.WeightedAverage, true
+	default:
+		return "", false
+	}
+}
+
+// formatQuantity formats a quantity without trailing zeros.
+func formatQuantity(quantity float64) string {
+	return strconv.FormatFloat(quantity, 'f', -1, 64)
+}
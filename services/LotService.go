@@ -0,0 +1,169 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-playground/validator"
+	"github.com/ysfada/product-management-system/domain/common"
+	"github.com/ysfada/product-management-system/domain/dtos"
+	"github.com/ysfada/product-management-system/domain/entities"
+	"github.com/ysfada/product-management-system/domain/interfaces"
+	"github.com/ysfada/product-management-system/util/fefo"
+)
+
+type LotService struct {
+	repository interfaces.ILotRepository
+	validate   *validator.Validate
+}
+
+var _ interfaces.ILotService = (*LotService)(nil)
+
+func NewLotService(repository interfaces.ILotRepository) *LotService {
+	return &LotService{
+		repository: repository,
+		validate:   validator.New(),
+	}
+}
+
+func (s *LotService) Fetch(ctx context.Context, id int, variantID int) ([]*dtos.LotDto, error) {
+	if lots, err := s.repository.Fetch(ctx, id, variantID); err != nil {
+		return nil, err
+	} else {
+		var lotsDto []*dtos.LotDto
+		for _, lot := range lots {
+			lotsDto = append(lotsDto, toLotDto(lot))
+		}
+
+		return lotsDto, nil
+	}
+}
+
+func (s *LotService) GetByID(ctx context.Context, id int, variantID int, lotID int) (*dtos.LotDto, error) {
+	if lot, err := s.repository.GetByID(ctx, id, variantID, lotID); err != nil {
+		return nil, err
+	} else {
+		return toLotDto(lot), nil
+	}
+}
+
+func (s *LotService) Create(ctx context.Context, dto *dtos.CreateLotDto) error {
+	if err := s.validate.Struct(dto); err != nil {
+		return err
+	}
+	return s.repository.Create(ctx, dto)
+}
+
+func (s *LotService) Update(ctx context.Context, dto *dtos.UpdateLotDto) error {
+	if err := s.validate.Struct(dto); err != nil {
+		return err
+	}
+	return s.repository.Update(ctx, dto)
+}
+
+func (s *LotService) Delete(ctx context.Context, id int, variantID int, lotID int) error {
+	return s.repository.Delete(ctx, id, variantID, lotID)
+}
+
+func (s *LotService) Expiring(ctx context.Context, days int, page int, size int) (*dtos.ExpiringLotPaginatedDto, error) {
+	if lots, err := s.repository.Expiring(ctx, days, page, size); err != nil {
+		return nil, err
+	} else {
+		var lotsDto dtos.ExpiringLotPaginatedDto
+		for _, lot := range lots.Lots {
+			lotDto := &dtos.ExpiringLotDto{
+				ID:        lot.ID,
+				LotNumber: lot.LotNumber,
+				ExpiresAt: lot.ExpiresAt,
+				DaysLeft:  lot.DaysLeft,
+				Quantity:  lot.Quantity,
+				ProductVariant: &dtos.ProductVariantDto{
+					ID:        lot.ProductVariant.ID,
+					Name:      lot.ProductVariant.Name,
+					ProductId: lot.ProductVariant.ProductId,
+					Price:     lot.ProductVariant.Price,
+					Stock:     lot.ProductVariant.Stock,
+					Reserved:  lot.ProductVariant.Reserved,
+					Available: lot.ProductVariant.Stock - lot.ProductVariant.Reserved,
+				},
+				Product: &dtos.ProductDto{
+					ID:          lot.Product.ID,
+					Name:        lot.Product.Name,
+					Description: lot.Product.Description,
+					CategoryID:  lot.Product.CategoryID,
+				},
+			}
+
+			lotsDto.Lots = append(lotsDto.Lots, lotDto)
+		}
+
+		lotsDto.TotalPage = lots.TotalPage
+		lotsDto.CurrentPage = lots.CurrentPage
+		lotsDto.NextPage = lots.NextPage
+		lotsDto.PreviousPage = lots.PreviousPage
+		lotsDto.Count = lots.Count
+		lotsDto.Size = lots.Size
+
+		return &lotsDto, nil
+	}
+}
+
+// Pick suggests the lots to pick quantity from, first-expired-first-out.
+// Expired lots are never suggested, a lot is usable until the end of its
+// expiry date.
+func (s *LotService) Pick(ctx context.Context, id int, variantID int, quantity float64) (*dtos.PickingDto, error) {
+	if quantity <= 0 {
+		return nil, common.ErrBadParamInput
+	}
+
+	lots, err := s.repository.Fetch(ctx, id, variantID)
+	if err != nil {
+		return nil, err
+	}
+	if len(lots) == 0 {
+		return nil, common.ErrNotFound
+	}
+
+	byID := make(map[int]*entities.Lot, len(lots))
+	candidates := make([]fefo.Lot, 0, len(lots))
+	for _, lot := range lots {
+		byID[lot.ID] = lot
+		candidates = append(candidates, fefo.Lot{
+			ID:        lot.ID,
+			Quantity:  lot.Quantity,
+			ExpiresAt: lot.ExpiresAt,
+		})
+	}
+
+	picks, short := fefo.Allocate(candidates, quantity, time.Now().UTC().Truncate(24*time.Hour))
+	if short > 0 {
+		return nil, common.ErrInsufficientStock
+	}
+
+	picking := &dtos.PickingDto{
+		ProductVariantID: variantID,
+		Quantity:         quantity,
+	}
+	for _, pick := range picks {
+		lot := byID[pick.LotID]
+		picking.Picks = append(picking.Picks, &dtos.PickDto{
+			LotID:     lot.ID,
+			LotNumber: lot.LotNumber,
+			ExpiresAt: lot.ExpiresAt,
+			Quantity:  pick.Quantity,
+		})
+	}
+
+	return picking, nil
+}
+
+func toLotDto(lot *entities.Lot) *dtos.LotDto {
+	return &dtos.LotDto{
+		ID:               lot.ID,
+		ProductVariantID: lot.ProductVariantID,
+		LotNumber:        lot.LotNumber,
+		ExpiresAt:        lot.ExpiresAt,
+		ManufacturedAt:   lot.ManufacturedAt,
+		Quantity:         lot.Quantity,
+	}
+}
@@ -2,23 +2,38 @@ package services
 
 import (
 	"context"
+	"fmt"
 	"mime/multipart"
+	"os"
+	"strconv"
+	"strings"
 
 	"github.com/ysfada/product-management-system/domain/dtos"
+	"github.com/ysfada/product-management-system/domain/entities"
 	"github.com/ysfada/product-management-system/domain/interfaces"
 )
 
 type ProductService struct {
 	repository   interfaces.IProductRepository
 	imageService interfaces.IImageService
+	// baseURL and currency of the structured data, those of the feeds
+	baseURL  string
+	currency string
 }
 
 var _ interfaces.IProductService = (*ProductService)(nil)
 
 func NewProductService(repository interfaces.IProductRepository, imageService interfaces.IImageService) *ProductService {
+	currency := os.Getenv("FEED_CURRENCY")
+	if len(currency) == 0 {
+		currency = defaultFeedCurrency
+	}
+
 	return &ProductService{
 		repository:   repository,
 		imageService: imageService,
+		baseURL:      strings.TrimRight(os.Getenv("FEED_BASE_URL"), "/"),
+		currency:     currency,
 	}
 }
 
@@ -33,6 +48,7 @@ func (s *ProductService) Fetch(ctx context.Context, page int, size int, sortBy s
 				Name:        product.Name,
 				Description: product.Description,
 				CategoryID:  product.CategoryID,
+				Version:     product.Version,
 				Category: &dtos.CategoryDto{
 					ID:          product.Category.ID,
 					Name:        product.Category.Name,
@@ -42,12 +58,7 @@ func (s *ProductService) Fetch(ctx context.Context, page int, size int, sortBy s
 			}
 
 			for _, image := range product.Images {
-				imageDto := &dtos.ImageDto{
-					ID:           image.ID,
-					Name:         image.Name,
-					ImageUrl:     image.ImageUrl,
-					ThumbnailUrl: image.ThumbnailUrl,
-				}
+				imageDto := s.imageService.ToImageDto(ctx, image)
 
 				productDto.Images = append(productDto.Images, imageDto)
 			}
@@ -76,6 +87,7 @@ func (s *ProductService) GetByID(ctx context.Context, id int) (res *dtos.Product
 				Name:        product.Name,
 				Description: product.Description,
 				CategoryID:  product.CategoryID,
+				Version:     product.Version,
 				Category: &dtos.CategoryDto{
 					ID:          product.Category.ID,
 					Name:        product.Category.Name,
@@ -85,12 +97,7 @@ func (s *ProductService) GetByID(ctx context.Context, id int) (res *dtos.Product
 			}
 
 			for _, image := range product.Images {
-				imageDto := &dtos.ImageDto{
-					ID:           image.ID,
-					Name:         image.Name,
-					ImageUrl:     image.ImageUrl,
-					ThumbnailUrl: image.ThumbnailUrl,
-				}
+				imageDto := s.imageService.ToImageDto(ctx, image)
 
 				productDto.Images = append(productDto.Images, imageDto)
 			}
@@ -101,16 +108,99 @@ func (s *ProductService) GetByID(ctx context.Context, id int) (res *dtos.Product
 	}
 }
 
+// GetJSONLD returns the product as a schema.org Product with an Offer per
+// variant. Links and image urls are made absolute with FEED_BASE_URL and
+// prices are in FEED_CURRENCY, like in the product feeds.
+func (s *ProductService) GetJSONLD(ctx context.Context, id int) (*dtos.ProductJSONLDDto, error) {
+	product, err := s.repository.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	variants, err := s.repository.GetAllVariants(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	url := fmt.Sprintf("%s/products/%d", s.baseURL, product.ID)
+	productDto := &dtos.ProductJSONLDDto{
+		Context:     "https://schema.org",
+		Type:        "Product",
+		ProductID:   strconv.Itoa(product.ID),
+		Name:        product.Name,
+		Description: product.Description,
+		URL:         url,
+		Offers:      []*dtos.OfferJSONLDDto{},
+	}
+	if product.Category != nil {
+		productDto.Category = product.Category.Name
+	}
+	for _, image := range product.Images {
+		if strings.HasPrefix(image.ImageUrl, "http://") || strings.HasPrefix(image.ImageUrl, "https://") {
+			productDto.Image = append(productDto.Image, image.ImageUrl)
+		} else {
+			productDto.Image = append(productDto.Image, s.baseURL+"/"+strings.TrimLeft(image.ImageUrl, "/"))
+		}
+	}
+
+	// attributes every variant has describe the product
+	shared := make(map[string]int)
+	for _, variant := range variants {
+		availability := "https://schema.org/OutOfStock"
+		if variant.Stock-variant.Reserved > 0 {
+			availability = "https://schema.org/InStock"
+		}
+
+		variantDto := &dtos.VariantJSONLDDto{
+			Type: "Product",
+			SKU:  strconv.Itoa(variant.ID),
+			Name: strings.TrimSpace(product.Name + " " + variant.Name),
+		}
+		for _, attribute := range variant.Attributes {
+			variantDto.AdditionalProperty = append(variantDto.AdditionalProperty, &dtos.PropertyValueJSONLDDto{
+				Type:  "PropertyValue",
+				Name:  attribute.Type,
+				Value: attribute.Name,
+			})
+			shared[strings.ToLower(attribute.Type)+"\x00"+strings.ToLower(attribute.Name)]++
+		}
+
+		productDto.Offers = append(productDto.Offers, &dtos.OfferJSONLDDto{
+			Type:          "Offer",
+			SKU:           strconv.Itoa(variant.ID),
+			Price:         strconv.FormatFloat(variant.Price, 'f', 2, 64),
+			PriceCurrency: s.currency,
+			Availability:  availability,
+			URL:           fmt.Sprintf("%s?variant=%d", url, variant.ID),
+			ItemOffered:   variantDto,
+		})
+	}
+	if len(variants) > 0 {
+		for _, attribute := range variants[0].Attributes {
+			key := strings.ToLower(attribute.Type) + "\x00" + strings.ToLower(attribute.Name)
+			if shared[key] == len(variants) {
+				productDto.AdditionalProperty = append(productDto.AdditionalProperty, &dtos.PropertyValueJSONLDDto{
+					Type:  "PropertyValue",
+					Name:  attribute.Type,
+					Value: attribute.Name,
+				})
+			}
+		}
+	}
+
+	return productDto, nil
+}
+
 func (s *ProductService) Update(ctx context.Context, dto *dtos.UpdateProductDto) error {
 	return s.repository.Update(ctx, dto)
 }
 
 func (s *ProductService) Create(ctx context.Context, dto *dtos.CreateProductDto) error {
-	return s.repository.Create(ctx, dto)
+	_, err := s.repository.Create(ctx, dto)
+	return err
 }
 
-func (s *ProductService) Delete(ctx context.Context, id int) error {
-	return s.repository.Delete(ctx, id)
+func (s *ProductService) Delete(ctx context.Context, id int, versions []int) error {
+	return s.repository.Delete(ctx, id, versions)
 }
 
 func (s *ProductService) Search(ctx context.Context, q string, page int, size int, sortBy string, orderBy string) (*dtos.ProductPaginatedDto, error) {
@@ -124,6 +214,7 @@ func (s *ProductService) Search(ctx context.Context, q string, page int, size in
 				Name:        product.Name,
 				Description: product.Description,
 				CategoryID:  product.CategoryID,
+				Version:     product.Version,
 				Category: &dtos.CategoryDto{
 					ID:          product.Category.ID,
 					Name:        product.Category.Name,
@@ -133,12 +224,7 @@ func (s *ProductService) Search(ctx context.Context, q string, page int, size in
 			}
 
 			for _, image := range product.Images {
-				imageDto := &dtos.ImageDto{
-					ID:           image.ID,
-					Name:         image.Name,
-					ImageUrl:     image.ImageUrl,
-					ThumbnailUrl: image.ThumbnailUrl,
-				}
+				imageDto := s.imageService.ToImageDto(ctx, image)
 
 				productDto.Images = append(productDto.Images, imageDto)
 			}
@@ -163,12 +249,7 @@ func (s *ProductService) GetImages(ctx context.Context, id int) ([]*dtos.ImageDt
 	} else {
 		var imagesDto []*dtos.ImageDto
 		for _, image := range images {
-			imageDto := &dtos.ImageDto{
-				ID:           image.ID,
-				Name:         image.Name,
-				ImageUrl:     image.ImageUrl,
-				ThumbnailUrl: image.ThumbnailUrl,
-			}
+			imageDto := s.imageService.ToImageDto(ctx, image)
 
 			imagesDto = append(imagesDto, imageDto)
 		}
@@ -204,9 +285,20 @@ func (s *ProductService) FetchVariants(ctx context.Context, id int, page int, si
 				Name:      variant.Name,
 				ProductId: variant.ProductId,
 				// Product:    &dtos.ProductDto{},
-				Price: variant.Price,
-				Stock: variant.Stock,
+				Price:           variant.Price,
+				Stock:           variant.Stock,
+				Reserved:        variant.Reserved,
+				Available:       variant.Stock - variant.Reserved,
+				ReorderPoint:    variant.ReorderPoint,
+				ReorderQuantity: variant.ReorderQuantity,
+				Serialized:      variant.Serialized,
+				BaseUnit:        variant.BaseUnit,
+				Fractional:      variant.Fractional,
+				UnitCost:        variant.UnitCost,
+				Version:         variant.Version,
 			}
+			setMargin(productVariantDto)
+			setUnits(productVariantDto, variant.Units)
 
 			for _, attribute := range variant.Attributes {
 				attributeDto := &dtos.AttributeDto{
@@ -245,6 +337,7 @@ func (s *ProductService) GetVariantByID(ctx context.Context, id int, variantID i
 					Name:        productVariant.Product.Name,
 					Description: productVariant.Product.Description,
 					CategoryID:  productVariant.Product.CategoryID,
+					Version:     productVariant.Product.Version,
 					Category: &dtos.CategoryDto{
 						ID:          productVariant.Product.Category.ID,
 						Name:        productVariant.Product.Category.Name,
@@ -253,9 +346,20 @@ func (s *ProductService) GetVariantByID(ctx context.Context, id int, variantID i
 					// Images:      []*dtos.ImageDto{},
 					// Variants:    []*dtos.ProductVariantDto{},
 				},
-				Price: productVariant.Price,
-				Stock: productVariant.Stock,
+				Price:           productVariant.Price,
+				Stock:           productVariant.Stock,
+				Reserved:        productVariant.Reserved,
+				Available:       productVariant.Stock - productVariant.Reserved,
+				ReorderPoint:    productVariant.ReorderPoint,
+				ReorderQuantity: productVariant.ReorderQuantity,
+				Serialized:      productVariant.Serialized,
+				BaseUnit:        productVariant.BaseUnit,
+				Fractional:      productVariant.Fractional,
+				UnitCost:        productVariant.UnitCost,
+				Version:         productVariant.Version,
 			}
+			setMargin(productVariantDto)
+			setUnits(productVariantDto, productVariant.Units)
 
 			for _, attribute := range productVariant.Attributes {
 				attributeDto := &dtos.AttributeDto{
@@ -274,15 +378,16 @@ func (s *ProductService) GetVariantByID(ctx context.Context, id int, variantID i
 }
 
 func (s *ProductService) CreateVariant(ctx context.Context, dto *dtos.CreateProductVariantDto) error {
-	return s.repository.CreateVariant(ctx, dto)
+	_, err := s.repository.CreateVariant(ctx, dto)
+	return err
 }
 
 func (s *ProductService) UpdateVariant(ctx context.Context, dto *dtos.UpdateProductVariantDto) error {
 	return s.repository.UpdateVariant(ctx, dto)
 }
 
-func (s *ProductService) DeleteVariant(ctx context.Context, id int, variantID int) error {
-	return s.repository.DeleteVariant(ctx, id, variantID)
+func (s *ProductService) DeleteVariant(ctx context.Context, id int, variantID int, versions []int) error {
+	return s.repository.DeleteVariant(ctx, id, variantID, versions)
 }
 
 func (s *ProductService) GetAttributes(ctx context.Context, id int, variantID int) ([]*dtos.AttributeDto, error) {
@@ -323,9 +428,20 @@ func (s *ProductService) SearchVariants(ctx context.Context, q string, id int, p
 				Name:      variant.Name,
 				ProductId: variant.ProductId,
 				// Product:    &dtos.ProductDto{},
-				Price: variant.Price,
-				Stock: variant.Stock,
+				Price:           variant.Price,
+				Stock:           variant.Stock,
+				Reserved:        variant.Reserved,
+				Available:       variant.Stock - variant.Reserved,
+				ReorderPoint:    variant.ReorderPoint,
+				ReorderQuantity: variant.ReorderQuantity,
+				Serialized:      variant.Serialized,
+				BaseUnit:        variant.BaseUnit,
+				Fractional:      variant.Fractional,
+				UnitCost:        variant.UnitCost,
+				Version:         variant.Version,
 			}
+			setMargin(productVariantDto)
+			setUnits(productVariantDto, variant.Units)
 
 			for _, attribute := range variant.Attributes {
 				attributeDto := &dtos.AttributeDto{
@@ -359,3 +475,35 @@ func (s *ProductService) SearchVariants(ctx context.Context, q string, id int, p
 		return &productVariantsDto, nil
 	}
 }
+
+// setMargin fills the gross margin of a variant from its weighted average
+// unit cost.
+func setMargin(variant *dtos.ProductVariantDto) {
+	if variant.UnitCost == nil {
+		return
+	}
+
+	margin := variant.Price - *variant.UnitCost
+	variant.Margin = &margin
+	if variant.Price > 0 {
+		marginPercent := margin / variant.Price * 100
+		variant.MarginPercent = &marginPercent
+	}
+}
+
+// setUnits expresses the stock of a variant in each of its alternative units.
+func setUnits(variant *dtos.ProductVariantDto, units []*entities.UnitOfMeasure) {
+	for _, unit := range units {
+		variant.Units = append(variant.Units, &dtos.UnitStockDto{
+			Name:      unit.Name,
+			Factor:    unit.Factor,
+			Stock:     variant.Stock / unit.Factor,
+			Available: variant.Available / unit.Factor,
+		})
+	}
+}
+
+// Reindex rebuilds the indexes the searches use.
+func (s *ProductService) Reindex(ctx context.Context) error {
+	return s.repository.Reindex(ctx)
+}
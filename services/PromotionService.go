@@ -0,0 +1,269 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator"
+	"github.com/ysfada/product-management-system/domain/dtos"
+	"github.com/ysfada/product-management-system/domain/entities"
+	"github.com/ysfada/product-management-system/domain/interfaces"
+	"github.com/ysfada/product-management-system/util/promotion"
+)
+
+type PromotionService struct {
+	repository interfaces.IPromotionRepository
+	validate   *validator.Validate
+}
+
+var _ interfaces.IPromotionService = (*PromotionService)(nil)
+
+func NewPromotionService(repository interfaces.IPromotionRepository) *PromotionService {
+	return &PromotionService{
+		repository: repository,
+		validate:   validator.New(),
+	}
+}
+
+func (s *PromotionService) Fetch(ctx context.Context, page int, size int) (*dtos.PromotionPaginatedDto, error) {
+	if promotions, err := s.repository.Fetch(ctx, page, size); err != nil {
+		return nil, err
+	} else {
+		var promotionsDto dtos.PromotionPaginatedDto
+		for _, promotion := range promotions.Promotions {
+			promotionsDto.Promotions = append(promotionsDto.Promotions, toPromotionDto(promotion))
+		}
+
+		promotionsDto.TotalPage = promotions.TotalPage
+		promotionsDto.CurrentPage = promotions.CurrentPage
+		promotionsDto.NextPage = promotions.NextPage
+		promotionsDto.PreviousPage = promotions.PreviousPage
+		promotionsDto.Count = promotions.Count
+		promotionsDto.Size = promotions.Size
+
+		return &promotionsDto, nil
+	}
+}
+
+func (s *PromotionService) GetByID(ctx context.Context, id int) (*dtos.PromotionDto, error) {
+	if promotion, err := s.repository.GetByID(ctx, id); err != nil {
+		return nil, err
+	} else {
+		return toPromotionDto(promotion), nil
+	}
+}
+
+func (s *PromotionService) Create(ctx context.Context, dto *dtos.CreatePromotionDto) error {
+	if err := s.validate.Struct(dto); err != nil {
+		return err
+	}
+	return s.repository.Create(ctx, dto)
+}
+
+func (s *PromotionService) Update(ctx context.Context, dto *dtos.UpdatePromotionDto) error {
+	if err := s.validate.Struct(dto); err != nil {
+		return err
+	}
+	return s.repository.Update(ctx, dto)
+}
+
+func (s *PromotionService) Delete(ctx context.Context, id int) error {
+	return s.repository.Delete(ctx, id)
+}
+
+// Evaluate applies the running automatic promotions and the promotions of
+// the codes to the lines at the current prices, without using them up.
+func (s *PromotionService) Evaluate(ctx context.Context, dto *dtos.EvaluatePromotionsDto) (*dtos.PromotionEvaluationDto, error) {
+	evaluation, _, err := s.evaluate(ctx, dto)
+	return evaluation, err
+}
+
+// Redeem evaluates the lines of a sales order like Evaluate and counts a use
+// of every promotion which gave a discount, once per order. If one of them
+// ran out meanwhile nothing is counted and common.ErrConflict is returned.
+func (s *PromotionService) Redeem(ctx context.Context, dto *dtos.RedeemPromotionsDto) (*dtos.PromotionEvaluationDto, error) {
+	if err := s.validate.Struct(dto); err != nil {
+		return nil, err
+	}
+
+	lines, err := s.repository.OrderLines(ctx, dto.SalesOrderID)
+	if err != nil {
+		return nil, err
+	}
+	evaluation, applied, err := s.evaluate(ctx, &dtos.EvaluatePromotionsDto{
+		Codes: dto.Codes,
+		Lines: lines,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(applied) > 0 {
+		if err := s.repository.Redeem(ctx, dto.SalesOrderID, applied); err != nil {
+			return nil, err
+		}
+	}
+	return evaluation, nil
+}
+
+// evaluate returns the evaluation and the ids of the promotions which gave
+// a discount.
+func (s *PromotionService) evaluate(ctx context.Context, dto *dtos.EvaluatePromotionsDto) (*dtos.PromotionEvaluationDto, []int, error) {
+	if err := s.validate.Struct(dto); err != nil {
+		return nil, nil, err
+	}
+	if err := uniqueVariants(len(dto.Lines), func(i int) int { return dto.Lines[i].ProductVariantID }); err != nil {
+		return nil, nil, err
+	}
+
+	lines, err := s.repository.Lines(ctx, dto.Lines)
+	if err != nil {
+		return nil, nil, err
+	}
+	promotions, err := s.repository.FetchByCodes(ctx, dto.Codes)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	now := time.Now()
+	byCode := make(map[string]*entities.Promotion)
+	var rules []promotion.Rule
+	for _, p := range promotions {
+		if p.Code != nil {
+			byCode[strings.ToLower(*p.Code)] = p
+		}
+		if status, _ := promotionStatus(p, now); len(status) == 0 {
+			rules = append(rules, toPromotionRule(p))
+		}
+	}
+
+	promotionLines := make([]promotion.Line, 0, len(lines))
+	for _, line := range lines {
+		promotionLines = append(promotionLines, promotion.Line{
+			ProductVariantID: line.ProductVariantID,
+			ProductID:        line.ProductID,
+			CategoryID:       line.CategoryID,
+			AttributeIDs:     line.AttributeIDs,
+			Quantity:         line.Quantity,
+			UnitPrice:        line.UnitPrice,
+		})
+	}
+	result := promotion.Evaluate(rules, promotionLines)
+
+	evaluation := &dtos.PromotionEvaluationDto{
+		Lines:    make([]*dtos.PromotionEvaluationLineDto, 0, len(result.Lines)),
+		Codes:    make([]*dtos.PromotionCodeDto, 0, len(dto.Codes)),
+		Subtotal: result.Subtotal,
+		Discount: result.Discount,
+		Total:    result.Total,
+	}
+	for i, line := range result.Lines {
+		lineDto := &dtos.PromotionEvaluationLineDto{
+			ProductVariantID: line.ProductVariantID,
+			Name:             lines[i].Name,
+			Quantity:         line.Quantity,
+			UnitPrice:        line.UnitPrice,
+			Subtotal:         line.Subtotal,
+			Discounts:        make([]*dtos.PromotionDiscountDto, 0, len(line.Discounts)),
+			Total:            line.Total,
+		}
+		for _, discount := range line.Discounts {
+			lineDto.Discounts = append(lineDto.Discounts, &dtos.PromotionDiscountDto{
+				PromotionID: discount.RuleID,
+				Amount:      discount.Amount,
+				Explanation: discount.Explanation,
+			})
+		}
+		evaluation.Lines = append(evaluation.Lines, lineDto)
+	}
+
+	for _, code := range dto.Codes {
+		codeDto := &dtos.PromotionCodeDto{Code: code}
+		if p, ok := byCode[strings.ToLower(code)]; !ok {
+			codeDto.Status, codeDto.Message = "unknown", "no promotion has this code"
+		} else if status, message := promotionStatus(p, now); len(status) > 0 {
+			codeDto.Status, codeDto.Message = status, message
+		} else if result.Applied[p.ID] {
+			codeDto.Status, codeDto.Message = "applied", p.Name
+		} else {
+			codeDto.Status, codeDto.Message = "not_applied", "no line qualifies, or a better exclusive promotion was applied"
+		}
+		evaluation.Codes = append(evaluation.Codes, codeDto)
+	}
+
+	applied := make([]int, 0, len(result.Applied))
+	for _, p := range promotions {
+		if result.Applied[p.ID] {
+			applied = append(applied, p.ID)
+		}
+	}
+	return evaluation, applied, nil
+}
+
+// promotionStatus returns why the promotion can not be used at now, an
+// empty status if it can.
+func promotionStatus(p *entities.Promotion, now time.Time) (string, string) {
+	switch {
+	case p.StartsAt != nil && now.Before(*p.StartsAt):
+		return "not_started", "the promotion starts at " + p.StartsAt.Format(time.RFC3339)
+	case p.EndsAt != nil && !now.Before(*p.EndsAt):
+		return "expired", "the promotion ended at " + p.EndsAt.Format(time.RFC3339)
+	case p.UsageLimit != nil && p.Used >= *p.UsageLimit:
+		return "exhausted", "the promotion reached its usage limit"
+	default:
+		return "", ""
+	}
+}
+
+func toPromotionRule(p *entities.Promotion) promotion.Rule {
+	rule := promotion.Rule{
+		ID:               p.ID,
+		Name:             p.Name,
+		Kind:             promotion.Kind(p.Kind),
+		MinSubtotal:      p.MinSubtotal,
+		CategoryID:       p.CategoryID,
+		ProductID:        p.ProductID,
+		ProductVariantID: p.ProductVariantID,
+		AttributeID:      p.AttributeID,
+		Exclusive:        p.Exclusive,
+		Priority:         p.Priority,
+	}
+	if p.Percent != nil {
+		rule.Percent = *p.Percent
+	}
+	if p.BuyQuantity != nil {
+		rule.BuyQuantity = *p.BuyQuantity
+	}
+	if p.GetQuantity != nil {
+		rule.GetQuantity = *p.GetQuantity
+	}
+	if p.Amount != nil {
+		rule.Amount = *p.Amount
+	}
+	return rule
+}
+
+func toPromotionDto(promotion *entities.Promotion) *dtos.PromotionDto {
+	return &dtos.PromotionDto{
+		ID:               promotion.ID,
+		Name:             promotion.Name,
+		Code:             promotion.Code,
+		Kind:             promotion.Kind,
+		Percent:          promotion.Percent,
+		BuyQuantity:      promotion.BuyQuantity,
+		GetQuantity:      promotion.GetQuantity,
+		Amount:           promotion.Amount,
+		MinSubtotal:      promotion.MinSubtotal,
+		CategoryID:       promotion.CategoryID,
+		ProductID:        promotion.ProductID,
+		ProductVariantID: promotion.ProductVariantID,
+		AttributeID:      promotion.AttributeID,
+		StartsAt:         promotion.StartsAt,
+		EndsAt:           promotion.EndsAt,
+		UsageLimit:       promotion.UsageLimit,
+		Used:             promotion.Used,
+		Exclusive:        promotion.Exclusive,
+		Priority:         promotion.Priority,
+	}
+}
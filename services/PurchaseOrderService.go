@@ -0,0 +1,169 @@
+package services
+
+import (
+	"context"
+
+	"github.com/go-playground/validator"
+	"github.com/ysfada/product-management-system/domain/dtos"
+	"github.com/ysfada/product-management-system/domain/entities"
+	"github.com/ysfada/product-management-system/domain/interfaces"
+)
+
+type PurchaseOrderService struct {
+	repository interfaces.IPurchaseOrderRepository
+	validate   *validator.Validate
+}
+
+var _ interfaces.IPurchaseOrderService = (*PurchaseOrderService)(nil)
+
+func NewPurchaseOrderService(repository interfaces.IPurchaseOrderRepository) *PurchaseOrderService {
+	return &PurchaseOrderService{
+		repository: repository,
+		validate:   validator.New(),
+	}
+}
+
+func (s *PurchaseOrderService) Fetch(ctx context.Context, supplierID int, status string, page int, size int) (*dtos.PurchaseOrderPaginatedDto, error) {
+	if purchaseOrders, err := s.repository.Fetch(ctx, supplierID, status, page, size); err != nil {
+		return nil, err
+	} else {
+		var purchaseOrdersDto dtos.PurchaseOrderPaginatedDto
+		for _, purchaseOrder := range purchaseOrders.PurchaseOrders {
+			purchaseOrdersDto.PurchaseOrders = append(purchaseOrdersDto.PurchaseOrders, toPurchaseOrderDto(purchaseOrder))
+		}
+
+		purchaseOrdersDto.TotalPage = purchaseOrders.TotalPage
+		purchaseOrdersDto.CurrentPage = purchaseOrders.CurrentPage
+		purchaseOrdersDto.NextPage = purchaseOrders.NextPage
+		purchaseOrdersDto.PreviousPage = purchaseOrders.PreviousPage
+		purchaseOrdersDto.Count = purchaseOrders.Count
+		purchaseOrdersDto.Size = purchaseOrders.Size
+
+		return &purchaseOrdersDto, nil
+	}
+}
+
+func (s *PurchaseOrderService) GetByID(ctx context.Context, id int) (*dtos.PurchaseOrderDto, error) {
+	if purchaseOrder, err := s.repository.GetByID(ctx, id); err != nil {
+		return nil, err
+	} else {
+		return toPurchaseOrderDto(purchaseOrder), nil
+	}
+}
+
+func (s *PurchaseOrderService) Create(ctx context.Context, dto *dtos.CreatePurchaseOrderDto) (*dtos.PurchaseOrderDto, error) {
+	if err := s.validate.Struct(dto); err != nil {
+		return nil, err
+	}
+
+	if id, err := s.repository.Create(ctx, dto); err != nil {
+		return nil, err
+	} else {
+		return s.GetByID(ctx, id)
+	}
+}
+
+func (s *PurchaseOrderService) Send(ctx context.Context, id int) error {
+	return s.repository.Send(ctx, id)
+}
+
+func (s *PurchaseOrderService) Receive(ctx context.Context, dto *dtos.CreatePurchaseOrderReceiptDto) (*dtos.PurchaseOrderDto, error) {
+	if err := s.validate.Struct(dto); err != nil {
+		return nil, err
+	}
+
+	if err := s.repository.Receive(ctx, dto); err != nil {
+		return nil, err
+	}
+	return s.GetByID(ctx, dto.PurchaseOrderID)
+}
+
+func (s *PurchaseOrderService) Close(ctx context.Context, id int) error {
+	return s.repository.Close(ctx, id)
+}
+
+// Suggestions proposes a purchase order per preferred supplier for the
+// variants at or below their reorder point. A variant is ordered up to one
+// unit above its reorder point, or its reorder quantity if that is more.
+func (s *PurchaseOrderService) Suggestions(ctx context.Context) ([]*dtos.ReorderSuggestionDto, error) {
+	suggestions, err := s.repository.Suggestions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var suggestionsDto []*dtos.ReorderSuggestionDto
+	bySupplier := make(map[int]*dtos.ReorderSuggestionDto)
+	for _, suggestion := range suggestions {
+		// 0 groups the variants without a preferred supplier
+		supplierID := 0
+		if suggestion.SupplierID != nil {
+			supplierID = *suggestion.SupplierID
+		}
+
+		suggestionDto, ok := bySupplier[supplierID]
+		if !ok {
+			suggestionDto = &dtos.ReorderSuggestionDto{}
+			if suggestion.SupplierID != nil {
+				suggestionDto.Supplier = &dtos.SupplierDto{
+					ID:           *suggestion.SupplierID,
+					Name:         *suggestion.SupplierName,
+					Currency:     *suggestion.Currency,
+					LeadTimeDays: *suggestion.LeadTimeDays,
+				}
+			}
+			bySupplier[supplierID] = suggestionDto
+			suggestionsDto = append(suggestionsDto, suggestionDto)
+		}
+
+		quantity := suggestion.ReorderPoint - suggestion.Available - suggestion.OnOrder + 1
+		if suggestion.ReorderQuantity != nil && *suggestion.ReorderQuantity > quantity {
+			quantity = *suggestion.ReorderQuantity
+		}
+
+		suggestionDto.Lines = append(suggestionDto.Lines, &dtos.ReorderSuggestionLineDto{
+			ProductVariantID: suggestion.ProductVariantID,
+			Name:             suggestion.Name,
+			Available:        suggestion.Available,
+			OnOrder:          suggestion.OnOrder,
+			ReorderPoint:     suggestion.ReorderPoint,
+			Quantity:         quantity,
+			SupplierSKU:      suggestion.SupplierSKU,
+			UnitCost:         suggestion.UnitCost,
+		})
+		if suggestion.UnitCost != nil {
+			suggestionDto.Total += *suggestion.UnitCost * float64(quantity)
+		}
+	}
+
+	return suggestionsDto, nil
+}
+
+func toPurchaseOrderDto(purchaseOrder *entities.PurchaseOrder) *dtos.PurchaseOrderDto {
+	purchaseOrderDto := &dtos.PurchaseOrderDto{
+		ID:           purchaseOrder.ID,
+		SupplierID:   purchaseOrder.SupplierID,
+		SupplierName: purchaseOrder.SupplierName,
+		Status:       purchaseOrder.Status,
+		Currency:     purchaseOrder.Currency,
+		ExpectedAt:   purchaseOrder.ExpectedAt,
+		Note:         purchaseOrder.Note,
+		CreatedBy:    purchaseOrder.CreatedBy,
+		SentAt:       purchaseOrder.SentAt,
+		ClosedAt:     purchaseOrder.ClosedAt,
+		Total:        purchaseOrder.Total,
+	}
+
+	for _, line := range purchaseOrder.Lines {
+		purchaseOrderDto.Lines = append(purchaseOrderDto.Lines, &dtos.PurchaseOrderLineDto{
+			ProductVariantID: line.ProductVariantID,
+			Name:             line.Name,
+			SupplierSKU:      line.SupplierSKU,
+			Quantity:         line.Quantity,
+			UnitCost:         line.UnitCost,
+			Received:         line.Received,
+			Outstanding:      line.Quantity - line.Received,
+		})
+	}
+
+	return purchaseOrderDto
+}
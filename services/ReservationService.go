@@ -0,0 +1,103 @@
+package services
+
+import (
+	"context"
+	"log"
+	"os"
+	"time"
+
+	"github.com/go-playground/validator"
+	"github.com/ysfada/product-management-system/domain/dtos"
+	"github.com/ysfada/product-management-system/domain/entities"
+	"github.com/ysfada/product-management-system/domain/interfaces"
+)
+
+const defaultReservationTTL = 15 * time.Minute
+
+type ReservationService struct {
+	repository interfaces.IReservationRepository
+	validate   *validator.Validate
+	ttl        time.Duration
+}
+
+var _ interfaces.IReservationService = (*ReservationService)(nil)
+
+func NewReservationService(repository interfaces.IReservationRepository) *ReservationService {
+	ttl, err := time.ParseDuration(os.Getenv("RESERVATION_TTL"))
+	if err != nil || ttl <= 0 {
+		ttl = defaultReservationTTL
+	}
+
+	return &ReservationService{
+		repository: repository,
+		validate:   validator.New(),
+		ttl:        ttl,
+	}
+}
+
+func (s *ReservationService) GetByID(ctx context.Context, id int) (*dtos.ReservationDto, error) {
+	if reservation, err := s.repository.GetByID(ctx, id); err != nil {
+		return nil, err
+	} else {
+		return toReservationDto(reservation), nil
+	}
+}
+
+func (s *ReservationService) Create(ctx context.Context, dto *dtos.CreateReservationDto) (*dtos.ReservationDto, error) {
+	if err := s.validate.Struct(dto); err != nil {
+		return nil, err
+	}
+
+	ttl := s.ttl
+	if dto.TTL > 0 {
+		ttl = time.Duration(dto.TTL) * time.Second
+	}
+
+	if reservation, err := s.repository.Create(ctx, dto, ttl); err != nil {
+		return nil, err
+	} else {
+		return toReservationDto(reservation), nil
+	}
+}
+
+func (s *ReservationService) Confirm(ctx context.Context, id int) error {
+	return s.repository.Confirm(ctx, id)
+}
+
+func (s *ReservationService) Release(ctx context.Context, id int) error {
+	return s.repository.Release(ctx, id)
+}
+
+func (s *ReservationService) ExpireStale(ctx context.Context) (int, error) {
+	return s.repository.ExpireStale(ctx)
+}
+
+// Sweep expires stale reservations every interval until ctx is done.
+func (s *ReservationService) Sweep(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if count, err := s.ExpireStale(ctx); err != nil {
+				log.Printf("Unable to expire reservations: %v\n", err)
+			} else if count > 0 {
+				log.Printf("Expired %d reservations\n", count)
+			}
+		}
+	}
+}
+
+func toReservationDto(reservation *entities.Reservation) *dtos.ReservationDto {
+	return &dtos.ReservationDto{
+		ID:               reservation.ID,
+		ProductVariantID: reservation.ProductVariantID,
+		Quantity:         reservation.Quantity,
+		OwnerRef:         reservation.OwnerRef,
+		Status:           reservation.Status,
+		ExpiresAt:        reservation.ExpiresAt,
+	}
+}
@@ -0,0 +1,155 @@
+package services
+
+import (
+	"context"
+
+	"github.com/go-playground/validator"
+	"github.com/ysfada/product-management-system/domain/common"
+	"github.com/ysfada/product-management-system/domain/dtos"
+	"github.com/ysfada/product-management-system/domain/entities"
+	"github.com/ysfada/product-management-system/domain/interfaces"
+)
+
+type ReturnAuthorizationService struct {
+	repository interfaces.IReturnAuthorizationRepository
+	validate   *validator.Validate
+}
+
+var _ interfaces.IReturnAuthorizationService = (*ReturnAuthorizationService)(nil)
+
+func NewReturnAuthorizationService(repository interfaces.IReturnAuthorizationRepository) *ReturnAuthorizationService {
+	return &ReturnAuthorizationService{
+		repository: repository,
+		validate:   validator.New(),
+	}
+}
+
+func (s *ReturnAuthorizationService) Fetch(ctx context.Context, customer string, status string, page int, size int) (*dtos.ReturnAuthorizationPaginatedDto, error) {
+	if returnAuthorizations, err := s.repository.Fetch(ctx, customer, status, page, size); err != nil {
+		return nil, err
+	} else {
+		var returnAuthorizationsDto dtos.ReturnAuthorizationPaginatedDto
+		for _, returnAuthorization := range returnAuthorizations.ReturnAuthorizations {
+			returnAuthorizationsDto.ReturnAuthorizations = append(returnAuthorizationsDto.ReturnAuthorizations, toReturnAuthorizationDto(returnAuthorization))
+		}
+
+		returnAuthorizationsDto.TotalPage = returnAuthorizations.TotalPage
+		returnAuthorizationsDto.CurrentPage = returnAuthorizations.CurrentPage
+		returnAuthorizationsDto.NextPage = returnAuthorizations.NextPage
+		returnAuthorizationsDto.PreviousPage = returnAuthorizations.PreviousPage
+		returnAuthorizationsDto.Count = returnAuthorizations.Count
+		returnAuthorizationsDto.Size = returnAuthorizations.Size
+
+		return &returnAuthorizationsDto, nil
+	}
+}
+
+func (s *ReturnAuthorizationService) GetByID(ctx context.Context, id int) (*dtos.ReturnAuthorizationDto, error) {
+	if returnAuthorization, err := s.repository.GetByID(ctx, id); err != nil {
+		return nil, err
+	} else {
+		return toReturnAuthorizationDto(returnAuthorization), nil
+	}
+}
+
+func (s *ReturnAuthorizationService) Create(ctx context.Context, dto *dtos.CreateReturnAuthorizationDto, customer string) (*dtos.ReturnAuthorizationDto, error) {
+	if err := s.validate.Struct(dto); err != nil {
+		return nil, err
+	}
+	if err := uniqueVariants(len(dto.Lines), func(i int) int { return dto.Lines[i].ProductVariantID }); err != nil {
+		return nil, err
+	}
+
+	if id, err := s.repository.Create(ctx, dto, customer); err != nil {
+		return nil, err
+	} else {
+		return s.GetByID(ctx, id)
+	}
+}
+
+func (s *ReturnAuthorizationService) Approve(ctx context.Context, dto *dtos.ReviewReturnAuthorizationDto) error {
+	if err := s.validate.Struct(dto); err != nil {
+		return err
+	}
+
+	return s.repository.Approve(ctx, dto)
+}
+
+func (s *ReturnAuthorizationService) Reject(ctx context.Context, dto *dtos.ReviewReturnAuthorizationDto) error {
+	if err := s.validate.Struct(dto); err != nil {
+		return err
+	}
+
+	return s.repository.Reject(ctx, dto)
+}
+
+func (s *ReturnAuthorizationService) Receive(ctx context.Context, dto *dtos.ReceiveReturnAuthorizationDto) error {
+	if err := s.validate.Struct(dto); err != nil {
+		return err
+	}
+	if err := uniqueVariants(len(dto.Lines), func(i int) int { return dto.Lines[i].ProductVariantID }); err != nil {
+		return err
+	}
+
+	return s.repository.Receive(ctx, dto)
+}
+
+func (s *ReturnAuthorizationService) Inspect(ctx context.Context, dto *dtos.InspectReturnAuthorizationDto) error {
+	if err := s.validate.Struct(dto); err != nil {
+		return err
+	}
+	if err := uniqueVariants(len(dto.Lines), func(i int) int { return dto.Lines[i].ProductVariantID }); err != nil {
+		return err
+	}
+
+	return s.repository.Inspect(ctx, dto)
+}
+
+// uniqueVariants reports common.ErrBadParamInput if a variant is on more
+// than one of n lines.
+func uniqueVariants(n int, variantID func(i int) int) error {
+	variants := make(map[int]bool, n)
+	for i := 0; i < n; i++ {
+		if variants[variantID(i)] {
+			return common.ErrBadParamInput
+		}
+		variants[variantID(i)] = true
+	}
+	return nil
+}
+
+func toReturnAuthorizationDto(returnAuthorization *entities.ReturnAuthorization) *dtos.ReturnAuthorizationDto {
+	returnAuthorizationDto := &dtos.ReturnAuthorizationDto{
+		ID:           returnAuthorization.ID,
+		Customer:     returnAuthorization.Customer,
+		SalesOrderID: returnAuthorization.SalesOrderID,
+		Status:       returnAuthorization.Status,
+		Note:         returnAuthorization.Note,
+		ApprovedAt:   returnAuthorization.ApprovedAt,
+		RejectedAt:   returnAuthorization.RejectedAt,
+		ReceivedAt:   returnAuthorization.ReceivedAt,
+		InspectedAt:  returnAuthorization.InspectedAt,
+	}
+
+	for _, line := range returnAuthorization.Lines {
+		returnAuthorizationDto.Lines = append(returnAuthorizationDto.Lines, &dtos.ReturnAuthorizationLineDto{
+			ProductVariantID: line.ProductVariantID,
+			Quantity:         line.Quantity,
+			Reason:           line.Reason,
+			Received:         line.Received,
+			Restocked:        line.Restocked,
+			Refurbished:      line.Refurbished,
+			Scrapped:         line.Scrapped,
+		})
+	}
+	for _, event := range returnAuthorization.Events {
+		returnAuthorizationDto.Events = append(returnAuthorizationDto.Events, &dtos.ReturnAuthorizationEventDto{
+			Status:    event.Status,
+			Actor:     event.Actor,
+			Note:      event.Note,
+			CreatedAt: event.CreatedAt,
+		})
+	}
+
+	return returnAuthorizationDto
+}
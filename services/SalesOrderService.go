@@ -0,0 +1,111 @@
+package services
+
+import (
+	"context"
+
+	"github.com/go-playground/validator"
+	"github.com/ysfada/product-management-system/domain/common"
+	"github.com/ysfada/product-management-system/domain/dtos"
+	"github.com/ysfada/product-management-system/domain/entities"
+	"github.com/ysfada/product-management-system/domain/interfaces"
+)
+
+type SalesOrderService struct {
+	repository interfaces.ISalesOrderRepository
+	validate   *validator.Validate
+}
+
+var _ interfaces.ISalesOrderService = (*SalesOrderService)(nil)
+
+func NewSalesOrderService(repository interfaces.ISalesOrderRepository) *SalesOrderService {
+	return &SalesOrderService{
+		repository: repository,
+		validate:   validator.New(),
+	}
+}
+
+func (s *SalesOrderService) Fetch(ctx context.Context, customer string, status string, page int, size int) (*dtos.SalesOrderPaginatedDto, error) {
+	if salesOrders, err := s.repository.Fetch(ctx, customer, status, page, size); err != nil {
+		return nil, err
+	} else {
+		var salesOrdersDto dtos.SalesOrderPaginatedDto
+		for _, salesOrder := range salesOrders.SalesOrders {
+			salesOrdersDto.SalesOrders = append(salesOrdersDto.SalesOrders, toSalesOrderDto(salesOrder))
+		}
+
+		salesOrdersDto.TotalPage = salesOrders.TotalPage
+		salesOrdersDto.CurrentPage = salesOrders.CurrentPage
+		salesOrdersDto.NextPage = salesOrders.NextPage
+		salesOrdersDto.PreviousPage = salesOrders.PreviousPage
+		salesOrdersDto.Count = salesOrders.Count
+		salesOrdersDto.Size = salesOrders.Size
+
+		return &salesOrdersDto, nil
+	}
+}
+
+func (s *SalesOrderService) GetByID(ctx context.Context, id int) (*dtos.SalesOrderDto, error) {
+	if salesOrder, err := s.repository.GetByID(ctx, id); err != nil {
+		return nil, err
+	} else {
+		return toSalesOrderDto(salesOrder), nil
+	}
+}
+
+func (s *SalesOrderService) Create(ctx context.Context, dto *dtos.CreateSalesOrderDto) (*dtos.SalesOrderDto, error) {
+	if err := s.validate.Struct(dto); err != nil {
+		return nil, err
+	}
+
+	// a variant is ordered on one line only
+	variants := make(map[int]bool, len(dto.Lines))
+	for _, line := range dto.Lines {
+		if variants[line.ProductVariantID] {
+			return nil, common.ErrBadParamInput
+		}
+		variants[line.ProductVariantID] = true
+	}
+
+	if id, err := s.repository.Create(ctx, dto); err != nil {
+		return nil, err
+	} else {
+		return s.GetByID(ctx, id)
+	}
+}
+
+func (s *SalesOrderService) Pay(ctx context.Context, id int) error {
+	return s.repository.Pay(ctx, id)
+}
+
+func (s *SalesOrderService) Fulfill(ctx context.Context, id int) error {
+	return s.repository.Fulfill(ctx, id)
+}
+
+func (s *SalesOrderService) Cancel(ctx context.Context, id int, customer string) error {
+	return s.repository.Cancel(ctx, id, customer)
+}
+
+func toSalesOrderDto(salesOrder *entities.SalesOrder) *dtos.SalesOrderDto {
+	salesOrderDto := &dtos.SalesOrderDto{
+		ID:          salesOrder.ID,
+		Customer:    salesOrder.Customer,
+		Status:      salesOrder.Status,
+		Note:        salesOrder.Note,
+		PaidAt:      salesOrder.PaidAt,
+		FulfilledAt: salesOrder.FulfilledAt,
+		CancelledAt: salesOrder.CancelledAt,
+		Total:       salesOrder.Total,
+	}
+
+	for _, line := range salesOrder.Lines {
+		salesOrderDto.Lines = append(salesOrderDto.Lines, &dtos.SalesOrderLineDto{
+			ProductVariantID: line.ProductVariantID,
+			Name:             line.Name,
+			Quantity:         line.Quantity,
+			UnitPrice:        line.UnitPrice,
+			Subtotal:         line.Quantity * line.UnitPrice,
+		})
+	}
+
+	return salesOrderDto
+}
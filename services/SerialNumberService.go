@@ -0,0 +1,116 @@
+package services
+
+import (
+	"context"
+
+	"github.com/go-playground/validator"
+	"github.com/ysfada/product-management-system/domain/common"
+	"github.com/ysfada/product-management-system/domain/dtos"
+	"github.com/ysfada/product-management-system/domain/entities"
+	"github.com/ysfada/product-management-system/domain/interfaces"
+)
+
+// serialTransitions maps every serial status to the statuses it can be moved
+// from. Scrapped serials never move again.
+var serialTransitions = map[string][]string{
+	"in_stock": {"reserved", "returned"},
+	"reserved": {"in_stock"},
+	"sold":     {"in_stock", "reserved"},
+	"returned": {"sold"},
+	"scrapped": {"in_stock", "reserved", "returned"},
+}
+
+type SerialNumberService struct {
+	repository interfaces.ISerialNumberRepository
+	validate   *validator.Validate
+}
+
+var _ interfaces.ISerialNumberService = (*SerialNumberService)(nil)
+
+func NewSerialNumberService(repository interfaces.ISerialNumberRepository) *SerialNumberService {
+	return &SerialNumberService{
+		repository: repository,
+		validate:   validator.New(),
+	}
+}
+
+func (s *SerialNumberService) Fetch(ctx context.Context, id int, variantID int, status string) ([]*dtos.SerialNumberDto, error) {
+	if serials, err := s.repository.Fetch(ctx, id, variantID, status); err != nil {
+		return nil, err
+	} else {
+		var serialsDto []*dtos.SerialNumberDto
+		for _, serial := range serials {
+			serialsDto = append(serialsDto, toSerialNumberDto(serial))
+		}
+
+		return serialsDto, nil
+	}
+}
+
+func (s *SerialNumberService) GetBySerial(ctx context.Context, serial string) (*dtos.SerialNumberLookupDto, error) {
+	if lookup, err := s.repository.GetBySerial(ctx, serial); err != nil {
+		return nil, err
+	} else {
+		lookupDto := &dtos.SerialNumberLookupDto{
+			SerialNumberDto: *toSerialNumberDto(&lookup.SerialNumber),
+			ProductVariant: &dtos.ProductVariantDto{
+				ID:        lookup.ProductVariant.ID,
+				Name:      lookup.ProductVariant.Name,
+				ProductId: lookup.ProductVariant.ProductId,
+				Product: &dtos.ProductDto{
+					ID:          lookup.ProductVariant.Product.ID,
+					Name:        lookup.ProductVariant.Product.Name,
+					Description: lookup.ProductVariant.Product.Description,
+					CategoryID:  lookup.ProductVariant.Product.CategoryID,
+				},
+				Price:      lookup.ProductVariant.Price,
+				Stock:      lookup.ProductVariant.Stock,
+				Reserved:   lookup.ProductVariant.Reserved,
+				Available:  lookup.ProductVariant.Stock - lookup.ProductVariant.Reserved,
+				Serialized: lookup.ProductVariant.Serialized,
+			},
+		}
+
+		for _, history := range lookup.History {
+			lookupDto.History = append(lookupDto.History, &dtos.SerialNumberHistoryDto{
+				FromStatus: history.FromStatus,
+				ToStatus:   history.ToStatus,
+				Note:       history.Note,
+				CreatedAt:  history.CreatedAt,
+			})
+		}
+
+		return lookupDto, nil
+	}
+}
+
+func (s *SerialNumberService) Create(ctx context.Context, dto *dtos.CreateSerialNumbersDto) error {
+	if err := s.validate.Struct(dto); err != nil {
+		return err
+	}
+	return s.repository.Create(ctx, dto)
+}
+
+// UpdateStatus moves a serial through its life cycle and reports
+// common.ErrConflict if its current status can not move to the new one.
+func (s *SerialNumberService) UpdateStatus(ctx context.Context, dto *dtos.UpdateSerialNumberStatusDto) error {
+	if err := s.validate.Struct(dto); err != nil {
+		return err
+	}
+
+	from, ok := serialTransitions[dto.Status]
+	if !ok {
+		return common.ErrBadParamInput
+	}
+	return s.repository.UpdateStatus(ctx, dto, from)
+}
+
+func toSerialNumberDto(serial *entities.SerialNumber) *dtos.SerialNumberDto {
+	return &dtos.SerialNumberDto{
+		ID:               serial.ID,
+		ProductVariantID: serial.ProductVariantID,
+		Serial:           serial.Serial,
+		Status:           serial.Status,
+		Note:             serial.Note,
+	}
+}
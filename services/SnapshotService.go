@@ -0,0 +1,258 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"path"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/ysfada/product-management-system/domain/common"
+	"github.com/ysfada/product-management-system/domain/dtos"
+	"github.com/ysfada/product-management-system/domain/entities"
+	"github.com/ysfada/product-management-system/domain/interfaces"
+	"github.com/ysfada/product-management-system/util/rendition"
+	"github.com/ysfada/product-management-system/util/snapshot"
+	"github.com/ysfada/product-management-system/util/storage"
+	"github.com/ysfada/product-management-system/util/upload"
+)
+
+// the documents of a snapshot archive, image files are under snapshotImages
+const (
+	snapshotCategories = "categories.json"
+	snapshotAttributes = "attributes.json"
+	snapshotImagesJSON = "images.json"
+	snapshotProducts   = "products.json"
+	snapshotImages     = "images/"
+)
+
+// imagesURL prefixes the urls of uploaded images
+const imagesURL = publicURL + imagesKey
+
+type SnapshotService struct {
+	repository   interfaces.ISnapshotRepository
+	imageService interfaces.IImageService
+	limits       upload.Limits
+	storage      storage.Storage
+}
+
+var _ interfaces.ISnapshotService = (*SnapshotService)(nil)
+
+func NewSnapshotService(repository interfaces.ISnapshotRepository, storage storage.Storage, imageService interfaces.IImageService) *SnapshotService {
+	return &SnapshotService{
+		repository:   repository,
+		imageService: imageService,
+		limits:       imageLimits(),
+		storage:      storage,
+	}
+}
+
+// Export writes the whole catalog as a snapshot archive with the files of
+// its uploaded images. An image whose file is missing keeps its url.
+func (s *SnapshotService) Export(ctx context.Context, w io.Writer) error {
+	catalog, err := s.repository.Export(ctx)
+	if err != nil {
+		return err
+	}
+
+	// the urls of the files by their names in the archive, in the order
+	// they are written
+	files := make(map[string]string)
+	var names []string
+	for _, image := range catalog.Images {
+		if image.File, err = s.snapshotFile(ctx, image.ID, image.ImageUrl, files, &names); err != nil {
+			return err
+		}
+		if image.ThumbnailFile, err = s.snapshotFile(ctx, image.ID, image.ThumbnailUrl, files, &names); err != nil {
+			return err
+		}
+	}
+
+	writer, err := snapshot.NewWriter(w)
+	if err != nil {
+		return err
+	}
+	if err := writer.WriteJSON(snapshotCategories, catalog.Categories); err != nil {
+		return err
+	}
+	if err := writer.WriteJSON(snapshotAttributes, catalog.Attributes); err != nil {
+		return err
+	}
+	if err := writer.WriteJSON(snapshotImagesJSON, catalog.Images); err != nil {
+		return err
+	}
+	if err := writer.WriteJSON(snapshotProducts, catalog.Products); err != nil {
+		return err
+	}
+	for _, name := range names {
+		if err := s.writeSnapshotFile(ctx, writer, name, files[name]); err != nil {
+			return err
+		}
+	}
+	return writer.Close()
+}
+
+// Import adds a snapshot archive to the catalog, the strategy resolves rows
+// named like existing ones and is skip if empty. The image files are saved
+// as new uploads, checked like them, those no image ends up using are
+// removed. The files of overwritten images are removed unless still in use
+// and the imported images are rendered, a failure of either is logged.
+func (s *SnapshotService) Import(ctx context.Context, r io.Reader, strategy string) (*dtos.SnapshotImportDto, error) {
+	strategy, err := snapshot.ParseStrategy(strategy)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", common.ErrBadParamInput, err)
+	}
+
+	var catalog entities.Snapshot
+	// the urls of the saved files by their names in the archive
+	saved := make(map[string]string)
+	removeSaved := func(keep map[string]bool) {
+		for _, url := range saved {
+			if key, ok := storageKey(url); ok && !keep[url] {
+				s.storage.Delete(ctx, key)
+			}
+		}
+	}
+
+	if err := snapshot.Read(r, func(name string, r io.Reader) error {
+		switch {
+		case name == snapshotCategories:
+			return snapshot.ReadJSON(name, r, &catalog.Categories)
+		case name == snapshotAttributes:
+			return snapshot.ReadJSON(name, r, &catalog.Attributes)
+		case name == snapshotImagesJSON:
+			return snapshot.ReadJSON(name, r, &catalog.Images)
+		case name == snapshotProducts:
+			return snapshot.ReadJSON(name, r, &catalog.Products)
+		case strings.HasPrefix(name, snapshotImages):
+			url, err := s.saveSnapshotFile(ctx, name, r)
+			if err != nil {
+				return err
+			}
+			saved[name] = url
+			return nil
+		default:
+			return nil
+		}
+	}); err != nil {
+		removeSaved(nil)
+		if errors.Is(err, snapshot.ErrArchive) {
+			return nil, fmt.Errorf("%w: %v", common.ErrBadParamInput, err)
+		}
+		return nil, err
+	}
+
+	for _, image := range catalog.Images {
+		for _, file := range []struct {
+			name string
+			url  *string
+		}{{image.File, &image.ImageUrl}, {image.ThumbnailFile, &image.ThumbnailUrl}} {
+			if len(file.name) == 0 {
+				continue
+			}
+			url, ok := saved[file.name]
+			if !ok {
+				removeSaved(nil)
+				return nil, fmt.Errorf("%w: %v: %s is missing", common.ErrBadParamInput, snapshot.ErrArchive, file.name)
+			}
+			*file.url = url
+		}
+	}
+
+	result, err := s.repository.Import(ctx, &catalog, strategy)
+	if err != nil {
+		removeSaved(nil)
+		return nil, err
+	}
+	used := make(map[string]bool)
+	for _, url := range result.ImageUrls {
+		used[url] = true
+	}
+	removeSaved(used)
+
+	// the import is committed, what is left are its files
+	for _, image := range result.Replaced {
+		if err := s.imageService.RemoveFiles(ctx, image); err != nil {
+			log.Printf("Unable to remove the files of image %d: %v\n", image.ID, err)
+		}
+	}
+	if _, err := s.imageService.RenderAll(ctx, false); err != nil {
+		log.Printf("Unable to render imported images: %v\n", err)
+	}
+
+	return &dtos.SnapshotImportDto{
+		Strategy:   result.Strategy,
+		Categories: toSnapshotImportCountDto(result.Categories),
+		Attributes: toSnapshotImportCountDto(result.Attributes),
+		Images:     toSnapshotImportCountDto(result.Images),
+		Products:   toSnapshotImportCountDto(result.Products),
+		Variants:   toSnapshotImportCountDto(result.Variants),
+	}, nil
+}
+
+// snapshotFile returns the name in the archive of the uploaded image url and
+// adds it to files and names, or an empty name if url is not an uploaded
+// image with a file in the storage.
+func (s *SnapshotService) snapshotFile(ctx context.Context, id int, url string, files map[string]string, names *[]string) (string, error) {
+	if !strings.HasPrefix(url, imagesURL) {
+		return "", nil
+	}
+	for name, fileURL := range files {
+		// the thumbnail may be the image itself
+		if fileURL == url {
+			return name, nil
+		}
+	}
+	key, _ := storageKey(url)
+	if exists, err := s.storage.Exists(ctx, key); err != nil || !exists {
+		return "", err
+	}
+	name := fmt.Sprintf("%s%d-%s", snapshotImages, id, path.Base(url))
+	files[name] = url
+	*names = append(*names, name)
+	return name, nil
+}
+
+func (s *SnapshotService) writeSnapshotFile(ctx context.Context, writer *snapshot.Writer, name string, url string) error {
+	key, _ := storageKey(url)
+	r, size, err := s.storage.Get(ctx, key)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+	return writer.WriteFile(name, size, r)
+}
+
+// saveSnapshotFile saves an image file of an archive like an upload and
+// returns its url.
+func (s *SnapshotService) saveSnapshotFile(ctx context.Context, name string, r io.Reader) (string, error) {
+	data, err := ioutil.ReadAll(io.LimitReader(r, s.limits.MaxBytes+1))
+	if err != nil {
+		return "", err
+	}
+	data, _, format, err := prepareImage(data, &s.limits)
+	if err != nil {
+		return "", fmt.Errorf("%w: %s: %v", common.ErrBadParamInput, name, err)
+	}
+
+	filename := strings.Replace(uuid.New().String(), "-", "", -1)
+	key := fmt.Sprintf("%s%s.%s", imagesKey, filename, rendition.Extension(format))
+	if err := s.storage.Put(ctx, key, bytes.NewReader(data), int64(len(data)), storage.ContentType(key)); err != nil {
+		return "", err
+	}
+	return publicURL + key, nil
+}
+
+func toSnapshotImportCountDto(count entities.SnapshotImportCount) *dtos.SnapshotImportCountDto {
+	return &dtos.SnapshotImportCountDto{
+		Created: count.Created,
+		Updated: count.Updated,
+		Skipped: count.Skipped,
+		Renamed: count.Renamed,
+	}
+}
@@ -0,0 +1,87 @@
+package services
+
+import (
+	"context"
+
+	"github.com/go-playground/validator"
+	"github.com/ysfada/product-management-system/domain/dtos"
+	"github.com/ysfada/product-management-system/domain/interfaces"
+)
+
+type StocktakeService struct {
+	repository interfaces.IStocktakeRepository
+	validate   *validator.Validate
+}
+
+var _ interfaces.IStocktakeService = (*StocktakeService)(nil)
+
+func NewStocktakeService(repository interfaces.IStocktakeRepository) *StocktakeService {
+	return &StocktakeService{
+		repository: repository,
+		validate:   validator.New(),
+	}
+}
+
+// GetByID returns a session with its variance report.
+func (s *StocktakeService) GetByID(ctx context.Context, id int) (*dtos.StocktakeDto, error) {
+	if stocktake, err := s.repository.GetByID(ctx, id); err != nil {
+		return nil, err
+	} else {
+		stocktakeDto := &dtos.StocktakeDto{
+			ID:         stocktake.ID,
+			CategoryID: stocktake.CategoryID,
+			Status:     stocktake.Status,
+			CreatedBy:  stocktake.CreatedBy,
+			ApprovedBy: stocktake.ApprovedBy,
+			ApprovedAt: stocktake.ApprovedAt,
+		}
+
+		for _, line := range stocktake.Lines {
+			lineDto := &dtos.StocktakeLineDto{
+				ProductVariantID: line.ProductVariantID,
+				Name:             line.Name,
+				Stock:            line.Stock,
+				Expected:         line.Expected,
+				Counted:          line.Counted,
+				Adjustment:       line.Adjustment,
+			}
+			if line.Counted != nil {
+				variance := *line.Counted - line.Expected
+				lineDto.Variance = &variance
+				stocktakeDto.CountedLines++
+				stocktakeDto.TotalVariance += variance
+			}
+
+			stocktakeDto.Lines = append(stocktakeDto.Lines, lineDto)
+		}
+
+		return stocktakeDto, nil
+	}
+}
+
+func (s *StocktakeService) Create(ctx context.Context, dto *dtos.CreateStocktakeDto) (*dtos.StocktakeDto, error) {
+	if err := s.validate.Struct(dto); err != nil {
+		return nil, err
+	}
+
+	if id, err := s.repository.Create(ctx, dto); err != nil {
+		return nil, err
+	} else {
+		return s.GetByID(ctx, id)
+	}
+}
+
+func (s *StocktakeService) AddCounts(ctx context.Context, dto *dtos.CreateStocktakeCountsDto) error {
+	if err := s.validate.Struct(dto); err != nil {
+		return err
+	}
+	return s.repository.AddCounts(ctx, dto)
+}
+
+func (s *StocktakeService) Approve(ctx context.Context, id int, approvedBy string) error {
+	return s.repository.Approve(ctx, id, approvedBy)
+}
+
+func (s *StocktakeService) Cancel(ctx context.Context, id int) error {
+	return s.repository.Cancel(ctx, id)
+}
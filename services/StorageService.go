@@ -0,0 +1,123 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/ysfada/product-management-system/domain/common"
+	"github.com/ysfada/product-management-system/domain/dtos"
+	"github.com/ysfada/product-management-system/domain/interfaces"
+	"github.com/ysfada/product-management-system/util/storage"
+)
+
+const (
+	// localStorageDir is where the local storage keeps the files, they are
+	// served from publicURL
+	localStorageDir = "./public"
+	// publicURL is the url the files of the local storage are served from,
+	// the urls images are saved with are publicURL followed by their key
+	publicURL = "/public/"
+)
+
+type StorageService struct {
+	storage storage.Storage
+}
+
+var _ interfaces.IStorageService = (*StorageService)(nil)
+
+func NewStorageService(storage storage.Storage) *StorageService {
+	return &StorageService{
+		storage: storage,
+	}
+}
+
+// NewStorage returns the storage of the backend, STORAGE_BACKEND if it is
+// empty, local or s3. The local storage keeps the files in ./public. The s3
+// storage is configured from S3_ENDPOINT, the host and port of the API,
+// S3_REGION, S3_BUCKET, S3_ACCESS_KEY, S3_SECRET_KEY and S3_USE_SSL. Its
+// files are served from S3_PUBLIC_URL followed by their key if it is set,
+// from urls presigned for S3_PRESIGN_EXPIRY otherwise.
+func NewStorage(backend string) (storage.Storage, error) {
+	if len(backend) == 0 {
+		backend = os.Getenv("STORAGE_BACKEND")
+	}
+	backend, err := storage.ParseBackend(backend)
+	if err != nil {
+		return nil, err
+	}
+	if backend == storage.Local {
+		return storage.NewLocal(localStorageDir, publicURL), nil
+	}
+
+	config := storage.S3Config{
+		Endpoint:  os.Getenv("S3_ENDPOINT"),
+		Region:    os.Getenv("S3_REGION"),
+		Bucket:    os.Getenv("S3_BUCKET"),
+		AccessKey: os.Getenv("S3_ACCESS_KEY"),
+		SecretKey: os.Getenv("S3_SECRET_KEY"),
+		UseSSL:    true,
+		PublicURL: os.Getenv("S3_PUBLIC_URL"),
+	}
+	if useSSL, err := strconv.ParseBool(os.Getenv("S3_USE_SSL")); err == nil {
+		config.UseSSL = useSSL
+	}
+	if expiry, err := time.ParseDuration(os.Getenv("S3_PRESIGN_EXPIRY")); err == nil {
+		config.PresignExpiry = expiry
+	}
+	return storage.NewS3(config)
+}
+
+// URL returns the url the file of an image url is served from by the
+// storage, the image url itself if the local storage serves it.
+func (s *StorageService) URL(ctx context.Context, url string) (string, error) {
+	key, ok := storageKey(url)
+	if !ok {
+		return "", fmt.Errorf("%w: %s", common.ErrNotFound, url)
+	}
+	if storageURL, err := s.storage.URL(ctx, key); err != nil {
+		if errors.Is(err, storage.ErrKey) {
+			return "", fmt.Errorf("%w: %v", common.ErrBadParamInput, err)
+		}
+		return "", err
+	} else {
+		return storageURL, nil
+	}
+}
+
+// Migrate copies the image files of the from backend to the to backend,
+// those the to backend already has are skipped unless overwrite is set.
+// The urls of the images stay the same, the files are only served from the
+// to backend once STORAGE_BACKEND is changed to it.
+func (s *StorageService) Migrate(ctx context.Context, from string, to string, overwrite bool) (*dtos.StorageMigrationDto, error) {
+	var err error
+	if from, err = storage.ParseBackend(from); err != nil {
+		return nil, fmt.Errorf("%w: %v", common.ErrBadParamInput, err)
+	}
+	if to, err = storage.ParseBackend(to); err != nil {
+		return nil, fmt.Errorf("%w: %v", common.ErrBadParamInput, err)
+	}
+	if from == to {
+		return nil, fmt.Errorf("%w: the backends are both %s", common.ErrBadParamInput, from)
+	}
+
+	src, err := NewStorage(from)
+	if err != nil {
+		return nil, err
+	}
+	dst, err := NewStorage(to)
+	if err != nil {
+		return nil, err
+	}
+
+	copied, skipped, err := storage.Copy(ctx, src, dst, imagesKey, overwrite)
+	return &dtos.StorageMigrationDto{
+		From:    from,
+		To:      to,
+		Copied:  copied,
+		Skipped: skipped,
+	}, err
+}
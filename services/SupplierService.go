@@ -0,0 +1,117 @@
+package services
+
+import (
+	"context"
+
+	"github.com/go-playground/validator"
+	"github.com/ysfada/product-management-system/domain/dtos"
+	"github.com/ysfada/product-management-system/domain/entities"
+	"github.com/ysfada/product-management-system/domain/interfaces"
+)
+
+type SupplierService struct {
+	repository interfaces.ISupplierRepository
+	validate   *validator.Validate
+}
+
+var _ interfaces.ISupplierService = (*SupplierService)(nil)
+
+func NewSupplierService(repository interfaces.ISupplierRepository) *SupplierService {
+	return &SupplierService{
+		repository: repository,
+		validate:   validator.New(),
+	}
+}
+
+func (s *SupplierService) Fetch(ctx context.Context, page int, size int, sortBy string, orderBy string) (*dtos.SupplierPaginatedDto, error) {
+	if suppliers, err := s.repository.Fetch(ctx, page, size, sortBy, orderBy); err != nil {
+		return nil, err
+	} else {
+		var suppliersDto dtos.SupplierPaginatedDto
+		for _, supplier := range suppliers.Suppliers {
+			suppliersDto.Suppliers = append(suppliersDto.Suppliers, toSupplierDto(supplier))
+		}
+
+		suppliersDto.TotalPage = suppliers.TotalPage
+		suppliersDto.CurrentPage = suppliers.CurrentPage
+		suppliersDto.NextPage = suppliers.NextPage
+		suppliersDto.PreviousPage = suppliers.PreviousPage
+		suppliersDto.Count = suppliers.Count
+		suppliersDto.Size = suppliers.Size
+
+		return &suppliersDto, nil
+	}
+}
+
+func (s *SupplierService) GetByID(ctx context.Context, id int) (*dtos.SupplierDto, error) {
+	if supplier, err := s.repository.GetByID(ctx, id); err != nil {
+		return nil, err
+	} else {
+		return toSupplierDto(supplier), nil
+	}
+}
+
+func (s *SupplierService) Create(ctx context.Context, dto *dtos.CreateSupplierDto) error {
+	if err := s.validate.Struct(dto); err != nil {
+		return err
+	}
+	return s.repository.Create(ctx, dto)
+}
+
+func (s *SupplierService) Update(ctx context.Context, dto *dtos.UpdateSupplierDto) error {
+	if err := s.validate.Struct(dto); err != nil {
+		return err
+	}
+	return s.repository.Update(ctx, dto)
+}
+
+func (s *SupplierService) Delete(ctx context.Context, id int) error {
+	return s.repository.Delete(ctx, id)
+}
+
+func (s *SupplierService) FetchVariants(ctx context.Context, id int) ([]*dtos.SupplierVariantDto, error) {
+	if _, err := s.repository.GetByID(ctx, id); err != nil {
+		return nil, err
+	}
+
+	if variants, err := s.repository.FetchVariants(ctx, id); err != nil {
+		return nil, err
+	} else {
+		var variantsDto []*dtos.SupplierVariantDto
+		for _, variant := range variants {
+			variantsDto = append(variantsDto, &dtos.SupplierVariantDto{
+				SupplierID:       variant.SupplierID,
+				ProductVariantID: variant.ProductVariantID,
+				Name:             variant.Name,
+				SupplierSKU:      variant.SupplierSKU,
+				UnitCost:         variant.UnitCost,
+				Preferred:        variant.Preferred,
+			})
+		}
+
+		return variantsDto, nil
+	}
+}
+
+func (s *SupplierService) SetVariant(ctx context.Context, dto *dtos.SetSupplierVariantDto) error {
+	if err := s.validate.Struct(dto); err != nil {
+		return err
+	}
+	return s.repository.SetVariant(ctx, dto)
+}
+
+func (s *SupplierService) DeleteVariant(ctx context.Context, id int, variantID int) error {
+	return s.repository.DeleteVariant(ctx, id, variantID)
+}
+
+func toSupplierDto(supplier *entities.Supplier) *dtos.SupplierDto {
+	return &dtos.SupplierDto{
+		ID:           supplier.ID,
+		Name:         supplier.Name,
+		ContactName:  supplier.ContactName,
+		Email:        supplier.Email,
+		Phone:        supplier.Phone,
+		LeadTimeDays: supplier.LeadTimeDays,
+		Currency:     supplier.Currency,
+	}
+}
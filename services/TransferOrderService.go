@@ -0,0 +1,120 @@
+package services
+
+import (
+	"context"
+
+	"github.com/go-playground/validator"
+	"github.com/ysfada/product-management-system/domain/dtos"
+	"github.com/ysfada/product-management-system/domain/entities"
+	"github.com/ysfada/product-management-system/domain/interfaces"
+)
+
+type TransferOrderService struct {
+	repository interfaces.ITransferOrderRepository
+	validate   *validator.Validate
+}
+
+var _ interfaces.ITransferOrderService = (*TransferOrderService)(nil)
+
+func NewTransferOrderService(repository interfaces.ITransferOrderRepository) *TransferOrderService {
+	return &TransferOrderService{
+		repository: repository,
+		validate:   validator.New(),
+	}
+}
+
+func (s *TransferOrderService) Fetch(ctx context.Context, warehouseID int, status string, page int, size int) (*dtos.TransferOrderPaginatedDto, error) {
+	if transferOrders, err := s.repository.Fetch(ctx, warehouseID, status, page, size); err != nil {
+		return nil, err
+	} else {
+		var transferOrdersDto dtos.TransferOrderPaginatedDto
+		for _, transferOrder := range transferOrders.TransferOrders {
+			transferOrdersDto.TransferOrders = append(transferOrdersDto.TransferOrders, toTransferOrderDto(transferOrder))
+		}
+
+		transferOrdersDto.TotalPage = transferOrders.TotalPage
+		transferOrdersDto.CurrentPage = transferOrders.CurrentPage
+		transferOrdersDto.NextPage = transferOrders.NextPage
+		transferOrdersDto.PreviousPage = transferOrders.PreviousPage
+		transferOrdersDto.Count = transferOrders.Count
+		transferOrdersDto.Size = transferOrders.Size
+
+		return &transferOrdersDto, nil
+	}
+}
+
+func (s *TransferOrderService) GetByID(ctx context.Context, id int) (*dtos.TransferOrderDto, error) {
+	if transferOrder, err := s.repository.GetByID(ctx, id); err != nil {
+		return nil, err
+	} else {
+		return toTransferOrderDto(transferOrder), nil
+	}
+}
+
+func (s *TransferOrderService) Create(ctx context.Context, dto *dtos.CreateTransferOrderDto) (*dtos.TransferOrderDto, error) {
+	if err := s.validate.Struct(dto); err != nil {
+		return nil, err
+	}
+
+	if id, err := s.repository.Create(ctx, dto); err != nil {
+		return nil, err
+	} else {
+		return s.GetByID(ctx, id)
+	}
+}
+
+func (s *TransferOrderService) Dispatch(ctx context.Context, id int, dispatchedBy string) error {
+	return s.repository.Dispatch(ctx, id, dispatchedBy)
+}
+
+func (s *TransferOrderService) Receive(ctx context.Context, dto *dtos.CreateTransferOrderReceiptDto) (*dtos.TransferOrderDto, error) {
+	if err := s.validate.Struct(dto); err != nil {
+		return nil, err
+	}
+
+	if err := s.repository.Receive(ctx, dto); err != nil {
+		return nil, err
+	}
+	return s.GetByID(ctx, dto.TransferOrderID)
+}
+
+func (s *TransferOrderService) Close(ctx context.Context, id int) error {
+	return s.repository.Close(ctx, id)
+}
+
+func (s *TransferOrderService) Cancel(ctx context.Context, id int) error {
+	return s.repository.Cancel(ctx, id)
+}
+
+func toTransferOrderDto(transferOrder *entities.TransferOrder) *dtos.TransferOrderDto {
+	transferOrderDto := &dtos.TransferOrderDto{
+		ID:                       transferOrder.ID,
+		SourceWarehouseID:        transferOrder.SourceWarehouseID,
+		SourceWarehouseCode:      transferOrder.SourceWarehouseCode,
+		DestinationWarehouseID:   transferOrder.DestinationWarehouseID,
+		DestinationWarehouseCode: transferOrder.DestinationWarehouseCode,
+		Status:                   transferOrder.Status,
+		Note:                     transferOrder.Note,
+		CreatedBy:                transferOrder.CreatedBy,
+		DispatchedBy:             transferOrder.DispatchedBy,
+		DispatchedAt:             transferOrder.DispatchedAt,
+		ClosedAt:                 transferOrder.ClosedAt,
+	}
+
+	for _, line := range transferOrder.Lines {
+		inTransit := 0.0
+		if transferOrder.Status == "in_transit" || transferOrder.Status == "partially_received" {
+			inTransit = line.Quantity - line.Received - line.Lost
+		}
+		transferOrderDto.Lines = append(transferOrderDto.Lines, &dtos.TransferOrderLineDto{
+			ProductVariantID: line.ProductVariantID,
+			Name:             line.Name,
+			Quantity:         line.Quantity,
+			Received:         line.Received,
+			Lost:             line.Lost,
+			InTransit:        inTransit,
+		})
+	}
+
+	return transferOrderDto
+}
@@ -0,0 +1,50 @@
+package services
+
+import (
+	"context"
+
+	"github.com/go-playground/validator"
+	"github.com/ysfada/product-management-system/domain/dtos"
+	"github.com/ysfada/product-management-system/domain/interfaces"
+)
+
+type UnitOfMeasureService struct {
+	repository interfaces.IUnitOfMeasureRepository
+	validate   *validator.Validate
+}
+
+var _ interfaces.IUnitOfMeasureService = (*UnitOfMeasureService)(nil)
+
+func NewUnitOfMeasureService(repository interfaces.IUnitOfMeasureRepository) *UnitOfMeasureService {
+	return &UnitOfMeasureService{
+		repository: repository,
+		validate:   validator.New(),
+	}
+}
+
+func (s *UnitOfMeasureService) Fetch(ctx context.Context, id int, variantID int) ([]*dtos.UnitOfMeasureDto, error) {
+	if units, err := s.repository.Fetch(ctx, id, variantID); err != nil {
+		return nil, err
+	} else {
+		var unitsDto []*dtos.UnitOfMeasureDto
+		for _, unit := range units {
+			unitsDto = append(unitsDto, &dtos.UnitOfMeasureDto{
+				Name:   unit.Name,
+				Factor: unit.Factor,
+			})
+		}
+
+		return unitsDto, nil
+	}
+}
+
+func (s *UnitOfMeasureService) Set(ctx context.Context, dto *dtos.SetUnitOfMeasureDto) error {
+	if err := s.validate.Struct(dto); err != nil {
+		return err
+	}
+	return s.repository.Set(ctx, dto)
+}
+
+func (s *UnitOfMeasureService) Delete(ctx context.Context, id int, variantID int, name string) error {
+	return s.repository.Delete(ctx, id, variantID, name)
+}
@@ -2,6 +2,7 @@ package services
 
 import (
 	"context"
+	"log"
 	"os"
 	"time"
 
@@ -16,15 +17,17 @@ import (
 type UserService struct {
 	repository interfaces.IUserRepository
 	hasher     hasher.Hasher
+	carts      interfaces.ICartService
 	validate   *validator.Validate
 }
 
 var _ interfaces.IUserService = (*UserService)(nil)
 
-func NewUserService(repository interfaces.IUserRepository, hasher hasher.Hasher) *UserService {
+func NewUserService(repository interfaces.IUserRepository, hasher hasher.Hasher, carts interfaces.ICartService) *UserService {
 	return &UserService{
 		repository: repository,
 		hasher:     hasher,
+		carts:      carts,
 		validate:   validator.New(),
 	}
 }
@@ -42,6 +45,25 @@ func (s *UserService) Signup(ctx context.Context, dto *dtos.SignupDto) error {
 	}
 }
 
+// CreateSuperuser creates an active user who is staff and superuser, which
+// the signup can not.
+func (s *UserService) CreateSuperuser(ctx context.Context, dto *dtos.SignupDto) error {
+	if err := s.validate.Struct(dto); err != nil {
+		return err
+	}
+
+	if hash, err := s.hasher.Hash(dto.Password); err != nil {
+		return err
+	} else {
+		dto.Password = string(hash)
+		return s.repository.CreateSuperuser(ctx, dto)
+	}
+}
+
+func (s *UserService) SetActive(ctx context.Context, username string, active bool) error {
+	return s.repository.SetActive(ctx, username, active)
+}
+
 func (s *UserService) Signin(ctx context.Context, dto *dtos.SigninDto) (token string, err error) {
 	if err := s.validate.Struct(dto); err != nil {
 		return "", err
@@ -54,6 +76,19 @@ func (s *UserService) Signin(ctx context.Context, dto *dtos.SigninDto) (token st
 			if err := s.hasher.Compare(user.Password, dto.Password); err != nil {
 				return "", common.ErrBadParamInput
 			}
+			// deactivated users can not sign in, their tokens last until they
+			// expire
+			if !user.IsActive {
+				return "", common.ErrBadParamInput
+			}
+
+			// a cart that could not be merged stays anonymous, it does not
+			// fail the signin
+			if len(dto.CartToken) > 0 {
+				if _, err := s.carts.Merge(ctx, dto.CartToken, user.Username); err != nil && err != common.ErrNotFound {
+					log.Printf("Unable to merge cart of %s: %v\n", user.Username, err)
+				}
+			}
 
 			// Create token
 			token := jwt.New(jwt.SigningMethodHS256)
@@ -0,0 +1,95 @@
+package services
+
+import (
+	"context"
+
+	"github.com/go-playground/validator"
+	"github.com/ysfada/product-management-system/domain/dtos"
+	"github.com/ysfada/product-management-system/domain/entities"
+	"github.com/ysfada/product-management-system/domain/interfaces"
+)
+
+type WarehouseService struct {
+	repository interfaces.IWarehouseRepository
+	validate   *validator.Validate
+}
+
+var _ interfaces.IWarehouseService = (*WarehouseService)(nil)
+
+func NewWarehouseService(repository interfaces.IWarehouseRepository) *WarehouseService {
+	return &WarehouseService{
+		repository: repository,
+		validate:   validator.New(),
+	}
+}
+
+func (s *WarehouseService) Fetch(ctx context.Context) ([]*dtos.WarehouseDto, error) {
+	if warehouses, err := s.repository.Fetch(ctx); err != nil {
+		return nil, err
+	} else {
+		var warehousesDto []*dtos.WarehouseDto
+		for _, warehouse := range warehouses {
+			warehousesDto = append(warehousesDto, toWarehouseDto(warehouse))
+		}
+
+		return warehousesDto, nil
+	}
+}
+
+func (s *WarehouseService) GetByID(ctx context.Context, id int) (*dtos.WarehouseDto, error) {
+	if warehouse, err := s.repository.GetByID(ctx, id); err != nil {
+		return nil, err
+	} else {
+		return toWarehouseDto(warehouse), nil
+	}
+}
+
+func (s *WarehouseService) Create(ctx context.Context, dto *dtos.CreateWarehouseDto) error {
+	if err := s.validate.Struct(dto); err != nil {
+		return err
+	}
+	return s.repository.Create(ctx, dto)
+}
+
+func (s *WarehouseService) Update(ctx context.Context, dto *dtos.UpdateWarehouseDto) error {
+	if err := s.validate.Struct(dto); err != nil {
+		return err
+	}
+	return s.repository.Update(ctx, dto)
+}
+
+func (s *WarehouseService) Delete(ctx context.Context, id int) error {
+	return s.repository.Delete(ctx, id)
+}
+
+func (s *WarehouseService) VariantStock(ctx context.Context, id int, variantID int) (*dtos.VariantStockDto, error) {
+	if stock, err := s.repository.VariantStock(ctx, id, variantID); err != nil {
+		return nil, err
+	} else {
+		stockDto := &dtos.VariantStockDto{
+			ProductVariantID: stock.ProductVariantID,
+			Name:             stock.Name,
+			Stock:            stock.Stock,
+			InTransit:        stock.InTransit,
+		}
+		for _, warehouse := range stock.Warehouses {
+			stockDto.Warehouses = append(stockDto.Warehouses, &dtos.WarehouseStockDto{
+				WarehouseID: warehouse.WarehouseID,
+				Code:        warehouse.Code,
+				Name:        warehouse.Name,
+				Quantity:    warehouse.Quantity,
+			})
+		}
+
+		return stockDto, nil
+	}
+}
+
+func toWarehouseDto(warehouse *entities.Warehouse) *dtos.WarehouseDto {
+	return &dtos.WarehouseDto{
+		ID:        warehouse.ID,
+		Code:      warehouse.Code,
+		Name:      warehouse.Name,
+		IsDefault: warehouse.IsDefault,
+	}
+}
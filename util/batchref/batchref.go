@@ -0,0 +1,75 @@
+// Package batchref resolves references to ids created earlier in a batch.
+//
+// An operation of a batch names the row it creates, later operations refer to
+// its id with an object of the name alone:
+//
+//	{"product_id": {"$ref": "shoe"}}
+//
+// Resolve replaces every such object with the id.
+package batchref
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// Key is the only key of a reference object.
+const Key = "$ref"
+
+var ErrUnknownRef = errors.New("unknown reference")
+
+// Resolve returns data with every reference replaced by its id in refs. Data
+// without references is returned as it is.
+func Resolve(data json.RawMessage, refs map[string]int) (json.RawMessage, error) {
+	if len(data) == 0 || !bytes.Contains(data, []byte(Key)) {
+		return data, nil
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	// numbers are kept as they were written
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+
+	if resolved, err := resolve(value, refs); err != nil {
+		return nil, err
+	} else {
+		return json.Marshal(resolved)
+	}
+}
+
+func resolve(value interface{}, refs map[string]int) (interface{}, error) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if ref, ok := v[Key]; ok && len(v) == 1 {
+			name, ok := ref.(string)
+			if !ok {
+				return nil, fmt.Errorf("%w: %v", ErrUnknownRef, ref)
+			}
+			if id, ok := refs[name]; ok {
+				return id, nil
+			}
+			return nil, fmt.Errorf("%w: %s", ErrUnknownRef, name)
+		}
+		for key, item := range v {
+			if resolved, err := resolve(item, refs); err != nil {
+				return nil, err
+			} else {
+				v[key] = resolved
+			}
+		}
+	case []interface{}:
+		for i, item := range v {
+			if resolved, err := resolve(item, refs); err != nil {
+				return nil, err
+			} else {
+				v[i] = resolved
+			}
+		}
+	}
+	return value, nil
+}
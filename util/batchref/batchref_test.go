@@ -0,0 +1,41 @@
+package batchref
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolve(t *testing.T) {
+	refs := map[string]int{"shoe": 7, "red": 12}
+
+	data, err := Resolve(json.RawMessage(`{"name":"Red 42","product_id":{"$ref":"shoe"},"price":499.90,"ids":[{"$ref":"red"},3]}`), refs)
+
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"name":"Red 42","product_id":7,"price":499.90,"ids":[12,3]}`, string(data))
+}
+
+func TestResolveKeepsDataWithoutReferences(t *testing.T) {
+	data := json.RawMessage(`{"name": "Shoes", "category_id": 3}`)
+
+	resolved, err := Resolve(data, nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, data, resolved)
+}
+
+func TestResolveKeepsObjectsWithMoreKeys(t *testing.T) {
+	data, err := Resolve(json.RawMessage(`{"note":{"$ref":"shoe","text":"not a reference"}}`), map[string]int{"shoe": 7})
+
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"note":{"$ref":"shoe","text":"not a reference"}}`, string(data))
+}
+
+func TestResolveRejectsUnknownReferences(t *testing.T) {
+	for _, data := range []string{`{"product_id":{"$ref":"boot"}}`, `{"product_id":{"$ref":7}}`} {
+		_, err := Resolve(json.RawMessage(data), map[string]int{"shoe": 7})
+		assert.True(t, errors.Is(err, ErrUnknownRef), data)
+	}
+}
@@ -0,0 +1,292 @@
+// Package catalogcsv reads and writes product catalogs as CSV.
+//
+// The first record is a header naming the columns, in any order:
+//
+//	product      product name, required
+//	description  product description
+//	category     category name, required
+//	variant      variant name, empty for a product without variants
+//	price        variant price, required with a variant
+//	stock        variant stock in its base unit, kept as is for existing
+//	             variants if empty
+//	attributes   type:value pairs separated by ";", e.g. color:red;size:42
+//	images       image file names separated by ";"
+//
+// A product is identified by its name and a variant by its product and its
+// name, both case insensitive. A row without a variant has no price, stock
+// or attributes. Attribute types with ":" and attributes or
+// images with ";" in their names can not be written.
+package catalogcsv
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+const (
+	ColumnProduct     = "product"
+	ColumnDescription = "description"
+	ColumnCategory    = "category"
+	ColumnVariant     = "variant"
+	ColumnPrice       = "price"
+	ColumnStock       = "stock"
+	ColumnAttributes  = "attributes"
+	ColumnImages      = "images"
+)
+
+var columns = []string{ColumnProduct, ColumnDescription, ColumnCategory, ColumnVariant, ColumnPrice, ColumnStock, ColumnAttributes, ColumnImages}
+
+var required = []string{ColumnProduct, ColumnCategory, ColumnVariant, ColumnPrice}
+
+// ErrHeader is returned if the header is missing, names an unknown column
+// or lacks a required one.
+var ErrHeader = errors.New("invalid header")
+
+type Attribute struct {
+	Type  string
+	Value string
+}
+
+type Row struct {
+	// Line is the line of the row in the file, the header is line 1
+	Line        int
+	Product     string
+	Description string
+	Category    string
+	Variant     string
+	Price       float64
+	Stock       *float64
+	Attributes  []Attribute
+	Images      []string
+}
+
+type RowError struct {
+	Line    int
+	Column  string
+	Message string
+}
+
+// Read reads the rows of the catalog. A row with errors is left out and
+// reported with every error it has, an empty column means the whole row.
+func Read(r io.Reader) ([]Row, []RowError, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		if err == io.EOF {
+			return nil, nil, fmt.Errorf("%w: the file is empty", ErrHeader)
+		}
+		return nil, nil, fmt.Errorf("%w: %v", ErrHeader, err)
+	}
+	index, err := readHeader(header)
+	if err != nil {
+		return nil, nil, err
+	}
+	reader.FieldsPerRecord = len(header)
+
+	var rows []Row
+	var rowErrors []RowError
+	keys := make(map[string]int)
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		line, _ := reader.FieldPos(0)
+		if err != nil {
+			var parseErr *csv.ParseError
+			if errors.As(err, &parseErr) {
+				rowErrors = append(rowErrors, RowError{Line: parseErr.StartLine, Message: parseErr.Err.Error()})
+				continue
+			}
+			return nil, nil, err
+		}
+
+		row, errs := readRow(line, record, index)
+		if len(errs) == 0 {
+			key := strings.ToLower(row.Product) + "\x00" + strings.ToLower(row.Variant)
+			if first, ok := keys[key]; ok {
+				errs = append(errs, RowError{Line: line, Column: ColumnVariant, Message: fmt.Sprintf("the variant is already on line %d", first)})
+			} else {
+				keys[key] = line
+			}
+		}
+
+		if len(errs) > 0 {
+			rowErrors = append(rowErrors, errs...)
+		} else {
+			rows = append(rows, row)
+		}
+	}
+
+	return rows, rowErrors, nil
+}
+
+// Header returns the columns in the order Record writes them.
+func Header() []string {
+	return append([]string(nil), columns...)
+}
+
+// Record returns the fields of a row in the order of Header, Read reads them
+// back as the same row.
+func Record(row Row) []string {
+	price := ""
+	if len(row.Variant) > 0 {
+		price = strconv.FormatFloat(row.Price, 'f', -1, 64)
+	}
+	stock := ""
+	if row.Stock != nil {
+		stock = strconv.FormatFloat(*row.Stock, 'f', -1, 64)
+	}
+	var attributes []string
+	for _, attribute := range row.Attributes {
+		attributes = append(attributes, attribute.Type+":"+attribute.Value)
+	}
+
+	return []string{
+		row.Product,
+		row.Description,
+		row.Category,
+		row.Variant,
+		price,
+		stock,
+		strings.Join(attributes, ";"),
+		strings.Join(row.Images, ";"),
+	}
+}
+
+func readHeader(header []string) (map[string]int, error) {
+	index := make(map[string]int, len(header))
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		if !contains(columns, name) {
+			return nil, fmt.Errorf("%w: unknown column %q", ErrHeader, name)
+		}
+		if _, ok := index[name]; ok {
+			return nil, fmt.Errorf("%w: column %q is given twice", ErrHeader, name)
+		}
+		index[name] = i
+	}
+	for _, name := range required {
+		if _, ok := index[name]; !ok {
+			return nil, fmt.Errorf("%w: column %q is missing", ErrHeader, name)
+		}
+	}
+	return index, nil
+}
+
+func readRow(line int, record []string, index map[string]int) (Row, []RowError) {
+	value := func(column string) string {
+		if i, ok := index[column]; ok {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+
+	row := Row{
+		Line:        line,
+		Product:     value(ColumnProduct),
+		Description: value(ColumnDescription),
+		Category:    value(ColumnCategory),
+		Variant:     value(ColumnVariant),
+	}
+	var errs []RowError
+	fail := func(column string, format string, args ...interface{}) {
+		errs = append(errs, RowError{Line: line, Column: column, Message: fmt.Sprintf(format, args...)})
+	}
+
+	// the limits of the product, category and variant api
+	if n := len([]rune(row.Product)); n < 2 || n > 16 {
+		fail(ColumnProduct, "must be 2 to 16 characters")
+	}
+	if n := len([]rune(row.Category)); n < 2 || n > 32 {
+		fail(ColumnCategory, "must be 2 to 32 characters")
+	}
+	if len(row.Variant) == 0 {
+		for _, column := range []string{ColumnPrice, ColumnStock, ColumnAttributes} {
+			if len(value(column)) > 0 {
+				fail(column, "must be empty without a variant")
+			}
+		}
+		row.Images = readImages(value(ColumnImages), fail)
+		return row, errs
+	}
+	if n := len([]rune(row.Variant)); n < 2 || n > 16 {
+		fail(ColumnVariant, "must be 2 to 16 characters")
+	}
+
+	if price, err := strconv.ParseFloat(value(ColumnPrice), 64); err != nil || price < 0 {
+		fail(ColumnPrice, "must be a number of at least 0")
+	} else {
+		row.Price = price
+	}
+
+	if stock := value(ColumnStock); len(stock) > 0 {
+		if quantity, err := strconv.ParseFloat(stock, 64); err != nil || quantity < 0 {
+			fail(ColumnStock, "must be a number of at least 0")
+		} else {
+			row.Stock = &quantity
+		}
+	}
+
+	for _, pair := range split(value(ColumnAttributes)) {
+		attributeType, attributeValue, ok := cut(pair, ":")
+		if !ok || len(attributeType) == 0 || len(attributeValue) == 0 {
+			fail(ColumnAttributes, "%q is not a type:value pair", pair)
+			continue
+		}
+		if len([]rune(attributeType)) > 32 || len([]rune(attributeValue)) > 32 {
+			fail(ColumnAttributes, "%q must have a type and value of at most 32 characters", pair)
+			continue
+		}
+		row.Attributes = append(row.Attributes, Attribute{Type: attributeType, Value: attributeValue})
+	}
+
+	row.Images = readImages(value(ColumnImages), fail)
+	return row, errs
+}
+
+// readImages reads a list of image file names, failing the invalid ones.
+func readImages(list string, fail func(column string, format string, args ...interface{})) []string {
+	var images []string
+	for _, image := range split(list) {
+		if n := len([]rune(image)); n < 2 || n > 64 {
+			fail(ColumnImages, "%q must be 2 to 64 characters", image)
+			continue
+		}
+		images = append(images, image)
+	}
+	return images
+}
+
+// split splits a list separated by ";", dropping empty items.
+func split(list string) []string {
+	var items []string
+	for _, item := range strings.Split(list, ";") {
+		if item = strings.TrimSpace(item); len(item) > 0 {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func cut(s string, sep string) (string, string, bool) {
+	if i := strings.Index(s, sep); i >= 0 {
+		return strings.TrimSpace(s[:i]), strings.TrimSpace(s[i+len(sep):]), true
+	}
+	return s, "", false
+}
+
+func contains(items []string, item string) bool {
+	for _, i := range items {
+		if i == item {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,110 @@
+package catalogcsv
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadRows(t *testing.T) {
+	file := `Product,Category,Variant,Price,Stock,Attributes,Images
+Runner,Shoes,Runner 42,499.90,12,color:red; size:42,runner.jpg;runner-side.jpg
+Runner,Shoes,Runner 43,499.90,,size:43,
+`
+	rows, rowErrors, err := Read(strings.NewReader(file))
+
+	assert.NoError(t, err)
+	assert.Empty(t, rowErrors)
+	assert.Len(t, rows, 2)
+	assert.Equal(t, 2, rows[0].Line)
+	assert.Equal(t, 499.90, rows[0].Price)
+	assert.Equal(t, 12.0, *rows[0].Stock)
+	assert.Equal(t, []Attribute{{Type: "color", Value: "red"}, {Type: "size", Value: "42"}}, rows[0].Attributes)
+	assert.Equal(t, []string{"runner.jpg", "runner-side.jpg"}, rows[0].Images)
+	assert.Nil(t, rows[1].Stock)
+	assert.Nil(t, rows[1].Images)
+}
+
+func TestReadReportsRowErrors(t *testing.T) {
+	file := `product,category,variant,price,attributes
+Runner,Shoes,Runner 42,-1,color
+Runner,Shoes,Runner 43,10,
+runner,Shoes,RUNNER 43,10,
+X,Shoes,Runner 44,10,
+`
+	rows, rowErrors, err := Read(strings.NewReader(file))
+
+	assert.NoError(t, err)
+	assert.Len(t, rows, 1)
+	assert.Equal(t, []RowError{
+		{Line: 2, Column: ColumnPrice, Message: "must be a number of at least 0"},
+		{Line: 2, Column: ColumnAttributes, Message: `"color" is not a type:value pair`},
+		{Line: 4, Column: ColumnVariant, Message: "the variant is already on line 3"},
+		{Line: 5, Column: ColumnProduct, Message: "must be 2 to 16 characters"},
+	}, rowErrors)
+}
+
+func TestReadProductWithoutVariants(t *testing.T) {
+	file := `product,category,variant,price,stock,attributes,images
+Runner,Shoes,,,,,runner.jpg
+Trail,Shoes,,10,,color:red,
+`
+	rows, rowErrors, err := Read(strings.NewReader(file))
+
+	assert.NoError(t, err)
+	if assert.Len(t, rows, 1) {
+		assert.Empty(t, rows[0].Variant)
+		assert.Equal(t, []string{"runner.jpg"}, rows[0].Images)
+		assert.Equal(t, []string{"Runner", "", "Shoes", "", "", "", "", "runner.jpg"}, Record(rows[0]))
+	}
+	assert.Equal(t, []RowError{
+		{Line: 3, Column: ColumnPrice, Message: "must be empty without a variant"},
+		{Line: 3, Column: ColumnAttributes, Message: "must be empty without a variant"},
+	}, rowErrors)
+}
+
+func TestReadReportsMalformedRecords(t *testing.T) {
+	file := "product,category,variant,price\nRunner,Shoes,Runner 42\nRunner,Shoes,Runner 43,10\n"
+
+	rows, rowErrors, err := Read(strings.NewReader(file))
+
+	assert.NoError(t, err)
+	assert.Len(t, rows, 1)
+	assert.Len(t, rowErrors, 1)
+	assert.Equal(t, 2, rowErrors[0].Line)
+}
+
+func TestReadRejectsInvalidHeader(t *testing.T) {
+	for _, file := range []string{"", "product,category,variant\n", "product,category,variant,price,colour\n"} {
+		_, _, err := Read(strings.NewReader(file))
+
+		assert.True(t, errors.Is(err, ErrHeader), file)
+	}
+}
+
+func TestRecordIsReadBack(t *testing.T) {
+	stock := 2.5
+	written := []Row{
+		{Line: 2, Product: "Runner", Description: "A shoe, for running", Category: "Shoes", Variant: "Runner 42", Price: 499.9, Stock: &stock,
+			Attributes: []Attribute{{Type: "color", Value: "red"}, {Type: "size", Value: "42"}}, Images: []string{"runner.jpg", "runner-side.jpg"}},
+		{Line: 3, Product: "Runner", Category: "Shoes", Variant: "Runner 43", Price: 0},
+	}
+
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+	assert.NoError(t, writer.Write(Header()))
+	for _, row := range written {
+		assert.NoError(t, writer.Write(Record(row)))
+	}
+	writer.Flush()
+
+	rows, rowErrors, err := Read(&buf)
+
+	assert.NoError(t, err)
+	assert.Empty(t, rowErrors)
+	assert.Equal(t, written, rows)
+}
@@ -0,0 +1,351 @@
+// Package feed writes product feeds for shopping engines, Google Merchant
+// Center RSS 2.0 and tab separated files.
+//
+// Every variant is an item, grouped into its product by item_group_id. The
+// fields of an item are templates with placeholders:
+//
+//	{product_id} {product} {description} {category}
+//	{variant_id} {variant} {attribute:TYPE}
+//	{price} {availability} {base_url} {image_link} {additional_image_link}
+//
+// e.g. title={product} {variant} or brand={attribute:brand}. A field which
+// comes out empty is left out, an item missing a required field is left out
+// of the feed and reported instead.
+package feed
+
+import (
+	"bufio"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Required are the fields Google requires of every item.
+var Required = []string{"id", "title", "description", "link", "image_link", "price", "availability"}
+
+var ErrFields = errors.New("invalid feed fields")
+
+var ErrConfig = errors.New("invalid feed config")
+
+type Field struct {
+	Name     string
+	Template string
+}
+
+// DefaultFields are the fields of a feed unless configured otherwise.
+func DefaultFields() []Field {
+	return []Field{
+		{Name: "id", Template: "{variant_id}"},
+		{Name: "item_group_id", Template: "{product_id}"},
+		{Name: "title", Template: "{product} {variant}"},
+		{Name: "description", Template: "{description}"},
+		{Name: "link", Template: "{base_url}/products/{product_id}?variant={variant_id}"},
+		{Name: "image_link", Template: "{image_link}"},
+		{Name: "additional_image_link", Template: "{additional_image_link}"},
+		{Name: "price", Template: "{price}"},
+		{Name: "availability", Template: "{availability}"},
+		{Name: "condition", Template: "new"},
+		{Name: "product_type", Template: "{category}"},
+		{Name: "color", Template: "{attribute:color}"},
+		{Name: "size", Template: "{attribute:size}"},
+	}
+}
+
+var fieldName = regexp.MustCompile(`^[a-z_]+$`)
+
+var placeholder = regexp.MustCompile(`\{([a-z_]+)(?::([^{}]+))?\}`)
+
+var placeholders = map[string]bool{
+	"product_id":            true,
+	"product":               true,
+	"description":           true,
+	"category":              true,
+	"variant_id":            true,
+	"variant":               true,
+	"attribute":             true,
+	"price":                 true,
+	"availability":          true,
+	"base_url":              true,
+	"image_link":            true,
+	"additional_image_link": true,
+}
+
+// ParseFields applies a comma separated list of name=template mappings to
+// fields. A mapping replaces the template of a field or adds a field, an
+// empty template removes an optional field.
+func ParseFields(spec string, fields []Field) ([]Field, error) {
+	fields = append([]Field(nil), fields...)
+	if len(strings.TrimSpace(spec)) == 0 {
+		return fields, nil
+	}
+
+	for _, mapping := range strings.Split(spec, ",") {
+		i := strings.Index(mapping, "=")
+		if i < 0 {
+			return nil, fmt.Errorf("%w: %q is not a name=template mapping", ErrFields, mapping)
+		}
+		name := strings.TrimSpace(mapping[:i])
+		template := strings.TrimSpace(mapping[i+1:])
+		if !fieldName.MatchString(name) {
+			return nil, fmt.Errorf("%w: %q is not a field name", ErrFields, name)
+		}
+		for _, match := range placeholder.FindAllStringSubmatch(template, -1) {
+			if !placeholders[match[1]] || (match[1] == "attribute") != (len(match[2]) > 0) {
+				return nil, fmt.Errorf("%w: unknown placeholder %q", ErrFields, match[0])
+			}
+		}
+
+		index := -1
+		for j, field := range fields {
+			if field.Name == name {
+				index = j
+			}
+		}
+		switch {
+		case len(template) == 0 && contains(Required, name):
+			return nil, fmt.Errorf("%w: %q is required", ErrFields, name)
+		case len(template) == 0 && index >= 0:
+			fields = append(fields[:index], fields[index+1:]...)
+		case len(template) == 0:
+		case index >= 0:
+			fields[index].Template = template
+		default:
+			fields = append(fields, Field{Name: name, Template: template})
+		}
+	}
+	return fields, nil
+}
+
+// Item is a variant with its product.
+type Item struct {
+	ProductID   int
+	Product     string
+	Description string
+	Category    string
+	VariantID   int
+	Variant     string
+	Price       float64
+	Available   float64
+	// Attributes maps attribute types to the name of the attribute
+	Attributes map[string]string
+	// Images are the urls of the product images, relative to the base url
+	// unless absolute
+	Images []string
+}
+
+type Config struct {
+	BaseURL     string
+	Currency    string
+	Title       string
+	Description string
+	Fields      []Field
+}
+
+var currencyCode = regexp.MustCompile(`^[A-Z]{3}$`)
+
+// Validate checks that BaseURL is an absolute http or https url and Currency
+// an ISO 4217 code. The link and price of every item are made of them, so an
+// empty value would still fill the required fields.
+func (c *Config) Validate() error {
+	if u, err := url.Parse(c.BaseURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || len(u.Host) == 0 {
+		return fmt.Errorf("%w: base url %q is not an absolute http or https url", ErrConfig, c.BaseURL)
+	}
+	if !currencyCode.MatchString(c.Currency) {
+		return fmt.Errorf("%w: currency %q is not an ISO 4217 code", ErrConfig, c.Currency)
+	}
+	return nil
+}
+
+type Value struct {
+	Name  string
+	Value string
+}
+
+// Entry returns the values of the fields of an item, in the order of the
+// fields. additional_image_link is repeated for every additional image.
+// missing lists the required fields which came out empty.
+func (c *Config) Entry(item Item) (values []Value, missing []string) {
+	images := make([]string, len(item.Images))
+	for i, image := range item.Images {
+		images[i] = c.url(image)
+	}
+
+	expand := func(template string, additionalImage string) string {
+		return strings.TrimSpace(placeholder.ReplaceAllStringFunc(template, func(match string) string {
+			parts := placeholder.FindStringSubmatch(match)
+			switch parts[1] {
+			case "product_id":
+				return strconv.Itoa(item.ProductID)
+			case "product":
+				return item.Product
+			case "description":
+				return item.Description
+			case "category":
+				return item.Category
+			case "variant_id":
+				return strconv.Itoa(item.VariantID)
+			case "variant":
+				return item.Variant
+			case "attribute":
+				return item.Attributes[strings.ToLower(parts[2])]
+			case "price":
+				return fmt.Sprintf("%.2f %s", item.Price, c.Currency)
+			case "availability":
+				if item.Available > 0 {
+					return "in_stock"
+				}
+				return "out_of_stock"
+			case "base_url":
+				return strings.TrimRight(c.BaseURL, "/")
+			case "image_link":
+				if len(images) > 0 {
+					return images[0]
+				}
+				return ""
+			case "additional_image_link":
+				return additionalImage
+			}
+			return match
+		}))
+	}
+
+	for _, field := range c.Fields {
+		if strings.Contains(field.Template, "{additional_image_link}") {
+			for i := 1; i < len(images); i++ {
+				if value := expand(field.Template, images[i]); len(value) > 0 {
+					values = append(values, Value{Name: field.Name, Value: value})
+				}
+			}
+			continue
+		}
+
+		if value := expand(field.Template, ""); len(value) > 0 {
+			values = append(values, Value{Name: field.Name, Value: value})
+		} else if contains(Required, field.Name) {
+			missing = append(missing, field.Name)
+		}
+	}
+	return values, missing
+}
+
+// url makes an image url absolute.
+func (c *Config) url(image string) string {
+	if strings.HasPrefix(image, "http://") || strings.HasPrefix(image, "https://") {
+		return image
+	}
+	return strings.TrimRight(c.BaseURL, "/") + "/" + strings.TrimLeft(image, "/")
+}
+
+// Writer writes the entries of a feed.
+type Writer interface {
+	Write(values []Value) error
+	Close() error
+}
+
+type xmlWriter struct {
+	w *bufio.Writer
+}
+
+// NewXMLWriter starts an RSS 2.0 feed with the g: namespace of Google
+// Merchant Center.
+func NewXMLWriter(w io.Writer, c *Config) (Writer, error) {
+	x := &xmlWriter{w: bufio.NewWriter(w)}
+	fmt.Fprint(x.w, `<?xml version="1.0" encoding="UTF-8"?>`+"\n")
+	fmt.Fprint(x.w, `<rss version="2.0" xmlns:g="http://base.google.com/ns/1.0">`+"\n")
+	fmt.Fprintf(x.w, "<channel>\n<title>%s</title>\n<link>%s</link>\n<description>%s</description>\n",
+		escape(c.Title), escape(c.BaseURL), escape(c.Description))
+	return x, nil
+}
+
+func (x *xmlWriter) Write(values []Value) error {
+	x.w.WriteString("<item>\n")
+	for _, value := range values {
+		fmt.Fprintf(x.w, "<g:%s>%s</g:%s>\n", value.Name, escape(value.Value), value.Name)
+	}
+	_, err := x.w.WriteString("</item>\n")
+	return err
+}
+
+func (x *xmlWriter) Close() error {
+	x.w.WriteString("</channel>\n</rss>\n")
+	return x.w.Flush()
+}
+
+type tsvWriter struct {
+	w      *csv.Writer
+	fields []string
+}
+
+// NewTSVWriter starts a tab separated feed with a header of the field names.
+// Repeated values of a field are separated by commas.
+func NewTSVWriter(w io.Writer, c *Config) (Writer, error) {
+	t := &tsvWriter{w: csv.NewWriter(w)}
+	t.w.Comma = '\t'
+	for _, field := range c.Fields {
+		t.fields = append(t.fields, field.Name)
+	}
+	if err := t.w.Write(t.fields); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+func (t *tsvWriter) Write(values []Value) error {
+	record := make([]string, len(t.fields))
+	for _, value := range values {
+		for i, name := range t.fields {
+			if name != value.Name {
+				continue
+			}
+			// tabs and line breaks are not allowed in a tsv feed
+			v := strings.Join(strings.Fields(value.Value), " ")
+			if len(record[i]) > 0 {
+				record[i] += ","
+			}
+			record[i] += v
+		}
+	}
+	return t.w.Write(record)
+}
+
+func (t *tsvWriter) Close() error {
+	t.w.Flush()
+	return t.w.Error()
+}
+
+func escape(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		switch {
+		case r == '&':
+			b.WriteString("&amp;")
+		case r == '<':
+			b.WriteString("&lt;")
+		case r == '>':
+			b.WriteString("&gt;")
+		case r == '"':
+			b.WriteString("&quot;")
+		case r == '\t' || r == '\n' || r == '\r':
+			b.WriteRune(r)
+		case r < 0x20 || r == 0xFFFE || r == 0xFFFF:
+			// not allowed in xml
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+func contains(items []string, item string) bool {
+	for _, i := range items {
+		if i == item {
+			return true
+		}
+	}
+	return false
+}
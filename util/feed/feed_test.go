@@ -0,0 +1,133 @@
+package feed
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func runner() Item {
+	return Item{
+		ProductID:   7,
+		Product:     "Runner",
+		Description: "A shoe for running",
+		Category:    "Shoes",
+		VariantID:   42,
+		Variant:     "Red 42",
+		Price:       499.9,
+		Available:   3,
+		Attributes:  map[string]string{"color": "red", "brand": "Acme"},
+		Images:      []string{"/public/images/a.jpg", "https://cdn.example.com/b.jpg"},
+	}
+}
+
+func TestEntry(t *testing.T) {
+	config := Config{BaseURL: "https://shop.example.com/", Currency: "TRY", Fields: DefaultFields()}
+
+	values, missing := config.Entry(runner())
+
+	assert.Empty(t, missing)
+	assert.Equal(t, []Value{
+		{Name: "id", Value: "42"},
+		{Name: "item_group_id", Value: "7"},
+		{Name: "title", Value: "Runner Red 42"},
+		{Name: "description", Value: "A shoe for running"},
+		{Name: "link", Value: "https://shop.example.com/products/7?variant=42"},
+		{Name: "image_link", Value: "https://shop.example.com/public/images/a.jpg"},
+		{Name: "additional_image_link", Value: "https://cdn.example.com/b.jpg"},
+		{Name: "price", Value: "499.90 TRY"},
+		{Name: "availability", Value: "in_stock"},
+		{Name: "condition", Value: "new"},
+		{Name: "product_type", Value: "Shoes"},
+		{Name: "color", Value: "red"},
+	}, values)
+}
+
+func TestEntryReportsMissingRequiredFields(t *testing.T) {
+	config := Config{BaseURL: "https://shop.example.com", Currency: "USD", Fields: DefaultFields()}
+	item := runner()
+	item.Description = ""
+	item.Images = nil
+	item.Available = 0
+
+	values, missing := config.Entry(item)
+
+	assert.Equal(t, []string{"description", "image_link"}, missing)
+	assert.Contains(t, values, Value{Name: "availability", Value: "out_of_stock"})
+}
+
+func TestValidate(t *testing.T) {
+	assert.NoError(t, (&Config{BaseURL: "https://shop.example.com", Currency: "USD"}).Validate())
+
+	for _, config := range []Config{
+		{BaseURL: "", Currency: "USD"},
+		{BaseURL: "/shop", Currency: "USD"},
+		{BaseURL: "ftp://shop.example.com", Currency: "USD"},
+		{BaseURL: "https://shop.example.com", Currency: ""},
+		{BaseURL: "https://shop.example.com", Currency: "usd"},
+	} {
+		assert.True(t, errors.Is(config.Validate(), ErrConfig), "%+v", config)
+	}
+}
+
+func TestParseFields(t *testing.T) {
+	fields, err := ParseFields("brand={attribute:Brand}, size=, title={product} - {variant}", DefaultFields())
+
+	assert.NoError(t, err)
+	assert.Contains(t, fields, Field{Name: "brand", Template: "{attribute:Brand}"})
+	assert.Contains(t, fields, Field{Name: "title", Template: "{product} - {variant}"})
+	assert.NotContains(t, fields, Field{Name: "size", Template: "{attribute:size}"})
+
+	config := Config{Currency: "USD", Fields: fields}
+	values, _ := config.Entry(runner())
+	assert.Contains(t, values, Value{Name: "brand", Value: "Acme"})
+	assert.Contains(t, values, Value{Name: "title", Value: "Runner - Red 42"})
+}
+
+func TestParseFieldsRejectsInvalidMappings(t *testing.T) {
+	for _, spec := range []string{"brand", "Brand=x", "title={name}", "brand={attribute}", "id="} {
+		_, err := ParseFields(spec, DefaultFields())
+		assert.True(t, errors.Is(err, ErrFields), spec)
+	}
+}
+
+func TestXMLWriter(t *testing.T) {
+	var buf bytes.Buffer
+	config := Config{BaseURL: "https://shop.example.com", Title: "Fish & Chips", Currency: "USD", Fields: DefaultFields()}
+	w, err := NewXMLWriter(&buf, &config)
+	assert.NoError(t, err)
+	assert.NoError(t, w.Write([]Value{{Name: "id", Value: "1"}, {Name: "title", Value: "<Cod>"}}))
+	assert.NoError(t, w.Close())
+
+	assert.Equal(t, `<?xml version="1.0" encoding="UTF-8"?>
+<rss version="2.0" xmlns:g="http://base.google.com/ns/1.0">
+<channel>
+<title>Fish &amp; Chips</title>
+<link>https://shop.example.com</link>
+<description></description>
+<item>
+<g:id>1</g:id>
+<g:title>&lt;Cod&gt;</g:title>
+</item>
+</channel>
+</rss>
+`, buf.String())
+}
+
+func TestTSVWriter(t *testing.T) {
+	var buf bytes.Buffer
+	config := Config{Fields: []Field{{Name: "id"}, {Name: "description"}, {Name: "additional_image_link"}}}
+	w, err := NewTSVWriter(&buf, &config)
+	assert.NoError(t, err)
+	assert.NoError(t, w.Write([]Value{
+		{Name: "id", Value: "1"},
+		{Name: "description", Value: "two\tlines\nof text"},
+		{Name: "additional_image_link", Value: "a.jpg"},
+		{Name: "additional_image_link", Value: "b.jpg"},
+	}))
+	assert.NoError(t, w.Close())
+
+	assert.Equal(t, "id\tdescription\tadditional_image_link\n1\ttwo lines of text\ta.jpg,b.jpg\n", buf.String())
+}
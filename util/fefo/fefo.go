@@ -0,0 +1,66 @@
+// Package fefo allocates quantities from lots first-expired-first-out.
+package fefo
+
+import (
+	"sort"
+	"time"
+)
+
+type Lot struct {
+	ID        int
+	Quantity  float64
+	ExpiresAt *time.Time
+}
+
+type Pick struct {
+	LotID    int
+	Quantity float64
+}
+
+// Allocate picks quantity from the lots that are not expired at now, taking
+// the lots that expire first before the later ones and lots without an expiry
+// date last. It returns the picks and the quantity the lots could not cover.
+func Allocate(lots []Lot, quantity float64, now time.Time) ([]Pick, float64) {
+	candidates := make([]Lot, 0, len(lots))
+	for _, lot := range lots {
+		if lot.Quantity <= 0 {
+			continue
+		}
+		if lot.ExpiresAt != nil && lot.ExpiresAt.Before(now) {
+			continue
+		}
+		candidates = append(candidates, lot)
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		a, b := candidates[i].ExpiresAt, candidates[j].ExpiresAt
+		switch {
+		case a == nil && b == nil:
+			return candidates[i].ID < candidates[j].ID
+		case a == nil:
+			return false
+		case b == nil:
+			return true
+		case !a.Equal(*b):
+			return a.Before(*b)
+		default:
+			return candidates[i].ID < candidates[j].ID
+		}
+	})
+
+	var picks []Pick
+	for _, lot := range candidates {
+		if quantity <= 0 {
+			break
+		}
+
+		take := lot.Quantity
+		if take > quantity {
+			take = quantity
+		}
+		picks = append(picks, Pick{LotID: lot.ID, Quantity: take})
+		quantity -= take
+	}
+
+	return picks, quantity
+}
@@ -0,0 +1,51 @@
+package fefo
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func date(year int, month time.Month, day int) *time.Time {
+	t := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	return &t
+}
+
+func TestAllocateFirstExpiredFirstOut(t *testing.T) {
+	lots := []Lot{
+		{ID: 1, Quantity: 12, ExpiresAt: date(2027, time.June, 1)},
+		{ID: 2, Quantity: 5, ExpiresAt: nil},
+		{ID: 3, Quantity: 40, ExpiresAt: date(2027, time.March, 1)},
+	}
+
+	picks, short := Allocate(lots, 45, time.Date(2026, time.October, 19, 0, 0, 0, 0, time.UTC))
+
+	assert.Equal(t, 0.0, short)
+	assert.Equal(t, []Pick{{LotID: 3, Quantity: 40}, {LotID: 1, Quantity: 5}}, picks)
+}
+
+func TestAllocateSkipsExpiredAndEmptyLots(t *testing.T) {
+	lots := []Lot{
+		{ID: 1, Quantity: 10, ExpiresAt: date(2026, time.January, 1)},
+		{ID: 2, Quantity: 0, ExpiresAt: date(2027, time.January, 1)},
+		{ID: 3, Quantity: 4, ExpiresAt: nil},
+	}
+
+	picks, short := Allocate(lots, 6, time.Date(2026, time.October, 19, 0, 0, 0, 0, time.UTC))
+
+	assert.Equal(t, 2.0, short)
+	assert.Equal(t, []Pick{{LotID: 3, Quantity: 4}}, picks)
+}
+
+func TestAllocateFractionalQuantities(t *testing.T) {
+	lots := []Lot{
+		{ID: 1, Quantity: 1.25, ExpiresAt: date(2027, time.March, 1)},
+		{ID: 2, Quantity: 4, ExpiresAt: date(2027, time.June, 1)},
+	}
+
+	picks, short := Allocate(lots, 2.5, time.Date(2026, time.October, 19, 0, 0, 0, 0, time.UTC))
+
+	assert.Equal(t, 0.0, short)
+	assert.Equal(t, []Pick{{LotID: 1, Quantity: 1.25}, {LotID: 2, Quantity: 1.25}}, picks)
+}
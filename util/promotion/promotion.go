@@ -0,0 +1,242 @@
+// Package promotion applies discount rules to the lines of an order.
+//
+// Percentage and buy x get y rules discount the lines they target, order
+// rules take an amount off the whole order once its subtotal reaches a
+// minimum. Rules are applied by priority, every discount is taken from what
+// is left of the line after the discounts before it, so a line never goes
+// below zero. Exclusive rules never combine with other rules, the evaluation
+// picks whichever of all non exclusive rules together or a single exclusive
+// rule gives the biggest discount.
+package promotion
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+type Kind string
+
+const (
+	PercentOff     Kind = "percent_off"
+	BuyXGetY       Kind = "buy_x_get_y"
+	AmountOffOrder Kind = "amount_off_order"
+)
+
+// Rule is a promotion. A line rule targets the lines matching all of its
+// non-nil targets, every line if it has none.
+type Rule struct {
+	ID   int
+	Name string
+	Kind Kind
+	// Percent is the percentage taken off a PercentOff line
+	Percent float64
+	// BuyQuantity and GetQuantity of a BuyXGetY rule make GetQuantity of
+	// every BuyQuantity + GetQuantity units of a line free
+	BuyQuantity int
+	GetQuantity int
+	// Amount is taken off the order by an AmountOffOrder rule once the
+	// order subtotal after line discounts is at least MinSubtotal
+	Amount      float64
+	MinSubtotal float64
+
+	CategoryID       *int
+	ProductID        *int
+	ProductVariantID *int
+	AttributeID      *int
+
+	Exclusive bool
+	// Priority orders the rules of a kind, higher first
+	Priority int
+}
+
+type Line struct {
+	ProductVariantID int
+	ProductID        int
+	CategoryID       int
+	AttributeIDs     []int
+	Quantity         float64
+	UnitPrice        float64
+}
+
+type Discount struct {
+	RuleID      int
+	Amount      float64
+	Explanation string
+}
+
+type LineResult struct {
+	Line
+	Subtotal  float64
+	Discounts []Discount
+	Total     float64
+}
+
+type Result struct {
+	Lines    []LineResult
+	Subtotal float64
+	Discount float64
+	Total    float64
+	// Applied holds the ids of the rules which discounted anything
+	Applied map[int]bool
+}
+
+// Evaluate applies the rules to the lines.
+func Evaluate(rules []Rule, lines []Line) Result {
+	var stackable []Rule
+	var exclusive []Rule
+	for _, rule := range rules {
+		if rule.Exclusive {
+			exclusive = append(exclusive, rule)
+		} else {
+			stackable = append(stackable, rule)
+		}
+	}
+
+	best := apply(stackable, lines)
+	for _, rule := range exclusive {
+		if result := apply([]Rule{rule}, lines); result.Discount > best.Discount {
+			best = result
+		}
+	}
+	return best
+}
+
+func apply(rules []Rule, lines []Line) Result {
+	result := Result{
+		Lines:   make([]LineResult, len(lines)),
+		Applied: make(map[int]bool),
+	}
+	for i, line := range lines {
+		subtotal := round(line.Quantity * line.UnitPrice)
+		result.Lines[i] = LineResult{Line: line, Subtotal: subtotal, Total: subtotal}
+		result.Subtotal += subtotal
+	}
+
+	rules = append([]Rule(nil), rules...)
+	sort.SliceStable(rules, func(i, j int) bool {
+		if order(rules[i].Kind) != order(rules[j].Kind) {
+			return order(rules[i].Kind) < order(rules[j].Kind)
+		}
+		if rules[i].Priority != rules[j].Priority {
+			return rules[i].Priority > rules[j].Priority
+		}
+		return rules[i].ID < rules[j].ID
+	})
+
+	for _, rule := range rules {
+		if rule.Kind == AmountOffOrder {
+			applyOrder(rule, &result)
+			continue
+		}
+
+		for i := range result.Lines {
+			line := &result.Lines[i]
+			if !matches(rule, line.Line) {
+				continue
+			}
+
+			var amount float64
+			var explanation string
+			switch rule.Kind {
+			case PercentOff:
+				amount = line.Total * rule.Percent / 100
+				explanation = fmt.Sprintf("%s: %g%% off", rule.Name, rule.Percent)
+			case BuyXGetY:
+				if rule.BuyQuantity <= 0 || rule.GetQuantity <= 0 {
+					continue
+				}
+				free := math.Floor(line.Quantity/float64(rule.BuyQuantity+rule.GetQuantity)) * float64(rule.GetQuantity)
+				amount = free * line.UnitPrice
+				explanation = fmt.Sprintf("%s: buy %d get %d free, %g free", rule.Name, rule.BuyQuantity, rule.GetQuantity, free)
+			}
+			discount(line, rule.ID, amount, explanation, &result)
+		}
+	}
+
+	result.Subtotal = round(result.Subtotal)
+	result.Discount = round(result.Discount)
+	result.Total = round(result.Subtotal - result.Discount)
+	return result
+}
+
+// applyOrder spreads the amount of an order rule over the lines in
+// proportion to what is left of them.
+func applyOrder(rule Rule, result *Result) {
+	var total float64
+	for _, line := range result.Lines {
+		total += line.Total
+	}
+	if total <= 0 || total < rule.MinSubtotal {
+		return
+	}
+
+	amount := math.Min(rule.Amount, total)
+	explanation := fmt.Sprintf("%s: %g off orders over %g, share of this line", rule.Name, rule.Amount, rule.MinSubtotal)
+	left := amount
+	last := -1
+	for i, line := range result.Lines {
+		if line.Total > 0 {
+			last = i
+		}
+	}
+	for i := range result.Lines {
+		line := &result.Lines[i]
+		if line.Total <= 0 {
+			continue
+		}
+		share := round(amount * line.Total / total)
+		if i == last {
+			// the rounding rest
+			share = round(left)
+		}
+		left -= share
+		discount(line, rule.ID, share, explanation, result)
+	}
+}
+
+func discount(line *LineResult, ruleID int, amount float64, explanation string, result *Result) {
+	amount = round(math.Min(amount, line.Total))
+	if amount <= 0 {
+		return
+	}
+
+	line.Discounts = append(line.Discounts, Discount{RuleID: ruleID, Amount: amount, Explanation: explanation})
+	line.Total = round(line.Total - amount)
+	result.Discount += amount
+	result.Applied[ruleID] = true
+}
+
+func matches(rule Rule, line Line) bool {
+	if rule.CategoryID != nil && *rule.CategoryID != line.CategoryID {
+		return false
+	}
+	if rule.ProductID != nil && *rule.ProductID != line.ProductID {
+		return false
+	}
+	if rule.ProductVariantID != nil && *rule.ProductVariantID != line.ProductVariantID {
+		return false
+	}
+	if rule.AttributeID != nil {
+		for _, attributeID := range line.AttributeIDs {
+			if attributeID == *rule.AttributeID {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+// order applies the line rules before the order rules.
+func order(kind Kind) int {
+	if kind == AmountOffOrder {
+		return 1
+	}
+	return 0
+}
+
+// round rounds to the precision of the prices in the database.
+func round(x float64) float64 {
+	return math.Round(x*1e4) / 1e4
+}
@@ -0,0 +1,88 @@
+package promotion
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func id(i int) *int {
+	return &i
+}
+
+var shoes = Line{ProductVariantID: 1, ProductID: 1, CategoryID: 7, Quantity: 2, UnitPrice: 300}
+var socks = Line{ProductVariantID: 2, ProductID: 2, CategoryID: 8, AttributeIDs: []int{5}, Quantity: 3, UnitPrice: 20}
+
+func TestEvaluatePercentOffCategory(t *testing.T) {
+	rules := []Rule{{ID: 1, Name: "Shoes", Kind: PercentOff, Percent: 10, CategoryID: id(7)}}
+
+	result := Evaluate(rules, []Line{shoes, socks})
+
+	assert.Equal(t, 660.0, result.Subtotal)
+	assert.Equal(t, 60.0, result.Discount)
+	assert.Equal(t, 600.0, result.Total)
+	assert.Equal(t, []Discount{{RuleID: 1, Amount: 60, Explanation: "Shoes: 10% off"}}, result.Lines[0].Discounts)
+	assert.Empty(t, result.Lines[1].Discounts)
+}
+
+func TestEvaluateBuyXGetY(t *testing.T) {
+	rules := []Rule{{ID: 2, Name: "Socks", Kind: BuyXGetY, BuyQuantity: 2, GetQuantity: 1, AttributeID: id(5)}}
+
+	result := Evaluate(rules, []Line{shoes, socks})
+
+	assert.Equal(t, 20.0, result.Discount)
+	assert.Equal(t, 40.0, result.Lines[1].Total)
+	assert.Equal(t, "Socks: buy 2 get 1 free, 1 free", result.Lines[1].Discounts[0].Explanation)
+}
+
+func TestEvaluateAmountOffOrderAfterLineDiscounts(t *testing.T) {
+	rules := []Rule{
+		{ID: 3, Name: "Big order", Kind: AmountOffOrder, Amount: 50, MinSubtotal: 500},
+		{ID: 1, Name: "Shoes", Kind: PercentOff, Percent: 10, CategoryID: id(7)},
+	}
+
+	result := Evaluate(rules, []Line{shoes, socks})
+
+	// 540 + 60 left after the percentage, 50 spread 45 and 5
+	assert.Equal(t, 110.0, result.Discount)
+	assert.Equal(t, 550.0, result.Total)
+	assert.Equal(t, 45.0, result.Lines[0].Discounts[1].Amount)
+	assert.Equal(t, 5.0, result.Lines[1].Discounts[0].Amount)
+	assert.True(t, result.Applied[1])
+	assert.True(t, result.Applied[3])
+}
+
+func TestEvaluateAmountOffOrderBelowMinimum(t *testing.T) {
+	rules := []Rule{{ID: 3, Name: "Big order", Kind: AmountOffOrder, Amount: 50, MinSubtotal: 500}}
+
+	result := Evaluate(rules, []Line{socks})
+
+	assert.Equal(t, 0.0, result.Discount)
+	assert.False(t, result.Applied[3])
+}
+
+func TestEvaluatePicksBestExclusiveRule(t *testing.T) {
+	rules := []Rule{
+		{ID: 1, Name: "Shoes", Kind: PercentOff, Percent: 10, CategoryID: id(7)},
+		{ID: 4, Name: "Half price", Kind: PercentOff, Percent: 50, Exclusive: true},
+		{ID: 5, Name: "Tiny", Kind: PercentOff, Percent: 1, Exclusive: true},
+	}
+
+	result := Evaluate(rules, []Line{shoes, socks})
+
+	assert.Equal(t, 330.0, result.Discount)
+	assert.Equal(t, map[int]bool{4: true}, result.Applied)
+}
+
+func TestEvaluateNeverBelowZero(t *testing.T) {
+	rules := []Rule{
+		{ID: 1, Name: "All", Kind: PercentOff, Percent: 100},
+		{ID: 3, Name: "Order", Kind: AmountOffOrder, Amount: 50},
+	}
+
+	result := Evaluate(rules, []Line{socks})
+
+	assert.Equal(t, 60.0, result.Discount)
+	assert.Equal(t, 0.0, result.Total)
+	assert.False(t, result.Applied[3])
+}
@@ -0,0 +1,201 @@
+// Package rendition resizes uploaded images into renditions, smaller copies
+// which keep the aspect ratio and are re-encoded at a controlled quality.
+package rendition
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	_ "image/gif" // gif is decoded as its first frame
+	"image/jpeg"
+	"image/png"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/webp" // decodes without cgo
+)
+
+// The formats renditions are encoded in.
+const (
+	JPEG = "jpeg"
+	PNG  = "png"
+	WebP = "webp"
+)
+
+// DefaultQuality is the JPEG and WebP quality, from 1 to 100.
+const DefaultQuality = 85
+
+var (
+	ErrSpec   = errors.New("invalid rendition spec")
+	ErrFormat = errors.New("unsupported image format")
+)
+
+var namePattern = regexp.MustCompile(`^[a-z0-9_]+$`)
+
+// Spec is a rendition, the image is scaled down to fit in Width x Height.
+type Spec struct {
+	Name   string
+	Width  int
+	Height int
+}
+
+// Config is what to render, every spec is also rendered as WebP if WebP is
+// set, which needs WebPSupported.
+type Config struct {
+	Specs   []Spec
+	Quality int
+	WebP    bool
+}
+
+// Rendition is an encoded rendition of an image.
+type Rendition struct {
+	Name   string
+	Format string
+	Width  int
+	Height int
+	Data   []byte
+}
+
+func DefaultSpecs() []Spec {
+	return []Spec{
+		{Name: "thumbnail", Width: 200, Height: 200},
+		{Name: "medium", Width: 640, Height: 640},
+		{Name: "large", Width: 1280, Height: 1280},
+	}
+}
+
+// ParseSpecs parses comma separated name:WIDTHxHEIGHT specs, e.g.
+// thumbnail:200x200,large:1280x1280. An empty spec is DefaultSpecs.
+func ParseSpecs(spec string) ([]Spec, error) {
+	if len(strings.TrimSpace(spec)) == 0 {
+		return DefaultSpecs(), nil
+	}
+
+	var specs []Spec
+	names := make(map[string]bool)
+	for _, field := range strings.Split(spec, ",") {
+		field = strings.TrimSpace(field)
+		parts := strings.SplitN(field, ":", 2)
+		if len(parts) != 2 || !namePattern.MatchString(parts[0]) {
+			return nil, fmt.Errorf("%w: %q", ErrSpec, field)
+		}
+		if names[parts[0]] {
+			return nil, fmt.Errorf("%w: %s is repeated", ErrSpec, parts[0])
+		}
+		size := strings.SplitN(parts[1], "x", 2)
+		if len(size) != 2 {
+			return nil, fmt.Errorf("%w: %q", ErrSpec, field)
+		}
+		width, err := strconv.Atoi(size[0])
+		if err != nil || width <= 0 {
+			return nil, fmt.Errorf("%w: %q", ErrSpec, field)
+		}
+		height, err := strconv.Atoi(size[1])
+		if err != nil || height <= 0 {
+			return nil, fmt.Errorf("%w: %q", ErrSpec, field)
+		}
+		names[parts[0]] = true
+		specs = append(specs, Spec{Name: parts[0], Width: width, Height: height})
+	}
+	return specs, nil
+}
+
+// Fit returns the size of a width x height image scaled down to fit in
+// maxWidth x maxHeight keeping its aspect ratio. Smaller images keep their
+// size.
+func Fit(width int, height int, maxWidth int, maxHeight int) (int, int) {
+	if width <= maxWidth && height <= maxHeight {
+		return width, height
+	}
+	// compare maxWidth / width with maxHeight / height without rounding
+	if maxWidth*height <= maxHeight*width {
+		return maxWidth, atLeastOne((height*maxWidth + width/2) / width)
+	}
+	return atLeastOne((width*maxHeight + height/2) / height), maxHeight
+}
+
+// Decode decodes a JPEG, PNG, GIF or WebP image and returns its format.
+func Decode(r io.Reader) (image.Image, string, error) {
+	img, format, err := image.Decode(r)
+	if err != nil {
+		return nil, "", fmt.Errorf("%w: %v", ErrFormat, err)
+	}
+	return img, format, nil
+}
+
+// Render renders every spec of config from img. Images with transparency are
+// encoded as PNG, others as JPEG.
+func Render(img image.Image, config *Config) ([]*Rendition, error) {
+	format := JPEG
+	if opaque, ok := img.(interface{ Opaque() bool }); ok && !opaque.Opaque() {
+		format = PNG
+	}
+	quality := config.Quality
+	if quality < 1 || quality > 100 {
+		quality = DefaultQuality
+	}
+
+	bounds := img.Bounds()
+	var renditions []*Rendition
+	for _, spec := range config.Specs {
+		width, height := Fit(bounds.Dx(), bounds.Dy(), spec.Width, spec.Height)
+		resized := image.NewNRGBA(image.Rect(0, 0, width, height))
+		draw.CatmullRom.Scale(resized, resized.Bounds(), img, bounds, draw.Src, nil)
+
+		formats := []string{format}
+		if config.WebP {
+			formats = append(formats, WebP)
+		}
+		for _, format := range formats {
+			data, err := encode(resized, format, quality)
+			if err != nil {
+				return nil, err
+			}
+			renditions = append(renditions, &Rendition{
+				Name:   spec.Name,
+				Format: format,
+				Width:  width,
+				Height: height,
+				Data:   data,
+			})
+		}
+	}
+	return renditions, nil
+}
+
+// Extension returns the file extension of format.
+func Extension(format string) string {
+	if format == JPEG {
+		return "jpg"
+	}
+	return format
+}
+
+func encode(img image.Image, format string, quality int) ([]byte, error) {
+	var buf bytes.Buffer
+	var err error
+	switch format {
+	case JPEG:
+		err = jpeg.Encode(&buf, img, &jpeg.Options{Quality: quality})
+	case PNG:
+		encoder := png.Encoder{CompressionLevel: png.BestCompression}
+		err = encoder.Encode(&buf, img)
+	case WebP:
+		return encodeWebP(img, quality)
+	default:
+		err = fmt.Errorf("%w: %s", ErrFormat, format)
+	}
+	return buf.Bytes(), err
+}
+
+// atLeastOne keeps a side of a very narrow image from being scaled to 0.
+func atLeastOne(n int) int {
+	if n < 1 {
+		return 1
+	}
+	return n
+}
@@ -0,0 +1,127 @@
+package rendition
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSpecs(t *testing.T) {
+	specs, err := ParseSpecs("")
+	assert.NoError(t, err)
+	assert.Equal(t, DefaultSpecs(), specs)
+
+	specs, err = ParseSpecs("thumbnail:150x100, large:1600x1200")
+	assert.NoError(t, err)
+	assert.Equal(t, []Spec{{"thumbnail", 150, 100}, {"large", 1600, 1200}}, specs)
+
+	for _, spec := range []string{
+		"thumbnail",
+		"thumbnail:150",
+		"thumbnail:0x100",
+		"thumbnail:150x-1",
+		"Thumb Nail:150x100",
+		"thumbnail:150x100,thumbnail:300x300",
+	} {
+		_, err := ParseSpecs(spec)
+		assert.True(t, errors.Is(err, ErrSpec), spec)
+	}
+}
+
+func TestFit(t *testing.T) {
+	for _, test := range []struct {
+		width, height, maxWidth, maxHeight int
+		expectedWidth, expectedHeight      int
+	}{
+		{4000, 3000, 200, 200, 200, 150},
+		{3000, 4000, 200, 200, 150, 200},
+		{1000, 1000, 640, 480, 480, 480},
+		{100, 50, 200, 200, 100, 50},
+		{10000, 10, 200, 200, 200, 1},
+		{1001, 1000, 200, 200, 200, 200},
+	} {
+		width, height := Fit(test.width, test.height, test.maxWidth, test.maxHeight)
+		assert.Equal(t, test.expectedWidth, width)
+		assert.Equal(t, test.expectedHeight, height)
+	}
+}
+
+func encodePNG(t *testing.T, img image.Image) *bytes.Buffer {
+	var buf bytes.Buffer
+	assert.NoError(t, png.Encode(&buf, img))
+	return &buf
+}
+
+func TestRender(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 400, 300))
+	for x := 0; x < 400; x++ {
+		for y := 0; y < 300; y++ {
+			img.Set(x, y, color.RGBA{R: uint8(x), G: uint8(y), B: 128, A: 255})
+		}
+	}
+
+	decoded, format, err := Decode(encodePNG(t, img))
+	assert.NoError(t, err)
+	assert.Equal(t, "png", format)
+
+	type expectedRendition struct {
+		name, format  string
+		width, height int
+	}
+	expectedRenditions := []expectedRendition{
+		{"thumbnail", JPEG, 100, 75},
+		{"thumbnail", WebP, 100, 75},
+		{"large", JPEG, 400, 300},
+		{"large", WebP, 400, 300},
+	}
+	if !WebPSupported {
+		expectedRenditions = []expectedRendition{expectedRenditions[0], expectedRenditions[2]}
+	}
+
+	renditions, err := Render(decoded, &Config{
+		Specs: []Spec{{"thumbnail", 100, 100}, {"large", 800, 800}},
+		WebP:  WebPSupported,
+	})
+	assert.NoError(t, err)
+	assert.Len(t, renditions, len(expectedRenditions))
+
+	for i, expected := range expectedRenditions {
+		rendition := renditions[i]
+		assert.Equal(t, expected.name, rendition.Name)
+		assert.Equal(t, expected.format, rendition.Format)
+		assert.Equal(t, expected.width, rendition.Width)
+		assert.Equal(t, expected.height, rendition.Height)
+
+		decoded, format, err := Decode(bytes.NewReader(rendition.Data))
+		assert.NoError(t, err)
+		assert.Equal(t, expected.format, format)
+		assert.Equal(t, image.Rect(0, 0, expected.width, expected.height), decoded.Bounds())
+	}
+}
+
+func TestRenderKeepsTransparency(t *testing.T) {
+	img := image.NewNRGBA(image.Rect(0, 0, 10, 10))
+	renditions, err := Render(img, &Config{Specs: []Spec{{"thumbnail", 5, 5}}})
+	assert.NoError(t, err)
+	assert.Len(t, renditions, 1)
+	assert.Equal(t, PNG, renditions[0].Format)
+	assert.Equal(t, "png", Extension(renditions[0].Format))
+}
+
+func TestRenderWithoutWebPEncoder(t *testing.T) {
+	if WebPSupported {
+		t.Skip("built with cgo")
+	}
+	_, err := Render(image.NewRGBA(image.Rect(0, 0, 10, 10)), &Config{Specs: DefaultSpecs(), WebP: true})
+	assert.True(t, errors.Is(err, ErrFormat))
+}
+
+func TestDecodeRejectsOtherFiles(t *testing.T) {
+	_, _, err := Decode(bytes.NewReader([]byte("%PDF-1.4")))
+	assert.True(t, errors.Is(err, ErrFormat))
+}
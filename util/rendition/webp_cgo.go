@@ -0,0 +1,18 @@
+//go:build cgo
+// +build cgo
+
+package rendition
+
+import (
+	"image"
+
+	"github.com/chai2010/webp"
+)
+
+// WebPSupported is whether renditions can be encoded as WebP, the encoder
+// needs cgo.
+const WebPSupported = true
+
+func encodeWebP(img image.Image, quality int) ([]byte, error) {
+	return webp.EncodeRGBA(img, float32(quality))
+}
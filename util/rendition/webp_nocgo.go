@@ -0,0 +1,17 @@
+//go:build !cgo
+// +build !cgo
+
+package rendition
+
+import (
+	"fmt"
+	"image"
+)
+
+// WebPSupported is whether renditions can be encoded as WebP, the encoder
+// needs cgo.
+const WebPSupported = false
+
+func encodeWebP(img image.Image, quality int) ([]byte, error) {
+	return nil, fmt.Errorf("%w: %s needs a build with cgo", ErrFormat, WebP)
+}
@@ -0,0 +1,175 @@
+// Package snapshot reads and writes catalog snapshots, gzipped tar archives
+// of JSON documents and the files they refer to.
+package snapshot
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"time"
+)
+
+// Version is the version of the archive layout written.
+const Version = 1
+
+// ManifestName is the first entry of an archive.
+const ManifestName = "manifest.json"
+
+// The strategies to resolve an imported row named like an existing one.
+const (
+	// Skip keeps the existing row and uses it instead
+	Skip = "skip"
+	// Overwrite updates the existing row with the imported one
+	Overwrite = "overwrite"
+	// Rename imports the row under a new name
+	Rename = "rename"
+)
+
+var (
+	ErrArchive  = errors.New("invalid snapshot archive")
+	ErrStrategy = errors.New("invalid conflict strategy")
+)
+
+type Manifest struct {
+	Version   int       `json:"version"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// ParseStrategy returns the strategy, Skip if it is empty.
+func ParseStrategy(strategy string) (string, error) {
+	switch strategy {
+	case "":
+		return Skip, nil
+	case Skip, Overwrite, Rename:
+		return strategy, nil
+	default:
+		return "", fmt.Errorf("%w: %q", ErrStrategy, strategy)
+	}
+}
+
+type Writer struct {
+	gzip *gzip.Writer
+	tar  *tar.Writer
+	now  time.Time
+}
+
+// NewWriter starts an archive on w with its manifest.
+func NewWriter(w io.Writer) (*Writer, error) {
+	gz := gzip.NewWriter(w)
+	writer := &Writer{
+		gzip: gz,
+		tar:  tar.NewWriter(gz),
+		now:  time.Now().UTC(),
+	}
+	if err := writer.WriteJSON(ManifestName, &Manifest{Version: Version, CreatedAt: writer.now}); err != nil {
+		return nil, err
+	}
+	return writer, nil
+}
+
+// WriteJSON adds v as the JSON document name.
+func (w *Writer) WriteJSON(name string, v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	if err := w.tar.WriteHeader(&tar.Header{
+		Name:    name,
+		Mode:    0644,
+		Size:    int64(len(data)),
+		ModTime: w.now,
+	}); err != nil {
+		return err
+	}
+	_, err = w.tar.Write(data)
+	return err
+}
+
+// WriteFile adds the size bytes of r as the file name.
+func (w *Writer) WriteFile(name string, size int64, r io.Reader) error {
+	if err := w.tar.WriteHeader(&tar.Header{
+		Name:    name,
+		Mode:    0644,
+		Size:    size,
+		ModTime: w.now,
+	}); err != nil {
+		return err
+	}
+	_, err := io.CopyN(w.tar, r, size)
+	return err
+}
+
+// Close finishes the archive, it does not close the underlying writer.
+func (w *Writer) Close() error {
+	if err := w.tar.Close(); err != nil {
+		return err
+	}
+	return w.gzip.Close()
+}
+
+// Read calls fn with every entry of the archive but the manifest, which must
+// come first and be of this Version. Names are checked to stay inside the
+// archive.
+func Read(r io.Reader, fn func(name string, r io.Reader) error) error {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrArchive, err)
+	}
+	defer gz.Close()
+
+	archive := tar.NewReader(gz)
+	first := true
+	for {
+		header, err := archive.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("%w: %v", ErrArchive, err)
+		}
+		if header.Typeflag != tar.TypeReg && header.Typeflag != tar.TypeRegA {
+			continue
+		}
+
+		name := path.Clean(header.Name)
+		if path.IsAbs(name) || name == ".." || strings.HasPrefix(name, "../") {
+			return fmt.Errorf("%w: entry %q is outside the archive", ErrArchive, header.Name)
+		}
+
+		if first {
+			first = false
+			if name != ManifestName {
+				return fmt.Errorf("%w: %s is missing", ErrArchive, ManifestName)
+			}
+			var manifest Manifest
+			if err := json.NewDecoder(archive).Decode(&manifest); err != nil {
+				return fmt.Errorf("%w: %v", ErrArchive, err)
+			}
+			if manifest.Version != Version {
+				return fmt.Errorf("%w: version %d is not supported", ErrArchive, manifest.Version)
+			}
+			continue
+		}
+
+		if err := fn(name, archive); err != nil {
+			return err
+		}
+	}
+	if first {
+		return fmt.Errorf("%w: %s is missing", ErrArchive, ManifestName)
+	}
+	return nil
+}
+
+// ReadJSON decodes the JSON document r into v.
+func ReadJSON(name string, r io.Reader, v interface{}) error {
+	if err := json.NewDecoder(r).Decode(v); err != nil {
+		return fmt.Errorf("%w: %s: %v", ErrArchive, name, err)
+	}
+	return nil
+}
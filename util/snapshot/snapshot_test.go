@@ -0,0 +1,79 @@
+package snapshot
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"io"
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriteAndRead(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := NewWriter(&buf)
+	assert.NoError(t, err)
+	assert.NoError(t, w.WriteJSON("categories.json", []map[string]string{{"name": "Shoes"}}))
+	assert.NoError(t, w.WriteFile("images/1.jpg", 3, bytes.NewReader([]byte("jpg"))))
+	assert.NoError(t, w.Close())
+
+	entries := make(map[string]string)
+	var categories []map[string]string
+	assert.NoError(t, Read(&buf, func(name string, r io.Reader) error {
+		if name == "categories.json" {
+			return ReadJSON(name, r, &categories)
+		}
+		data, err := ioutil.ReadAll(r)
+		entries[name] = string(data)
+		return err
+	}))
+
+	assert.Equal(t, []map[string]string{{"name": "Shoes"}}, categories)
+	assert.Equal(t, map[string]string{"images/1.jpg": "jpg"}, entries)
+}
+
+func archive(t *testing.T, files ...string) *bytes.Buffer {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	w := tar.NewWriter(gz)
+	for i := 0; i < len(files); i += 2 {
+		assert.NoError(t, w.WriteHeader(&tar.Header{Name: files[i], Mode: 0644, Size: int64(len(files[i+1])), Typeflag: tar.TypeReg}))
+		_, err := w.Write([]byte(files[i+1]))
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, w.Close())
+	assert.NoError(t, gz.Close())
+	return &buf
+}
+
+func TestReadRejectsInvalidArchives(t *testing.T) {
+	for name, r := range map[string]io.Reader{
+		"not gzip":         bytes.NewReader([]byte("categories")),
+		"empty":            archive(t),
+		"no manifest":      archive(t, "categories.json", "[]"),
+		"other version":    archive(t, ManifestName, `{"version":2}`),
+		"outside":          archive(t, ManifestName, `{"version":1}`, "../etc/passwd", "x"),
+		"absolute":         archive(t, ManifestName, `{"version":1}`, "/etc/passwd", "x"),
+		"invalid manifest": archive(t, ManifestName, `{`),
+	} {
+		err := Read(r, func(name string, r io.Reader) error { return nil })
+		assert.True(t, errors.Is(err, ErrArchive), name)
+	}
+}
+
+func TestParseStrategy(t *testing.T) {
+	for _, strategy := range []string{Skip, Overwrite, Rename} {
+		parsed, err := ParseStrategy(strategy)
+		assert.NoError(t, err)
+		assert.Equal(t, strategy, parsed)
+	}
+	parsed, err := ParseStrategy("")
+	assert.NoError(t, err)
+	assert.Equal(t, Skip, parsed)
+
+	_, err = ParseStrategy("merge")
+	assert.True(t, errors.Is(err, ErrStrategy))
+}
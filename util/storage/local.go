@@ -0,0 +1,155 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// LocalStorage stores the files under Dir, they are served from BaseURL.
+type LocalStorage struct {
+	Dir     string
+	BaseURL string
+}
+
+var _ Storage = (*LocalStorage)(nil)
+
+func NewLocal(dir string, baseURL string) Storage {
+	return &LocalStorage{
+		Dir:     dir,
+		BaseURL: strings.TrimSuffix(baseURL, "/"),
+	}
+}
+
+func (s *LocalStorage) path(key string) (string, error) {
+	if err := checkKey(key); err != nil {
+		return "", err
+	}
+	return filepath.Join(s.Dir, filepath.FromSlash(key)), nil
+}
+
+// Put writes a temporary file next to the file and renames it, a file is
+// never read half written.
+func (s *LocalStorage) Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error {
+	name, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(name), 0755); err != nil {
+		return err
+	}
+
+	file, err := ioutil.TempFile(filepath.Dir(name), ".put-*")
+	if err != nil {
+		return err
+	}
+	written, err := io.Copy(file, r)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil && written != size {
+		err = fmt.Errorf("wrote %d bytes of %d", written, size)
+	}
+	if err == nil {
+		err = os.Chmod(file.Name(), 0644)
+	}
+	if err == nil {
+		err = os.Rename(file.Name(), name)
+	}
+	if err != nil {
+		os.Remove(file.Name())
+	}
+	return err
+}
+
+func (s *LocalStorage) Get(ctx context.Context, key string) (io.ReadCloser, int64, error) {
+	name, err := s.path(key)
+	if err != nil {
+		return nil, 0, err
+	}
+	file, err := os.Open(name)
+	if os.IsNotExist(err) {
+		return nil, 0, fmt.Errorf("%w: %s", ErrNotFound, key)
+	} else if err != nil {
+		return nil, 0, err
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, 0, err
+	}
+	if info.IsDir() {
+		file.Close()
+		return nil, 0, fmt.Errorf("%w: %s", ErrNotFound, key)
+	}
+	return file, info.Size(), nil
+}
+
+func (s *LocalStorage) Exists(ctx context.Context, key string) (bool, error) {
+	name, err := s.path(key)
+	if err != nil {
+		return false, err
+	}
+	if info, err := os.Stat(name); os.IsNotExist(err) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	} else {
+		return !info.IsDir(), nil
+	}
+}
+
+func (s *LocalStorage) Delete(ctx context.Context, key string) error {
+	name, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(name); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+func (s *LocalStorage) URL(ctx context.Context, key string) (string, error) {
+	if err := checkKey(key); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s/%s", s.BaseURL, key), nil
+}
+
+// Walk leaves out the temporary files of Put and other hidden files.
+func (s *LocalStorage) Walk(ctx context.Context, prefix string, fn func(key string) error) error {
+	root := s.Dir
+	if len(prefix) > 0 {
+		if err := checkKey(strings.TrimSuffix(prefix, "/")); err != nil {
+			return err
+		}
+		root = filepath.Join(s.Dir, filepath.FromSlash(prefix))
+	}
+
+	err := filepath.Walk(root, func(name string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if info.IsDir() || strings.HasPrefix(info.Name(), ".") {
+			return nil
+		}
+		rel, err := filepath.Rel(s.Dir, name)
+		if err != nil {
+			return err
+		}
+		return fn(filepath.ToSlash(rel))
+	})
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
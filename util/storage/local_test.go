@@ -0,0 +1,36 @@
+package storage
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocal(t *testing.T) {
+	testStorage(t, NewLocal(t.TempDir(), "/public"))
+}
+
+func TestLocalFiles(t *testing.T) {
+	dir := t.TempDir()
+	s := NewLocal(dir, "/public/")
+	put(t, s, "images/a.jpg", "a")
+
+	data, err := ioutil.ReadFile(filepath.Join(dir, "images", "a.jpg"))
+	assert.NoError(t, err)
+	assert.Equal(t, "a", string(data))
+
+	url, err := s.URL(context.Background(), "images/a.jpg")
+	assert.NoError(t, err)
+	assert.Equal(t, "/public/images/a.jpg", url)
+
+	// a short write leaves no file behind
+	assert.Error(t, s.Put(context.Background(), "images/b.jpg", strings.NewReader("b"), 2, ""))
+	entries, err := os.ReadDir(filepath.Join(dir, "images"))
+	assert.NoError(t, err)
+	assert.Len(t, entries, 1)
+}
@@ -0,0 +1,159 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+)
+
+// DefaultPresignExpiry is how long a presigned url is valid.
+const DefaultPresignExpiry = time.Hour
+
+// maxPresignExpiry is the longest expiry S3 signs.
+const maxPresignExpiry = 7 * 24 * time.Hour
+
+// S3Config configures an S3 compatible bucket, e.g. of AWS or MinIO. The
+// files are served from PublicURL followed by their key if it is set, from
+// presigned urls valid for PresignExpiry otherwise.
+type S3Config struct {
+	Endpoint      string
+	Region        string
+	Bucket        string
+	AccessKey     string
+	SecretKey     string
+	UseSSL        bool
+	PublicURL     string
+	PresignExpiry time.Duration
+}
+
+type S3Storage struct {
+	client *minio.Client
+	config S3Config
+}
+
+var _ Storage = (*S3Storage)(nil)
+
+// NewS3 does not connect to the endpoint, the bucket must exist.
+func NewS3(config S3Config) (Storage, error) {
+	if len(config.Endpoint) == 0 || len(config.Bucket) == 0 {
+		return nil, fmt.Errorf("%w: s3 requires an endpoint and a bucket", ErrBackend)
+	}
+	if config.PresignExpiry <= 0 {
+		config.PresignExpiry = DefaultPresignExpiry
+	} else if config.PresignExpiry > maxPresignExpiry {
+		config.PresignExpiry = maxPresignExpiry
+	}
+	config.PublicURL = strings.TrimSuffix(config.PublicURL, "/")
+
+	client, err := minio.New(config.Endpoint, &minio.Options{
+		Creds:  credentials.NewStaticV4(config.AccessKey, config.SecretKey, ""),
+		Secure: config.UseSSL,
+		Region: config.Region,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &S3Storage{
+		client: client,
+		config: config,
+	}, nil
+}
+
+func (s *S3Storage) Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error {
+	if err := checkKey(key); err != nil {
+		return err
+	}
+	_, err := s.client.PutObject(ctx, s.config.Bucket, key, r, size, minio.PutObjectOptions{
+		ContentType: contentType,
+	})
+	return err
+}
+
+func (s *S3Storage) Get(ctx context.Context, key string) (io.ReadCloser, int64, error) {
+	if err := checkKey(key); err != nil {
+		return nil, 0, err
+	}
+	object, err := s.client.GetObject(ctx, s.config.Bucket, key, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, 0, s3Error(key, err)
+	}
+	// the object is requested by its first read or stat
+	info, err := object.Stat()
+	if err != nil {
+		object.Close()
+		return nil, 0, s3Error(key, err)
+	}
+	return object, info.Size, nil
+}
+
+func (s *S3Storage) Exists(ctx context.Context, key string) (bool, error) {
+	if err := checkKey(key); err != nil {
+		return false, err
+	}
+	if _, err := s.client.StatObject(ctx, s.config.Bucket, key, minio.StatObjectOptions{}); err != nil {
+		if err := s3Error(key, err); errors.Is(err, ErrNotFound) {
+			return false, nil
+		} else {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+func (s *S3Storage) Delete(ctx context.Context, key string) error {
+	if err := checkKey(key); err != nil {
+		return err
+	}
+	return s.client.RemoveObject(ctx, s.config.Bucket, key, minio.RemoveObjectOptions{})
+}
+
+func (s *S3Storage) URL(ctx context.Context, key string) (string, error) {
+	if err := checkKey(key); err != nil {
+		return "", err
+	}
+	if len(s.config.PublicURL) > 0 {
+		return fmt.Sprintf("%s/%s", s.config.PublicURL, key), nil
+	}
+	url, err := s.client.PresignedGetObject(ctx, s.config.Bucket, key, s.config.PresignExpiry, nil)
+	if err != nil {
+		return "", err
+	}
+	return url.String(), nil
+}
+
+func (s *S3Storage) Walk(ctx context.Context, prefix string, fn func(key string) error) error {
+	// cancelling stops the listing when fn fails
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	for object := range s.client.ListObjects(ctx, s.config.Bucket, minio.ListObjectsOptions{
+		Prefix:    prefix,
+		Recursive: true,
+	}) {
+		if object.Err != nil {
+			return object.Err
+		}
+		if strings.HasSuffix(object.Key, "/") {
+			continue
+		}
+		if err := fn(object.Key); err != nil {
+			return err
+		}
+	}
+	return ctx.Err()
+}
+
+func s3Error(key string, err error) error {
+	switch minio.ToErrorResponse(err).Code {
+	case "NoSuchKey", "NotFound":
+		return fmt.Errorf("%w: %s", ErrNotFound, key)
+	default:
+		return err
+	}
+}
@@ -0,0 +1,87 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/stretchr/testify/assert"
+)
+
+// testS3 connects to the MinIO of S3_TEST_ENDPOINT, e.g. one started with
+// docker run -p 9000:9000 minio/minio server /data, with the credentials of
+// S3_TEST_ACCESS_KEY and S3_TEST_SECRET_KEY. It creates a bucket for the
+// test and removes it afterwards.
+func testS3(t *testing.T, publicURL string) *S3Storage {
+	endpoint := os.Getenv("S3_TEST_ENDPOINT")
+	if len(endpoint) == 0 {
+		t.Skip("S3_TEST_ENDPOINT is not set")
+	}
+
+	bucket := fmt.Sprintf("test-%d", time.Now().UnixNano())
+	s, err := NewS3(S3Config{
+		Endpoint:  endpoint,
+		Region:    "us-east-1",
+		Bucket:    bucket,
+		AccessKey: os.Getenv("S3_TEST_ACCESS_KEY"),
+		SecretKey: os.Getenv("S3_TEST_SECRET_KEY"),
+		PublicURL: publicURL,
+	})
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	s3 := s.(*S3Storage)
+
+	ctx := context.Background()
+	if !assert.NoError(t, s3.client.MakeBucket(ctx, bucket, minio.MakeBucketOptions{})) {
+		t.FailNow()
+	}
+	t.Cleanup(func() {
+		for object := range s3.client.ListObjects(ctx, bucket, minio.ListObjectsOptions{Recursive: true}) {
+			s3.client.RemoveObject(ctx, bucket, object.Key, minio.RemoveObjectOptions{})
+		}
+		s3.client.RemoveBucket(ctx, bucket)
+	})
+	return s3
+}
+
+func TestS3(t *testing.T) {
+	testStorage(t, testS3(t, ""))
+}
+
+func TestS3URL(t *testing.T) {
+	s := testS3(t, "")
+	put(t, s, "images/a.jpg", "a")
+
+	url, err := s.URL(context.Background(), "images/a.jpg")
+	assert.NoError(t, err)
+	assert.True(t, strings.Contains(url, "X-Amz-Signature="), url)
+
+	response, err := http.Get(url)
+	if assert.NoError(t, err) {
+		defer response.Body.Close()
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+		assert.Equal(t, "image/jpeg", response.Header.Get("Content-Type"))
+	}
+
+	public := testS3(t, "https://cdn.example.com/")
+	url, err = public.URL(context.Background(), "images/a.jpg")
+	assert.NoError(t, err)
+	assert.Equal(t, "https://cdn.example.com/images/a.jpg", url)
+}
+
+func TestCopyToS3(t *testing.T) {
+	src := NewLocal(t.TempDir(), "/public")
+	dst := testS3(t, "")
+	put(t, src, "images/a.jpg", "a")
+
+	copied, _, err := Copy(context.Background(), src, dst, "images/", false)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, copied)
+	assert.Equal(t, "a", read(t, dst, "images/a.jpg"))
+}
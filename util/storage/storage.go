@@ -0,0 +1,102 @@
+// Package storage stores files by key on the local disk or in an S3
+// compatible bucket. Keys are relative slash separated paths such as
+// images/photo.jpg, the same key refers to the same file on every backend.
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"path"
+	"strings"
+)
+
+// The backends a storage can be configured with.
+const (
+	Local = "local"
+	S3    = "s3"
+)
+
+var (
+	ErrNotFound = errors.New("file not found")
+	ErrKey      = errors.New("invalid key")
+	ErrBackend  = errors.New("unknown storage backend")
+)
+
+type Storage interface {
+	// Put stores size bytes of r under key, replacing the file stored
+	// under it.
+	Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error
+	// Get opens the file stored under key and returns its size, ErrNotFound
+	// if there is none.
+	Get(ctx context.Context, key string) (io.ReadCloser, int64, error)
+	Exists(ctx context.Context, key string) (bool, error)
+	// Delete removes the file stored under key, a missing file is not an
+	// error.
+	Delete(ctx context.Context, key string) error
+	// URL returns the url clients fetch the file stored under key from.
+	URL(ctx context.Context, key string) (string, error)
+	// Walk calls fn with the key of every file under prefix.
+	Walk(ctx context.Context, prefix string, fn func(key string) error) error
+}
+
+// ParseBackend parses a backend name, an empty name is the local backend.
+func ParseBackend(backend string) (string, error) {
+	switch backend = strings.ToLower(strings.TrimSpace(backend)); backend {
+	case "":
+		return Local, nil
+	case Local, S3:
+		return backend, nil
+	default:
+		return "", fmt.Errorf("%w: %q, expected %s or %s", ErrBackend, backend, Local, S3)
+	}
+}
+
+// ContentType returns the media type of a file from the extension of its key.
+func ContentType(key string) string {
+	if contentType := mime.TypeByExtension(path.Ext(key)); len(contentType) > 0 {
+		return contentType
+	}
+	return "application/octet-stream"
+}
+
+// Copy copies every file under prefix from src to dst and returns how many
+// were copied and skipped. Files dst already has are skipped unless
+// overwrite is set.
+func Copy(ctx context.Context, src Storage, dst Storage, prefix string, overwrite bool) (int, int, error) {
+	copied, skipped := 0, 0
+	err := src.Walk(ctx, prefix, func(key string) error {
+		if !overwrite {
+			if exists, err := dst.Exists(ctx, key); err != nil {
+				return err
+			} else if exists {
+				skipped++
+				return nil
+			}
+		}
+
+		r, size, err := src.Get(ctx, key)
+		if err != nil {
+			return err
+		}
+		defer r.Close()
+		if err := dst.Put(ctx, key, r, size, ContentType(key)); err != nil {
+			return fmt.Errorf("%s: %w", key, err)
+		}
+		copied++
+		return nil
+	})
+	return copied, skipped, err
+}
+
+// checkKey rejects keys which are not clean relative paths, they could
+// escape the directory of a local storage.
+func checkKey(key string) error {
+	if len(key) == 0 || strings.HasPrefix(key, "/") || strings.Contains(key, "\\") ||
+		path.Clean(key) != key || key == ".." || strings.HasPrefix(key, "../") {
+		return fmt.Errorf("%w: %q", ErrKey, key)
+	}
+	return nil
+}
@@ -0,0 +1,118 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseBackend(t *testing.T) {
+	backend, err := ParseBackend("")
+	assert.NoError(t, err)
+	assert.Equal(t, Local, backend)
+
+	backend, err = ParseBackend(" S3 ")
+	assert.NoError(t, err)
+	assert.Equal(t, S3, backend)
+
+	_, err = ParseBackend("ftp")
+	assert.True(t, errors.Is(err, ErrBackend))
+}
+
+func TestContentType(t *testing.T) {
+	assert.Equal(t, "image/png", ContentType("images/a.png"))
+	assert.Equal(t, "image/jpeg", ContentType("images/a.jpg"))
+	assert.Equal(t, "application/octet-stream", ContentType("images/a"))
+}
+
+func TestCheckKey(t *testing.T) {
+	assert.NoError(t, checkKey("images/a.jpg"))
+	for _, key := range []string{"", "/images/a.jpg", "../a.jpg", "images/../../a.jpg", "images//a.jpg", "images\\a.jpg", ".."} {
+		assert.True(t, errors.Is(checkKey(key), ErrKey), key)
+	}
+}
+
+func put(t *testing.T, s Storage, key string, data string) {
+	assert.NoError(t, s.Put(context.Background(), key, strings.NewReader(data), int64(len(data)), ContentType(key)))
+}
+
+func read(t *testing.T, s Storage, key string) string {
+	r, size, err := s.Get(context.Background(), key)
+	if !assert.NoError(t, err) {
+		return ""
+	}
+	defer r.Close()
+	data, err := ioutil.ReadAll(r)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(len(data)), size)
+	return string(data)
+}
+
+func keys(t *testing.T, s Storage, prefix string) []string {
+	var keys []string
+	assert.NoError(t, s.Walk(context.Background(), prefix, func(key string) error {
+		keys = append(keys, key)
+		return nil
+	}))
+	return keys
+}
+
+// testStorage runs the tests every backend must pass on an empty storage.
+func testStorage(t *testing.T, s Storage) {
+	ctx := context.Background()
+
+	put(t, s, "images/a.jpg", "first")
+	put(t, s, "images/a.jpg", "second")
+	put(t, s, "images/b.png", "png")
+	put(t, s, "other/c.txt", "other")
+	assert.Equal(t, "second", read(t, s, "images/a.jpg"))
+
+	exists, err := s.Exists(ctx, "images/a.jpg")
+	assert.NoError(t, err)
+	assert.True(t, exists)
+	exists, err = s.Exists(ctx, "images/missing.jpg")
+	assert.NoError(t, err)
+	assert.False(t, exists)
+
+	_, _, err = s.Get(ctx, "images/missing.jpg")
+	assert.True(t, errors.Is(err, ErrNotFound))
+
+	assert.ElementsMatch(t, []string{"images/a.jpg", "images/b.png"}, keys(t, s, "images/"))
+	assert.Len(t, keys(t, s, ""), 3)
+	assert.Empty(t, keys(t, s, "missing/"))
+
+	assert.NoError(t, s.Delete(ctx, "images/b.png"))
+	assert.NoError(t, s.Delete(ctx, "images/b.png"))
+	assert.ElementsMatch(t, []string{"images/a.jpg"}, keys(t, s, "images/"))
+
+	assert.True(t, errors.Is(s.Put(ctx, "../a.jpg", strings.NewReader("a"), 1, ""), ErrKey))
+	_, _, err = s.Get(ctx, "/etc/passwd")
+	assert.True(t, errors.Is(err, ErrKey))
+}
+
+func TestCopy(t *testing.T) {
+	src := NewLocal(t.TempDir(), "/public")
+	dst := NewLocal(t.TempDir(), "/public")
+	put(t, src, "images/a.jpg", "a")
+	put(t, src, "images/b.jpg", "b")
+	put(t, src, "other/c.txt", "c")
+	put(t, dst, "images/b.jpg", "changed")
+
+	copied, skipped, err := Copy(context.Background(), src, dst, "images/", false)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, copied)
+	assert.Equal(t, 1, skipped)
+	assert.Equal(t, "a", read(t, dst, "images/a.jpg"))
+	assert.Equal(t, "changed", read(t, dst, "images/b.jpg"))
+	assert.ElementsMatch(t, []string{"images/a.jpg", "images/b.jpg"}, keys(t, dst, ""))
+
+	copied, skipped, err = Copy(context.Background(), src, dst, "images/", true)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, copied)
+	assert.Equal(t, 0, skipped)
+	assert.Equal(t, "b", read(t, dst, "images/b.jpg"))
+}
@@ -0,0 +1,248 @@
+// Package synthetic generates catalogs for load testing.
+//
+// The sizes of a catalog follow the long tails of real ones: a few
+// categories hold most of the products, most products have a few variants
+// and some many, prices are log-normal around a base price of their category
+// and popular attribute values are picked more often. The same options and
+// seed always generate the same catalog.
+package synthetic
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"strings"
+)
+
+var ErrOptions = errors.New("invalid generator options")
+
+type Options struct {
+	Categories int
+	Products   int
+	// Variants is the number of variants of all products, at least one per
+	// product
+	Variants   int
+	Attributes int
+	Seed       int64
+}
+
+type Catalog struct {
+	Categories []*Category
+	Attributes []*Attribute
+	Products   []*Product
+}
+
+type Category struct {
+	Name        string
+	Description string
+	// Types are the attribute types its variants differ in
+	Types []string
+}
+
+type Attribute struct {
+	Type string
+	Name string
+}
+
+type Product struct {
+	Name        string
+	Description string
+	// Category is an index of Catalog.Categories
+	Category int
+	Variants []*Variant
+}
+
+type Variant struct {
+	Name  string
+	Price float64
+	Stock float64
+	// Attributes are indexes of Catalog.Attributes, one of every type of the
+	// category
+	Attributes []int
+}
+
+var (
+	departments = []string{
+		"Phones", "Cameras", "Televisions", "Laptops", "Audio", "Clothing", "Shoes", "Furniture",
+		"Kitchen", "Garden", "Toys", "Books", "Sports", "Beauty", "Jewelry", "Tools",
+		"Automotive", "Pet Supplies", "Office", "Music", "Groceries", "Lighting", "Bedding", "Outdoor",
+	}
+	adjectives = []string{
+		"Classic", "Compact", "Deluxe", "Eco", "Elite", "Flex", "Fresh", "Grand",
+		"Lite", "Max", "Mini", "Nova", "Prime", "Pro", "Pure", "Rapid",
+		"Royal", "Smart", "Solid", "Sport", "Swift", "Ultra", "Urban", "Vivid",
+	}
+	nouns = []string{
+		"Bag", "Band", "Bench", "Boot", "Bottle", "Box", "Brush", "Chair",
+		"Clock", "Coat", "Cup", "Desk", "Drill", "Fan", "Grill", "Hat",
+		"Jacket", "Kettle", "Lamp", "Mat", "Mug", "Pan", "Pen", "Phone",
+		"Rack", "Shirt", "Sofa", "Speaker", "Table", "Tent", "Vase", "Watch",
+	}
+	// values of the attribute types, the most popular first
+	values = map[string][]string{
+		"color":    {"black", "white", "blue", "red", "grey", "green", "silver", "navy", "beige", "pink", "yellow", "brown", "orange", "purple", "gold"},
+		"size":     {"M", "L", "S", "XL", "XS", "XXL", "36", "38", "40", "42", "44", "46"},
+		"material": {"cotton", "steel", "plastic", "wood", "leather", "glass", "aluminium", "wool", "linen", "ceramic", "bamboo", "silk"},
+		"memory":   {"128", "64", "256", "32", "512", "1024", "16"},
+		"style":    {"classic", "modern", "sport", "casual", "vintage", "minimal", "rustic"},
+	}
+	types = []string{"color", "size", "material", "memory", "style"}
+)
+
+// Generate returns a catalog of the sizes in options.
+func Generate(options Options) (*Catalog, error) {
+	if options.Categories < 1 || options.Products < 0 || options.Attributes < 0 ||
+		options.Variants < options.Products || (options.Products == 0 && options.Variants > 0) {
+		return nil, fmt.Errorf("%w: %d categories, %d products, %d variants, %d attributes",
+			ErrOptions, options.Categories, options.Products, options.Variants, options.Attributes)
+	}
+
+	r := rand.New(rand.NewSource(options.Seed))
+	catalog := &Catalog{}
+
+	// attributes are spread over the types, type by type the popular values
+	// come first
+	byType := make(map[string][]int)
+	for i := 0; i < options.Attributes; i++ {
+		t := types[i%len(types)]
+		n := len(byType[t])
+		name := values[t][n%len(values[t])]
+		if n >= len(values[t]) {
+			name = fmt.Sprintf("%s %d", name, n/len(values[t])+1)
+		}
+		byType[t] = append(byType[t], len(catalog.Attributes))
+		catalog.Attributes = append(catalog.Attributes, &Attribute{Type: t, Name: name})
+	}
+	var available []string
+	// popular values are picked more often
+	popular := make(map[string]*rand.Zipf)
+	for _, t := range types {
+		if len(byType[t]) > 0 {
+			available = append(available, t)
+		}
+		if len(byType[t]) > 1 {
+			popular[t] = rand.NewZipf(r, 1.3, 1, uint64(len(byType[t])-1))
+		}
+	}
+
+	basePrices := make([]float64, options.Categories)
+	for i := 0; i < options.Categories; i++ {
+		name := departments[i%len(departments)]
+		if i >= len(departments) {
+			name = fmt.Sprintf("%s %d", name, i/len(departments)+1)
+		}
+		category := &Category{
+			Name:        name,
+			Description: strings.ToLower(name),
+		}
+		// one to three types, in the order of types
+		if len(available) > 0 {
+			for _, j := range r.Perm(len(available))[:1+r.Intn(min(3, len(available)))] {
+				category.Types = append(category.Types, available[j])
+			}
+			sort.Slice(category.Types, func(a, b int) bool {
+				return typeIndex(category.Types[a]) < typeIndex(category.Types[b])
+			})
+		}
+		catalog.Categories = append(catalog.Categories, category)
+		basePrices[i] = clamp(math.Exp(3.5+1.2*r.NormFloat64()), 1, 20000)
+	}
+
+	// a few categories hold most of the products
+	var categoryOf *rand.Zipf
+	if options.Categories > 1 {
+		categoryOf = rand.NewZipf(r, 1.2, 2, uint64(options.Categories-1))
+	}
+	// every product has a variant, the others go to products by log-normal
+	// weights
+	weights := make([]float64, options.Products)
+	total := 0.0
+	for i := range weights {
+		total += math.Exp(r.NormFloat64())
+		weights[i] = total
+	}
+	variants := make([]int, options.Products)
+	for i := range variants {
+		variants[i] = 1
+	}
+	for i := options.Products; i < options.Variants; i++ {
+		variants[sort.SearchFloat64s(weights, r.Float64()*total)]++
+	}
+
+	for i := 0; i < options.Products; i++ {
+		category := 0
+		if categoryOf != nil {
+			category = int(categoryOf.Uint64())
+		}
+		adjective := adjectives[r.Intn(len(adjectives))]
+		noun := nouns[r.Intn(len(nouns))]
+		product := &Product{
+			// the number keeps the names unique
+			Name:        fmt.Sprintf("%s %s %d", adjective, noun, i+1),
+			Description: fmt.Sprintf("%s %s from %s", adjective, strings.ToLower(noun), strings.ToLower(catalog.Categories[category].Name)),
+			Category:    category,
+		}
+		price := basePrices[category] * math.Exp(0.4*r.NormFloat64())
+
+		names := make(map[string]bool)
+		for j := 0; j < variants[i]; j++ {
+			variant := &Variant{
+				Price: roundPrice(price * (0.9 + 0.3*r.Float64())),
+			}
+			// out of stock now and then, mostly a dozen or so
+			if r.Float64() >= 0.08 {
+				variant.Stock = math.Max(1, math.Round(math.Exp(2.5+r.NormFloat64())))
+			}
+
+			var parts []string
+			for _, t := range catalog.Categories[category].Types {
+				attributes := byType[t]
+				k := 0
+				if zipf, ok := popular[t]; ok {
+					k = int(zipf.Uint64())
+				}
+				variant.Attributes = append(variant.Attributes, attributes[k])
+				parts = append(parts, catalog.Attributes[attributes[k]].Name)
+			}
+			variant.Name = strings.Join(parts, " ")
+			if len(variant.Name) < 2 || len(variant.Name) > 32 || names[variant.Name] {
+				variant.Name = fmt.Sprintf("Variant %d", j+1)
+			}
+			names[variant.Name] = true
+			product.Variants = append(product.Variants, variant)
+		}
+		catalog.Products = append(catalog.Products, product)
+	}
+
+	return catalog, nil
+}
+
+// roundPrice rounds to cents, prices over 5 end in .99.
+func roundPrice(price float64) float64 {
+	if price > 5 {
+		return math.Floor(price) + 0.99
+	}
+	return math.Max(0.5, math.Round(price*100)/100)
+}
+
+func typeIndex(t string) int {
+	for i := range types {
+		if types[i] == t {
+			return i
+		}
+	}
+	return len(types)
+}
+
+func clamp(value float64, low float64, high float64) float64 {
+	return math.Min(high, math.Max(low, value))
+}
+
+func min(a int, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
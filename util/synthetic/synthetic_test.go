@@ -0,0 +1,97 @@
+package synthetic
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerate(t *testing.T) {
+	catalog, err := Generate(Options{Categories: 30, Products: 500, Variants: 1800, Attributes: 60, Seed: 1})
+	assert.NoError(t, err)
+
+	assert.Len(t, catalog.Categories, 30)
+	assert.Len(t, catalog.Attributes, 60)
+	assert.Len(t, catalog.Products, 500)
+
+	categories := make(map[string]bool)
+	for _, category := range catalog.Categories {
+		assert.False(t, categories[category.Name], category.Name)
+		categories[category.Name] = true
+		assert.True(t, len(category.Name) >= 2 && len(category.Name) <= 32, category.Name)
+	}
+	attributes := make(map[Attribute]bool)
+	for _, attribute := range catalog.Attributes {
+		assert.False(t, attributes[*attribute], attribute.Name)
+		attributes[*attribute] = true
+	}
+
+	variants := 0
+	products := make(map[string]bool)
+	perCategory := make([]int, len(catalog.Categories))
+	for _, product := range catalog.Products {
+		assert.False(t, products[product.Name], product.Name)
+		products[product.Name] = true
+		assert.True(t, len(product.Name) >= 2 && len(product.Name) <= 32, product.Name)
+		assert.NotEmpty(t, product.Variants)
+		perCategory[product.Category]++
+
+		names := make(map[string]bool)
+		for _, variant := range product.Variants {
+			variants++
+			assert.False(t, names[variant.Name], variant.Name)
+			names[variant.Name] = true
+			assert.True(t, len(variant.Name) >= 2 && len(variant.Name) <= 32, variant.Name)
+			assert.True(t, variant.Price > 0)
+			assert.True(t, variant.Stock >= 0)
+			// one attribute of every type of the category
+			assert.Len(t, variant.Attributes, len(catalog.Categories[product.Category].Types))
+			for i, a := range variant.Attributes {
+				assert.Equal(t, catalog.Categories[product.Category].Types[i], catalog.Attributes[a].Type)
+			}
+		}
+	}
+	assert.Equal(t, 1800, variants)
+	// the first categories hold most of the products
+	assert.Greater(t, perCategory[0], perCategory[len(perCategory)-1])
+}
+
+func TestGenerateIsDeterministic(t *testing.T) {
+	options := Options{Categories: 5, Products: 40, Variants: 100, Attributes: 20, Seed: 42}
+
+	a, err := Generate(options)
+	assert.NoError(t, err)
+	b, err := Generate(options)
+	assert.NoError(t, err)
+	assert.Equal(t, a, b)
+
+	options.Seed = 43
+	c, err := Generate(options)
+	assert.NoError(t, err)
+	assert.NotEqual(t, a, c)
+}
+
+func TestGenerateWithoutAttributes(t *testing.T) {
+	catalog, err := Generate(Options{Categories: 1, Products: 3, Variants: 5})
+	assert.NoError(t, err)
+
+	for _, product := range catalog.Products {
+		for i, variant := range product.Variants {
+			assert.Empty(t, variant.Attributes)
+			assert.Equal(t, []string{"Variant 1", "Variant 2", "Variant 3"}[i], variant.Name)
+		}
+	}
+}
+
+func TestGenerateRejectsInvalidOptions(t *testing.T) {
+	for _, options := range []Options{
+		{Categories: 0, Products: 1, Variants: 1},
+		{Categories: 1, Products: 5, Variants: 4},
+		{Categories: 1, Products: 0, Variants: 4},
+		{Categories: 1, Products: 1, Variants: 1, Attributes: -1},
+	} {
+		_, err := Generate(options)
+		assert.True(t, errors.Is(err, ErrOptions), "%+v", options)
+	}
+}
@@ -0,0 +1,336 @@
+// Package upload checks uploaded images before they are stored: their format
+// is sniffed from their magic bytes, their size and dimensions are capped
+// and their metadata is stripped.
+package upload
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"strings"
+)
+
+// The formats an upload can be sniffed as.
+const (
+	JPEG = "jpeg"
+	PNG  = "png"
+	GIF  = "gif"
+	WebP = "webp"
+)
+
+var (
+	ErrTooLarge   = errors.New("file is too large")
+	ErrFormat     = errors.New("file format is not allowed")
+	ErrDimensions = errors.New("image dimensions are too large")
+	ErrInvalid    = errors.New("file is not a valid image")
+)
+
+// Limits caps the uploads, Formats is the allow-list.
+type Limits struct {
+	MaxBytes  int64
+	MaxWidth  int
+	MaxHeight int
+	MaxPixels int
+	Formats   []string
+}
+
+// Info is what Check found out about an upload.
+type Info struct {
+	Format string
+	Width  int
+	Height int
+}
+
+func DefaultLimits() Limits {
+	return Limits{
+		MaxBytes:  4 << 20,
+		MaxWidth:  8192,
+		MaxHeight: 8192,
+		MaxPixels: 40000000,
+		Formats:   []string{JPEG, PNG, GIF, WebP},
+	}
+}
+
+// ParseFormats parses comma separated formats, an empty list is every format.
+func ParseFormats(formats string) ([]string, error) {
+	if len(strings.TrimSpace(formats)) == 0 {
+		return DefaultLimits().Formats, nil
+	}
+
+	var parsed []string
+	for _, format := range strings.Split(formats, ",") {
+		format = strings.ToLower(strings.TrimSpace(format))
+		if format == "jpg" {
+			format = JPEG
+		}
+		switch format {
+		case JPEG, PNG, GIF, WebP:
+			parsed = append(parsed, format)
+		default:
+			return nil, fmt.Errorf("%w: %q", ErrFormat, format)
+		}
+	}
+	return parsed, nil
+}
+
+// Sniff returns the format of data from its magic bytes, or an empty string.
+func Sniff(data []byte) string {
+	switch {
+	case bytes.HasPrefix(data, []byte("\xff\xd8\xff")):
+		return JPEG
+	case bytes.HasPrefix(data, []byte("\x89PNG\r\n\x1a\n")):
+		return PNG
+	case bytes.HasPrefix(data, []byte("GIF87a")), bytes.HasPrefix(data, []byte("GIF89a")):
+		return GIF
+	case len(data) >= 12 && string(data[:4]) == "RIFF" && string(data[8:12]) == "WEBP":
+		return WebP
+	default:
+		return ""
+	}
+}
+
+// Check checks the upload against limits without decoding its pixels, the
+// decoders of the formats must be registered.
+func Check(data []byte, limits *Limits) (*Info, error) {
+	if int64(len(data)) > limits.MaxBytes {
+		return nil, fmt.Errorf("%w: %d bytes, at most %d are allowed", ErrTooLarge, len(data), limits.MaxBytes)
+	}
+
+	format := Sniff(data)
+	allowed := false
+	for _, allowedFormat := range limits.Formats {
+		allowed = allowed || format == allowedFormat
+	}
+	if !allowed {
+		if len(format) == 0 {
+			format = "unknown"
+		}
+		return nil, fmt.Errorf("%w: %s, allowed are %s", ErrFormat, format, strings.Join(limits.Formats, ", "))
+	}
+
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalid, err)
+	}
+	if config.Width <= 0 || config.Height <= 0 {
+		return nil, fmt.Errorf("%w: %dx%d", ErrInvalid, config.Width, config.Height)
+	}
+	if config.Width > limits.MaxWidth || config.Height > limits.MaxHeight ||
+		int64(config.Width)*int64(config.Height) > int64(limits.MaxPixels) {
+		return nil, fmt.Errorf("%w: %dx%d, at most %dx%d and %d pixels are allowed",
+			ErrDimensions, config.Width, config.Height, limits.MaxWidth, limits.MaxHeight, limits.MaxPixels)
+	}
+
+	return &Info{
+		Format: format,
+		Width:  config.Width,
+		Height: config.Height,
+	}, nil
+}
+
+// Strip removes the metadata of a JPEG, PNG or WebP image without decoding
+// it: EXIF, XMP and IPTC segments of JPEG, text and eXIf chunks of PNG and
+// EXIF and XMP chunks of WebP. GIF is returned as is. The EXIF orientation
+// of a JPEG is returned, 1 if it has none, since the image is not upright
+// without it.
+func Strip(data []byte, format string) ([]byte, int, error) {
+	if Sniff(data) != format {
+		return nil, 0, fmt.Errorf("%w: not a %s image", ErrInvalid, format)
+	}
+
+	switch format {
+	case JPEG:
+		return stripJPEG(data)
+	case PNG:
+		stripped, err := stripPNG(data)
+		return stripped, 1, err
+	case WebP:
+		stripped, err := stripWebP(data)
+		return stripped, 1, err
+	default:
+		return data, 1, nil
+	}
+}
+
+func stripJPEG(data []byte) ([]byte, int, error) {
+	out := append([]byte(nil), data[:2]...)
+	orientation := 1
+	i := 2
+	for {
+		if i+2 > len(data) || data[i] != 0xff {
+			return nil, 0, fmt.Errorf("%w: truncated jpeg segment", ErrInvalid)
+		}
+		marker := data[i+1]
+		switch {
+		case marker == 0xff:
+			// fill byte
+			i++
+			continue
+		case marker == 0xda || marker == 0xd9:
+			// the entropy coded data follows the start of scan as is
+			return append(out, data[i:]...), orientation, nil
+		case marker == 0x01 || (marker >= 0xd0 && marker <= 0xd7):
+			out = append(out, data[i:i+2]...)
+			i += 2
+			continue
+		}
+
+		if i+4 > len(data) {
+			return nil, 0, fmt.Errorf("%w: truncated jpeg segment", ErrInvalid)
+		}
+		end := i + 2 + int(binary.BigEndian.Uint16(data[i+2:]))
+		if end > len(data) || end < i+4 {
+			return nil, 0, fmt.Errorf("%w: truncated jpeg segment", ErrInvalid)
+		}
+		switch marker {
+		case 0xe1:
+			// EXIF or XMP
+			if segment := data[i+4 : end]; bytes.HasPrefix(segment, []byte("Exif\x00\x00")) {
+				orientation = exifOrientation(segment[6:])
+			}
+		case 0xed:
+			// Photoshop IPTC
+		default:
+			out = append(out, data[i:end]...)
+		}
+		i = end
+	}
+}
+
+// exifOrientation returns the orientation tag of IFD0 of a TIFF structure, 1
+// if it is missing or invalid.
+func exifOrientation(tiff []byte) int {
+	if len(tiff) < 8 {
+		return 1
+	}
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 1
+	}
+
+	offset := int(order.Uint32(tiff[4:]))
+	if offset < 8 || offset+2 > len(tiff) {
+		return 1
+	}
+	entries := int(order.Uint16(tiff[offset:]))
+	for n := 0; n < entries; n++ {
+		entry := offset + 2 + n*12
+		if entry+12 > len(tiff) {
+			return 1
+		}
+		// a SHORT value is stored in the first two bytes of the value field
+		if order.Uint16(tiff[entry:]) == 0x0112 {
+			if orientation := int(order.Uint16(tiff[entry+8:])); orientation >= 1 && orientation <= 8 {
+				return orientation
+			}
+			return 1
+		}
+	}
+	return 1
+}
+
+func stripPNG(data []byte) ([]byte, error) {
+	out := append([]byte(nil), data[:8]...)
+	i := 8
+	for i < len(data) {
+		if i+12 > len(data) {
+			return nil, fmt.Errorf("%w: truncated png chunk", ErrInvalid)
+		}
+		length := int64(binary.BigEndian.Uint32(data[i:]))
+		if length > int64(len(data)-i-12) {
+			return nil, fmt.Errorf("%w: truncated png chunk", ErrInvalid)
+		}
+		end := i + 12 + int(length)
+		switch string(data[i+4 : i+8]) {
+		case "eXIf", "tEXt", "zTXt", "iTXt":
+		case "IEND":
+			return append(out, data[i:end]...), nil
+		default:
+			out = append(out, data[i:end]...)
+		}
+		i = end
+	}
+	return nil, fmt.Errorf("%w: png without IEND chunk", ErrInvalid)
+}
+
+// VP8X flags of the metadata chunks
+const (
+	webpEXIF = 0x08
+	webpXMP  = 0x04
+)
+
+func stripWebP(data []byte) ([]byte, error) {
+	out := append([]byte(nil), data[:12]...)
+	i := 12
+	for i+8 <= len(data) {
+		size := int64(binary.LittleEndian.Uint32(data[i+4:]))
+		if size > int64(len(data)-i-8) {
+			return nil, fmt.Errorf("%w: truncated webp chunk", ErrInvalid)
+		}
+		end := i + 8 + int(size)
+		// chunks are padded to an even size, the last pad byte may be missing
+		if size%2 == 1 && end < len(data) {
+			end++
+		}
+		switch string(data[i : i+4]) {
+		case "EXIF", "XMP ":
+		case "VP8X":
+			chunk := append([]byte(nil), data[i:end]...)
+			if len(chunk) > 8 {
+				chunk[8] &^= webpEXIF | webpXMP
+			}
+			out = append(out, chunk...)
+		default:
+			out = append(out, data[i:end]...)
+		}
+		i = end
+	}
+	binary.LittleEndian.PutUint32(out[4:], uint32(len(out)-8))
+	return out, nil
+}
+
+// Orient turns img upright according to its EXIF orientation.
+func Orient(img image.Image, orientation int) image.Image {
+	if orientation < 2 || orientation > 8 {
+		return img
+	}
+
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	oriented := image.NewNRGBA(image.Rect(0, 0, width, height))
+	if orientation >= 5 {
+		// the sides are swapped
+		oriented = image.NewNRGBA(image.Rect(0, 0, height, width))
+	}
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			var dx, dy int
+			switch orientation {
+			case 2:
+				dx, dy = width-1-x, y
+			case 3:
+				dx, dy = width-1-x, height-1-y
+			case 4:
+				dx, dy = x, height-1-y
+			case 5:
+				dx, dy = y, x
+			case 6:
+				dx, dy = height-1-y, x
+			case 7:
+				dx, dy = height-1-y, width-1-x
+			case 8:
+				dx, dy = y, width-1-x
+			}
+			oriented.Set(dx, dy, color.NRGBAModel.Convert(img.At(bounds.Min.X+x, bounds.Min.Y+y)))
+		}
+	}
+	return oriented
+}
@@ -0,0 +1,194 @@
+package upload
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"image"
+	"image/color"
+	_ "image/gif"
+	"image/jpeg"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testImage(width int, height int) image.Image {
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for x := 0; x < width; x++ {
+		for y := 0; y < height; y++ {
+			img.Set(x, y, color.NRGBA{R: uint8(x * 10), G: uint8(y * 10), B: 100, A: 255})
+		}
+	}
+	return img
+}
+
+// testWebP is a 4x4 lossy WebP, there is no WebP encoder without cgo.
+var testWebP = []byte("RIFF<\x00\x00\x00WEBPVP8 0\x00\x00\x00\xb0\x01\x00\x9d\x01*\x04\x00\x04\x00\x01@&%\xb0\x02t\x01\x0e\xfe\x02\xec\x00\xfe\xfe\x90\x9b\x05\xdf\xfeZ^\x7fz\x81\xfeQs\xdc\x11\x00\xf7\x05\x1c\x00\xc0\x00\x00")
+
+func encodeJPEG(t *testing.T, img image.Image) []byte {
+	var buf bytes.Buffer
+	assert.NoError(t, jpeg.Encode(&buf, img, nil))
+	return buf.Bytes()
+}
+
+func encodePNG(t *testing.T, img image.Image) []byte {
+	var buf bytes.Buffer
+	assert.NoError(t, png.Encode(&buf, img))
+	return buf.Bytes()
+}
+
+// exifSegment is an APP1 segment with a big endian EXIF orientation and a
+// GPS IFD pointer.
+func exifSegment(orientation uint16) []byte {
+	tiff := []byte("MM\x00\x2a\x00\x00\x00\x08")
+	tiff = append(tiff, 0, 2)
+	tiff = append(tiff, 0x01, 0x12, 0, 3, 0, 0, 0, 1, byte(orientation>>8), byte(orientation), 0, 0)
+	tiff = append(tiff, 0x88, 0x25, 0, 4, 0, 0, 0, 1, 0, 0, 0, 0)
+	payload := append([]byte("Exif\x00\x00"), tiff...)
+	return append([]byte{0xff, 0xe1, byte((len(payload) + 2) >> 8), byte(len(payload) + 2)}, payload...)
+}
+
+func pngChunk(kind string, data []byte) []byte {
+	chunk := make([]byte, 4, 12+len(data))
+	binary.BigEndian.PutUint32(chunk, uint32(len(data)))
+	chunk = append(chunk, kind...)
+	chunk = append(chunk, data...)
+	crc := make([]byte, 4)
+	binary.BigEndian.PutUint32(crc, crc32.ChecksumIEEE(chunk[4:]))
+	return append(chunk, crc...)
+}
+
+func TestSniff(t *testing.T) {
+	assert.Equal(t, JPEG, Sniff(encodeJPEG(t, testImage(4, 4))))
+	assert.Equal(t, PNG, Sniff(encodePNG(t, testImage(4, 4))))
+	assert.Equal(t, GIF, Sniff([]byte("GIF89a....")))
+	assert.Equal(t, WebP, Sniff(testWebP))
+	assert.Equal(t, "", Sniff([]byte("<?php echo 1; ?>")))
+	assert.Equal(t, "", Sniff(nil))
+}
+
+func TestParseFormats(t *testing.T) {
+	formats, err := ParseFormats("")
+	assert.NoError(t, err)
+	assert.Equal(t, DefaultLimits().Formats, formats)
+
+	formats, err = ParseFormats("JPG, png")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{JPEG, PNG}, formats)
+
+	_, err = ParseFormats("jpeg,svg")
+	assert.True(t, errors.Is(err, ErrFormat))
+}
+
+func TestCheck(t *testing.T) {
+	limits := DefaultLimits()
+	data := encodePNG(t, testImage(20, 10))
+
+	info, err := Check(data, &limits)
+	assert.NoError(t, err)
+	assert.Equal(t, &Info{Format: PNG, Width: 20, Height: 10}, info)
+
+	small := limits
+	small.MaxBytes = int64(len(data) - 1)
+	_, err = Check(data, &small)
+	assert.True(t, errors.Is(err, ErrTooLarge))
+
+	jpegOnly := limits
+	jpegOnly.Formats = []string{JPEG}
+	_, err = Check(data, &jpegOnly)
+	assert.True(t, errors.Is(err, ErrFormat))
+
+	_, err = Check([]byte("<html><script></script></html>"), &limits)
+	assert.True(t, errors.Is(err, ErrFormat))
+
+	narrow := limits
+	narrow.MaxWidth = 19
+	_, err = Check(data, &narrow)
+	assert.True(t, errors.Is(err, ErrDimensions))
+
+	few := limits
+	few.MaxPixels = 199
+	_, err = Check(data, &few)
+	assert.True(t, errors.Is(err, ErrDimensions))
+
+	// a bomb declares huge dimensions in a few bytes
+	bomb := append([]byte(nil), data...)
+	binary.BigEndian.PutUint32(bomb[16:], 100000)
+	binary.BigEndian.PutUint32(bomb[20:], 100000)
+	binary.BigEndian.PutUint32(bomb[29:], crc32.ChecksumIEEE(bomb[12:29]))
+	_, err = Check(bomb, &limits)
+	assert.True(t, errors.Is(err, ErrDimensions))
+
+	_, err = Check(data[:20], &limits)
+	assert.True(t, errors.Is(err, ErrInvalid))
+}
+
+func TestStripJPEG(t *testing.T) {
+	data := encodeJPEG(t, testImage(8, 8))
+	withExif := append(append(append([]byte(nil), data[:2]...), exifSegment(6)...), data[2:]...)
+
+	stripped, orientation, err := Strip(withExif, JPEG)
+	assert.NoError(t, err)
+	assert.Equal(t, 6, orientation)
+	assert.Equal(t, data, stripped)
+	assert.False(t, bytes.Contains(stripped, []byte("Exif")))
+
+	_, err = jpeg.Decode(bytes.NewReader(stripped))
+	assert.NoError(t, err)
+
+	_, orientation, err = Strip(data, JPEG)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, orientation)
+
+	_, _, err = Strip(withExif[:30], JPEG)
+	assert.True(t, errors.Is(err, ErrInvalid))
+}
+
+func TestStripPNG(t *testing.T) {
+	data := encodePNG(t, testImage(8, 8))
+	iend := len(data) - 12
+	withText := append(append(append([]byte(nil), data[:iend]...),
+		pngChunk("tEXt", []byte("GPS\x0041.0082,28.9784"))...), data[iend:]...)
+	withText = append(withText[:iend], append(pngChunk("eXIf", []byte("MM\x00\x2a")), withText[iend:]...)...)
+
+	_, err := png.Decode(bytes.NewReader(withText))
+	assert.NoError(t, err)
+
+	stripped, orientation, err := Strip(withText, PNG)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, orientation)
+	assert.Equal(t, data, stripped)
+}
+
+func TestStripWebP(t *testing.T) {
+	data := testWebP
+	withExif := append(append([]byte(nil), data...), "EXIF"...)
+	withExif = append(withExif, 3, 0, 0, 0, 'G', 'P', 'S', 0)
+	binary.LittleEndian.PutUint32(withExif[4:], uint32(len(withExif)-8))
+
+	stripped, _, err := Strip(withExif, WebP)
+	assert.NoError(t, err)
+	assert.Equal(t, data, stripped)
+}
+
+func TestOrient(t *testing.T) {
+	img := image.NewNRGBA(image.Rect(0, 0, 2, 1))
+	red := color.NRGBA{R: 255, A: 255}
+	img.Set(0, 0, red)
+
+	assert.Equal(t, img, Orient(img, 1))
+
+	rotated := Orient(img, 6)
+	assert.Equal(t, image.Rect(0, 0, 1, 2), rotated.Bounds())
+	assert.Equal(t, red, rotated.At(0, 0))
+
+	rotated = Orient(img, 8)
+	assert.Equal(t, red, rotated.At(0, 1))
+
+	flipped := Orient(img, 2)
+	assert.Equal(t, image.Rect(0, 0, 2, 1), flipped.Bounds())
+	assert.Equal(t, red, flipped.At(1, 0))
+}
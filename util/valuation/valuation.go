@@ -0,0 +1,106 @@
+// Package valuation values stock from its movements with the FIFO or the
+// weighted average costing method.
+package valuation
+
+type Method string
+
+const (
+	FIFO            Method = "fifo"
+	WeightedAverage Method = "average"
+)
+
+// Movement is a change of stock, positive for incoming and negative for
+// outgoing quantities. UnitCost is the purchase cost of incoming quantities,
+// incoming quantities without a cost are valued at the running average cost.
+type Movement struct {
+	Quantity float64
+	UnitCost *float64
+}
+
+type layer struct {
+	quantity float64
+	unitCost float64
+}
+
+// Value applies the movements in order and returns the quantity on hand and
+// its value. Outgoing quantities beyond the quantity on hand are ignored.
+func Value(movements []Movement, method Method) (float64, float64) {
+	if method == WeightedAverage {
+		return weightedAverage(movements)
+	}
+	return fifo(movements)
+}
+
+func fifo(movements []Movement) (float64, float64) {
+	var layers []layer
+	quantity, value := 0.0, 0.0
+
+	for _, movement := range movements {
+		if movement.Quantity > 0 {
+			unitCost := averageCost(quantity, value)
+			if movement.UnitCost != nil {
+				unitCost = *movement.UnitCost
+			}
+			layers = append(layers, layer{quantity: movement.Quantity, unitCost: unitCost})
+			quantity += movement.Quantity
+			value += movement.Quantity * unitCost
+			continue
+		}
+
+		out := -movement.Quantity
+		for out > 0 && len(layers) > 0 {
+			take := layers[0].quantity
+			if take > out {
+				take = out
+			}
+			layers[0].quantity -= take
+			quantity -= take
+			value -= take * layers[0].unitCost
+			out -= take
+
+			if layers[0].quantity == 0 {
+				layers = layers[1:]
+			}
+		}
+	}
+
+	if quantity == 0 {
+		value = 0
+	}
+	return quantity, value
+}
+
+func weightedAverage(movements []Movement) (float64, float64) {
+	quantity, value := 0.0, 0.0
+
+	for _, movement := range movements {
+		if movement.Quantity > 0 {
+			unitCost := averageCost(quantity, value)
+			if movement.UnitCost != nil {
+				unitCost = *movement.UnitCost
+			}
+			quantity += movement.Quantity
+			value += movement.Quantity * unitCost
+			continue
+		}
+
+		out := -movement.Quantity
+		if out > quantity {
+			out = quantity
+		}
+		value -= out * averageCost(quantity, value)
+		quantity -= out
+	}
+
+	if quantity == 0 {
+		value = 0
+	}
+	return quantity, value
+}
+
+func averageCost(quantity float64, value float64) float64 {
+	if quantity <= 0 {
+		return 0
+	}
+	return value / quantity
+}
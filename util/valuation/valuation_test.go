@@ -0,0 +1,41 @@
+package valuation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func cost(c float64) *float64 {
+	return &c
+}
+
+var movements = []Movement{
+	{Quantity: 10, UnitCost: cost(2)},
+	{Quantity: 10, UnitCost: cost(4)},
+	{Quantity: -15},
+	{Quantity: 5},
+}
+
+func TestValueFIFO(t *testing.T) {
+	quantity, value := Value(movements, FIFO)
+
+	// 5 units left of the second receipt at 4, 5 uncosted units at their average of 4
+	assert.Equal(t, 10.0, quantity)
+	assert.InDelta(t, 40, value, 1e-9)
+}
+
+func TestValueWeightedAverage(t *testing.T) {
+	quantity, value := Value(movements, WeightedAverage)
+
+	// 5 units left at the average of 3, 5 uncosted units at 3
+	assert.Equal(t, 10.0, quantity)
+	assert.InDelta(t, 30, value, 1e-9)
+}
+
+func TestValueIgnoresOversell(t *testing.T) {
+	quantity, value := Value([]Movement{{Quantity: 2, UnitCost: cost(5)}, {Quantity: -3}}, FIFO)
+
+	assert.Equal(t, 0.0, quantity)
+	assert.Equal(t, 0.0, value)
+}
@@ -0,0 +1,61 @@
+package xlsx
+
+import (
+	"archive/zip"
+	"bytes"
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriterWritesWorkbook(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := NewWriter(&buf, "Catalog")
+	assert.NoError(t, err)
+	assert.NoError(t, w.WriteRow("name", "price"))
+	assert.NoError(t, w.WriteRow("Fish & <Chips>", 12.5, nil, 3))
+	assert.NoError(t, w.Close())
+
+	r, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	assert.NoError(t, err)
+
+	files := make(map[string]string)
+	for _, f := range r.File {
+		rc, err := f.Open()
+		assert.NoError(t, err)
+		content, err := ioutil.ReadAll(rc)
+		assert.NoError(t, err)
+		rc.Close()
+		files[f.Name] = string(content)
+	}
+
+	assert.Contains(t, files, "[Content_Types].xml")
+	assert.Contains(t, files, "_rels/.rels")
+	assert.Contains(t, files["xl/workbook.xml"], `<sheet name="Catalog" sheetId="1" r:id="rId1"/>`)
+	assert.Contains(t, files, "xl/_rels/workbook.xml.rels")
+
+	sheet := files["xl/worksheets/sheet1.xml"]
+	assert.Contains(t, sheet, `<row r="1"><c r="A1" t="inlineStr"><is><t xml:space="preserve">name</t></is></c><c r="B1" t="inlineStr"><is><t xml:space="preserve">price</t></is></c></row>`)
+	assert.Contains(t, sheet, `<c r="A2" t="inlineStr"><is><t xml:space="preserve">Fish &amp; &lt;Chips&gt;</t></is></c><c r="B2"><v>12.5</v></c><c r="D2"><v>3</v></c></row>`)
+	assert.Contains(t, sheet, `</sheetData></worksheet>`)
+}
+
+func TestWriterRejectsUnsupportedValuesAndWritesAfterClose(t *testing.T) {
+	w, err := NewWriter(ioutil.Discard, "Sheet")
+	assert.NoError(t, err)
+	assert.Error(t, w.WriteRow(true))
+	assert.NoError(t, w.Close())
+	assert.Equal(t, ErrClosed, w.WriteRow("late"))
+	assert.Equal(t, ErrClosed, w.Close())
+}
+
+func TestColumn(t *testing.T) {
+	assert.Equal(t, "A", column(0))
+	assert.Equal(t, "Z", column(25))
+	assert.Equal(t, "AA", column(26))
+	assert.Equal(t, "AZ", column(51))
+	assert.Equal(t, "BA", column(52))
+	assert.Equal(t, "ZZ", column(701))
+	assert.Equal(t, "AAA", column(702))
+}